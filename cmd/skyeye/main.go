@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"runtime"
 	"runtime/pprof"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -27,7 +28,13 @@ import (
 	"github.com/dharmab/skyeye/internal/application"
 	"github.com/dharmab/skyeye/internal/cli"
 	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/datasource"
+	"github.com/dharmab/skyeye/pkg/datasource/dcs"
+	"github.com/dharmab/skyeye/pkg/debrief"
+	"github.com/dharmab/skyeye/pkg/queue"
+	"github.com/dharmab/skyeye/pkg/radar"
 	"github.com/dharmab/skyeye/pkg/synthesizer/voices"
 	"github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
 )
@@ -38,6 +45,9 @@ var (
 	logLevel                     string
 	logFormat                    string
 	enableTranscriptionLogging   bool
+	enableDebriefLogging         bool
+	debriefRedactions            []string
+	groupLogFile                 string
 	acmiFile                     string
 	telemetryAddress             string
 	telemetryConnectionTimeout   time.Duration
@@ -46,12 +56,26 @@ var (
 	srsConnectionTimeout         time.Duration
 	srsExternalAWACSModePassword string
 	srsFrequencies               []string
+	srsFilterCoalition           bool
+	srsMonitorGuard              bool
+	srsAllowSpectators           bool
+	srsJitterBufferDepth         time.Duration
+	srsEndOfTransmissionSilence  time.Duration
+	srsMaxTransmissionLength     time.Duration
+	srsMaxTransmitHold           time.Duration
+	srsKeepaliveTimeout          time.Duration
+	replayFile                   string
+	replaySpeed                  float64
 	enableGRPC                   bool
 	grpcAddress                  string
 	gciCallsign                  string
 	gciCallsigns                 []string
 	coalitionName                string
 	telemetryUpdateInterval      time.Duration
+	lotATCAddress                string
+	dcsExportAddress             string
+	dcsTypeMappingPath           string
+	deduplication                []string
 	whisperModelPath             string
 	voiceName                    string
 	mute                         bool
@@ -59,16 +83,54 @@ var (
 	voicePauseLength             time.Duration
 	enableAutomaticPicture       bool
 	automaticPictureInterval     time.Duration
+	pictureThreshold             int
+	pictureThresholdCooldown     time.Duration
 	enableThreatMonitoring       bool
 	threatMonitoringInterval     time.Duration
 	threatMonitoringRequiresSRS  bool
 	mandatoryThreatRadiusNM      float64
+	magneticVariation            string
+	gorillaThreshold             int
+	mergeDistanceNM              float64
+	fadedDelay                   time.Duration
+	maxResponseRate              int
+	responseQueueDepth           int
+	responseCacheTTL             time.Duration
+	vanishedTimeout              time.Duration
+	contactTTL                   time.Duration
+	mergeAltitudeFeet            float64
+	altitudeFloorFeet            float64
+	altitudeCeilingFeet          float64
+	speedFloorKnots              float64
+	speedCeilingKnots            float64
+	groupLateralThresholdNM      float64
+	groupVerticalThresholdFeet   float64
+	pictureClosureWeight         float64
+	pictureRangeWeight           float64
+	pictureAltitudeWeight        float64
+	iffRules                     map[string]string
+	pilotRegistryPath            string
+	sectorsPath                  string
+	geoFencesPath                string
 	enableTracing                bool
 	discordWebhookID             string
 	discordWebhookToken          string
 	exitAfter                    time.Duration
+	metricsAddress               string
+	apiAddress                   string
+	apiToken                     string
+	controllerSettings           []controllerSetting
 )
 
+// controllerSetting configures one of several GCI controllers which run in the same process. These can only be set in
+// the config file.
+type controllerSetting struct {
+	Callsign    string   `mapstructure:"callsign"`
+	Coalition   string   `mapstructure:"coalition"`
+	Frequencies []string `mapstructure:"srs-frequencies"`
+	GeoFences   string   `mapstructure:"geofences"`
+}
+
 func init() {
 	skyeye.Flags().StringVar(&configFile, "config-file", "/etc/skyeye/config.yaml", "Path to config file")
 
@@ -78,6 +140,9 @@ func init() {
 	logFormats := cli.NewEnum(&logFormat, "Format", "pretty", "json")
 	skyeye.Flags().Var(logFormats, "log-format", "Log format (pretty, json)")
 	skyeye.Flags().BoolVar(&enableTranscriptionLogging, "enable-transcription-logging", true, "Include transcriptions of SRS transmissions in logs and traces")
+	skyeye.Flags().BoolVar(&enableDebriefLogging, "enable-debrief-logging", false, "Log PICTURE and BOGEY DOPE responses as structured records for debriefs. Best used with --log-format=json")
+	skyeye.Flags().StringSliceVar(&debriefRedactions, "debrief-redact", []string{}, "Sensitive fields to redact from debrief logs (coalition, position)")
+	skyeye.Flags().StringVar(&groupLogFile, "group-log-file", "", "Path to a JSON Lines file where group merge and split events are written at the end of each mission, for debriefs")
 
 	// Telemetry
 	skyeye.Flags().StringVar(&acmiFile, "acmi-file", "", "path to ACMI file")
@@ -85,6 +150,13 @@ func init() {
 	skyeye.MarkFlagsMutuallyExclusive("acmi-file", "telemetry-address")
 	skyeye.Flags().DurationVar(&telemetryConnectionTimeout, "telemetry-connection-timeout", 10*time.Second, "Connection timeout for real-time telemetry client")
 	skyeye.Flags().StringVar(&telemetryPassword, "telemetry-password", "", "Password for the real-time telemetry service")
+	skyeye.Flags().StringVar(&lotATCAddress, "lotatc-address", "", "Local UDP address to receive the LotATC JSON feed on, e.g. 0.0.0.0:10310")
+	skyeye.MarkFlagsMutuallyExclusive("acmi-file", "lotatc-address")
+	skyeye.MarkFlagsMutuallyExclusive("lotatc-address", "telemetry-address")
+	skyeye.Flags().StringVar(&dcsExportAddress, "dcs-export-address", "", "Address of the DCS export socket to read contacts from, e.g. localhost:10308")
+	skyeye.Flags().StringVar(&dcsTypeMappingPath, "dcs-type-mapping", "", "Path to a YAML file mapping DCS aircraft types to names and IFF classifications")
+	skyeye.Flags().StringSliceVar(&deduplication, "deduplication", []string{string(datasource.DeduplicateByID)}, "Keys used to merge aircraft reported more than once by the DCS export or LotATC (id, position)")
+	skyeye.MarkFlagsMutuallyExclusive("dcs-export-address", "acmi-file", "lotatc-address", "telemetry-address")
 	skyeye.Flags().DurationVar(&telemetryUpdateInterval, "telemetry-update-interval", 2*time.Second, "Interval at which trackfiles are updated from telemetry data")
 
 	// SRS
@@ -92,6 +164,16 @@ func init() {
 	skyeye.Flags().DurationVar(&srsConnectionTimeout, "srs-connection-timeout", 10*time.Second, "Connection timeout for SRS client")
 	skyeye.Flags().StringVar(&srsExternalAWACSModePassword, "srs-eam-password", "", "SRS external AWACS mode password")
 	skyeye.Flags().StringSliceVar(&srsFrequencies, "srs-frequencies", []string{"251.0AM", "133.0AM", "30.0FM"}, "List of SRS frequencies to use")
+	skyeye.Flags().BoolVar(&srsMonitorGuard, "srs-monitor-guard", false, "Also receive on the guard frequencies 243.0AM and 121.5AM. Replies to requests on guard are only sent on guard")
+	skyeye.Flags().BoolVar(&srsFilterCoalition, "srs-filter-coalition", false, "Ignore SRS transmissions from other coalitions and spectators")
+	skyeye.Flags().BoolVar(&srsAllowSpectators, "srs-allow-spectators", false, "Receive SRS transmissions from spectators. Transmissions from the opposing coalition are always ignored")
+	skyeye.Flags().DurationVar(&srsJitterBufferDepth, "srs-jitter-buffer", 120*time.Millisecond, "How long to wait for SRS voice packets which arrive out of order. Set to 0 to disable reordering")
+	skyeye.Flags().DurationVar(&srsEndOfTransmissionSilence, "srs-end-of-transmission-silence", 500*time.Millisecond, "How long after the last SRS voice packet a transmission is considered over")
+	skyeye.Flags().DurationVar(&srsMaxTransmissionLength, "srs-max-transmission-length", 15*time.Second, "Maximum length of an SRS transmission before it is recognized even if the pilot is still talking")
+	skyeye.Flags().DurationVar(&srsMaxTransmitHold, "srs-max-transmit-hold", 30*time.Second, "Maximum time to wait for players to stop talking on a frequency before transmitting anyway")
+	skyeye.Flags().DurationVar(&srsKeepaliveTimeout, "srs-keepalive-timeout", 45*time.Second, "How long to wait without any traffic or ping responses from the SRS server before reconnecting")
+	skyeye.Flags().StringVar(&replayFile, "replay", "", "Path to a JSON Lines file of pre-recorded SRS transmissions to play back instead of connecting to SRS. Useful for testing")
+	skyeye.Flags().Float64Var(&replaySpeed, "replay-speed", 1.0, "Playback speed multiplier for --replay")
 
 	// DCS-gRPC
 	skyeye.Flags().BoolVar(&enableGRPC, "enable-grpc", false, "Enable DCS-gRPC features")
@@ -116,9 +198,34 @@ func init() {
 	// Controller behavior
 	skyeye.Flags().BoolVar(&enableAutomaticPicture, "auto-picture", true, "Enable automatic PICTURE broadcasts")
 	skyeye.Flags().DurationVar(&automaticPictureInterval, "auto-picture-interval", 2*time.Minute, "How often to broadcast PICTURE")
+	skyeye.Flags().IntVar(&pictureThreshold, "picture-threshold", 0, "Number of hostile contacts at which to broadcast a NUMEROUS call. Set to 0 to disable")
+	skyeye.Flags().DurationVar(&pictureThresholdCooldown, "picture-threshold-cooldown", 5*time.Minute, "Minimum interval between NUMEROUS calls")
 	skyeye.Flags().BoolVar(&enableThreatMonitoring, "threat-monitoring", true, "Enable THREAT monitoring")
 	skyeye.Flags().DurationVar(&threatMonitoringInterval, "threat-monitoring-interval", 3*time.Minute, "How often to broadcast THREAT")
 	skyeye.Flags().Float64Var(&mandatoryThreatRadiusNM, "mandatory-threat-radius", 25, "Briefed radius for mandatory THREAT calls, in nautical miles")
+	skyeye.Flags().StringVar(&magneticVariation, "magnetic-variation", "", "Magnetic variation of the theater in degrees, positive east and negative west, e.g. 6 for the Caucasus. Computed from the IGRF model if not provided")
+	skyeye.Flags().StringVar(&pilotRegistryPath, "pilot-registry", "", "Path to a YAML file mapping pilot names to callsigns. Reloaded automatically when changed")
+	skyeye.Flags().StringVar(&sectorsPath, "sectors", "", "Path to a YAML file defining named sectors. A PICTURE CLEAN call is broadcast when the last hostile contact leaves a sector")
+	skyeye.Flags().StringVar(&geoFencesPath, "geofences", "", "Path to a YAML file defining named geo-fences. PICTURE and BOGEY DOPE only report contacts within a geo-fence")
+	skyeye.Flags().StringToStringVar(&iffRules, "iff-rules", map[string]string{}, "Declarations for aircraft types in DECLARE responses, e.g. MiG-*=hostile,An-26B=neutral")
+	skyeye.Flags().Float64Var(&mergeDistanceNM, "merge-distance", brevity.MergeEntryDistance.NauticalMiles(), "Distance within which friendly and hostile aircraft are MERGED, in nautical miles")
+	skyeye.Flags().Float64Var(&mergeAltitudeFeet, "merge-altitude", brevity.MergeEntryAltitude.Feet(), "Altitude separation within which friendly and hostile aircraft are MERGED, in feet")
+	skyeye.Flags().Float64Var(&altitudeFloorFeet, "altitude-floor", 0, "Altitude below which contacts are ignored, in feet")
+	skyeye.Flags().Float64Var(&altitudeCeilingFeet, "altitude-ceiling", 0, "Altitude above which contacts are ignored, in feet. Set to 0 for no ceiling")
+	skyeye.Flags().Float64Var(&speedFloorKnots, "speed-floor", radar.DefaultSpeedFloor.Knots(), "Speed at or below which contacts are ignored, in knots")
+	skyeye.Flags().Float64Var(&speedCeilingKnots, "speed-ceiling", radar.DefaultSpeedCeiling.Knots(), "Speed above which contacts are ignored, in knots")
+	skyeye.Flags().Float64Var(&groupLateralThresholdNM, "group-lateral-threshold", radar.DefaultGroupLateralThreshold.NauticalMiles(), "Maximum distance between contacts linked into the same group, in nautical miles")
+	skyeye.Flags().Float64Var(&groupVerticalThresholdFeet, "group-vertical-threshold", 0, "Maximum altitude separation between contacts linked into the same group, in feet. Set to 0 to group contacts regardless of altitude")
+	skyeye.Flags().Float64Var(&pictureClosureWeight, "picture-closure-weight", brevity.DefaultPriorityWeights.Closure, "Weight of closure when ordering groups in a requested PICTURE")
+	skyeye.Flags().Float64Var(&pictureRangeWeight, "picture-range-weight", brevity.DefaultPriorityWeights.Range, "Weight of range when ordering groups in a requested PICTURE")
+	skyeye.Flags().Float64Var(&pictureAltitudeWeight, "picture-altitude-weight", brevity.DefaultPriorityWeights.Altitude, "Weight of altitude advantage when ordering groups in a requested PICTURE")
+	skyeye.Flags().IntVar(&maxResponseRate, "max-response-rate", 0, "Maximum number of requests to respond to per minute. Additional requests are queued. Set to 0 to disable rate limiting")
+	skyeye.Flags().IntVar(&responseQueueDepth, "response-queue-depth", queue.DefaultMaxDepth, "Maximum number of requests waiting for a response. Additional requests are dropped")
+	skyeye.Flags().DurationVar(&responseCacheTTL, "response-cache-ttl", 10*time.Second, "How long to reuse a PICTURE response while the picture is unchanged. Set to 0 to disable")
+	skyeye.Flags().DurationVar(&fadedDelay, "faded-delay", 0, "Additional delay after a group stops being updated by sensors before broadcasting a FADED call")
+	skyeye.Flags().DurationVar(&vanishedTimeout, "vanished-timeout", 2*time.Minute, "Delay after a group stops being updated by sensors before broadcasting a VANISHED call. Set to 0 to disable VANISHED calls")
+	skyeye.Flags().DurationVar(&contactTTL, "contact-ttl", 45*time.Second, "How long a contact may go without sensor updates before it is removed and a FADED call is broadcast. Set to 0 to disable")
+	skyeye.Flags().IntVar(&gorillaThreshold, "gorilla-threshold", brevity.DefaultGorillaThreshold, "Minimum number of contacts in a group to call it a GORILLA instead of HEAVY")
 	skyeye.Flags().BoolVar(&threatMonitoringRequiresSRS, "threat-monitoring-requires-srs", true, "Require aircraft to be on SRS to receive THREAT calls. Only useful to disable when debugging")
 
 	// Tracing
@@ -127,6 +234,14 @@ func init() {
 	skyeye.Flags().StringVar(&discordWebhookToken, "discord-webhook-token", "", "Discord webhook token for tracing")
 	skyeye.MarkFlagsRequiredTogether("discord-webhook-id", "discord-webhook-token")
 
+	// Metrics
+	skyeye.Flags().StringVar(&metricsAddress, "metrics-address", "", "Address to serve Prometheus metrics on at /metrics, e.g. localhost:9090. Disabled if not provided")
+
+	// API
+	skyeye.Flags().StringVar(&apiAddress, "api-address", "", "Address to serve the GCI API on, e.g. localhost:8080. Disabled if not provided")
+	skyeye.Flags().StringVar(&apiToken, "api-token", "", "Pre-shared token which API clients must present")
+	skyeye.MarkFlagsRequiredTogether("api-address", "api-token")
+
 	// Runtime
 	skyeye.Flags().DurationVar(&exitAfter, "exit-after", time.Hour*24*365*20, "Exit after running for the specified duration")
 }
@@ -178,6 +293,10 @@ func initializeConfig(cmd *cobra.Command) error {
 	v.AutomaticEnv()
 
 	bindFlags(cmd, v)
+
+	if err := v.UnmarshalKey("controllers", &controllerSettings); err != nil {
+		return fmt.Errorf("failed to parse controllers: %w", err)
+	}
 	return nil
 }
 
@@ -193,9 +312,13 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) {
 	})
 }
 
-func loadCoalition() (coalition coalitions.Coalition) {
-	log.Info().Str("coalition", coalitionName).Msg("setting GCI coalition")
-	switch coalitionName {
+func loadCoalition() coalitions.Coalition {
+	return parseCoalition(coalitionName)
+}
+
+func parseCoalition(name string) (coalition coalitions.Coalition) {
+	log.Info().Str("coalition", name).Msg("setting GCI coalition")
+	switch name {
 	case "blue":
 		coalition = coalitions.Blue
 	case "red":
@@ -203,10 +326,92 @@ func loadCoalition() (coalition coalitions.Coalition) {
 	default:
 		log.Fatal().Msg("GCI coalition must be either blue or red")
 	}
-	log.Info().Int("id", int(coalition)).Msg("GCI coalition set")
+	log.Info().Stringer("coalition", coalition).Msg("GCI coalition set")
 	return
 }
 
+func loadControllers() []conf.Controller {
+	controllers := make([]conf.Controller, 0, len(controllerSettings))
+	for _, setting := range controllerSettings {
+		controller := conf.Controller{
+			Callsign:       setting.Callsign,
+			Coalition:      parseCoalition(setting.Coalition),
+			SRSFrequencies: cli.LoadFrequencies(setting.Frequencies),
+			GeoFencesPath:  setting.GeoFences,
+		}
+		if setting.Callsign != "" {
+			controller.SRSClientName = fmt.Sprintf("GCI %s [BOT]", setting.Callsign)
+		}
+		controllers = append(controllers, controller)
+	}
+	return controllers
+}
+
+func loadDebriefRedactions() []debrief.Field {
+	fields := make([]debrief.Field, 0, len(debriefRedactions))
+	for _, name := range debriefRedactions {
+		fields = append(fields, debrief.Field(strings.ToLower(strings.TrimSpace(name))))
+	}
+	return fields
+}
+
+func loadIFFRules() brevity.IFFRules {
+	rules, err := brevity.ParseIFFRules(iffRules)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to parse IFF rules")
+	}
+	return rules
+}
+
+func loadPicturePriorityWeights() brevity.PriorityWeights {
+	weights := brevity.PriorityWeights{
+		Closure:  pictureClosureWeight,
+		Range:    pictureRangeWeight,
+		Altitude: pictureAltitudeWeight,
+	}
+	if err := weights.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("invalid PICTURE priority weights")
+	}
+	return weights
+}
+
+func loadDCSTypeMapping() *dcs.TypeMapping {
+	if dcsTypeMappingPath == "" {
+		return nil
+	}
+	log.Info().Str("path", dcsTypeMappingPath).Msg("loading DCS type mapping")
+	mapping, err := dcs.LoadTypeMapping(dcsTypeMappingPath)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", dcsTypeMappingPath).Msg("failed to load DCS type mapping")
+	}
+	return mapping
+}
+
+func loadDeduplication() []datasource.DeduplicationKey {
+	keys := make([]datasource.DeduplicationKey, 0, len(deduplication))
+	for _, s := range deduplication {
+		key, err := datasource.ParseDeduplicationKey(s)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to parse deduplication keys")
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func loadMagneticVariation() *unit.Angle {
+	if magneticVariation == "" {
+		return nil
+	}
+	degrees, err := strconv.ParseFloat(magneticVariation, 64)
+	if err != nil {
+		log.Fatal().Err(err).Str("variation", magneticVariation).Msg("failed to parse magnetic variation")
+	}
+	variation := unit.Angle(degrees) * unit.Degree
+	log.Info().Float64("degrees", variation.Degrees()).Msg("using configured magnetic variation")
+	return &variation
+}
+
 func loadWhisperModel() *whisper.Model {
 	if runtime.GOARCH == "amd64" && !cpu.X86.HasAVX2 {
 		log.Fatal().Msg("The CPU on this machine does not support AVX2 instructions.")
@@ -285,6 +490,9 @@ func preRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// shutdownTimeout is how long to wait for outgoing transmissions to finish when shutting down.
+const shutdownTimeout = 3 * time.Second
+
 func run(cmd *cobra.Command, args []string) {
 	// Set up an application-scoped context and a cancel function to shut down the application.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -304,12 +512,25 @@ func run(cmd *cobra.Command, args []string) {
 
 	log.Info().Str("version", Version).Msg("SkyEye GCI Bot")
 
+	// apps are the running applications, which are closed gracefully on shutdown.
+	var apps []application.Application
+	var appsLock sync.Mutex
+
 	log.Info().Msg("setting up interrupt and TERM signal handler")
 	interuptChan := make(chan os.Signal, 1)
 	signal.Notify(interuptChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		s := <-interuptChan
 		log.Info().Any("signal", s).Msg("received shutdown signal")
+		closeCtx, cancelClose := context.WithTimeout(ctx, shutdownTimeout)
+		appsLock.Lock()
+		for _, app := range apps {
+			if err := app.Close(closeCtx); err != nil {
+				log.Warn().Err(err).Msg("error closing application")
+			}
+		}
+		appsLock.Unlock()
+		cancelClose()
 		cancel()
 		wg.Wait()
 		os.Exit(0)
@@ -329,12 +550,29 @@ func run(cmd *cobra.Command, args []string) {
 		TelemetryConnectionTimeout:   telemetryConnectionTimeout,
 		TelemetryClientName:          callsign,
 		TelemetryPassword:            telemetryPassword,
+		LotATCAddress:                lotATCAddress,
+		DCSExportAddress:             dcsExportAddress,
+		DCSTypeMapping:               loadDCSTypeMapping(),
+		Deduplication:                loadDeduplication(),
 		SRSAddress:                   srsAddress,
 		SRSConnectionTimeout:         srsConnectionTimeout,
 		SRSClientName:                fmt.Sprintf("GCI %s [BOT]", callsign),
 		SRSExternalAWACSModePassword: srsExternalAWACSModePassword,
 		SRSFrequencies:               parsedSRSFrequencies,
+		SRSMonitorGuard:              srsMonitorGuard,
+		SRSFilterCoalition:           srsFilterCoalition,
+		SRSAllowSpectators:           srsAllowSpectators,
+		SRSJitterBufferDepth:         srsJitterBufferDepth,
+		SRSEndOfTransmissionSilence:  srsEndOfTransmissionSilence,
+		SRSMaxTransmissionLength:     srsMaxTransmissionLength,
+		SRSMaxTransmitHold:           srsMaxTransmitHold,
+		SRSKeepaliveTimeout:          srsKeepaliveTimeout,
+		ReplayFile:                   replayFile,
+		ReplaySpeed:                  replaySpeed,
 		EnableTranscriptionLogging:   enableTranscriptionLogging,
+		EnableDebriefLogging:         enableDebriefLogging,
+		DebriefRedactions:            loadDebriefRedactions(),
+		GroupLogFile:                 groupLogFile,
 		Callsign:                     callsign,
 		Coalition:                    coalition,
 		RadarSweepInterval:           telemetryUpdateInterval,
@@ -345,24 +583,56 @@ func run(cmd *cobra.Command, args []string) {
 		VoicePauseLength:             voicePauseLength,
 		EnableAutomaticPicture:       enableAutomaticPicture,
 		PictureBroadcastInterval:     automaticPictureInterval,
+		PictureThreshold:             pictureThreshold,
+		PictureThresholdCooldown:     pictureThresholdCooldown,
 		EnableThreatMonitoring:       enableThreatMonitoring,
 		ThreatMonitoringInterval:     threatMonitoringInterval,
 		ThreatMonitoringRequiresSRS:  threatMonitoringRequiresSRS,
 		MandatoryThreatRadius:        unit.Length(mandatoryThreatRadiusNM) * unit.NauticalMile,
+		MagneticVariation:            loadMagneticVariation(),
+		PilotRegistryPath:            pilotRegistryPath,
+		SectorsPath:                  sectorsPath,
+		GeoFencesPath:                geoFencesPath,
+		IFFRules:                     loadIFFRules(),
+		MergeDistance:                unit.Length(mergeDistanceNM) * unit.NauticalMile,
+		MergeAltitude:                unit.Length(mergeAltitudeFeet) * unit.Foot,
+		AltitudeFloor:                unit.Length(altitudeFloorFeet) * unit.Foot,
+		AltitudeCeiling:              unit.Length(altitudeCeilingFeet) * unit.Foot,
+		SpeedFloor:                   unit.Speed(speedFloorKnots) * unit.Knot,
+		SpeedCeiling:                 unit.Speed(speedCeilingKnots) * unit.Knot,
+		GroupLateralThreshold:        unit.Length(groupLateralThresholdNM) * unit.NauticalMile,
+		GroupVerticalThreshold:       unit.Length(groupVerticalThresholdFeet) * unit.Foot,
+		PicturePriorityWeights:       loadPicturePriorityWeights(),
+		MaxResponseRate:              maxResponseRate,
+		ResponseQueueDepth:           responseQueueDepth,
+		ResponseCacheTTL:             responseCacheTTL,
+		FadedDelay:                   fadedDelay,
+		VanishedTimeout:              vanishedTimeout,
+		ContactTTL:                   contactTTL,
+		GorillaThreshold:             gorillaThreshold,
 		EnableTracing:                enableTracing,
 		DiscordWebhookID:             discordWebhookID,
 		DiscorbWebhookToken:          discordWebhookToken,
 		ExitAfter:                    exitAfter,
+		MetricsAddress:               metricsAddress,
+		APIAddress:                   apiAddress,
+		APIToken:                     apiToken,
+		Controllers:                  loadControllers(),
 	}
 
-	log.Info().Msg("starting application")
-	app, err := application.NewApplication(ctx, config)
-	if err != nil {
-		log.Fatal().Err(err).Msg("failed to start application")
-	}
-	err = app.Run(ctx, cancel, &wg)
-	if err != nil {
-		log.Fatal().Err(err).Msg("application exited with error")
+	for _, controllerConfig := range config.ControllerConfigurations() {
+		log.Info().Str("callsign", controllerConfig.Callsign).Stringer("coalition", controllerConfig.Coalition).Msg("starting application")
+		app, err := application.NewApplication(ctx, controllerConfig)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to start application")
+		}
+		err = app.Run(ctx, cancel, &wg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("application exited with error")
+		}
+		appsLock.Lock()
+		apps = append(apps, app)
+		appsLock.Unlock()
 	}
 	wg.Wait()
 }
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/api"
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+// apiScope provides the radar picture to the API.
+type apiScope struct {
+	radar     radar.Radar
+	coalition coalitions.Coalition
+}
+
+var _ api.Scope = &apiScope{}
+
+// Picture implements [api.Scope.Picture].
+func (s *apiScope) Picture() (int, []brevity.Group) {
+	count, groups := s.radar.GetPicture(conf.DefaultPictureRadius, s.coalition.Opposite(), brevity.FixedWing, []uint64{})
+	for _, group := range groups {
+		group.SetDeclaration(brevity.Hostile)
+	}
+	return count, groups
+}
+
+// FindUnit implements [api.Scope.FindUnit].
+func (s *apiScope) FindUnit(id uint64) *trackfiles.Trackfile {
+	return s.radar.FindUnit(id)
+}
+
+// MagneticVariation implements [api.Scope.MagneticVariation].
+func (s *apiScope) MagneticVariation() bearings.MagneticVariation {
+	return s.radar.MagneticVariation()
+}
+
+// serveAPI serves the GCI API until the context is canceled.
+func (a *app) serveAPI(ctx context.Context) {
+	server := &http.Server{
+		Addr:              a.apiAddress,
+		Handler:           a.apiServer,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		log.Info().Msg("stopping API server due to context cancellation")
+		a.apiServer.Close()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("error shutting down API server")
+		}
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error().Err(err).Msg("error serving API")
+	}
+}
+
+// publishTelemetry forwards telemetry events to the radar, publishing contact events to the API along the way.
+func (a *app) publishTelemetry(ctx context.Context, starts <-chan sim.Started, updates <-chan sim.Updated, fades <-chan sim.Faded) {
+	seen := make(map[uint64]struct{})
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("stopping API telemetry publishing due to context cancellation")
+			return
+		case started := <-starts:
+			clear(seen)
+			forward(ctx, a.starts, started)
+		case updated := <-updates:
+			eventType := api.ContactUpdated
+			if _, ok := seen[updated.Labels.ID]; !ok {
+				eventType = api.ContactAdded
+				seen[updated.Labels.ID] = struct{}{}
+			}
+			a.apiServer.Publish(api.NewContactEvent(eventType, updated.Labels, updated.Frame))
+			forward(ctx, a.updates, updated)
+		case faded := <-fades:
+			delete(seen, faded.ID)
+			a.apiServer.Publish(api.NewFadedEvent(faded.ID))
+			forward(ctx, a.fades, faded)
+		}
+	}
+}
+
+// forward sends a value to a channel, unless the context is canceled first.
+func forward[T any](ctx context.Context, out chan<- T, value T) {
+	select {
+	case out <- value:
+	case <-ctx.Done():
+	}
+}
+
+// publishCallout publishes a composed call to the API, if the API is enabled.
+func (a *app) publishCallout(call any, subtitle string) {
+	if a.apiServer == nil {
+		return
+	}
+	name := strings.TrimPrefix(fmt.Sprintf("%T", call), "brevity.")
+	a.apiServer.Publish(api.NewCalloutEvent(name, subtitle))
+}
@@ -11,13 +11,23 @@ import (
 	"github.com/DCS-gRPC/go-bindings/dcs/v0/coalition"
 	"github.com/DCS-gRPC/go-bindings/dcs/v0/mission"
 	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/api"
+	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/commands"
 	"github.com/dharmab/skyeye/pkg/composer"
 	"github.com/dharmab/skyeye/pkg/controller"
+	"github.com/dharmab/skyeye/pkg/datasource"
+	"github.com/dharmab/skyeye/pkg/datasource/dcs"
+	"github.com/dharmab/skyeye/pkg/datasource/lotatc"
+	"github.com/dharmab/skyeye/pkg/debrief"
+	"github.com/dharmab/skyeye/pkg/geofences"
 	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/dharmab/skyeye/pkg/pilots"
+	"github.com/dharmab/skyeye/pkg/queue"
 	"github.com/dharmab/skyeye/pkg/radar"
 	"github.com/dharmab/skyeye/pkg/recognizer"
+	"github.com/dharmab/skyeye/pkg/sectors"
 	"github.com/dharmab/skyeye/pkg/sim"
 	"github.com/dharmab/skyeye/pkg/simpleradio"
 	srs "github.com/dharmab/skyeye/pkg/simpleradio/types"
@@ -32,16 +42,21 @@ import (
 type Application interface {
 	// Run runs the SkyEye application. It should be called exactly once.
 	Run(context.Context, context.CancelFunc, *sync.WaitGroup) error
+	// Close finishes any outgoing transmissions and disconnects from the SRS server, waiting until the context is done
+	// at most. It should be called before the context passed to Run is canceled.
+	Close(context.Context) error
 }
 
 // app implements the Application.
 type app struct {
 	// callsign of the GCI controller
 	callsign string
+	// coalition of the GCI controller
+	coalition coalitions.Coalition
 	// srsClient is a SimpleRadio Standalone client
 	srsClient simpleradio.Client
-	// tacviewClient streams ACMI data
-	tacviewClient tacview.Client
+	// contactSource streams radar contacts from Tacview, LotATC or the DCS export
+	contactSource datasource.ContactSource
 	// recognizer provides speech-to-text recognition
 	recognizer recognizer.Recognizer
 	// chatListener listens for chat messages
@@ -52,6 +67,8 @@ type app struct {
 	radar radar.Radar
 	// controller publishes responses and calls
 	controller controller.Controller
+	// requests limits the rate at which requests are routed to the controller
+	requests *queue.ResponseQueue[Message[any]]
 	// composer converts responses and calls from internal representations to English brevity text
 	composer composer.Composer
 	// speaker provides text-to-speech synthesis
@@ -67,6 +84,20 @@ type app struct {
 
 	// exitAfter is the duration after which the application should exit
 	exitAfter time.Duration
+
+	// metricsAddress is the address to serve metrics on. Metrics are not served if empty.
+	metricsAddress string
+
+	// apiAddress is the address to serve the API on. The API is not served if empty.
+	apiAddress string
+	// apiServer serves the API. Nil if the API is disabled.
+	apiServer *api.Server
+
+	// debriefFilter redacts sensitive fields from debrief logs. Debrief logging is disabled if nil.
+	debriefFilter *debrief.Filter
+
+	// pilots maps the names of human pilots to callsigns. Nil if no pilot registry is configured.
+	pilots *pilots.PilotRegistry
 }
 
 // NewApplication constructs a new Application.
@@ -94,8 +125,12 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 		)
 	}
 
-	radios := make([]srs.Radio, 0, len(config.SRSFrequencies))
-	for _, radioFrequency := range config.SRSFrequencies {
+	frequencies := config.SRSFrequencies
+	if config.SRSMonitorGuard {
+		frequencies = simpleradio.WithGuardFrequencies(frequencies)
+	}
+	radios := make([]srs.Radio, 0, len(frequencies))
+	for _, radioFrequency := range frequencies {
 		radios = append(radios, srs.Radio{
 			Frequency:        radioFrequency.Frequency.Hertz(),
 			Modulation:       radioFrequency.Modulation,
@@ -107,28 +142,52 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 		Str("address", config.SRSAddress).
 		Stringer("timeout", config.SRSConnectionTimeout).
 		Str("clientName", config.SRSClientName).
-		Int("coalitionID", int(config.Coalition)).
+		Stringer("coalition", config.Coalition).
 		Int("modulationID", int(srs.ModulationAM)).
 		Msg("constructing SRS client")
-	srsClient, err := simpleradio.NewClient(srs.ClientConfiguration{
-		Address:                   config.SRSAddress,
-		ConnectionTimeout:         config.SRSConnectionTimeout,
-		ClientName:                config.SRSClientName,
-		ExternalAWACSModePassword: config.SRSExternalAWACSModePassword,
-		Coalition:                 config.Coalition,
-		Radios:                    radios,
-	})
+	var coalitionFilter coalitions.Coalition
+	if config.SRSFilterCoalition {
+		coalitionFilter = config.Coalition
+	}
+	var srsClient simpleradio.Client
+	var err error
+	if config.ReplayFile != "" {
+		log.Info().Str("file", config.ReplayFile).Float64("speed", config.ReplaySpeed).Msg("constructing SRS replay client")
+		srsClient, err = simpleradio.NewReplayClient(config.ReplayFile, config.ReplaySpeed, frequencies)
+	} else {
+		srsClient, err = simpleradio.NewClient(srs.ClientConfiguration{
+			Address:                   config.SRSAddress,
+			ConnectionTimeout:         config.SRSConnectionTimeout,
+			ClientName:                config.SRSClientName,
+			ExternalAWACSModePassword: config.SRSExternalAWACSModePassword,
+			Coalition:                 config.Coalition,
+			CoalitionFilter:           coalitionFilter,
+			AllowSpectators:           config.SRSAllowSpectators,
+			JitterBufferDepth:         config.SRSJitterBufferDepth,
+			EndOfTransmissionSilence:  config.SRSEndOfTransmissionSilence,
+			MaxTransmissionLength:     config.SRSMaxTransmissionLength,
+			MaxTransmitHold:           config.SRSMaxTransmitHold,
+			KeepaliveTimeout:          config.SRSKeepaliveTimeout,
+			Radios:                    radios,
+		})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct application: %w", err)
 	}
 
-	var tacviewClient tacview.Client
+	var contactSource datasource.ContactSource
 	if config.ACMIFile != "" {
 		log.Info().Str("file", config.ACMIFile).Msg("constructing ACMI file reader")
-		tacviewClient = tacview.NewFileClient(config.ACMIFile, config.RadarSweepInterval)
+		contactSource = tacview.NewFileClient(config.ACMIFile, config.RadarSweepInterval)
+	} else if config.LotATCAddress != "" {
+		log.Info().Str("address", config.LotATCAddress).Msg("constructing LotATC client")
+		contactSource = lotatc.NewClient(config.LotATCAddress, config.RadarSweepInterval, config.Deduplication)
+	} else if config.DCSExportAddress != "" {
+		log.Info().Str("address", config.DCSExportAddress).Msg("constructing DCS export client")
+		contactSource = dcs.NewClient(config.DCSExportAddress, config.Coalition, config.DCSTypeMapping, config.RadarSweepInterval, config.Deduplication)
 	} else {
 		log.Info().Str("address", config.TelemetryAddress).Msg("constructing telemetry client")
-		tacviewClient = tacview.NewTelemetryClient(
+		contactSource = tacview.NewTelemetryClient(
 			config.TelemetryAddress,
 			config.Callsign,
 			config.TelemetryPassword,
@@ -143,23 +202,74 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 	log.Info().Msg("constructing text parser")
 	parser := parser.New(config.Callsign, config.EnableTranscriptionLogging)
 
+	var pilotRegistry *pilots.PilotRegistry
+	if config.PilotRegistryPath != "" {
+		log.Info().Str("path", config.PilotRegistryPath).Msg("loading pilot registry")
+		pilotRegistry, err = pilots.LoadPilotRegistry(config.PilotRegistryPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+	}
+
+	var sectorDefinitions []sectors.Sector
+	if config.SectorsPath != "" {
+		log.Info().Str("path", config.SectorsPath).Msg("loading sectors")
+		sectorDefinitions, err = sectors.Load(config.SectorsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+	}
+
+	var geoFences []geofences.GeoFence
+	if config.GeoFencesPath != "" {
+		log.Info().Str("path", config.GeoFencesPath).Msg("loading geo-fences")
+		geoFences, err = geofences.Load(config.GeoFencesPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+	}
+
 	log.Info().Msg("constructing radar scope")
 
-	rdr := radar.New(config.Coalition, starts, updates, fades, config.MandatoryThreatRadius)
+	rdr := radar.New(starts, updates, fades, radar.Options{
+		Coalition:              config.Coalition,
+		MandatoryThreatRadius:  config.MandatoryThreatRadius,
+		Pilots:                 pilotRegistry,
+		GroupLogPath:           config.GroupLogFile,
+		Sectors:                sectorDefinitions,
+		MagneticVariation:      config.MagneticVariation,
+		AltitudeFloor:          config.AltitudeFloor,
+		AltitudeCeiling:        config.AltitudeCeiling,
+		SpeedFloor:             config.SpeedFloor,
+		SpeedCeiling:           config.SpeedCeiling,
+		GroupLateralThreshold:  config.GroupLateralThreshold,
+		GroupVerticalThreshold: config.GroupVerticalThreshold,
+	})
 	log.Info().Msg("constructing GCI controller")
-	controller := controller.New(
-		rdr,
-		srsClient,
-		config.Coalition,
-		config.EnableAutomaticPicture,
-		config.PictureBroadcastInterval,
-		config.EnableThreatMonitoring,
-		config.ThreatMonitoringInterval,
-		config.ThreatMonitoringRequiresSRS,
-	)
+	controller := controller.New(rdr, srsClient, controller.Options{
+		Callsign:                    config.Callsign,
+		Coalition:                   config.Coalition,
+		EnableAutomaticPicture:      config.EnableAutomaticPicture,
+		PictureBroadcastInterval:    config.PictureBroadcastInterval,
+		PictureThreshold:            config.PictureThreshold,
+		PictureThresholdCooldown:    config.PictureThresholdCooldown,
+		RadarSweepInterval:          config.RadarSweepInterval,
+		EnableThreatMonitoring:      config.EnableThreatMonitoring,
+		ThreatMonitoringCooldown:    config.ThreatMonitoringInterval,
+		ThreatMonitoringRequiresSRS: config.ThreatMonitoringRequiresSRS,
+		MergeCriteria:               brevity.NewMergeCriteria(config.MergeDistance, config.MergeAltitude),
+		PictureWeights:              config.PicturePriorityWeights,
+		FadeDelay:                   config.FadedDelay,
+		VanishTimeout:               config.VanishedTimeout,
+		ContactTTL:                  config.ContactTTL,
+		ResponseCacheTTL:            config.ResponseCacheTTL,
+		IFFRules:                    config.IFFRules,
+		GeoFences:                   geoFences,
+		Pilots:                      pilotRegistry,
+	})
 
 	log.Info().Msg("constructing text composer")
-	composer := composer.New(config.Callsign)
+	composer := composer.New(config.Callsign, config.GorillaThreshold)
 
 	log.Info().Msg("constructing text-to-speech synthesizer")
 	synthesizer, err := speakers.NewPiperSpeaker(config.Voice, config.VoiceSpeed, config.VoicePauseLength)
@@ -181,16 +291,36 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 	}
 
 	log.Info().Msg("constructing application")
+	var debriefFilter *debrief.Filter
+	if config.EnableDebriefLogging {
+		filter, err := debrief.NewFilter(config.DebriefRedactions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct debrief filter: %w", err)
+		}
+		debriefFilter = &filter
+	}
+
+	var apiServer *api.Server
+	if config.APIAddress != "" {
+		log.Info().Msg("constructing API server")
+		apiServer, err = api.NewServer(config.APIToken, &apiScope{radar: rdr, coalition: config.Coalition})
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct API server: %w", err)
+		}
+	}
+
 	app := &app{
 		callsign:                   config.Callsign,
+		coalition:                  config.Coalition,
 		enableTranscriptionLogging: config.EnableTranscriptionLogging,
 		chatListener:               chatListener,
 		srsClient:                  srsClient,
-		tacviewClient:              tacviewClient,
+		contactSource:              contactSource,
 		recognizer:                 recognizer,
 		parser:                     parser,
 		radar:                      rdr,
 		controller:                 controller,
+		requests:                   queue.NewResponseQueue[Message[any]](config.MaxResponseRate, config.ResponseQueueDepth),
 		composer:                   composer,
 		speaker:                    synthesizer,
 		tracers:                    tracers,
@@ -198,6 +328,11 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 		updates:                    updates,
 		fades:                      fades,
 		exitAfter:                  config.ExitAfter,
+		metricsAddress:             config.MetricsAddress,
+		apiAddress:                 config.APIAddress,
+		apiServer:                  apiServer,
+		debriefFilter:              debriefFilter,
+		pilots:                     pilotRegistry,
 	}
 	return app, nil
 }
@@ -208,7 +343,7 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 	go func() {
 		defer wg.Done()
 		log.Info().Msg("running telemetry client")
-		if err := a.tacviewClient.Run(ctx, wg); err != nil {
+		if err := a.contactSource.Run(ctx, wg); err != nil {
 			if !errors.Is(err, context.Canceled) {
 				log.Error().Err(err).Msg("error running telemetry client")
 				cancel()
@@ -216,11 +351,31 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 		}
 	}()
 
+	if a.pilots != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log.Info().Msg("watching pilot registry for changes")
+			a.pilots.Watch(ctx, 10*time.Second)
+		}()
+	}
+
+	starts, updates, fades := a.starts, a.updates, a.fades
+	if a.apiServer != nil {
+		starts, updates, fades = make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log.Info().Msg("publishing telemetry data to API")
+			a.publishTelemetry(ctx, starts, updates, fades)
+		}()
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		log.Info().Msg("streaming telemetry data to radar")
-		a.tacviewClient.Stream(ctx, wg, a.starts, a.updates, a.fades)
+		a.contactSource.Stream(ctx, wg, starts, updates, fades)
 	}()
 
 	wg.Add(1)
@@ -253,6 +408,12 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.logSRSStatusChanges(ctx)
+	}()
+
 	rxTextChan := make(chan Message[string])
 	requestChan := make(chan Message[any])
 	callChan := make(chan controller.Call)
@@ -300,6 +461,29 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 		defer wg.Done()
 		a.parse(ctx, rxTextChan, requestChan)
 	}()
+	log.Info().Msg("starting response queue routines")
+	queuedRequestChan := make(chan Message[any])
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message := <-requestChan:
+				if simpleradio.GetPriority(message.Context) == simpleradio.PriorityUrgent {
+					a.requests.EnqueueUrgent(message)
+				} else {
+					a.requests.Enqueue(message)
+				}
+			}
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.requests.Run(ctx, queuedRequestChan)
+	}()
 	log.Info().Msg("starting radar scope routine")
 	wg.Add(1)
 	go func() {
@@ -310,7 +494,7 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		a.control(ctx, wg, requestChan, callChan)
+		a.control(ctx, wg, queuedRequestChan, callChan)
 	}()
 	log.Info().Msg("starting response composer routine")
 	wg.Add(1)
@@ -331,6 +515,24 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 		a.transmit(ctx, txAudioChan)
 	}()
 
+	if a.metricsAddress != "" {
+		log.Info().Str("address", a.metricsAddress).Msg("starting metrics server routine")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.serveMetrics(ctx)
+		}()
+	}
+
+	if a.apiServer != nil {
+		log.Info().Str("address", a.apiAddress).Msg("starting API server routine")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.serveAPI(ctx)
+		}()
+	}
+
 	log.Info().Dur("duration", a.exitAfter).Msg("starting exit timer routine")
 	wg.Add(1)
 	go func() {
@@ -362,16 +564,25 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 	return nil
 }
 
+// Close implements Application.Close.
+func (a *app) Close(ctx context.Context) error {
+	log.Info().Msg("closing SRS client")
+	if err := a.srsClient.Close(ctx); err != nil {
+		return fmt.Errorf("error closing SRS client: %w", err)
+	}
+	return nil
+}
+
 // updateMissionTime updates the mission time on the radar.
 func (a *app) updateMissionTime() {
-	missionTime := a.tacviewClient.Time()
+	missionTime := a.contactSource.Time()
 	a.radar.SetMissionTime(missionTime)
 }
 
 // updateBullseyes updates the positions of the bullseyes on the radar.
 func (a *app) updateBullseyes() {
 	for _, coalition := range []coalitions.Coalition{coalitions.Red, coalitions.Blue} {
-		bullseye, err := a.tacviewClient.Bullseye(coalition)
+		bullseye, err := a.contactSource.Bullseye(coalition)
 		if err != nil {
 			log.Warn().Err(err).Msg("error reading bullseye")
 		} else {
@@ -389,3 +600,22 @@ func (a *app) trace(ctx context.Context) {
 		tracer.Trace(ctx)
 	}
 }
+
+// logSRSStatusChanges logs each change in the status of the SRS connection.
+func (a *app) logSRSStatusChanges(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case status := <-a.srsClient.StatusChanges():
+			switch status {
+			case simpleradio.StatusConnected:
+				log.Info().Stringer("status", status).Msg("connected to SRS server")
+			case simpleradio.StatusReconnecting:
+				log.Warn().Stringer("status", status).Msg("lost connection to SRS server, reconnecting")
+			default:
+				log.Warn().Stringer("status", status).Msg("disconnected from SRS server")
+			}
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/composer"
 	"github.com/dharmab/skyeye/pkg/controller"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
 	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/rs/zerolog/log"
 )
@@ -40,6 +41,12 @@ func (a *app) composeCall(ctx context.Context, call any, out chan<- Message[comp
 		response = a.composer.ComposeDeclareResponse(c)
 	case brevity.FadedCall:
 		response = a.composer.ComposeFadedCall(c)
+	case brevity.VanishedCall:
+		response = a.composer.ComposeVanishedCall(c)
+	case brevity.CleanResponse:
+		response = a.composer.ComposeCleanResponse(c)
+	case brevity.NoRadarDataResponse:
+		response = a.composer.ComposeNoRadarDataResponse(c)
 	case brevity.NegativeRadarContactResponse:
 		response = a.composer.ComposeNegativeRadarContactResponse(c)
 	case brevity.PictureResponse:
@@ -52,12 +59,18 @@ func (a *app) composeCall(ctx context.Context, call any, out chan<- Message[comp
 		response = a.composer.ComposeSpikedResponse(c)
 	case brevity.TripwireResponse:
 		response = a.composer.ComposeTripwireResponse(c)
+	case brevity.NumerousCall:
+		response = a.composer.ComposeNumerousCall(c)
+	case brevity.SectorCleanCall:
+		response = a.composer.ComposeSectorCleanCall(c)
 	case brevity.SunriseCall:
 		response = a.composer.ComposeSunriseCall(c)
 	case brevity.ThreatCall:
 		response = a.composer.ComposeThreatCall(c)
+		ctx = simpleradio.WithPriority(ctx, simpleradio.PriorityUrgent)
 	case brevity.MergedCall:
 		response = a.composer.ComposeMergedCall(c)
+		ctx = simpleradio.WithPriority(ctx, simpleradio.PriorityUrgent)
 	case brevity.SayAgainResponse:
 		response = a.composer.ComposeSayAgainResponse(c)
 	default:
@@ -65,6 +78,9 @@ func (a *app) composeCall(ctx context.Context, call any, out chan<- Message[comp
 		a.trace(traces.WithRequestError(ctx, errors.New("no route for call")))
 	}
 
+	a.recordDebrief(ctx, call)
+	a.publishCallout(call, response.Subtitle)
+
 	logger.Info().Str("speech", response.Speech).Str("subtitle", response.Subtitle).Msg("composed brevity call")
 	ctx = traces.WithCallText(ctx, response.Subtitle)
 	ctx = traces.WithComposedAt(ctx, time.Now())
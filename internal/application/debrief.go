@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/debrief"
+	"github.com/dharmab/skyeye/pkg/traces"
+	"github.com/rs/zerolog/log"
+)
+
+// recordDebrief logs PICTURE and BOGEY DOPE responses for post-mission debriefs, if debrief logging is enabled.
+func (a *app) recordDebrief(ctx context.Context, call any) {
+	if a.debriefFilter == nil {
+		return
+	}
+	hostile := a.coalition.Opposite()
+	var record debrief.Record
+	switch c := call.(type) {
+	case brevity.PictureResponse:
+		callsign := ""
+		if request, ok := traces.GetRequest(ctx).(*brevity.PictureRequest); ok {
+			callsign = request.Callsign
+		}
+		record = debrief.NewPictureRecord(time.Now(), callsign, hostile, c)
+	case brevity.BogeyDopeResponse:
+		record = debrief.NewBogeyDopeRecord(time.Now(), hostile, c)
+	default:
+		return
+	}
+	debrief.Log(&log.Logger, *a.debriefFilter, record)
+}
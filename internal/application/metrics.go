@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+// serveMetrics serves Prometheus metrics until the context is canceled.
+func (a *app) serveMetrics(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	server := &http.Server{
+		Addr:              a.metricsAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		log.Info().Msg("stopping metrics server due to context cancellation")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("error shutting down metrics server")
+		}
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error().Err(err).Msg("error serving metrics")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/metrics"
 	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/rs/zerolog/log"
 )
@@ -30,6 +32,14 @@ func (a *app) parseText(ctx context.Context, text string, out chan<- Message[any
 	logger.Info().Msg("parsing text")
 	request := a.parser.Parse(text)
 	ctx = traces.WithParsedAt(ctx, time.Now())
+	switch request.(type) {
+	case nil:
+		// Not addressed to the GCI.
+	case *brevity.UnableToUnderstandRequest:
+		metrics.ParseTotal.WithLabelValues(metrics.ParseFail).Inc()
+	default:
+		metrics.ParseTotal.WithLabelValues(metrics.ParseOK).Inc()
+	}
 	if request != nil {
 		ctx = traces.WithRequest(ctx, request)
 		logger.Info().Any("request", request).Msg("parsed text")
@@ -21,7 +21,17 @@ func (a *app) recognize(ctx context.Context, out chan<- Message[string]) {
 			rCtx := context.Background()
 			rCtx = traces.WithTraceID(rCtx, transmission.TraceID)
 			rCtx = traces.WithClientName(rCtx, transmission.ClientName)
+			rCtx = traces.WithTransmitterGUID(rCtx, string(transmission.Transmitter.GUID))
+			rCtx = traces.WithTransmitterUnit(rCtx, transmission.Transmitter.Unit)
 			rCtx = traces.WithReceivedAt(rCtx, time.Now())
+			if len(transmission.Frequencies) > 0 {
+				rCtx = simpleradio.WithRadioFrequency(rCtx, transmission.Frequencies[0])
+			}
+			if transmission.Guard {
+				// Requests on guard may be emergencies, so they are handled ahead of requests on tactical frequencies.
+				log.Info().Str("traceID", transmission.TraceID).Msg("received transmission on guard frequency")
+				rCtx = simpleradio.WithPriority(rCtx, simpleradio.PriorityUrgent)
+			}
 			a.recognizeSample(ctx, rCtx, transmission.Audio, out)
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/metrics"
 	"github.com/dharmab/skyeye/pkg/simpleradio"
 	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/rs/zerolog/log"
@@ -28,9 +29,25 @@ func (a *app) transmitMessage(rCtx context.Context, audio simpleradio.Audio) {
 		TraceID:    traces.GetTraceID(rCtx),
 		ClientName: traces.GetClientName(rCtx),
 		Audio:      audio,
+		Priority:   simpleradio.GetPriority(rCtx),
+	}
+	// Reply on the frequency the request was received on, so that replies to requests on guard are only sent on guard.
+	// Calls which are not replies are sent on all frequencies except guard.
+	if frequency := simpleradio.GetRadioFrequency(rCtx); frequency != nil {
+		transmission.Frequencies = []simpleradio.RadioFrequency{*frequency}
+	} else {
+		transmission.Frequencies = simpleradio.TacticalFrequencies(a.srsClient.Frequencies())
+		if len(transmission.Frequencies) == 0 {
+			log.Warn().Str("traceID", transmission.TraceID).Msg("not transmitting call because there are no tactical frequencies")
+			return
+		}
 	}
 
 	log.Info().Str("traceID", transmission.TraceID).Msg("transmitting audio")
 	a.srsClient.Transmit(transmission)
-	a.trace(traces.WithSubmittedAt(rCtx, time.Now()))
+	submittedAt := time.Now()
+	if receivedAt := traces.GetReceivedAt(rCtx); !receivedAt.IsZero() {
+		metrics.ResponseLatency.Observe(submittedAt.Sub(receivedAt).Seconds())
+	}
+	a.trace(traces.WithSubmittedAt(rCtx, submittedAt))
 }
@@ -3,7 +3,11 @@ package conf
 import (
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/datasource"
+	"github.com/dharmab/skyeye/pkg/datasource/dcs"
+	"github.com/dharmab/skyeye/pkg/debrief"
 	"github.com/dharmab/skyeye/pkg/simpleradio"
 	"github.com/dharmab/skyeye/pkg/synthesizer/voices"
 	"github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
@@ -22,6 +26,15 @@ type Configuration struct {
 	TelemetryClientName string
 	// TelemetryPassword is the password for connecting to the real-time telemetry server
 	TelemetryPassword string
+	// LotATCAddress is the local UDP address to receive the LotATC JSON feed on. If set, contacts are read from LotATC instead of real-time telemetry
+	LotATCAddress string
+	// DCSExportAddress is the address of the DCS export socket. If set, contacts are read from the DCS export instead of real-time telemetry
+	DCSExportAddress string
+	// DCSTypeMapping maps DCS aircraft types to names and IFF classifications. It may be nil
+	DCSTypeMapping *dcs.TypeMapping
+	// Deduplication are the keys used to merge aircraft reported more than once by the DCS export or LotATC. It may be
+	// empty
+	Deduplication []datasource.DeduplicationKey
 	// SRSAddress is the network address of the SimpleRadio Standalone server (including port)
 	SRSAddress string
 	// SRSConnectionTimeout is the connection timeout for connecting to the SimpleRadio Standalone server
@@ -32,6 +45,26 @@ type Configuration struct {
 	SRSExternalAWACSModePassword string
 	// SRSFrequencies that the bot simultaneously receives and transmits on
 	SRSFrequencies []simpleradio.RadioFrequency
+	// SRSMonitorGuard controls whether the bot also receives on the UHF and VHF guard frequencies. Replies to requests on guard are only sent on guard, and other calls are never sent on guard
+	SRSMonitorGuard bool
+	// SRSFilterCoalition controls whether the bot ignores transmissions from other coalitions and spectators, even if the SimpleRadio Standalone server does not enforce secure coalition radios
+	SRSFilterCoalition bool
+	// SRSAllowSpectators controls whether the bot receives transmissions from spectators. Transmissions from the opposing coalition are never received
+	SRSAllowSpectators bool
+	// SRSJitterBufferDepth is how long the bot waits for SRS voice packets which arrive out of order before giving up on them
+	SRSJitterBufferDepth time.Duration
+	// SRSEndOfTransmissionSilence is how long after the last SRS voice packet the bot considers a transmission over
+	SRSEndOfTransmissionSilence time.Duration
+	// SRSMaxTransmissionLength is the length of SRS audio after which the bot recognizes a transmission even if the pilot is still talking
+	SRSMaxTransmissionLength time.Duration
+	// SRSMaxTransmitHold is how long the bot waits for players to stop talking before it transmits anyway
+	SRSMaxTransmitHold time.Duration
+	// SRSKeepaliveTimeout is how long the bot may go without hearing from the SRS server before it reconnects
+	SRSKeepaliveTimeout time.Duration
+	// ReplayFile is the path to a file of pre-recorded SRS transmissions. If set, the transmissions are played back instead of connecting to the SimpleRadio Standalone server
+	ReplayFile string
+	// ReplaySpeed is a playback speed multiplier for ReplayFile
+	ReplaySpeed float64
 	// EnableGRPC controls whether DCS-gRPC features are enabled
 	EnableGRPC bool
 	// GRPCAddress is the network address of the DCS-gRPC server (including port)
@@ -59,12 +92,69 @@ type Configuration struct {
 	EnableAutomaticPicture bool
 	// PictureBroadcastInterval is the interval at which the controller will automatically broadcast a PICTURE.
 	PictureBroadcastInterval time.Duration
+	// PictureThreshold is the number of hostile contacts at which the controller proactively broadcasts a NUMEROUS call.
+	// Zero disables the alert.
+	PictureThreshold int
+	// PictureThresholdCooldown is the minimum interval between NUMEROUS calls.
+	PictureThresholdCooldown time.Duration
 	// EnableThreatMonitoring controls whether the controller will broadcast THREAT calls.
 	EnableThreatMonitoring bool
 	// ThreatMonitoringInterval is the cooldown period between THREAT calls.
 	ThreatMonitoringInterval time.Duration
 	// MandatoryThreatRadius is the brief range at which a THREAT call is mandatory.
 	MandatoryThreatRadius unit.Length
+	// MagneticVariation is the magnetic variation of the theater, positive to the east and negative to the west. It is
+	// used to convert between true and magnetic bearings. If nil, the variation is computed from the IGRF model.
+	MagneticVariation *unit.Angle
+	// PilotRegistryPath is the path to a YAML file mapping pilot names to callsigns. The file is reloaded when it changes.
+	PilotRegistryPath string
+	// SectorsPath is the path to a YAML file defining named sectors. When the last hostile contact leaves a sector, the
+	// controller broadcasts that the sector is clean. Sectors are disabled if empty
+	SectorsPath string
+	// GeoFencesPath is the path to a YAML file defining named geo-fences. PICTURE and BOGEY DOPE responses only report
+	// contacts within at least one geo-fence. Geo-fences are disabled if empty.
+	GeoFencesPath string
+	// IFFRules declare aircraft by type in DECLARE responses, overriding declarations based on coalition.
+	IFFRules brevity.IFFRules
+	// MergeDistance is the distance within which friendly and hostile contacts enter the merge.
+	MergeDistance unit.Length
+	// MergeAltitude is the altitude separation within which friendly and hostile contacts enter the merge.
+	MergeAltitude unit.Length
+	// AltitudeFloor is the altitude below which contacts are excluded from all GCI responses.
+	AltitudeFloor unit.Length
+	// AltitudeCeiling is the altitude above which contacts are excluded from all GCI responses. If zero, there is no
+	// ceiling.
+	AltitudeCeiling unit.Length
+	// SpeedFloor is the speed at or below which contacts are excluded from all GCI responses.
+	SpeedFloor unit.Speed
+	// SpeedCeiling is the speed above which contacts are excluded from all GCI responses.
+	SpeedCeiling unit.Speed
+	// GroupLateralThreshold is the maximum 2D distance between contacts which are linked into the same group.
+	GroupLateralThreshold unit.Length
+	// GroupVerticalThreshold is the maximum altitude separation between contacts which are linked into the same group.
+	// If zero, contacts are grouped regardless of altitude.
+	GroupVerticalThreshold unit.Length
+	// PicturePriorityWeights are the weights used to order groups in a PICTURE requested by a player.
+	PicturePriorityWeights brevity.PriorityWeights
+	// MaxResponseRate is the maximum number of requests the controller services per minute. Zero or less disables
+	// rate limiting.
+	MaxResponseRate int
+	// ResponseQueueDepth is the maximum number of requests which may wait for the controller. If MaxResponseRate is
+	// set, requests which arrive while the queue is full are dropped.
+	ResponseQueueDepth int
+	// ResponseCacheTTL is how long a response may be reused for an identical request while the contacts it describes
+	// have not moved significantly. Zero disables caching.
+	ResponseCacheTTL time.Duration
+	// FadedDelay is how long after a group stops being updated by sensors that a FADED call is broadcast.
+	FadedDelay time.Duration
+	// VanishedTimeout is how long after a group stops being updated by sensors that a VANISHED call is broadcast. If
+	// this is not longer than FadedDelay, VANISHED calls are disabled.
+	VanishedTimeout time.Duration
+	// ContactTTL is how long a trackfile may go without sensor updates before it is removed and a FADED call is
+	// broadcast. If zero, stale trackfiles are not removed this way.
+	ContactTTL time.Duration
+	// GorillaThreshold is the minimum number of contacts in a group which is described as a GORILLA instead of HEAVY.
+	GorillaThreshold int
 	// ThreatMonitoringRequiresSRS controls whether threat calls are issued to aircraft that are not on an SRS frequency. This is mostly
 	// for debugging.
 	ThreatMonitoringRequiresSRS bool
@@ -74,8 +164,74 @@ type Configuration struct {
 	DiscordWebhookID string
 	// DiscordWebhookToken is the token for the Discord webhook
 	DiscorbWebhookToken string
+	// APIAddress is the network address to serve the GCI API on. The API is disabled if empty
+	APIAddress string
+	// APIToken is the pre-shared token which API clients must present
+	APIToken string
+	// EnableDebriefLogging controls whether PICTURE and BOGEY DOPE responses are logged as structured records for debriefs
+	EnableDebriefLogging bool
+	// DebriefRedactions are sensitive fields which are redacted from debrief logs
+	DebriefRedactions []debrief.Field
+	// GroupLogFile is the path to a JSON Lines file where group merge and split events are written at the end of each
+	// mission. Group membership is not recorded if empty
+	GroupLogFile string
 	// ExitAfter is the duration after which the application will exit
 	ExitAfter time.Duration
+	// MetricsAddress is the network address to serve Prometheus metrics on (including port). Metrics are not served if empty.
+	MetricsAddress string
+	// Controllers configures the GCI controllers which run in the same process. If not empty, these controllers replace
+	// the single controller configured by the above settings, and each inherits any settings which it does not
+	// override. If empty, a single controller runs with the above settings.
+	Controllers []Controller
+}
+
+// Controller overrides the settings of one of several GCI controllers which run in the same process. Each controller
+// has its own SRS client, parser and radar scope.
+type Controller struct {
+	// Callsign is the GCI callsign used on SRS. If empty, the shared callsign is used.
+	Callsign string
+	// Coalition is the coalition that the controller will act on.
+	Coalition coalitions.Coalition
+	// SRSClientName is the name of the controller in the SRS client list. If empty, the shared name is used.
+	SRSClientName string
+	// SRSFrequencies that the controller receives and transmits on. If empty, the shared frequencies are used.
+	SRSFrequencies []simpleradio.RadioFrequency
+	// GeoFencesPath is the path to a YAML file defining the controller's named geo-fences. If empty, the shared
+	// geo-fences are used.
+	GeoFencesPath string
+}
+
+// ControllerConfigurations returns a configuration for each GCI controller. Metrics and the API are only served by the
+// first controller, since they cannot share a network address.
+func (c Configuration) ControllerConfigurations() []Configuration {
+	if len(c.Controllers) == 0 {
+		return []Configuration{c}
+	}
+	configs := make([]Configuration, 0, len(c.Controllers))
+	for i, controller := range c.Controllers {
+		config := c
+		config.Controllers = nil
+		config.Coalition = controller.Coalition
+		if controller.Callsign != "" {
+			config.Callsign = controller.Callsign
+			config.TelemetryClientName = controller.Callsign
+		}
+		if controller.SRSClientName != "" {
+			config.SRSClientName = controller.SRSClientName
+		}
+		if len(controller.SRSFrequencies) > 0 {
+			config.SRSFrequencies = controller.SRSFrequencies
+		}
+		if controller.GeoFencesPath != "" {
+			config.GeoFencesPath = controller.GeoFencesPath
+		}
+		if i > 0 {
+			config.MetricsAddress = ""
+			config.APIAddress = ""
+		}
+		configs = append(configs, config)
+	}
+	return configs
 }
 
 var DefaultCallsigns = []string{"Sky Eye", "Thunderhead", "Eagle Eye", "Ghost Eye", "Sky Keeper", "Bandog", "Long Caster", "Galaxy"}
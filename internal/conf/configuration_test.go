@@ -0,0 +1,71 @@
+package conf
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControllerConfigurationsSingle(t *testing.T) {
+	t.Parallel()
+	config := Configuration{Callsign: "Focus", Coalition: coalitions.Blue}
+	configs := config.ControllerConfigurations()
+	require.Len(t, configs, 1)
+	assert.Equal(t, "Focus", configs[0].Callsign)
+	assert.EqualValues(t, coalitions.Blue, configs[0].Coalition)
+}
+
+func TestControllerConfigurationsMultiple(t *testing.T) {
+	t.Parallel()
+	shared := []simpleradio.RadioFrequency{{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}}
+	red := []simpleradio.RadioFrequency{{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM}}
+	config := Configuration{
+		Callsign:            "Focus",
+		TelemetryClientName: "Focus",
+		SRSClientName:       "GCI Focus [BOT]",
+		Coalition:           coalitions.Blue,
+		SRSFrequencies:      shared,
+		GeoFencesPath:       "/etc/skyeye/geofences.yaml",
+		MetricsAddress:      "localhost:9090",
+		APIAddress:          "localhost:8080",
+		Controllers: []Controller{
+			{Coalition: coalitions.Blue},
+			{
+				Callsign:       "Wizard",
+				Coalition:      coalitions.Red,
+				SRSClientName:  "GCI Wizard [BOT]",
+				SRSFrequencies: red,
+				GeoFencesPath:  "/etc/skyeye/geofences-red.yaml",
+			},
+		},
+	}
+	configs := config.ControllerConfigurations()
+	require.Len(t, configs, 2)
+
+	blue := configs[0]
+	assert.Equal(t, "Focus", blue.Callsign)
+	assert.Equal(t, "Focus", blue.TelemetryClientName)
+	assert.Equal(t, "GCI Focus [BOT]", blue.SRSClientName)
+	assert.EqualValues(t, coalitions.Blue, blue.Coalition)
+	assert.Equal(t, shared, blue.SRSFrequencies)
+	assert.Equal(t, "/etc/skyeye/geofences.yaml", blue.GeoFencesPath)
+	assert.Equal(t, "localhost:9090", blue.MetricsAddress)
+	assert.Equal(t, "localhost:8080", blue.APIAddress)
+	assert.Empty(t, blue.Controllers)
+
+	redConfig := configs[1]
+	assert.Equal(t, "Wizard", redConfig.Callsign)
+	assert.Equal(t, "Wizard", redConfig.TelemetryClientName)
+	assert.Equal(t, "GCI Wizard [BOT]", redConfig.SRSClientName)
+	assert.EqualValues(t, coalitions.Red, redConfig.Coalition)
+	assert.Equal(t, red, redConfig.SRSFrequencies)
+	assert.Equal(t, "/etc/skyeye/geofences-red.yaml", redConfig.GeoFencesPath)
+	assert.Empty(t, redConfig.MetricsAddress)
+	assert.Empty(t, redConfig.APIAddress)
+	assert.Empty(t, redConfig.Controllers)
+}
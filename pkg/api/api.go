@@ -0,0 +1,67 @@
+// package api serves the GCI state to external dashboards over HTTP.
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// subscriberBuffer is the number of events buffered for each WebSocket client. Clients which fall this far behind are
+// disconnected, since they would otherwise miss events and drift out of sync.
+const subscriberBuffer = 256
+
+// Server is an HTTP server for the GCI API.
+type Server struct {
+	// token is the pre-shared token which clients must present.
+	token string
+	// scope provides the current picture.
+	scope Scope
+	// handler routes requests to handlers.
+	handler http.Handler
+	// subscribers is the set of connected WebSocket clients.
+	subscribers map[*subscriber]struct{}
+	// subscribersLock protects subscribers.
+	subscribersLock sync.Mutex
+}
+
+// NewServer creates a new API server which serves the picture from the given scope. Clients must authenticate with
+// the given token, either in an "Authorization: Bearer <token>" header or a "token" query parameter.
+func NewServer(token string, scope Scope) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("API token is required")
+	}
+	s := &Server{
+		token:       token,
+		scope:       scope,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /events", s.eventsHandler())
+	mux.HandleFunc("GET /picture", s.pictureHandler)
+	mux.HandleFunc("GET /contacts/{id}", s.contactHandler)
+	s.handler = cors(s.authenticate(mux))
+	return s, nil
+}
+
+// ServeHTTP implements [http.Handler].
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// authenticate wraps a handler to reject requests without a valid token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if header := r.Header.Get("Authorization"); header != "" {
+			token = strings.TrimPrefix(header, "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
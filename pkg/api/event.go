@@ -0,0 +1,99 @@
+package api
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+)
+
+// EventType identifies the kind of an [Event].
+type EventType string
+
+const (
+	// ContactAdded is published when the GCI first sees a contact.
+	ContactAdded EventType = "contact_added"
+	// ContactUpdated is published when a known contact's position is updated.
+	ContactUpdated EventType = "contact_updated"
+	// ContactFaded is published when a contact disappears.
+	ContactFaded EventType = "contact_faded"
+	// CalloutMade is published when the GCI makes a radio call.
+	CalloutMade EventType = "callout_made"
+)
+
+// Event is a change to the GCI state, streamed to clients as a JSON object.
+type Event struct {
+	// Type of the event.
+	Type EventType `json:"type"`
+	// Time is the wall-clock time when the event was published.
+	Time time.Time `json:"time"`
+	// Contact is set for contact events. For ContactFaded events, only the ID is set.
+	Contact *Contact `json:"contact,omitempty"`
+	// Callout is set for CalloutMade events.
+	Callout *Callout `json:"callout,omitempty"`
+}
+
+// Contact describes a single aircraft.
+type Contact struct {
+	// ID is the object ID from TacView.
+	ID uint64 `json:"id"`
+	// Name is the player's name or the unit name.
+	Name string `json:"name,omitempty"`
+	// Coalition the aircraft belongs to.
+	Coalition string `json:"coalition,omitempty"`
+	// Aircraft is the name of the aircraft type in the ACMI file.
+	Aircraft string `json:"aircraft,omitempty"`
+	// Latitude in decimal degrees. This is nil for ContactFaded events.
+	Latitude *float64 `json:"latitude,omitempty"`
+	// Longitude in decimal degrees. This is nil for ContactFaded events.
+	Longitude *float64 `json:"longitude,omitempty"`
+	// Altitude above sea level in feet. This is nil for ContactFaded events.
+	Altitude *float64 `json:"altitude,omitempty"`
+	// Heading is the true direction of movement in degrees. This is nil for ContactFaded events.
+	Heading *float64 `json:"heading,omitempty"`
+}
+
+// Callout describes a radio call made by the GCI.
+type Callout struct {
+	// Call is the type of the call, e.g. "PICTURE".
+	Call string `json:"call"`
+	// Text is the subtitle of the call.
+	Text string `json:"text"`
+}
+
+// NewContactEvent creates a ContactAdded or ContactUpdated event from a contact's labels and latest frame.
+func NewContactEvent(eventType EventType, labels trackfiles.Labels, frame trackfiles.Frame) Event {
+	latitude, longitude := frame.Point.Lat(), frame.Point.Lon()
+	altitude, heading := frame.Altitude.Feet(), frame.Heading.Degrees()
+	return Event{
+		Type: eventType,
+		Time: time.Now(),
+		Contact: &Contact{
+			ID:        labels.ID,
+			Name:      labels.Name,
+			Coalition: labels.Coalition.String(),
+			Aircraft:  labels.ACMIName,
+			Latitude:  &latitude,
+			Longitude: &longitude,
+			Altitude:  &altitude,
+			Heading:   &heading,
+		},
+	}
+}
+
+// NewFadedEvent creates a ContactFaded event.
+func NewFadedEvent(id uint64) Event {
+	return Event{
+		Type:    ContactFaded,
+		Time:    time.Now(),
+		Contact: &Contact{ID: id},
+	}
+}
+
+// NewCalloutEvent creates a CalloutMade event.
+func NewCalloutEvent(call, text string) Event {
+	return Event{
+		Type:    CalloutMade,
+		Time:    time.Now(),
+		Callout: &Callout{Call: call, Text: text},
+	}
+}
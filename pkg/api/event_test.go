@@ -0,0 +1,37 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactEventZeroValues(t *testing.T) {
+	t.Parallel()
+	// A helicopter parked at sea level at the null island, facing north.
+	labels := trackfiles.Labels{ID: 7, Name: "Garuda 1", Coalition: coalitions.Blue, ACMIName: "UH-1H"}
+	event := NewContactEvent(ContactUpdated, labels, trackfiles.Frame{Point: orb.Point{0, 0}})
+
+	b, err := json.Marshal(event)
+	require.NoError(t, err)
+	var actual map[string]any
+	require.NoError(t, json.Unmarshal(b, &actual))
+	contact, ok := actual["contact"].(map[string]any)
+	require.True(t, ok)
+	for _, key := range []string{"latitude", "longitude", "altitude", "heading"} {
+		assert.Contains(t, contact, key)
+		assert.InDelta(t, 0, contact[key], 0)
+	}
+
+	b, err = json.Marshal(NewFadedEvent(7))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &actual))
+	contact, ok = actual["contact"].(map[string]any)
+	require.True(t, ok)
+	assert.NotContains(t, contact, "altitude")
+}
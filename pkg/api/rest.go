@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+// Scope provides the current GCI picture to the API.
+type Scope interface {
+	// Picture returns the total number of hostile groups and up to a few high priority groups, each with Bullseye and
+	// Declaration set.
+	Picture() (int, []brevity.Group)
+	// FindUnit returns the trackfile for the given unit ID, or nil if no trackfile was found.
+	FindUnit(uint64) *trackfiles.Trackfile
+	// MagneticVariation returns the conversion between true and magnetic bearings.
+	MagneticVariation() bearings.MagneticVariation
+}
+
+// Picture is the response body for GET /picture.
+type Picture struct {
+	// Count is the total number of groups, which may be more than the number of groups listed.
+	Count int `json:"count"`
+	// Groups are the highest priority groups.
+	Groups []Group `json:"groups"`
+}
+
+// Group describes a group of contacts.
+type Group struct {
+	// Bearing from the bullseye in magnetic degrees.
+	Bearing float64 `json:"bearing"`
+	// Range from the bullseye in nautical miles.
+	Range float64 `json:"range"`
+	// Altitude of the highest stack in feet.
+	Altitude float64 `json:"altitude"`
+	// Count is the number of contacts in the group.
+	Count int `json:"count"`
+	// Declaration is the IFF result for the group.
+	Declaration string `json:"declaration"`
+	// Track is the direction the group is moving.
+	Track string `json:"track"`
+	// Platforms are the aircraft types in the group.
+	Platforms []string `json:"platforms"`
+}
+
+// Track is the response body for GET /contacts/{id}.
+type Track struct {
+	Contact
+	// Speed is the ground speed in knots.
+	Speed float64 `json:"speed"`
+	// Course is the magnetic course in degrees.
+	Course float64 `json:"course"`
+	// Direction is the cardinal direction of the course.
+	Direction string `json:"direction"`
+}
+
+// pictureHandler serves the current picture.
+func (s *Server) pictureHandler(w http.ResponseWriter, _ *http.Request) {
+	count, groups := s.scope.Picture()
+	picture := Picture{Count: count, Groups: make([]Group, 0, len(groups))}
+	for _, group := range groups {
+		g := Group{
+			Altitude:    group.Altitude().Feet(),
+			Count:       group.Contacts(),
+			Declaration: string(group.Declaration()),
+			Track:       string(group.Track()),
+			Platforms:   group.Platforms(),
+		}
+		if bullseye := group.Bullseye(); bullseye != nil {
+			g.Bearing = bullseye.Bearing().RoundedDegrees()
+			g.Range = bullseye.Distance().NauticalMiles()
+		}
+		picture.Groups = append(picture.Groups, g)
+	}
+	writeJSON(w, http.StatusOK, picture)
+}
+
+// contactHandler serves a single trackfile.
+func (s *Server) contactHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid contact ID", http.StatusBadRequest)
+		return
+	}
+	trackfile := s.scope.FindUnit(id)
+	if trackfile == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	event := NewContactEvent(ContactUpdated, trackfile.Contact, trackfile.LastKnown())
+	writeJSON(w, http.StatusOK, Track{
+		Contact:   *event.Contact,
+		Speed:     trackfile.Speed().Knots(),
+		Course:    trackfile.Course(s.scope.MagneticVariation()).RoundedDegrees(),
+		Direction: string(trackfile.Direction(s.scope.MagneticVariation())),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn().Err(err).Msg("failed to write API response")
+	}
+}
+
+// cors wraps a handler to allow requests from dashboards served on other origins. Preflight requests are answered
+// without authentication, since browsers do not send credentials with them.
+func cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
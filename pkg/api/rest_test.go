@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGroup struct {
+	brevity.Group
+}
+
+func (g *fakeGroup) Contacts() int                    { return 2 }
+func (g *fakeGroup) Altitude() unit.Length            { return 27000 * unit.Foot }
+func (g *fakeGroup) Declaration() brevity.Declaration { return brevity.Hostile }
+func (g *fakeGroup) Track() brevity.Track             { return brevity.West }
+func (g *fakeGroup) Platforms() []string              { return []string{"Flanker"} }
+func (g *fakeGroup) Bullseye() *brevity.Bullseye {
+	return brevity.NewBullseye(bearings.NewMagneticBearing(120*unit.Degree), 35*unit.NauticalMile)
+}
+
+type fakeScope struct {
+	trackfiles map[uint64]*trackfiles.Trackfile
+}
+
+func (s *fakeScope) Picture() (int, []brevity.Group) {
+	return 4, []brevity.Group{&fakeGroup{}}
+}
+
+func (s *fakeScope) FindUnit(id uint64) *trackfiles.Trackfile {
+	return s.trackfiles[id]
+}
+
+func (s *fakeScope) MagneticVariation() bearings.MagneticVariation {
+	return bearings.NewFixedMagneticVariation(0)
+}
+
+func newTestScope() *fakeScope {
+	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
+		ID:        7,
+		Name:      "Yellow 13",
+		Coalition: coalitions.Red,
+		ACMIName:  "Su-27",
+	})
+	now := time.Now()
+	start := orb.Point{41.6, 42.1}
+	trackfile.Update(trackfiles.Frame{Time: now.Add(-10 * time.Second), Point: start, Altitude: 20000 * unit.Foot, Heading: 90 * unit.Degree})
+	trackfile.Update(trackfiles.Frame{
+		Time:     now,
+		Point:    spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(90*unit.Degree), 2*unit.Kilometer),
+		Altitude: 20000 * unit.Foot,
+		Heading:  90 * unit.Degree,
+	})
+	return &fakeScope{trackfiles: map[uint64]*trackfiles.Trackfile{7: trackfile}}
+}
+
+func get(t *testing.T, server *httptest.Server, path string, authorized bool) *http.Response {
+	t.Helper()
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	if authorized {
+		request.Header.Set("Authorization", "Bearer "+testToken)
+	}
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+func TestPicture(t *testing.T) {
+	t.Parallel()
+	s, err := NewServer(testToken, newTestScope())
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	response := get(t, server, "/picture", true)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	assert.Equal(t, "*", response.Header.Get("Access-Control-Allow-Origin"))
+
+	var picture map[string]any
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&picture))
+	assert.InDelta(t, 4, picture["count"], 0)
+	groups, ok := picture["groups"].([]any)
+	require.True(t, ok)
+	require.Len(t, groups, 1)
+	group, ok := groups[0].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 120, group["bearing"], 0.5)
+	assert.InDelta(t, 35, group["range"], 0.5)
+	assert.InDelta(t, 27000, group["altitude"], 0)
+	assert.InDelta(t, 2, group["count"], 0)
+	assert.Equal(t, "hostile", group["declaration"])
+	assert.Equal(t, "west", group["track"])
+	assert.Equal(t, []any{"Flanker"}, group["platforms"])
+}
+
+func TestContact(t *testing.T) {
+	t.Parallel()
+	s, err := NewServer(testToken, newTestScope())
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	response := get(t, server, "/contacts/7", true)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var contact map[string]any
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&contact))
+	assert.InDelta(t, 7, contact["id"], 0)
+	assert.Equal(t, "Yellow 13", contact["name"])
+	assert.Equal(t, "Red", contact["coalition"])
+	assert.Equal(t, "Su-27", contact["aircraft"])
+	assert.InDelta(t, 20000, contact["altitude"], 1)
+	assert.InDelta(t, 389, contact["speed"], 5)
+	assert.Equal(t, "east", contact["direction"])
+	for _, key := range []string{"latitude", "longitude", "heading", "course"} {
+		assert.Contains(t, contact, key)
+	}
+}
+
+func TestRESTStatusCodes(t *testing.T) {
+	t.Parallel()
+	s, err := NewServer(testToken, newTestScope())
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	testCases := []struct {
+		path       string
+		authorized bool
+		expected   int
+	}{
+		{"/picture", true, http.StatusOK},
+		{"/picture", false, http.StatusUnauthorized},
+		{"/contacts/7", true, http.StatusOK},
+		{"/contacts/7", false, http.StatusUnauthorized},
+		{"/contacts/8", true, http.StatusNotFound},
+		{"/contacts/bandit", true, http.StatusBadRequest},
+		{"/unknown", true, http.StatusNotFound},
+	}
+	for _, test := range testCases {
+		response := get(t, server, test.path, test.authorized)
+		assert.Equal(t, test.expected, response.StatusCode, test.path)
+		assert.Equal(t, "*", response.Header.Get("Access-Control-Allow-Origin"), test.path)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	t.Parallel()
+	s, err := NewServer(testToken, newTestScope())
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodOptions, server.URL+"/picture", nil)
+	require.NoError(t, err)
+	request.Header.Set("Origin", "https://dashboard.example")
+	request.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Equal(t, "*", response.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, response.Header.Get("Access-Control-Allow-Headers"), "Authorization")
+}
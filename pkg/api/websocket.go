@@ -0,0 +1,106 @@
+package api
+
+import (
+	"io"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/websocket"
+)
+
+// writeTimeout is how long to wait when writing an event to a WebSocket client.
+const writeTimeout = 10 * time.Second
+
+// subscriber is a connected WebSocket client.
+type subscriber struct {
+	// events is a buffered channel of events to send to the client. It is closed when the client should be disconnected.
+	events chan Event
+}
+
+// Publish sends an event to all connected WebSocket clients. It does not block; clients which are too slow to keep up
+// are disconnected.
+func (s *Server) Publish(event Event) {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+	for sub := range s.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			log.Warn().Msg("disconnecting API client which is not keeping up with events")
+			delete(s.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// Close disconnects all WebSocket clients.
+func (s *Server) Close() {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *Server) subscribe() *subscriber {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+	sub := &subscriber{events: make(chan Event, subscriberBuffer)}
+	s.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (s *Server) unsubscribe(sub *subscriber) {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *Server) subscriberCount() int {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+	return len(s.subscribers)
+}
+
+// eventsHandler streams events to a WebSocket client as JSON text frames.
+func (s *Server) eventsHandler() websocket.Server {
+	// The Origin header is not checked, since dashboards may be served from anywhere. Clients are authenticated by token instead.
+	return websocket.Server{Handler: func(conn *websocket.Conn) {
+		logger := log.With().Str("remote", conn.Request().RemoteAddr).Logger()
+		logger.Info().Msg("API client connected")
+		defer logger.Info().Msg("API client disconnected")
+
+		sub := s.subscribe()
+		defer s.unsubscribe(sub)
+
+		// Clients are not expected to send anything, but reading is needed to notice when they disconnect.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			_, _ = io.Copy(io.Discard, conn)
+		}()
+
+		for {
+			select {
+			case event, ok := <-sub.events:
+				if !ok {
+					_ = conn.Close()
+					return
+				}
+				if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+					logger.Warn().Err(err).Msg("failed to set write deadline")
+				}
+				if err := websocket.JSON.Send(conn, event); err != nil {
+					logger.Warn().Err(err).Msg("failed to send event to API client")
+					return
+				}
+			case <-closed:
+				return
+			}
+		}
+	}}
+}
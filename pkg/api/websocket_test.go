@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+const testToken = "hunter2"
+
+func dial(t *testing.T, server *httptest.Server, header http.Header, query string) (*websocket.Conn, error) {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/events" + query
+	config, err := websocket.NewConfig(url, server.URL)
+	require.NoError(t, err)
+	for k, v := range header {
+		config.Header[k] = v
+	}
+	return websocket.DialConfig(config)
+}
+
+func TestEventStream(t *testing.T) {
+	t.Parallel()
+	s, err := NewServer(testToken, nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+	defer s.Close()
+
+	conn, err := dial(t, server, http.Header{"Authorization": {"Bearer " + testToken}}, "")
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool { return s.subscriberCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	labels := trackfiles.Labels{ID: 42, Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-22A"}
+	frame := trackfiles.Frame{
+		Point:    orb.Point{41.6, 42.1},
+		Altitude: 25000 * unit.Foot,
+		Heading:  90 * unit.Degree,
+	}
+	events := []Event{
+		NewContactEvent(ContactAdded, labels, frame),
+		NewContactEvent(ContactUpdated, labels, frame),
+		NewCalloutEvent("PICTURE", "Mobius 1, Skyeye, single contact."),
+		NewFadedEvent(42),
+	}
+	for _, event := range events {
+		s.Publish(event)
+	}
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for _, expected := range events {
+		var actual map[string]any
+		require.NoError(t, websocket.JSON.Receive(conn, &actual))
+		assert.Equal(t, string(expected.Type), actual["type"])
+		assert.Contains(t, actual, "time")
+		switch expected.Type {
+		case ContactAdded, ContactUpdated:
+			contact, ok := actual["contact"].(map[string]any)
+			require.True(t, ok)
+			assert.InDelta(t, 42, contact["id"], 0)
+			assert.Equal(t, "Mobius 1", contact["name"])
+			assert.Equal(t, "Blue", contact["coalition"])
+			assert.Equal(t, "F-22A", contact["aircraft"])
+			assert.InDelta(t, 42.1, contact["latitude"], 0.001)
+			assert.InDelta(t, 41.6, contact["longitude"], 0.001)
+			assert.InDelta(t, 25000, contact["altitude"], 1)
+			assert.InDelta(t, 90, contact["heading"], 0.001)
+		case ContactFaded:
+			contact, ok := actual["contact"].(map[string]any)
+			require.True(t, ok)
+			assert.InDelta(t, 42, contact["id"], 0)
+			assert.NotContains(t, contact, "name")
+		case CalloutMade:
+			callout, ok := actual["callout"].(map[string]any)
+			require.True(t, ok)
+			assert.Equal(t, "PICTURE", callout["call"])
+			assert.Equal(t, "Mobius 1, Skyeye, single contact.", callout["text"])
+		}
+	}
+
+	conn.Close()
+	require.Eventually(t, func() bool { return s.subscriberCount() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestEventStreamQueryToken(t *testing.T) {
+	t.Parallel()
+	s, err := NewServer(testToken, nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+	defer s.Close()
+
+	conn, err := dial(t, server, nil, "?token="+testToken)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool { return s.subscriberCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	s.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var event Event
+	require.Error(t, websocket.JSON.Receive(conn, &event))
+}
+
+func TestEventStreamUnauthorized(t *testing.T) {
+	t.Parallel()
+	s, err := NewServer(testToken, nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	for _, header := range []http.Header{nil, {"Authorization": {"Bearer wrong"}}} {
+		_, err := dial(t, server, header, "")
+		require.Error(t, err)
+	}
+	assert.Equal(t, 0, s.subscriberCount())
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/events", nil)
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestNewServerRequiresToken(t *testing.T) {
+	t.Parallel()
+	_, err := NewServer("", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,89 @@
+// package areas defines named geographic areas bounded by polygons, such as sectors and geo-fences.
+package areas
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+	"gopkg.in/yaml.v3"
+)
+
+// Area is a named geographic area.
+type Area struct {
+	// Name is spoken or logged when describing the area, e.g. "North" or "CAP West".
+	Name string
+	// Boundary is the closed ring of points which encloses the area.
+	Boundary orb.Ring
+}
+
+// Contains returns true if the point is inside the area.
+func (a Area) Contains(point orb.Point) bool {
+	return planar.RingContains(a.Boundary, point)
+}
+
+// areaDefinition is the format of a single area in an areas file.
+type areaDefinition struct {
+	// Name of the area.
+	Name string `yaml:"name"`
+	// Boundary is a list of at least 3 points which enclose the area, in order.
+	Boundary []point `yaml:"boundary"`
+}
+
+// point is the format of a point in an areas file.
+type point struct {
+	// Lat is the latitude in decimal degrees.
+	Lat float64 `yaml:"lat"`
+	// Lon is the longitude in decimal degrees.
+	Lon float64 `yaml:"lon"`
+}
+
+// Load reads area definitions from a YAML file. The areas are listed under the given top-level key, e.g. "sectors".
+func Load(path, key string) ([]Area, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s file: %w", key, err)
+	}
+	return Parse(b, key)
+}
+
+// Parse parses area definitions from YAML. The areas are listed under the given top-level key, e.g. "sectors".
+func Parse(b []byte, key string) ([]Area, error) {
+	var file map[string][]areaDefinition
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+	}
+
+	var err error
+	names := make(map[string]struct{})
+	areas := make([]Area, 0, len(file[key]))
+	for i, definition := range file[key] {
+		if definition.Name == "" {
+			err = errors.Join(err, fmt.Errorf("area %d has no name", i))
+			continue
+		}
+		if _, ok := names[definition.Name]; ok {
+			err = errors.Join(err, fmt.Errorf("area %q is defined more than once", definition.Name))
+			continue
+		}
+		names[definition.Name] = struct{}{}
+		if len(definition.Boundary) < 3 {
+			err = errors.Join(err, fmt.Errorf("area %q must have at least 3 boundary points", definition.Name))
+			continue
+		}
+		ring := make(orb.Ring, 0, len(definition.Boundary)+1)
+		for _, p := range definition.Boundary {
+			ring = append(ring, orb.Point{p.Lon, p.Lat})
+		}
+		if !ring.Closed() {
+			ring = append(ring, ring[0])
+		}
+		areas = append(areas, Area{Name: definition.Name, Boundary: ring})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+	}
+	return areas, nil
+}
@@ -0,0 +1,82 @@
+package areas
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	areas, err := Parse([]byte("boxes: [{name: Box, boundary: [{lat: 42, lon: 41}, {lat: 42, lon: 42}, {lat: 43, lon: 42}, {lat: 43, lon: 41}]}]"), "boxes")
+	require.NoError(t, err)
+	require.Len(t, areas, 1)
+	assert.Equal(t, "Box", areas[0].Name)
+	assert.True(t, areas[0].Boundary.Closed())
+	assert.Len(t, areas[0].Boundary, 5)
+
+	areas, err = Parse([]byte("boxes: []"), "sectors")
+	require.NoError(t, err)
+	assert.Empty(t, areas, "areas under other keys should be ignored")
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+	area := Area{
+		Name:     "Box",
+		Boundary: orb.Ring{{41, 42}, {42, 42}, {42, 43}, {41, 43}, {41, 42}},
+	}
+	testCases := []struct {
+		name     string
+		point    orb.Point
+		expected bool
+	}{
+		{name: "inside", point: orb.Point{41.5, 42.5}, expected: true},
+		{name: "north", point: orb.Point{41.5, 43.5}, expected: false},
+		{name: "east", point: orb.Point{42.5, 42.5}, expected: false},
+		{name: "south", point: orb.Point{41.5, 41.5}, expected: false},
+		{name: "west", point: orb.Point{40.5, 42.5}, expected: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, area.Contains(test.point))
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name string
+		yaml string
+	}{
+		{
+			name: "missing name",
+			yaml: "sectors: [{boundary: [{lat: 0, lon: 0}, {lat: 1, lon: 0}, {lat: 1, lon: 1}]}]",
+		},
+		{
+			name: "too few points",
+			yaml: "sectors: [{name: North, boundary: [{lat: 0, lon: 0}, {lat: 1, lon: 0}]}]",
+		},
+		{
+			name: "duplicate name",
+			yaml: "sectors: [" +
+				"{name: North, boundary: [{lat: 0, lon: 0}, {lat: 1, lon: 0}, {lat: 1, lon: 1}]}," +
+				"{name: North, boundary: [{lat: 0, lon: 0}, {lat: 1, lon: 0}, {lat: 1, lon: 1}]}]",
+		},
+		{
+			name: "malformed",
+			yaml: "sectors: {name: North}",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Parse([]byte(test.yaml), "sectors")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -155,3 +155,13 @@ var testCompassBearings = []struct {
 	{34.5, 34.5, "035"},
 	{33.49, 33.49, "033"},
 }
+
+func TestRoundedDegreesNormalized(t *testing.T) {
+	t.Parallel()
+	for _, degrees := range []float64{0.3, 359.7, 360.4, -0.2} {
+		for _, bearing := range []Bearing{NewTrueBearing(unit.Angle(degrees) * unit.Degree), NewMagneticBearing(unit.Angle(degrees) * unit.Degree)} {
+			require.InDelta(t, 360, bearing.RoundedDegrees(), 0.0001, "%.1f", degrees)
+			require.Equal(t, "360", bearing.String())
+		}
+	}
+}
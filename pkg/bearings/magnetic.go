@@ -1,8 +1,6 @@
 package bearings
 
 import (
-	"math"
-
 	"github.com/martinlindhe/unit"
 )
 
@@ -33,9 +31,10 @@ func (b *Magnetic) Degrees() float64 {
 	return b.Value().Degrees()
 }
 
-// RoundedDegrees returns the magnetic bearing in degrees, rounded to the nearest degree.
+// RoundedDegrees returns the magnetic bearing in degrees, rounded to the nearest degree and normalized to the range
+// (0, 360].
 func (b *Magnetic) RoundedDegrees() float64 {
-	return math.Round(b.Degrees())
+	return roundedDegrees(b.Value())
 }
 
 // True converts this magnetic bearing to a true bearing by removing the given declination.
@@ -20,6 +20,12 @@ func normalize(a unit.Angle) unit.Angle {
 	return unit.Angle(θ) * unit.Degree
 }
 
+// roundedDegrees returns the angle in degrees, rounded to the nearest degree and normalized to the range (0, 360], so
+// that an angle just east of north rounds to 360 rather than 0.
+func roundedDegrees(a unit.Angle) float64 {
+	return normalize(unit.Angle(math.Round(a.Degrees())) * unit.Degree).Degrees()
+}
+
 func toString(b Bearing) string {
 	return fmt.Sprintf("%03.0f", b.RoundedDegrees())
 }
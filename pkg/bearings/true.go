@@ -1,8 +1,6 @@
 package bearings
 
 import (
-	"math"
-
 	"github.com/martinlindhe/unit"
 )
 
@@ -33,9 +31,9 @@ func (b True) Degrees() float64 {
 	return b.Value().Degrees()
 }
 
-// RoundedDegrees returns the bearing in degrees, rounded to the nearest degree.
+// RoundedDegrees returns the bearing in degrees, rounded to the nearest degree and normalized to the range (0, 360].
 func (b True) RoundedDegrees() float64 {
-	return math.Round(b.Degrees())
+	return roundedDegrees(b.Value())
 }
 
 // True returns this bearing.
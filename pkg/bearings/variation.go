@@ -0,0 +1,58 @@
+package bearings
+
+import (
+	"time"
+
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// MagneticVariation converts bearings between true north and magnetic north. Telemetry gives positions relative to true
+// north, while pilots fly and call bearings relative to magnetic north.
+type MagneticVariation struct {
+	// fixed is a single declination used for the entire map, if not nil.
+	fixed *unit.Angle
+	// t is the time at which the IGRF model is evaluated when fixed is nil.
+	t time.Time
+}
+
+// NewFixedMagneticVariation creates a MagneticVariation which uses the same declination everywhere, such as the
+// variation published for a DCS map. Declination is positive east and negative west.
+func NewFixedMagneticVariation(declination unit.Angle) MagneticVariation {
+	return MagneticVariation{fixed: &declination}
+}
+
+// NewModelMagneticVariation creates a MagneticVariation which computes the declination at each point from the IGRF
+// model at the given time.
+func NewModelMagneticVariation(t time.Time) MagneticVariation {
+	return MagneticVariation{t: t}
+}
+
+// Declination returns the magnetic declination at the given point. Declination is positive east and negative west.
+func (v MagneticVariation) Declination(p orb.Point) unit.Angle {
+	if v.fixed != nil {
+		return *v.fixed
+	}
+	declination, err := Declination(p, v.t)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get declination")
+	}
+	return declination
+}
+
+// Magnetic converts the given bearing at the given point to a magnetic bearing. Magnetic bearings are returned unchanged.
+func (v MagneticVariation) Magnetic(bearing Bearing, p orb.Point) Bearing {
+	if bearing.IsMagnetic() {
+		return bearing
+	}
+	return bearing.Magnetic(v.Declination(p))
+}
+
+// True converts the given bearing at the given point to a true bearing. True bearings are returned unchanged.
+func (v MagneticVariation) True(bearing Bearing, p orb.Point) Bearing {
+	if bearing.IsTrue() {
+		return bearing
+	}
+	return bearing.True(v.Declination(p))
+}
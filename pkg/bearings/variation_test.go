@@ -0,0 +1,67 @@
+package bearings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// kutaisi is a point in the Caucasus map.
+	kutaisi = orb.Point{42.48, 42.18}
+	// dubai is a point in the Persian Gulf map.
+	dubai = orb.Point{55.36, 25.25}
+)
+
+func TestModelMagneticVariation(t *testing.T) {
+	t.Parallel()
+	variation := NewModelMagneticVariation(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	// The Caucasus map has a magnetic variation of about 6° East.
+	assert.InDelta(t, 6.5, variation.Declination(kutaisi).Degrees(), 1)
+	// The Persian Gulf map has a magnetic variation of about 2° East.
+	assert.InDelta(t, 2.5, variation.Declination(dubai).Degrees(), 1)
+}
+
+func TestFixedMagneticVariation(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name             string
+		declination      unit.Angle
+		point            orb.Point
+		trueBearing      unit.Angle
+		magneticExpected unit.Angle
+	}{
+		{name: "Caucasus north", declination: 6 * unit.Degree, point: kutaisi, trueBearing: 0, magneticExpected: 354 * unit.Degree},
+		{name: "Caucasus east", declination: 6 * unit.Degree, point: kutaisi, trueBearing: 90 * unit.Degree, magneticExpected: 84 * unit.Degree},
+		{name: "Persian Gulf north", declination: 2.5 * unit.Degree, point: dubai, trueBearing: 360 * unit.Degree, magneticExpected: 357.5 * unit.Degree},
+		{name: "Persian Gulf wraparound", declination: 2.5 * unit.Degree, point: dubai, trueBearing: 1 * unit.Degree, magneticExpected: 358.5 * unit.Degree},
+		{name: "west variation", declination: -10 * unit.Degree, point: orb.Point{-115, 36}, trueBearing: 355 * unit.Degree, magneticExpected: 5 * unit.Degree},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			variation := NewFixedMagneticVariation(test.declination)
+			assert.InDelta(t, test.declination.Degrees(), variation.Declination(test.point).Degrees(), 0.001)
+
+			magnetic := variation.Magnetic(NewTrueBearing(test.trueBearing), test.point)
+			assert.True(t, magnetic.IsMagnetic())
+			assert.InDelta(t, test.magneticExpected.Degrees(), magnetic.Degrees(), 0.001)
+
+			roundTrip := variation.True(magnetic, test.point)
+			assert.True(t, roundTrip.IsTrue())
+			assert.InDelta(t, NewTrueBearing(test.trueBearing).Degrees(), roundTrip.Degrees(), 0.001)
+		})
+	}
+}
+
+func TestMagneticVariationPreservesReference(t *testing.T) {
+	t.Parallel()
+	variation := NewFixedMagneticVariation(6 * unit.Degree)
+	magnetic := NewMagneticBearing(270 * unit.Degree)
+	assert.Equal(t, magnetic, variation.Magnetic(magnetic, kutaisi))
+	trueBearing := NewTrueBearing(270 * unit.Degree)
+	assert.Equal(t, trueBearing, variation.True(trueBearing, kutaisi))
+}
@@ -1,5 +1,11 @@
 package brevity
 
+import (
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
 // AlphaCheckRequest is a request for an ALPHA CHECK.
 // An ALPHA CHECK is a request for the friendly aircraft's position.
 // It is used by aircrews to check their position equipment, especially for aircraft without GPS.
@@ -21,4 +27,31 @@ type AlphaCheckResponse struct {
 	Status bool
 	// Location of the friendly aircraft. If Status is false, this may be nil.
 	Location Bullseye
+	// Altitude of the friendly aircraft, rounded to the nearest thousand feet. If Status is false, this may be zero.
+	Altitude unit.Length
+	// Track direction of the friendly aircraft. This may be UnknownDirection.
+	Track Track
+}
+
+// NewAlphaCheckResponse creates a successful response to an ALPHA CHECK for a friendly aircraft at the given position
+// and altitude, on the given magnetic course. The position is reported relative to the given bullseye point, using the
+// given declination to compute a magnetic bearing. The bearing is rounded to the nearest degree, the range to the
+// nearest nautical mile, and the altitude to the nearest thousand feet.
+func NewAlphaCheckResponse(
+	callsign string,
+	position orb.Point,
+	altitude unit.Length,
+	course bearings.Bearing,
+	bullseye orb.Point,
+	declination unit.Angle,
+) AlphaCheckResponse {
+	location := BullseyeFromPoints(bullseye, position, declination)
+	bearing := bearings.NewMagneticBearing(RoundBearing(location.Bearing().Value()))
+	return AlphaCheckResponse{
+		Callsign: callsign,
+		Status:   true,
+		Location: *NewBullseye(bearing, location.Distance()),
+		Altitude: RoundAltitude(altitude),
+		Track:    TrackFromBearing(course),
+	}
 }
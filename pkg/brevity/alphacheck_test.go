@@ -0,0 +1,29 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAlphaCheckResponse(t *testing.T) {
+	t.Parallel()
+	response := NewAlphaCheckResponse(
+		"eagle 1",
+		orb.Point{40.0000, 44.6680},
+		20400*unit.Foot,
+		bearings.NewMagneticBearing(268*unit.Degree),
+		orb.Point{42.4826, 42.1769},
+		0,
+	)
+	assert.Equal(t, "eagle 1", response.Callsign)
+	assert.True(t, response.Status)
+	assert.True(t, response.Location.Bearing().IsMagnetic())
+	assert.InDelta(t, 325, response.Location.Bearing().Degrees(), 0.001)
+	assert.InDelta(t, 185, response.Location.Distance().NauticalMiles(), 0.001)
+	assert.InDelta(t, 20000, response.Altitude.Feet(), 0.001)
+	assert.Equal(t, West, response.Track)
+}
@@ -1,10 +1,12 @@
 package brevity
 
 import (
+	"cmp"
 	"fmt"
+	"math"
 	"slices"
+	"strings"
 
-	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
 )
 
@@ -14,37 +16,88 @@ type Stack struct {
 	Count    int
 }
 
+// String returns the layer's altitude and number of contacts for logging, e.g. "25000 FT x2".
 func (s Stack) String() string {
-	return fmt.Sprintf("%d %.0f", s.Count, s.Altitude.Feet())
+	return fmt.Sprintf("%.0f FT x%d", s.Altitude.Feet(), s.Count)
 }
 
-// Stacks creates altitude STACKS from altitudes.
-func Stacks(a ...unit.Length) []Stack {
-	for i, alt := range a {
-		a[i] = spatial.NormalizeAltitude(alt)
+// StacksString returns the layers for logging, e.g. "25000 FT x2 / 15000 FT x1".
+func StacksString(stacks []Stack) string {
+	layers := make([]string, 0, len(stacks))
+	for _, stack := range stacks {
+		layers = append(layers, stack.String())
 	}
-	// reverse sort
+	return strings.Join(layers, " / ")
+}
+
+// Format renders the layer's altitude in the given unit system, e.g. "20000" or "6000 m".
+func (s Stack) Format(system UnitSystem) string {
+	return system.FormatAltitude(system.RoundAltitude(s.Altitude))
+}
+
+// DeckAltitude is the altitude below which contacts are considered to be on the deck.
+const DeckAltitude = 1000 * unit.Foot
+
+// OnTheDeck is true if the layer is below [DeckAltitude]. A low altitude reading is not meaningful to pilots, so such a
+// layer should be described as "on the deck" rather than by its altitude.
+func (s Stack) OnTheDeck() bool {
+	return s.Altitude < DeckAltitude
+}
+
+// DefaultStackSeparation is the default altitude separation between STACK layers.
+const DefaultStackSeparation = 10000 * unit.Foot
+
+// DefaultStackBand is the default interval to which STACK altitudes are rounded.
+const DefaultStackBand = 1000 * unit.Foot
+
+// Stacks creates altitude STACKS from altitudes, using [DefaultStackSeparation]. The given altitudes are not modified.
+// Following brevity convention, the layers are ordered from highest to lowest regardless of the order of the altitudes.
+func Stacks(altitudes ...unit.Length) []Stack {
+	return StacksWithSeparation(DefaultStackSeparation, altitudes...)
+}
+
+// StacksWithBand creates altitude STACKS from altitudes using the given band size, e.g. 500 feet for low altitude
+// helicopter operations. Altitudes are rounded to the nearest band, or the nearest 100 feet below one band. The
+// separation between layers is one band, so each layer spans a single band and finer bands report finer layers: 1,499
+// and 501 feet share a layer with a 1,000 foot band, but are separate layers with a 500 foot band. Unlike [Stacks],
+// layers are not separated by [DefaultStackSeparation]. The given altitudes are not modified.
+func StacksWithBand(band unit.Length, altitudes ...unit.Length) []Stack {
+	return stacks(band, band, altitudes)
+}
+
+// StacksWithSeparation creates altitude STACKS from altitudes. The given altitudes are not modified.
+// Altitudes below [DeckAltitude], including altitudes which round to zero, are kept at their rounded altitude; see
+// [Stack.OnTheDeck].
+//
+// Each layer begins at its highest contact. A contact starts a new layer if it is at least sep below the top of the
+// current layer. Comparing against the top rather than the lowest member keeps each layer within a single band;
+// comparing against the lowest member would allow a series of contacts at small intervals to chain into a single
+// layer of unbounded depth.
+func StacksWithSeparation(sep unit.Length, altitudes ...unit.Length) []Stack {
+	return stacks(DefaultStackBand, sep, altitudes)
+}
+
+// stacks rounds each altitude to the given band and groups them into layers separated by at least sep. The layers are
+// ordered from highest to lowest.
+func stacks(band unit.Length, sep unit.Length, altitudes []unit.Length) []Stack {
+	a := make([]unit.Length, len(altitudes))
+	for i, alt := range altitudes {
+		a[i] = roundAltitudeToBand(alt, band)
+	}
+	// Layers are built from the top down, so sort from highest to lowest.
 	slices.SortFunc(a, func(i, j unit.Length) int {
-		if i < j {
-			return -1
-		}
-		if i > j {
-			return 1
-		}
-		return 0
+		return cmp.Compare(j, i)
 	})
 
 	stacks := []Stack{}
-	for i := len(a) - 1; i >= 0; i-- {
-		if a[i] == 0 {
-			continue
-		}
+	for i := range a {
 		if len(stacks) == 0 {
 			stacks = append(stacks, Stack{Altitude: a[i], Count: 1})
 		} else {
 			j := len(stacks) - 1
-			highest := stacks[j].Altitude
-			if a[i] <= highest-9900*unit.Foot {
+			top := stacks[j].Altitude
+			// Compare in whole feet to avoid floating point error at the boundary.
+			if math.Round((top - a[i]).Feet()) >= math.Round(sep.Feet()) {
 				stacks = append(stacks, Stack{Altitude: a[i], Count: 1})
 			} else {
 				stacks[j].Count++
@@ -1,11 +1,14 @@
 package brevity
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
 	"testing"
 
 	"github.com/martinlindhe/unit"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStacks(t *testing.T) {
@@ -20,15 +23,15 @@ func TestStacks(t *testing.T) {
 		},
 		{
 			input:    []unit.Length{0 * unit.Foot},
-			expected: []Stack{},
+			expected: []Stack{{Altitude: 0, Count: 1}},
 		},
 		{
 			input:    []unit.Length{0 * unit.Foot, 100 * unit.Foot},
-			expected: []Stack{{Altitude: 100 * unit.Foot, Count: 1}},
+			expected: []Stack{{Altitude: 100 * unit.Foot, Count: 2}},
 		},
 		{
 			input:    []unit.Length{40 * unit.Foot},
-			expected: []Stack{},
+			expected: []Stack{{Altitude: 0, Count: 1}},
 		},
 		{
 			input:    []unit.Length{100 * unit.Foot},
@@ -59,6 +62,7 @@ func TestStacks(t *testing.T) {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			t.Parallel()
 			stacks := Stacks(test.input...)
+			require.Len(t, stacks, len(test.expected))
 			for i, stack := range stacks {
 				expectedStack := test.expected[i]
 				assert.InDelta(t, expectedStack.Altitude.Feet(), stack.Altitude.Feet(), 0.5)
@@ -67,3 +71,174 @@ func TestStacks(t *testing.T) {
 		})
 	}
 }
+
+func TestStacksDescending(t *testing.T) {
+	t.Parallel()
+	stacks := Stacks(5000*unit.Foot, 25000*unit.Foot, 15000*unit.Foot)
+	require.Len(t, stacks, 3)
+	for i, expected := range []unit.Length{25000 * unit.Foot, 15000 * unit.Foot, 5000 * unit.Foot} {
+		assert.InDelta(t, expected.Feet(), stacks[i].Altitude.Feet(), 0.5)
+		assert.Equal(t, 1, stacks[i].Count)
+	}
+}
+
+func TestStackString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "25000 FT x2", Stack{Altitude: 25000 * unit.Foot, Count: 2}.String())
+	assert.Equal(t, "500 FT x1", Stack{Altitude: 500 * unit.Foot, Count: 1}.String())
+}
+
+func TestStacksString(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, StacksString(nil))
+	assert.Equal(t, "25000 FT x2", StacksString([]Stack{{Altitude: 25000 * unit.Foot, Count: 2}}))
+	assert.Equal(
+		t,
+		"25000 FT x2 / 15000 FT x1",
+		StacksString(Stacks(25000*unit.Foot, 24600*unit.Foot, 15000*unit.Foot)),
+	)
+}
+
+func TestStacksDoesNotModifyInput(t *testing.T) {
+	t.Parallel()
+	input := []unit.Length{12345 * unit.Foot, 250 * unit.Foot, 31900 * unit.Foot, 12345 * unit.Foot}
+	original := slices.Clone(input)
+	_ = Stacks(input...)
+	assert.Equal(t, original, input)
+}
+
+func TestStacksWithSeparation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		separation unit.Length
+		input      []unit.Length
+		expected   []Stack
+	}{
+		{
+			separation: DefaultStackSeparation,
+			input:      []unit.Length{20000 * unit.Foot, 10000 * unit.Foot},
+			expected:   []Stack{{Altitude: 20000 * unit.Foot, Count: 1}, {Altitude: 10000 * unit.Foot, Count: 1}},
+		},
+		{
+			separation: DefaultStackSeparation,
+			input:      []unit.Length{20000 * unit.Foot, 11000 * unit.Foot},
+			expected:   []Stack{{Altitude: 20000 * unit.Foot, Count: 2}},
+		},
+		{
+			separation: DefaultStackSeparation,
+			input:      []unit.Length{10000 * unit.Foot, 900 * unit.Foot},
+			expected:   []Stack{{Altitude: 10000 * unit.Foot, Count: 2}},
+		},
+		{
+			separation: DefaultStackSeparation,
+			input:      []unit.Length{20000 * unit.Foot, 15000 * unit.Foot, 11000 * unit.Foot, 10000 * unit.Foot},
+			expected:   []Stack{{Altitude: 20000 * unit.Foot, Count: 3}, {Altitude: 10000 * unit.Foot, Count: 1}},
+		},
+		{
+			separation: 5000 * unit.Foot,
+			input:      []unit.Length{20000 * unit.Foot, 15000 * unit.Foot},
+			expected:   []Stack{{Altitude: 20000 * unit.Foot, Count: 1}, {Altitude: 15000 * unit.Foot, Count: 1}},
+		},
+		{
+			separation: 5000 * unit.Foot,
+			input:      []unit.Length{20000 * unit.Foot, 16000 * unit.Foot},
+			expected:   []Stack{{Altitude: 20000 * unit.Foot, Count: 2}},
+		},
+		{
+			separation: 5000 * unit.Foot,
+			input:      []unit.Length{20000 * unit.Foot, 16000 * unit.Foot, 15000 * unit.Foot, 11000 * unit.Foot, 10000 * unit.Foot},
+			expected:   []Stack{{Altitude: 20000 * unit.Foot, Count: 2}, {Altitude: 15000 * unit.Foot, Count: 2}, {Altitude: 10000 * unit.Foot, Count: 1}},
+		},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			stacks := StacksWithSeparation(test.separation, test.input...)
+			require.Len(t, stacks, len(test.expected))
+			for i, stack := range stacks {
+				assert.InDelta(t, test.expected[i].Altitude.Feet(), stack.Altitude.Feet(), 0.5)
+				assert.Equal(t, test.expected[i].Count, stack.Count, "stack count mismatch")
+			}
+		})
+	}
+}
+
+func TestStacksWithBand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		band     unit.Length
+		input    []unit.Length
+		expected []Stack
+	}{
+		{
+			band:     1000 * unit.Foot,
+			input:    []unit.Length{1499 * unit.Foot, 501 * unit.Foot},
+			expected: []Stack{{Altitude: 1000 * unit.Foot, Count: 2}},
+		},
+		{
+			band:     1000 * unit.Foot,
+			input:    []unit.Length{2499 * unit.Foot, 501 * unit.Foot},
+			expected: []Stack{{Altitude: 2000 * unit.Foot, Count: 1}, {Altitude: 500 * unit.Foot, Count: 1}},
+		},
+		{
+			band:     500 * unit.Foot,
+			input:    []unit.Length{1499 * unit.Foot, 501 * unit.Foot},
+			expected: []Stack{{Altitude: 1500 * unit.Foot, Count: 1}, {Altitude: 500 * unit.Foot, Count: 1}},
+		},
+		{
+			band:     500 * unit.Foot,
+			input:    []unit.Length{1260 * unit.Foot, 1490 * unit.Foot, 740 * unit.Foot},
+			expected: []Stack{{Altitude: 1500 * unit.Foot, Count: 2}, {Altitude: 500 * unit.Foot, Count: 1}},
+		},
+		{
+			band:     500 * unit.Foot,
+			input:    []unit.Length{300 * unit.Foot, 200 * unit.Foot},
+			expected: []Stack{{Altitude: 300 * unit.Foot, Count: 2}},
+		},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			stacks := StacksWithBand(test.band, test.input...)
+			require.Len(t, stacks, len(test.expected))
+			for i, stack := range stacks {
+				assert.InDelta(t, test.expected[i].Altitude.Feet(), stack.Altitude.Feet(), 0.5)
+				assert.Equal(t, test.expected[i].Count, stack.Count, "stack count mismatch")
+			}
+		})
+	}
+}
+
+func TestStacksWithDefaultBand(t *testing.T) {
+	t.Parallel()
+	altitudes := []unit.Length{24600 * unit.Foot, 23100 * unit.Foot, 14000 * unit.Foot, 5400 * unit.Foot, 950 * unit.Foot}
+	assert.Equal(t, StacksWithSeparation(DefaultStackBand, altitudes...), StacksWithBand(DefaultStackBand, altitudes...))
+}
+
+func TestStacksLowAltitude(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input      unit.Length
+		expected   unit.Length
+		onTheDeck  bool
+		isReported bool
+	}{
+		{input: 0 * unit.Foot, expected: 0 * unit.Foot, onTheDeck: true, isReported: true},
+		{input: 300 * unit.Foot, expected: 300 * unit.Foot, onTheDeck: true, isReported: true},
+		{input: 800 * unit.Foot, expected: 800 * unit.Foot, onTheDeck: true, isReported: true},
+		{input: 1400 * unit.Foot, expected: 1000 * unit.Foot, onTheDeck: false, isReported: true},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%.0f", test.input.Feet()), func(t *testing.T) {
+			t.Parallel()
+			stacks := Stacks(test.input)
+			if !test.isReported {
+				assert.Empty(t, stacks)
+				return
+			}
+			require.Len(t, stacks, 1)
+			assert.InDelta(t, test.expected.Feet(), stacks[0].Altitude.Feet(), 0.5)
+			assert.Equal(t, test.onTheDeck, stacks[0].OnTheDeck())
+		})
+	}
+}
@@ -1,8 +1,9 @@
 package brevity
 
 import (
+	"math"
+
 	"github.com/dharmab/skyeye/pkg/bearings"
-	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
@@ -11,47 +12,48 @@ import (
 type Aspect string
 
 const (
-	UnknownAspect Aspect = "maneuver"
+	// Maneuver indicates the contact is maneuvering and its aspect cannot be determined.
+	Maneuver Aspect = "maneuver"
+	// UnknownAspect is used when the aspect cannot be determined.
+	UnknownAspect = Maneuver
 	// Hot aspect is 0-30° target aspect or 180-150° aspect angle.
-	Hot = "hot"
-	// Flank is 40-70° target aspect or 140-110° aspect angle.
-	Flank = "flank"
-	// Beam is 80-110° target aspect or 100-70° aspect angle.
-	Beam = "beam"
-	// Drag is 120-180° target aspect or 60-0° aspect angle.
-	Drag = "drag"
+	Hot Aspect = "hot"
+	// Flank is 30-60° target aspect or 150-120° aspect angle.
+	Flank Aspect = "flank"
+	// Beam is 60-110° target aspect or 120-70° aspect angle.
+	Beam Aspect = "beam"
+	// Drag is 110-180° target aspect or 70-0° aspect angle.
+	Drag Aspect = "drag"
 )
 
 // AspectFromAngle computes target aspect based on the magnetic bearing from an aircraft to the target and the track direction of the target.
+//
+// Target aspect is measured between the target's track and the reciprocal of the bearing, i.e. the bearing from the target back to the
+// aircraft. Each bucket includes its upper bound, so a target aspect of exactly 30° is Hot and exactly 110° is Beam.
 func AspectFromAngle(bearing bearings.Bearing, track bearings.Bearing) Aspect {
 	if !bearing.IsMagnetic() || !track.IsMagnetic() {
 		log.Warn().Stringer("bearing", bearing).Stringer("track", track).Msg("bearing and track provided to AspectFromAngle should be magnetic")
 	}
 
-	var θ float64
-	if bearing.Reciprocal().Value() > track.Value() {
-		θ = bearing.Reciprocal().Degrees() - track.Degrees()
-	} else {
-		θ = track.Reciprocal().Degrees() - bearing.Degrees()
+	// Fold the difference into [0, 180] so that left and right aspects are symmetric across the 360/0 boundary.
+	θ := math.Mod(track.Degrees()-bearing.Reciprocal().Degrees(), 360)
+	θ = math.Abs(θ)
+	if θ > 180 {
+		θ = 360 - θ
 	}
-	θ = bearings.NewMagneticBearing(unit.Angle(θ) * unit.Degree).Degrees()
+	// Round away floating point error so that bucket boundaries are stable.
+	θ = math.Round(θ*1e6) / 1e6
 
 	switch {
-	case 0 <= θ && θ <= 35:
+	case math.IsNaN(θ):
+		return UnknownAspect
+	case θ <= 30:
 		return Hot
-	case 35 < θ && θ <= 75:
+	case θ <= 60:
 		return Flank
-	case 75 < θ && θ <= 115:
-		return Beam
-	case 115 < θ && θ <= 245:
-		return Drag
-	case 245 < θ && θ <= 285:
+	case θ <= 110:
 		return Beam
-	case 285 < θ && θ <= 325:
-		return Flank
-	case 325 < θ && θ <= 360:
-		return Hot
 	default:
-		return UnknownAspect
+		return Drag
 	}
 }
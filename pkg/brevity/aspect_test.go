@@ -55,7 +55,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  n,
 			track:    ene,
-			expected: Beam,
+			expected: Drag,
 		},
 		// ⇨
 		// ⬆
@@ -69,7 +69,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  n,
 			track:    ese,
-			expected: Flank,
+			expected: Beam,
 		},
 		{
 			bearing:  n,
@@ -103,7 +103,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  n,
 			track:    wsw,
-			expected: Flank,
+			expected: Beam,
 		},
 		// ⇦
 		// ⬆
@@ -117,7 +117,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  n,
 			track:    wnw,
-			expected: Beam,
+			expected: Drag,
 		},
 		{
 			bearing:  n,
@@ -139,7 +139,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  e,
 			track:    nne,
-			expected: Beam,
+			expected: Drag,
 		},
 		{
 			bearing:  e,
@@ -172,7 +172,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  e,
 			track:    sse,
-			expected: Beam,
+			expected: Drag,
 		},
 		// ⮕⇩
 		{
@@ -184,7 +184,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  e,
 			track:    ssw,
-			expected: Flank,
+			expected: Beam,
 		},
 		{
 			bearing:  e,
@@ -216,7 +216,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  e,
 			track:    nnw,
-			expected: Flank,
+			expected: Beam,
 		},
 		// ⬇
 		// ⇧
@@ -240,7 +240,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  s,
 			track:    ene,
-			expected: Flank,
+			expected: Beam,
 		},
 		// ⬇
 		// ⇨
@@ -254,7 +254,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  s,
 			track:    ese,
-			expected: Beam,
+			expected: Drag,
 		},
 		{
 			bearing:  s,
@@ -288,7 +288,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  s,
 			track:    wsw,
-			expected: Beam,
+			expected: Drag,
 		},
 		// ⬇
 		// ⇦
@@ -302,7 +302,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  s,
 			track:    wnw,
-			expected: Flank,
+			expected: Beam,
 		},
 		{
 			bearing:  s,
@@ -324,7 +324,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  w,
 			track:    nne,
-			expected: Flank,
+			expected: Beam,
 		},
 		{
 			bearing:  w,
@@ -356,7 +356,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  w,
 			track:    sse,
-			expected: Flank,
+			expected: Beam,
 		},
 		// ⇩⬅
 
@@ -369,7 +369,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  w,
 			track:    ssw,
-			expected: Beam,
+			expected: Drag,
 		},
 		{
 			bearing:  w,
@@ -401,7 +401,7 @@ func TestAspectFromAngle(t *testing.T) {
 		{
 			bearing:  w,
 			track:    nnw,
-			expected: Beam,
+			expected: Drag,
 		},
 	}
 
@@ -413,3 +413,49 @@ func TestAspectFromAngle(t *testing.T) {
 		})
 	}
 }
+
+func TestAspectFromAngleBoundaries(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		bearing  float64
+		track    float64
+		expected Aspect
+	}{
+		// Target aspect 0°: contact flying directly at the fighter.
+		{bearing: 360, track: 180, expected: Hot},
+		{bearing: 360, track: 150, expected: Hot},
+		{bearing: 360, track: 210, expected: Hot},
+		{bearing: 360, track: 149, expected: Flank},
+		{bearing: 360, track: 211, expected: Flank},
+		{bearing: 360, track: 120, expected: Flank},
+		{bearing: 360, track: 240, expected: Flank},
+		{bearing: 360, track: 119, expected: Beam},
+		{bearing: 360, track: 241, expected: Beam},
+		{bearing: 360, track: 70, expected: Beam},
+		{bearing: 360, track: 290, expected: Beam},
+		{bearing: 360, track: 69, expected: Drag},
+		{bearing: 360, track: 291, expected: Drag},
+		{bearing: 360, track: 360, expected: Drag},
+		// Wraparound: the reciprocal of the bearing is near 360/0.
+		{bearing: 180, track: 360, expected: Hot},
+		{bearing: 180, track: 30, expected: Hot},
+		{bearing: 180, track: 330, expected: Hot},
+		{bearing: 180, track: 31, expected: Flank},
+		{bearing: 180, track: 329, expected: Flank},
+		{bearing: 170, track: 10, expected: Hot},
+		{bearing: 190, track: 350, expected: Hot},
+		{bearing: 200, track: 290, expected: Beam},
+		{bearing: 160, track: 160, expected: Drag},
+	}
+
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("bearing %.0f track %.0f", test.bearing, test.track), func(t *testing.T) {
+			t.Parallel()
+			actual := AspectFromAngle(
+				bearings.NewMagneticBearing(unit.Angle(test.bearing)*unit.Degree),
+				bearings.NewMagneticBearing(unit.Angle(test.track)*unit.Degree),
+			)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
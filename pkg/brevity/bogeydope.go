@@ -41,6 +41,6 @@ func (r BogeyDopeRequest) String() string {
 type BogeyDopeResponse struct {
 	// Callsign of the friendly aircraft requesting the BOGEY DOPE.
 	Callsign string
-	// Group which is closest to the fighter. If there are no eligible groups, this may be nil.
+	// Group which is closest to the fighter. If there are no eligible groups, a [CleanResponse] is sent instead.
 	Group Group
 }
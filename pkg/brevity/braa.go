@@ -2,10 +2,8 @@ package brevity
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
-	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
@@ -28,6 +26,9 @@ type BRA interface {
 	Altitude() unit.Length
 	// Altitude STACKS of the contact above sea level, rounded to the nearest thousands of feet.
 	Stacks() []Stack
+	// Format renders the bearing, range and altitude in the given unit system, e.g. "090/45 20000" or
+	// "090/83 km 6000 m".
+	Format(UnitSystem) string
 }
 
 type bra struct {
@@ -54,7 +55,7 @@ func (b *bra) Bearing() bearings.Bearing {
 
 // Range implements [BRA.Range].
 func (b *bra) Range() unit.Length {
-	return unit.Length(math.Round(b._range.NauticalMiles())) * unit.NauticalMile
+	return unit.Length(roundToStep(b._range.NauticalMiles(), 1)) * unit.NauticalMile
 }
 
 // Altitude implements [BRA.Altitude].
@@ -62,7 +63,7 @@ func (b *bra) Altitude() unit.Length {
 	if len(b.stacks) == 0 {
 		return 0
 	}
-	return spatial.NormalizeAltitude(b.stacks[0].Altitude)
+	return RoundAltitude(b.stacks[0].Altitude)
 }
 
 // Stacks implements [BRA.Stacks].
@@ -70,16 +71,31 @@ func (b *bra) Stacks() []Stack {
 	return b.stacks
 }
 
+// Format implements [BRA.Format].
+func (b *bra) Format(system UnitSystem) string {
+	return formatBRA(b.bearing, system.RoundRange(b._range), b.stacks, system)
+}
+
+// formatBRA renders a bearing, a rounded range and altitude STACKS in the given unit system.
+func formatBRA(bearing bearings.Bearing, r unit.Length, stacks []Stack, system UnitSystem) string {
+	s := fmt.Sprintf("%s/%s", bearing, system.FormatRange(r))
+	if len(stacks) > 0 {
+		s += " " + stacks[0].Format(system)
+	}
+	return s
+}
+
 func (b *bra) String() string {
 	s := fmt.Sprintf("BRA %s/%.0f %.0f", b.Bearing(), b.Range().NauticalMiles(), b.Altitude().Feet())
 	if len(b.Stacks()) > 1 {
-		s += fmt.Sprintf(" (%v)", b.Stacks())
+		s += fmt.Sprintf(" (%s)", StacksString(b.Stacks()))
 	}
 	return s
 }
 
 type braa struct {
 	bra    BRA
+	_range unit.Length
 	aspect Aspect
 }
 
@@ -89,6 +105,7 @@ func NewBRAA(b bearings.Bearing, r unit.Length, a []unit.Length, aspect Aspect)
 	}
 	return &braa{
 		bra:    NewBRA(b, r, a...),
+		_range: r,
 		aspect: aspect,
 	}
 }
@@ -98,9 +115,10 @@ func (b *braa) Bearing() bearings.Bearing {
 	return b.bra.Bearing()
 }
 
-// Range implements [BRA.Range].
+// Range implements [BRA.Range]. Unlike [BRA.Range], the range is rounded in nautical miles following
+// [UnitSystem.RoundContactRange], since BRAA is only used to report contacts.
 func (b *braa) Range() unit.Length {
-	return b.bra.Range()
+	return ImperialUnits.RoundContactRange(b._range)
 }
 
 // Altitude implements [BRA.Altitude].
@@ -118,6 +136,17 @@ func (b *braa) Aspect() Aspect {
 	return b.aspect
 }
 
+// Format implements [BRA.Format]. The aspect is appended, e.g. "090/45 20000 hot". The range is rounded following
+// [UnitSystem.RoundContactRange].
+func (b *braa) Format(system UnitSystem) string {
+	r := system.RoundContactRange(b._range)
+	return fmt.Sprintf("%s %s", formatBRA(b.Bearing(), r, b.Stacks(), system), b.Aspect())
+}
+
 func (b *braa) String() string {
-	return fmt.Sprintf("%s %s", b.bra, b.Aspect())
+	s := fmt.Sprintf("BRAA %s/%.0f %.0f", b.Bearing(), b.Range().NauticalMiles(), b.Altitude().Feet())
+	if len(b.Stacks()) > 1 {
+		s += fmt.Sprintf(" (%s)", StacksString(b.Stacks()))
+	}
+	return fmt.Sprintf("%s %s", s, b.Aspect())
 }
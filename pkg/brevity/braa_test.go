@@ -0,0 +1,26 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBRAARounding(t *testing.T) {
+	t.Parallel()
+	braa := NewBRAA(
+		bearings.NewMagneticBearing(44.6*unit.Degree),
+		23*unit.NauticalMile,
+		[]unit.Length{24600 * unit.Foot},
+		Hot,
+	)
+	assert.Equal(t, "045", braa.Bearing().String())
+	assert.InDelta(t, 25, braa.Range().NauticalMiles(), 0.001)
+	assert.InDelta(t, 25000, braa.Altitude().Feet(), 0.001)
+
+	// BRA is also used for requests, where the range the pilot gave is kept to the nearest mile.
+	bra := NewBRA(bearings.NewMagneticBearing(44.6*unit.Degree), 23*unit.NauticalMile, 24600*unit.Foot)
+	assert.InDelta(t, 23, bra.Range().NauticalMiles(), 0.001)
+}
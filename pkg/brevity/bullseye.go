@@ -2,10 +2,11 @@ package brevity
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 	"github.com/rs/zerolog/log"
 )
 
@@ -33,7 +34,26 @@ func (b *Bullseye) Bearing() bearings.Bearing {
 
 // Distance from the BULLSEYE to the contact, rounded to the nearest nautical mile.
 func (b *Bullseye) Distance() unit.Length {
-	return unit.Length(math.Round(b.distance.NauticalMiles())) * unit.NauticalMile
+	return unit.Length(roundToStep(b.distance.NauticalMiles(), 1)) * unit.NauticalMile
+}
+
+// BullseyeFromPoints computes the bullseye reading of a contact relative to the given bullseye anchor point. The
+// declination at the anchor is used to convert the great-circle bearing to a magnetic bearing.
+func BullseyeFromPoints(anchor, contact orb.Point, declination unit.Angle) *Bullseye {
+	bearing := spatial.TrueBearing(anchor, contact).Magnetic(declination)
+	return NewBullseye(bearing, spatial.Distance(anchor, contact))
+}
+
+// Point computes the approximate position described by this bullseye reading relative to the given anchor point.
+// The declination at the anchor is used to convert the magnetic bearing to a true bearing. The unrounded distance is
+// used, so that converting a point to a bullseye and back returns approximately the same point.
+func (b *Bullseye) Point(anchor orb.Point, declination unit.Angle) orb.Point {
+	return spatial.PointAtBearingAndDistance(anchor, b.bearing.True(declination), b.distance)
+}
+
+// Format renders the bullseye reading in the given unit system, e.g. "090/45" or "090/83 km".
+func (b *Bullseye) Format(system UnitSystem) string {
+	return fmt.Sprintf("%s/%s", b.bearing, system.FormatRange(system.RoundRange(b.distance)))
 }
 
 func (b Bullseye) String() string {
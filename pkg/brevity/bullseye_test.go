@@ -0,0 +1,67 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBullseyeConversion(t *testing.T) {
+	t.Parallel()
+	// References computed by hand with the haversine and initial bearing formulas on a spherical earth.
+	testCases := []struct {
+		name        string
+		anchor      orb.Point
+		contact     orb.Point
+		declination unit.Angle
+		bearing     float64
+		distance    float64
+	}{
+		{
+			name:     "Kutaisi to Batumi",
+			anchor:   orb.Point{42.4826, 42.1769},
+			contact:  orb.Point{41.6003, 41.6103},
+			bearing:  229.5,
+			distance: 52.14,
+		},
+		{
+			name:        "Kutaisi to Batumi with declination",
+			anchor:      orb.Point{42.4826, 42.1769},
+			contact:     orb.Point{41.6003, 41.6103},
+			declination: 6 * unit.Degree,
+			bearing:     223.5,
+			distance:    52.14,
+		},
+		{
+			name:     "Kutaisi to Krasnodar area",
+			anchor:   orb.Point{42.4826, 42.1769},
+			contact:  orb.Point{40.0000, 44.6680},
+			bearing:  324.9,
+			distance: 184.82,
+		},
+		{
+			name:        "Andersen to Saipan",
+			anchor:      orb.Point{144.9298, 13.5840},
+			contact:     orb.Point{145.7290, 15.1190},
+			declination: -1 * unit.Degree,
+			bearing:     27.7,
+			distance:    103.34,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			bullseye := BullseyeFromPoints(test.anchor, test.contact, test.declination)
+			assert.True(t, bullseye.Bearing().IsMagnetic())
+			assert.InDelta(t, test.bearing, bullseye.Bearing().Degrees(), 0.5)
+			assert.InDelta(t, test.distance, bullseye.distance.NauticalMiles(), 0.2)
+			assert.InDelta(t, test.distance, bullseye.Distance().NauticalMiles(), 0.5)
+
+			point := bullseye.Point(test.anchor, test.declination)
+			assert.Less(t, spatial.Distance(point, test.contact).Meters(), 300.0)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package brevity
+
+// CleanResponse reports that there are no hostile groups which match the caller's request.
+type CleanResponse struct {
+	// Callsign of the friendly aircraft that made the request.
+	Callsign string
+}
+
+// NoRadarDataResponse reports that the controller cannot answer the caller's request because it has no radar data at
+// all, e.g. because the telemetry source has not yet provided any contacts.
+type NoRadarDataResponse struct {
+	// Callsign of the friendly aircraft that made the request. This may be empty.
+	Callsign string
+}
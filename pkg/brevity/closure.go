@@ -0,0 +1,34 @@
+package brevity
+
+import (
+	"github.com/martinlindhe/unit"
+)
+
+// Closure indicates whether the range between a contact and fighter is increasing or decreasing.
+// Reference: ATP 3-52.4 Chapter IV section 4.
+type Closure string
+
+const (
+	// UnknownClosure is used when the range is steady or the closure cannot be determined.
+	UnknownClosure Closure = ""
+	// Opening indicates the range is increasing.
+	Opening Closure = "opening"
+	// Closing indicates the range is decreasing.
+	Closing Closure = "closing"
+)
+
+// ClosureDeadband is the minimum rate of change of range which is described as OPENING or CLOSING.
+const ClosureDeadband = 25 * unit.Knot
+
+// ClosureFromRangeRate returns the closure for the given range rate. A positive range rate means the range is
+// increasing. Range rates within [ClosureDeadband] of zero are neither OPENING nor CLOSING.
+func ClosureFromRangeRate(rate unit.Speed) Closure {
+	switch {
+	case rate >= ClosureDeadband:
+		return Opening
+	case rate <= -ClosureDeadband:
+		return Closing
+	default:
+		return UnknownClosure
+	}
+}
@@ -0,0 +1,65 @@
+package brevity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosureFromRangeRate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		rate     unit.Speed
+		expected Closure
+	}{
+		{rate: -900 * unit.Knot, expected: Closing},
+		{rate: -ClosureDeadband, expected: Closing},
+		{rate: -10 * unit.Knot, expected: UnknownClosure},
+		{rate: 0, expected: UnknownClosure},
+		{rate: 10 * unit.Knot, expected: UnknownClosure},
+		{rate: ClosureDeadband, expected: Opening},
+		{rate: 300 * unit.Knot, expected: Opening},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.0f", test.rate.Knots()), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, ClosureFromRangeRate(test.rate))
+		})
+	}
+}
+
+func TestClosureGeometry(t *testing.T) {
+	t.Parallel()
+	fighter := orb.Point{-115.0338, 36.2350}
+	north := bearings.NewTrueBearing(360 * unit.Degree)
+	south := bearings.NewTrueBearing(180 * unit.Degree)
+	east := bearings.NewTrueBearing(90 * unit.Degree)
+	contact := spatial.PointAtBearingAndDistance(fighter, north, 20*unit.NauticalMile)
+	testCases := []struct {
+		name          string
+		fighterCourse bearings.Bearing
+		fighterSpeed  unit.Speed
+		contactCourse bearings.Bearing
+		contactSpeed  unit.Speed
+		expected      Closure
+	}{
+		{name: "head-on", fighterCourse: north, fighterSpeed: 450 * unit.Knot, contactCourse: south, contactSpeed: 450 * unit.Knot, expected: Closing},
+		{name: "tail chase gaining", fighterCourse: north, fighterSpeed: 500 * unit.Knot, contactCourse: north, contactSpeed: 350 * unit.Knot, expected: Closing},
+		{name: "tail chase losing", fighterCourse: north, fighterSpeed: 350 * unit.Knot, contactCourse: north, contactSpeed: 500 * unit.Knot, expected: Opening},
+		{name: "tail chase matched", fighterCourse: north, fighterSpeed: 450 * unit.Knot, contactCourse: north, contactSpeed: 450 * unit.Knot, expected: UnknownClosure},
+		{name: "perpendicular", fighterCourse: east, fighterSpeed: 450 * unit.Knot, contactCourse: east, contactSpeed: 450 * unit.Knot, expected: UnknownClosure},
+		{name: "running away", fighterCourse: south, fighterSpeed: 450 * unit.Knot, contactCourse: north, contactSpeed: 450 * unit.Knot, expected: Opening},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			rate := spatial.RangeRate(fighter, test.fighterCourse, test.fighterSpeed, contact, test.contactCourse, test.contactSpeed)
+			assert.Equal(t, test.expected, ClosureFromRangeRate(rate))
+		})
+	}
+}
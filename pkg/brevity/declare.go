@@ -54,11 +54,19 @@ type DeclareRequest struct {
 	Altitude unit.Length
 	// Track direction. Optional, used to discriminate between multiple contacts at the same location.
 	Track Track
+	// GridPosition is the MGRS grid reference of the contact, if provided as a grid reference instead of Bullseye or
+	// BRAA, e.g. "38T LM 123 456". The grid zone designator may be omitted, e.g. "LM 123 456".
+	GridPosition string
 }
 
 func (r DeclareRequest) String() string {
 	s := fmt.Sprintf("DECLARE for %s: ", r.Callsign)
-	if r.IsBRAA {
+	if r.GridPosition != "" {
+		s += "grid " + r.GridPosition
+		if r.Altitude != 0 {
+			s += fmt.Sprintf(", altitude %.0f", r.Altitude.Feet())
+		}
+	} else if r.IsBRAA {
 		s += fmt.Sprintf("bearing %s, range %.0f", r.Bearing, r.Range.NauticalMiles())
 		if r.Altitude != 0 {
 			s += fmt.Sprintf(", altitude %.0f", r.Altitude.Feet())
@@ -80,4 +88,47 @@ type DeclareResponse struct {
 	// Group that was identified, if a specific one was identifiable.
 	// This may be nil if Declaration is Furball, Unable, or Clean.
 	Group Group
+	// GridPosition is the MGRS grid reference of the group, if the DECLARE was requested using a grid reference. It is
+	// given to the same precision as the request.
+	GridPosition string
+}
+
+// DeclareContact is a contact found within the search volume of a DECLARE.
+type DeclareContact struct {
+	// Declaration of the contact relative to the requester. This should be Friendly, Hostile, Bandit, Bogey or Neutral.
+	Declaration Declaration
+	// Distance from the contact to the declared location.
+	Distance unit.Length
+}
+
+// Declare picks the declaration for a DECLARE given the contacts found within the search volume. Only contacts within
+// the resolution cell (the given radius around the declared location) are considered.
+//
+//   - If there are no contacts in the cell, the declaration is [Clean].
+//   - If there are both friendly and non-friendly contacts in the cell, the declaration is [Furball].
+//   - If all contacts in the cell have the same declaration, that declaration is used.
+//   - Otherwise, the contacts are ambiguous and the declaration is [Unable].
+func Declare(contacts []DeclareContact, cell unit.Length) Declaration {
+	declarations := make(map[Declaration]struct{})
+	for _, contact := range contacts {
+		if contact.Distance <= cell {
+			declarations[contact.Declaration] = struct{}{}
+		}
+	}
+
+	if len(declarations) == 0 {
+		return Clean
+	}
+	if _, ok := declarations[Friendly]; ok {
+		if len(declarations) == 1 {
+			return Friendly
+		}
+		return Furball
+	}
+	if len(declarations) == 1 {
+		for declaration := range declarations {
+			return declaration
+		}
+	}
+	return Unable
 }
@@ -0,0 +1,124 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeclare(t *testing.T) {
+	t.Parallel()
+	cell := 5 * unit.NauticalMile
+	testCases := []struct {
+		name     string
+		contacts []DeclareContact
+		expected Declaration
+	}{
+		{
+			name:     "nothing found",
+			contacts: []DeclareContact{},
+			expected: Clean,
+		},
+		{
+			name:     "only contacts outside the cell",
+			contacts: []DeclareContact{{Declaration: Hostile, Distance: 6 * unit.NauticalMile}},
+			expected: Clean,
+		},
+		{
+			name:     "exactly one friendly",
+			contacts: []DeclareContact{{Declaration: Friendly, Distance: 1 * unit.NauticalMile}},
+			expected: Friendly,
+		},
+		{
+			name:     "exactly one hostile",
+			contacts: []DeclareContact{{Declaration: Hostile, Distance: 2 * unit.NauticalMile}},
+			expected: Hostile,
+		},
+		{
+			name:     "several hostiles",
+			contacts: []DeclareContact{{Declaration: Hostile, Distance: 2 * unit.NauticalMile}, {Declaration: Hostile, Distance: 3 * unit.NauticalMile}},
+			expected: Hostile,
+		},
+		{
+			name: "one friendly, one hostile just outside the cell",
+			contacts: []DeclareContact{
+				{Declaration: Friendly, Distance: 1 * unit.NauticalMile},
+				{Declaration: Hostile, Distance: 5.1 * unit.NauticalMile},
+			},
+			expected: Friendly,
+		},
+		{
+			name: "one friendly, one hostile exactly on the edge of the cell",
+			contacts: []DeclareContact{
+				{Declaration: Friendly, Distance: 1 * unit.NauticalMile},
+				{Declaration: Hostile, Distance: 5 * unit.NauticalMile},
+			},
+			expected: Furball,
+		},
+		{
+			name: "one hostile, one friendly just outside the cell",
+			contacts: []DeclareContact{
+				{Declaration: Hostile, Distance: 4 * unit.NauticalMile},
+				{Declaration: Friendly, Distance: 5.1 * unit.NauticalMile},
+			},
+			expected: Hostile,
+		},
+		{
+			name: "friendly and hostile inside the cell",
+			contacts: []DeclareContact{
+				{Declaration: Friendly, Distance: 1 * unit.NauticalMile},
+				{Declaration: Hostile, Distance: 2 * unit.NauticalMile},
+			},
+			expected: Furball,
+		},
+		{
+			name: "friendly and bogey inside the cell",
+			contacts: []DeclareContact{
+				{Declaration: Bogey, Distance: 1 * unit.NauticalMile},
+				{Declaration: Friendly, Distance: 2 * unit.NauticalMile},
+			},
+			expected: Furball,
+		},
+		{
+			name:     "exactly one neutral",
+			contacts: []DeclareContact{{Declaration: Neutral, Distance: 3 * unit.NauticalMile}},
+			expected: Neutral,
+		},
+		{
+			name:     "exactly one bogey",
+			contacts: []DeclareContact{{Declaration: Bogey, Distance: 3 * unit.NauticalMile}},
+			expected: Bogey,
+		},
+		{
+			name: "hostile and neutral inside the cell",
+			contacts: []DeclareContact{
+				{Declaration: Hostile, Distance: 1 * unit.NauticalMile},
+				{Declaration: Neutral, Distance: 2 * unit.NauticalMile},
+			},
+			expected: Unable,
+		},
+		{
+			name: "hostile and bogey inside the cell",
+			contacts: []DeclareContact{
+				{Declaration: Hostile, Distance: 1 * unit.NauticalMile},
+				{Declaration: Bogey, Distance: 2 * unit.NauticalMile},
+			},
+			expected: Unable,
+		},
+		{
+			name: "hostile inside the cell, neutral outside",
+			contacts: []DeclareContact{
+				{Declaration: Hostile, Distance: 1 * unit.NauticalMile},
+				{Declaration: Neutral, Distance: 7 * unit.NauticalMile},
+			},
+			expected: Hostile,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, Declare(test.contacts, cell))
+		})
+	}
+}
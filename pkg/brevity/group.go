@@ -1,6 +1,8 @@
 package brevity
 
 import (
+	"fmt"
+
 	"github.com/martinlindhe/unit"
 )
 
@@ -16,8 +18,13 @@ type Group interface {
 	Threat() bool
 	// SetThreat sets the THREAT status.
 	SetThreat(bool)
+	// Popup is true if the group recently appeared where no group was previously seen, such as aircraft which just took
+	// off or emerged from terrain masking.
+	Popup() bool
 	// Contacts is the number of contacts in the group.
 	Contacts() int
+	// SpokenContacts is the spoken number of contacts in the group. See [SpokenContacts].
+	SpokenContacts() (string, bool)
 	// Bullseye is the location of the group. This may be nil for BOGEY DOPE, SNAPLOCK, and THREAT calls.
 	Bullseye() *Bullseye
 	// Altitude is the group's highest altitude. This may be zero for BOGEY DOPE, SNAPLOCK, and THREAT calls.
@@ -31,21 +38,32 @@ type Group interface {
 	Aspect() Aspect
 	// BRAA is an alternate format for the group's location. This is nil except for BOGEY DOPE, SNAPLOCK, and some THREAT calls.
 	BRAA() BRAA
+	// Closure indicates whether the group's range from the fighter is increasing or decreasing. This is only used
+	// alongside BRAA, and may be UnknownClosure.
+	Closure() Closure
+	// SetClosure sets the group's closure.
+	SetClosure(Closure)
 	// Declaration of the group's friend or foe status.
 	Declaration() Declaration
 	// SetDeclaration sets the group's friend or foe status.
 	SetDeclaration(Declaration)
-	// Heavy is true if the group contacts 3 or more contacts.
+	// Heavy is true if the group contains [HeavyThreshold] or more contacts.
 	Heavy() bool
+	// ThreatRadius is the longest threat radius of the group's aircraft platforms. This is zero for unarmed aircraft.
+	ThreatRadius() unit.Length
 	// Platforms are the NATO reporting names of the group's aircraft platforms (for Soviet/Russian/Chinese aircraft) or
 	// alternative names for other aircraft. Skyeye supports mixed-platform groups, so this returns multiple values.
 	Platforms() []string
 	// High is true if the aircraft altitude is above 40,000 feet.
 	High() bool
-	// Fast is true if the group's speed is 600-900kts ground speed or 1.0-1.5 Mach.
+	// Fast is true if the group's speed is at least 600 knots ground speed or Mach 1.0, but less than Mach 1.5.
+	// See [DescribeSpeed].
 	Fast() bool
-	// VeryFast is true is the group's speed is above 900kts ground speed or 1.5 Mach.
+	// VeryFast is true if the group's speed is at least Mach 1.5. See [DescribeSpeed].
 	VeryFast() bool
+	// Extrapolated is true if the group's position is predicted from its last known heading and speed, because sensors
+	// have not recently updated it.
+	Extrapolated() bool
 	// MergedWith is the number of friendlies this group is merged with.
 	MergedWith() int
 	// SetMergedWith sets the number of friendlies this group is merged with.
@@ -55,3 +73,132 @@ type Group interface {
 	// ObjectIDs returns the object IDs of all contacts in the group.
 	ObjectIDs() []uint64
 }
+
+// FillIn identifies a piece of information within a group description.
+type FillIn string
+
+const (
+	// PositionFillIn is the group's location, either Bullseye or BRAA. BRAA includes the group's altitude and aspect.
+	PositionFillIn FillIn = "position"
+	// EstimatedFillIn indicates the group's position is extrapolated rather than observed.
+	EstimatedFillIn FillIn = "estimated"
+	// AltitudeFillIn is the group's altitude or altitude STACKS. It is only used with Bullseye, since BRAA includes altitude.
+	AltitudeFillIn FillIn = "altitude"
+	// TrackFillIn is the group's track direction.
+	TrackFillIn FillIn = "track"
+	// ClosureFillIn indicates the group is OPENING or CLOSING. It is only used with BRAA.
+	ClosureFillIn FillIn = "closure"
+	// DeclarationFillIn is the group's declaration.
+	DeclarationFillIn FillIn = "declaration"
+	// MergedFillIn is the number of friendlies the group is merged with.
+	MergedFillIn FillIn = "merged"
+	// HeavyFillIn indicates the group has 3 or more contacts. A group large enough to be a GORILLA is described as such
+	// instead.
+	HeavyFillIn FillIn = "heavy"
+	// ContactsFillIn is the number of contacts in the group. It is omitted for a single contact.
+	ContactsFillIn FillIn = "contacts"
+	// StackFillIn is the number of contacts in each of two altitude STACKS. Groups with three or more STACKS include
+	// the number of contacts in each STACK with its altitude instead.
+	StackFillIn FillIn = "stack"
+	// PlatformFillIn is the group's aircraft platforms.
+	PlatformFillIn FillIn = "platform"
+	// HighFillIn indicates the group is above 40,000 feet.
+	HighFillIn FillIn = "high"
+	// FastFillIn indicates the group is fast.
+	FastFillIn FillIn = "fast"
+	// VeryFastFillIn indicates the group is very fast.
+	VeryFastFillIn FillIn = "very fast"
+)
+
+const (
+	// HeavyThreshold is the minimum number of contacts in a HEAVY group.
+	HeavyThreshold = 3
+	// DefaultGorillaThreshold is the default minimum number of contacts in a GORILLA.
+	DefaultGorillaThreshold = 6
+)
+
+// IsHeavy returns true if a group with the given number of contacts is HEAVY.
+// Reference: ATP 1-02.1 Chapter I Table 2.
+func IsHeavy(contacts int) bool {
+	return contacts >= HeavyThreshold
+}
+
+// IsGorilla returns true if a group with the given number of contacts is a GORILLA: a large force of indeterminate
+// numbers and unknown intentions. A threshold less than [HeavyThreshold] disables GORILLA calls.
+// Reference: ATP 1-02.1 Chapter I Table 2.
+func IsGorilla(contacts int, threshold int) bool {
+	if threshold < HeavyThreshold {
+		return false
+	}
+	return contacts >= threshold
+}
+
+// SpokenContacts returns the spoken number of contacts: "single contact" for one contact, or e.g. "3 contacts" for
+// more. The second return value is false if the number of contacts is unknown, in which case nothing should be said.
+func SpokenContacts(n int) (string, bool) {
+	switch {
+	case n <= 0:
+		return "", false
+	case n == 1:
+		return "single contact", true
+	default:
+		return fmt.Sprintf("%d contacts", n), true
+	}
+}
+
+// FillIns returns the information which should be communicated about the group, in standard order: location, altitude,
+// track, declaration, and then other fill-ins. Information which is unknown or not applicable to the group is omitted.
+// Reference: ATP 3-52.4 chapter IV section 3.
+func FillIns(group Group) []FillIn {
+	fillIns := make([]FillIn, 0)
+	isTrackKnown := group.Track() != UnknownDirection
+	if group.Bullseye() != nil {
+		fillIns = append(fillIns, PositionFillIn)
+		if group.Extrapolated() {
+			fillIns = append(fillIns, EstimatedFillIn)
+		}
+		fillIns = append(fillIns, AltitudeFillIn)
+		if isTrackKnown {
+			fillIns = append(fillIns, TrackFillIn)
+		}
+	} else if braa := group.BRAA(); braa != nil {
+		fillIns = append(fillIns, PositionFillIn)
+		if group.Extrapolated() {
+			fillIns = append(fillIns, EstimatedFillIn)
+		}
+		// Track is only useful alongside BRAA when the aspect does not already imply it.
+		isCardinalAspect := braa.Aspect() == Flank || braa.Aspect() == Beam || braa.Aspect() == Drag
+		if isCardinalAspect && isTrackKnown && group.Declaration() != Furball {
+			fillIns = append(fillIns, TrackFillIn)
+		}
+		if group.Closure() != UnknownClosure {
+			fillIns = append(fillIns, ClosureFillIn)
+		}
+	}
+
+	fillIns = append(fillIns, DeclarationFillIn)
+	if group.MergedWith() > 0 {
+		fillIns = append(fillIns, MergedFillIn)
+	}
+	if group.Heavy() {
+		fillIns = append(fillIns, HeavyFillIn)
+	}
+	if group.Contacts() > 1 {
+		fillIns = append(fillIns, ContactsFillIn)
+	}
+	if !group.High() && len(group.Stacks()) == 2 {
+		fillIns = append(fillIns, StackFillIn)
+	}
+	if len(group.Platforms()) > 0 {
+		fillIns = append(fillIns, PlatformFillIn)
+	}
+	if group.High() {
+		fillIns = append(fillIns, HighFillIn)
+	}
+	if group.Fast() {
+		fillIns = append(fillIns, FastFillIn)
+	} else if group.VeryFast() {
+		fillIns = append(fillIns, VeryFastFillIn)
+	}
+	return fillIns
+}
@@ -0,0 +1,244 @@
+package brevity
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+type testGroup struct {
+	Group
+	contacts     int
+	bullseye     *Bullseye
+	braa         BRAA
+	stacks       []Stack
+	track        Track
+	declaration  Declaration
+	heavy        bool
+	platforms    []string
+	high         bool
+	fast         bool
+	veryFast     bool
+	mergedWith   int
+	threat       bool
+	estimated    bool
+	closure      Closure
+	ids          []uint64
+	threatRadius unit.Length
+	popup        bool
+}
+
+func (g *testGroup) Contacts() int                  { return g.contacts }
+func (g *testGroup) SpokenContacts() (string, bool) { return SpokenContacts(g.contacts) }
+func (g *testGroup) Bullseye() *Bullseye            { return g.bullseye }
+func (g *testGroup) BRAA() BRAA                     { return g.braa }
+func (g *testGroup) Stacks() []Stack                { return g.stacks }
+func (g *testGroup) Track() Track                   { return g.track }
+func (g *testGroup) Declaration() Declaration       { return g.declaration }
+func (g *testGroup) Heavy() bool                    { return g.heavy }
+func (g *testGroup) Platforms() []string            { return g.platforms }
+func (g *testGroup) High() bool                     { return g.high }
+func (g *testGroup) Fast() bool                     { return g.fast }
+func (g *testGroup) VeryFast() bool                 { return g.veryFast }
+func (g *testGroup) MergedWith() int                { return g.mergedWith }
+func (g *testGroup) Threat() bool                   { return g.threat }
+func (g *testGroup) Extrapolated() bool             { return g.estimated }
+func (g *testGroup) Closure() Closure               { return g.closure }
+func (g *testGroup) ObjectIDs() []uint64            { return g.ids }
+func (g *testGroup) ThreatRadius() unit.Length      { return g.threatRadius }
+func (g *testGroup) Popup() bool                    { return g.popup }
+func (g *testGroup) SetDeclaration(d Declaration)   { g.declaration = d }
+
+func (g *testGroup) Altitude() unit.Length {
+	if len(g.stacks) == 0 {
+		return 0
+	}
+	return g.stacks[0].Altitude
+}
+
+func (g *testGroup) Aspect() Aspect {
+	if g.braa == nil {
+		return UnknownAspect
+	}
+	return g.braa.Aspect()
+}
+
+func TestSpokenContacts(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		n        int
+		expected string
+		ok       bool
+	}{
+		{n: -1, expected: "", ok: false},
+		{n: 0, expected: "", ok: false},
+		{n: 1, expected: "single contact", ok: true},
+		{n: 2, expected: "2 contacts", ok: true},
+		{n: 4, expected: "4 contacts", ok: true},
+		{n: 12, expected: "12 contacts", ok: true},
+	}
+	for _, test := range testCases {
+		t.Run(strconv.Itoa(test.n), func(t *testing.T) {
+			t.Parallel()
+			actual, ok := SpokenContacts(test.n)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func TestFillIns(t *testing.T) {
+	t.Parallel()
+	bullseye := NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile)
+	braa := func(aspect Aspect) BRAA {
+		return NewBRAA(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, aspect)
+	}
+	testCases := []struct {
+		name     string
+		group    *testGroup
+		expected []FillIn
+	}{
+		{
+			name:     "unknown location",
+			group:    &testGroup{contacts: 1, track: UnknownDirection, declaration: Hostile},
+			expected: []FillIn{DeclarationFillIn},
+		},
+		{
+			name:     "bullseye without track",
+			group:    &testGroup{contacts: 1, bullseye: bullseye, track: UnknownDirection, declaration: Hostile},
+			expected: []FillIn{PositionFillIn, AltitudeFillIn, DeclarationFillIn},
+		},
+		{
+			name: "bullseye with every fill-in",
+			group: &testGroup{
+				contacts:    4,
+				bullseye:    bullseye,
+				stacks:      []Stack{{Altitude: 30000 * unit.Foot, Count: 2}, {Altitude: 10000 * unit.Foot, Count: 2}},
+				track:       West,
+				declaration: Hostile,
+				heavy:       true,
+				platforms:   []string{"Flanker"},
+				fast:        true,
+				mergedWith:  2,
+			},
+			expected: []FillIn{
+				PositionFillIn,
+				AltitudeFillIn,
+				TrackFillIn,
+				DeclarationFillIn,
+				MergedFillIn,
+				HeavyFillIn,
+				ContactsFillIn,
+				StackFillIn,
+				PlatformFillIn,
+				FastFillIn,
+			},
+		},
+		{
+			name: "high group omits stack fill-in",
+			group: &testGroup{
+				contacts:    2,
+				bullseye:    bullseye,
+				stacks:      []Stack{{Altitude: 45000 * unit.Foot, Count: 1}, {Altitude: 30000 * unit.Foot, Count: 1}},
+				track:       North,
+				declaration: Bogey,
+				high:        true,
+				veryFast:    true,
+			},
+			expected: []FillIn{PositionFillIn, AltitudeFillIn, TrackFillIn, DeclarationFillIn, ContactsFillIn, HighFillIn, VeryFastFillIn},
+		},
+		{
+			name: "three stacks omit stack fill-in",
+			group: &testGroup{
+				contacts:    3,
+				bullseye:    bullseye,
+				stacks:      []Stack{{Altitude: 30000 * unit.Foot, Count: 1}, {Altitude: 20000 * unit.Foot, Count: 1}, {Altitude: 10000 * unit.Foot, Count: 1}},
+				track:       North,
+				declaration: Hostile,
+			},
+			expected: []FillIn{PositionFillIn, AltitudeFillIn, TrackFillIn, DeclarationFillIn, ContactsFillIn},
+		},
+		{
+			name:     "extrapolated bullseye",
+			group:    &testGroup{contacts: 1, bullseye: bullseye, track: UnknownDirection, declaration: Hostile, estimated: true},
+			expected: []FillIn{PositionFillIn, EstimatedFillIn, AltitudeFillIn, DeclarationFillIn},
+		},
+		{
+			name:     "BRAA hot omits track",
+			group:    &testGroup{contacts: 1, braa: braa(Hot), track: West, declaration: Hostile},
+			expected: []FillIn{PositionFillIn, DeclarationFillIn},
+		},
+		{
+			name:     "BRAA flank includes track",
+			group:    &testGroup{contacts: 1, braa: braa(Flank), track: West, declaration: Hostile},
+			expected: []FillIn{PositionFillIn, TrackFillIn, DeclarationFillIn},
+		},
+		{
+			name:     "BRAA closing",
+			group:    &testGroup{contacts: 1, braa: braa(Flank), track: West, declaration: Hostile, closure: Closing},
+			expected: []FillIn{PositionFillIn, TrackFillIn, ClosureFillIn, DeclarationFillIn},
+		},
+		{
+			name:     "BRAA furball omits track",
+			group:    &testGroup{contacts: 1, braa: braa(Beam), track: West, declaration: Furball},
+			expected: []FillIn{PositionFillIn, DeclarationFillIn},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, FillIns(test.group))
+		})
+	}
+}
+
+func TestIsHeavy(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		contacts int
+		expected bool
+	}{
+		{contacts: 0, expected: false},
+		{contacts: 1, expected: false},
+		{contacts: 2, expected: false},
+		{contacts: 3, expected: true},
+		{contacts: 4, expected: true},
+		{contacts: 12, expected: true},
+	}
+	for _, test := range testCases {
+		t.Run(strconv.Itoa(test.contacts), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, IsHeavy(test.contacts))
+		})
+	}
+}
+
+func TestIsGorilla(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		contacts  int
+		threshold int
+		expected  bool
+	}{
+		{contacts: 1, threshold: DefaultGorillaThreshold, expected: false},
+		{contacts: 3, threshold: DefaultGorillaThreshold, expected: false},
+		{contacts: 5, threshold: DefaultGorillaThreshold, expected: false},
+		{contacts: 6, threshold: DefaultGorillaThreshold, expected: true},
+		{contacts: 20, threshold: DefaultGorillaThreshold, expected: true},
+		{contacts: 4, threshold: 4, expected: true},
+		{contacts: 3, threshold: 4, expected: false},
+		{contacts: 3, threshold: 3, expected: true},
+		{contacts: 20, threshold: 2, expected: false},
+		{contacts: 20, threshold: 0, expected: false},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%d/%d", test.contacts, test.threshold), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, IsGorilla(test.contacts, test.threshold))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package brevity
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+)
+
+// IFFRules maps aircraft type patterns to declarations. Patterns are matched against the aircraft type from the
+// simulator, e.g. "MiG-29S", and may contain wildcards in [path.Match] syntax, e.g. "MiG-*".
+type IFFRules map[string]Declaration
+
+// IFFResult is the outcome of classifying an aircraft.
+type IFFResult struct {
+	// Declaration of the aircraft.
+	Declaration Declaration
+	// Rule is the pattern which matched the aircraft type. This is empty if no rule matched.
+	Rule string
+}
+
+// Matched returns true if a rule matched the aircraft type.
+func (r IFFResult) Matched() bool {
+	return r.Rule != ""
+}
+
+// ParseIFFRules parses rules from a map of aircraft type patterns to declaration names.
+func ParseIFFRules(m map[string]string) (IFFRules, error) {
+	rules := make(IFFRules, len(m))
+	var err error
+	for pattern, name := range m {
+		if _, matchErr := path.Match(pattern, ""); matchErr != nil {
+			err = errors.Join(err, fmt.Errorf("invalid IFF rule pattern %q: %w", pattern, matchErr))
+			continue
+		}
+		declaration := Declaration(strings.ToLower(strings.TrimSpace(name)))
+		switch declaration {
+		case Friendly, Hostile, Bandit, Bogey, Neutral:
+			rules[pattern] = declaration
+		default:
+			err = errors.Join(err, fmt.Errorf("invalid declaration %q for IFF rule %q", name, pattern))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ClassifyIFF declares an aircraft of the given type and coalition using the given rules. An exact match takes
+// precedence over wildcard patterns, and a longer wildcard pattern takes precedence over a shorter one. If no rule
+// matches, aircraft in the neutral coalition are declared [Neutral] and other aircraft are declared [Bogey].
+func ClassifyIFF(aircraftType string, coalition coalitions.Coalition, rules IFFRules) IFFResult {
+	if declaration, ok := rules[aircraftType]; ok && aircraftType != "" {
+		return IFFResult{Declaration: declaration, Rule: aircraftType}
+	}
+
+	patterns := make([]string, 0)
+	for pattern := range rules {
+		if ok, err := path.Match(pattern, aircraftType); ok && err == nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if len(patterns) > 0 {
+		slices.SortFunc(patterns, func(a, b string) int {
+			if len(a) != len(b) {
+				return len(b) - len(a)
+			}
+			return strings.Compare(a, b)
+		})
+		return IFFResult{Declaration: rules[patterns[0]], Rule: patterns[0]}
+	}
+
+	if coalition == coalitions.Neutrals {
+		return IFFResult{Declaration: Neutral}
+	}
+	return IFFResult{Declaration: Bogey}
+}
+
+// CoalitionDeclaration declares a contact of the given coalition relative to a requester of the given coalition. Red
+// and blue oppose each other: a contact of the opposing coalition is [Hostile] and a contact of the requester's
+// coalition is [Friendly]. A contact in the neutral coalition is [Neutral]. Any other contact is a [Bogey], as is any
+// contact when the requester is a spectator, i.e. not red or blue, following SRS.
+func CoalitionDeclaration(requester, contact coalitions.Coalition) Declaration {
+	if contact == coalitions.Neutrals {
+		return Neutral
+	}
+	hostile, err := coalitions.Opposing(requester)
+	switch {
+	case err != nil:
+		return Bogey
+	case contact == hostile:
+		return Hostile
+	case contact == requester:
+		return Friendly
+	default:
+		return Bogey
+	}
+}
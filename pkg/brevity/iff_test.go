@@ -0,0 +1,103 @@
+package brevity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyIFF(t *testing.T) {
+	t.Parallel()
+	rules := IFFRules{
+		"MiG-*":     Hostile,
+		"MiG-29*":   Bandit,
+		"MiG-29S":   Hostile,
+		"Su-2[57]*": Hostile,
+		"An-*":      Neutral,
+		"F-16C_50":  Friendly,
+		"Yak-?2":    Bogey,
+	}
+	testCases := []struct {
+		aircraftType string
+		coalition    coalitions.Coalition
+		declaration  Declaration
+		rule         string
+	}{
+		{aircraftType: "MiG-29S", coalition: coalitions.Red, declaration: Hostile, rule: "MiG-29S"},
+		{aircraftType: "MiG-29A", coalition: coalitions.Red, declaration: Bandit, rule: "MiG-29*"},
+		{aircraftType: "MiG-21Bis", coalition: coalitions.Red, declaration: Hostile, rule: "MiG-*"},
+		{aircraftType: "Su-27", coalition: coalitions.Red, declaration: Hostile, rule: "Su-2[57]*"},
+		{aircraftType: "Su-25T", coalition: coalitions.Red, declaration: Hostile, rule: "Su-2[57]*"},
+		{aircraftType: "Su-24M", coalition: coalitions.Red, declaration: Bogey},
+		{aircraftType: "An-26B", coalition: coalitions.Red, declaration: Neutral, rule: "An-*"},
+		{aircraftType: "F-16C_50", coalition: coalitions.Blue, declaration: Friendly, rule: "F-16C_50"},
+		{aircraftType: "F-16C", coalition: coalitions.Blue, declaration: Bogey},
+		{aircraftType: "Yak-52", coalition: coalitions.Neutrals, declaration: Bogey, rule: "Yak-?2"},
+		{aircraftType: "Yak-40", coalition: coalitions.Neutrals, declaration: Neutral},
+		{aircraftType: "", coalition: coalitions.Red, declaration: Bogey},
+	}
+	for _, test := range testCases {
+		t.Run(test.aircraftType, func(t *testing.T) {
+			t.Parallel()
+			result := ClassifyIFF(test.aircraftType, test.coalition, rules)
+			assert.Equal(t, test.declaration, result.Declaration)
+			assert.Equal(t, test.rule, result.Rule)
+			assert.Equal(t, test.rule != "", result.Matched())
+		})
+	}
+}
+
+func TestClassifyIFFWithoutRules(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, Bogey, ClassifyIFF("MiG-29S", coalitions.Red, nil).Declaration)
+	assert.Equal(t, Neutral, ClassifyIFF("Yak-52", coalitions.Neutrals, IFFRules{}).Declaration)
+	assert.False(t, ClassifyIFF("MiG-29S", coalitions.Red, nil).Matched())
+}
+
+func TestParseIFFRules(t *testing.T) {
+	t.Parallel()
+	rules, err := ParseIFFRules(map[string]string{"MiG-*": "Hostile", "An-26B": " neutral "})
+	require.NoError(t, err)
+	assert.Equal(t, IFFRules{"MiG-*": Hostile, "An-26B": Neutral}, rules)
+
+	_, err = ParseIFFRules(map[string]string{"MiG-*": "furball"})
+	require.Error(t, err)
+	_, err = ParseIFFRules(map[string]string{"MiG-[": "hostile"})
+	require.Error(t, err)
+}
+
+func TestCoalitionDeclaration(t *testing.T) {
+	t.Parallel()
+	unknown := coalitions.Coalition(0)
+	testCases := []struct {
+		requester coalitions.Coalition
+		contact   coalitions.Coalition
+		expected  Declaration
+	}{
+		{requester: coalitions.Blue, contact: coalitions.Blue, expected: Friendly},
+		{requester: coalitions.Blue, contact: coalitions.Red, expected: Hostile},
+		{requester: coalitions.Blue, contact: coalitions.Neutrals, expected: Neutral},
+		{requester: coalitions.Blue, contact: unknown, expected: Bogey},
+		{requester: coalitions.Red, contact: coalitions.Red, expected: Friendly},
+		{requester: coalitions.Red, contact: coalitions.Blue, expected: Hostile},
+		{requester: coalitions.Red, contact: coalitions.Neutrals, expected: Neutral},
+		{requester: coalitions.Red, contact: unknown, expected: Bogey},
+		{requester: coalitions.Neutrals, contact: coalitions.Blue, expected: Bogey},
+		{requester: coalitions.Neutrals, contact: coalitions.Red, expected: Bogey},
+		{requester: coalitions.Neutrals, contact: coalitions.Neutrals, expected: Neutral},
+		{requester: coalitions.Neutrals, contact: unknown, expected: Bogey},
+		{requester: unknown, contact: coalitions.Blue, expected: Bogey},
+		{requester: unknown, contact: coalitions.Red, expected: Bogey},
+		{requester: unknown, contact: coalitions.Neutrals, expected: Neutral},
+		{requester: unknown, contact: unknown, expected: Bogey},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%d vs %d", test.requester, test.contact), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, CoalitionDeclaration(test.requester, test.contact))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package brevity
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// LadderAzimuthTolerance is the default maximum angle between the track axis and the line from the lead group to
+	// each other group in a LADDER.
+	LadderAzimuthTolerance = 10 * unit.Degree
+	// LadderMinSeparation is the default minimum in-line distance between consecutive groups in a LADDER.
+	LadderMinSeparation = 10 * unit.NauticalMile
+)
+
+// LadderCriteria are the thresholds used to decide when groups are in a LADDER.
+type LadderCriteria struct {
+	// AzimuthTolerance is the maximum angle between the track axis and the line from the lead group to each other
+	// group.
+	AzimuthTolerance unit.Angle
+	// MinSeparation is the minimum in-line distance between consecutive groups.
+	MinSeparation unit.Length
+}
+
+// DefaultLadderCriteria are the default thresholds for a LADDER.
+var DefaultLadderCriteria = LadderCriteria{
+	AzimuthTolerance: LadderAzimuthTolerance,
+	MinSeparation:    LadderMinSeparation,
+}
+
+// ClassifyLadder returns true if the groups are in a LADDER by [DefaultLadderCriteria].
+func ClassifyLadder(groups []Group) bool {
+	return DefaultLadderCriteria.ClassifyLadder(groups)
+}
+
+// ClassifyLadder returns true if the groups are in a LADDER: three or more groups on the same azimuth along their
+// track axis, separated in range. The track axis is found as for a pair of groups in a PICTURE. All groups must have a
+// BULLSEYE position.
+func (c LadderCriteria) ClassifyLadder(groups []Group) bool {
+	positions, ok := groupPositions(groups)
+	if !ok {
+		return false
+	}
+	_, _, ok = c.ladder(groups, positions)
+	return ok
+}
+
+// ladder checks if the groups at the given positions are in a LADDER. If they are, it returns the label of each group
+// in the given order, and the in-line distances between consecutive groups from lead to trail.
+func (c LadderCriteria) ladder(groups []Group, positions []vector) ([]GroupLabel, []unit.Length, bool) {
+	if len(groups) < 3 || len(positions) != len(groups) {
+		return nil, nil, false
+	}
+	axis := formationAxis(groups, positions)
+
+	// Order the groups from lead to trail.
+	order := make([]int, len(positions))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(positions[b].dot(axis), positions[a].dot(axis))
+	})
+
+	lead := positions[order[0]]
+	separations := make([]unit.Length, 0, len(order)-1)
+	for i, j := range order[1:] {
+		// The displacement from the lead group to this group should point back along the track axis.
+		displacement := positions[j].sub(lead)
+		behind := -displacement.dot(axis)
+		beside := displacement.x*axis.y - displacement.y*axis.x
+		offAxis := unit.Angle(math.Atan2(math.Abs(beside), behind)) * unit.Radian
+		if offAxis > c.AzimuthTolerance {
+			return nil, nil, false
+		}
+
+		separation := positions[order[i]].sub(positions[j]).dot(axis)
+		if separation < c.MinSeparation.NauticalMiles() {
+			return nil, nil, false
+		}
+		separations = append(separations, unit.Length(math.Round(separation))*unit.NauticalMile)
+	}
+
+	labels := make([]GroupLabel, len(groups))
+	for i, j := range order {
+		switch i {
+		case 0:
+			labels[j] = LeadGroup
+		case len(order) - 1:
+			labels[j] = TrailGroup
+		default:
+			labels[j] = MiddleGroup
+		}
+	}
+	return labels, separations, true
+}
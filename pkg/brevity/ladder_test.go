@@ -0,0 +1,86 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyLadder(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		groups   []Group
+		expected bool
+	}{
+		{
+			name:     "ideal ladder tracking south",
+			groups:   []Group{groupAt(360, 20, South), groupAt(360, 35, South), groupAt(360, 50, South)},
+			expected: true,
+		},
+		{
+			name:     "ideal ladder tracking east",
+			groups:   []Group{groupAt(270, 60, East), groupAt(270, 40, East), groupAt(270, 20, East)},
+			expected: true,
+		},
+		{
+			name:     "slightly off azimuth",
+			groups:   []Group{groupAt(2, 20, South), groupAt(360, 35, South), groupAt(358, 50, South)},
+			expected: true,
+		},
+		{
+			name:     "ideal ladder with unknown track",
+			groups:   []Group{groupAt(90, 30, UnknownDirection), groupAt(90, 45, UnknownDirection), groupAt(90, 60, UnknownDirection)},
+			expected: true,
+		},
+		{
+			name:     "four groups",
+			groups:   []Group{groupAt(180, 10, North), groupAt(180, 25, North), groupAt(181, 40, North), groupAt(179, 55, North)},
+			expected: true,
+		},
+		{
+			name:     "middle group off azimuth",
+			groups:   []Group{groupAt(360, 20, South), groupAt(20, 35, South), groupAt(360, 50, South)},
+			expected: false,
+		},
+		{
+			name:     "trail group off azimuth",
+			groups:   []Group{groupAt(360, 20, South), groupAt(360, 35, South), groupAt(345, 50, South)},
+			expected: false,
+		},
+		{
+			name:     "groups too close in range",
+			groups:   []Group{groupAt(360, 20, South), groupAt(360, 25, South), groupAt(360, 40, South)},
+			expected: false,
+		},
+		{
+			name:     "two groups",
+			groups:   []Group{groupAt(360, 20, South), groupAt(360, 35, South)},
+			expected: false,
+		},
+		{
+			name:     "group without bullseye",
+			groups:   []Group{groupAt(360, 20, South), groupAt(360, 35, South), &testGroup{contacts: 1, track: South}},
+			expected: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, ClassifyLadder(test.groups))
+		})
+	}
+}
+
+func TestLadderCriteria(t *testing.T) {
+	t.Parallel()
+	groups := []Group{groupAt(3, 20, South), groupAt(360, 35, South), groupAt(357, 50, South)}
+	assert.True(t, DefaultLadderCriteria.ClassifyLadder(groups))
+
+	strict := LadderCriteria{AzimuthTolerance: 2 * unit.Degree, MinSeparation: LadderMinSeparation}
+	assert.False(t, strict.ClassifyLadder(groups))
+
+	spread := LadderCriteria{AzimuthTolerance: LadderAzimuthTolerance, MinSeparation: 20 * unit.NauticalMile}
+	assert.False(t, spread.ClassifyLadder(groups))
+}
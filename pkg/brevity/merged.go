@@ -1,12 +1,17 @@
 package brevity
 
 import (
+	"time"
+
 	"github.com/martinlindhe/unit"
 )
 
+// MergedCall announces that friendly aircraft have merged with a hostile group.
 type MergedCall struct {
 	// Callsigns of the friendly aircraft in the merge.
 	Callsigns []string
+	// HostileGroup is the hostile group the friendly aircraft merged with. It may be nil.
+	HostileGroup Group
 }
 
 const (
@@ -14,4 +19,84 @@ const (
 	MergeEntryDistance = 3 * unit.NauticalMile
 	// MergeExitDistance is the distance at which contacts are considered to exit the merge.
 	MergeExitDistance = 5 * unit.NauticalMile
+	// MergeEntryAltitude is the altitude separation within which contacts are considered to enter the merge.
+	MergeEntryAltitude = 6000 * unit.Foot
+	// MergeExitAltitude is the altitude separation beyond which contacts are considered to exit the merge.
+	MergeExitAltitude = 10000 * unit.Foot
 )
+
+// MergeCriteria are the thresholds used to decide when contacts enter and exit the merge. The exit thresholds are
+// larger than the entry thresholds, so that contacts near a threshold do not repeatedly enter and exit the merge.
+type MergeCriteria struct {
+	// EntryDistance is the distance at which contacts enter the merge.
+	EntryDistance unit.Length
+	// ExitDistance is the distance at which contacts exit the merge.
+	ExitDistance unit.Length
+	// EntryAltitude is the altitude separation at which contacts enter the merge.
+	EntryAltitude unit.Length
+	// ExitAltitude is the altitude separation at which contacts exit the merge.
+	ExitAltitude unit.Length
+}
+
+// DefaultMergeCriteria are the default thresholds for entering and exiting the merge.
+var DefaultMergeCriteria = MergeCriteria{
+	EntryDistance: MergeEntryDistance,
+	ExitDistance:  MergeExitDistance,
+	EntryAltitude: MergeEntryAltitude,
+	ExitAltitude:  MergeExitAltitude,
+}
+
+// NewMergeCriteria returns criteria which enter the merge within the given distance and altitude separation. The exit
+// thresholds are scaled in the same proportion as [DefaultMergeCriteria].
+func NewMergeCriteria(distance, altitude unit.Length) MergeCriteria {
+	return MergeCriteria{
+		EntryDistance: distance,
+		ExitDistance:  distance * MergeExitDistance / MergeEntryDistance,
+		EntryAltitude: altitude,
+		ExitAltitude:  altitude * MergeExitAltitude / MergeEntryAltitude,
+	}
+}
+
+// Enters returns true if contacts at the given distance and altitude separation should enter the merge.
+func (c MergeCriteria) Enters(distance, altitudeSeparation unit.Length) bool {
+	return absLength(distance) < c.EntryDistance && absLength(altitudeSeparation) < c.EntryAltitude
+}
+
+// Exits returns true if contacts at the given distance and altitude separation should exit the merge.
+func (c MergeCriteria) Exits(distance, altitudeSeparation unit.Length) bool {
+	return absLength(distance) > c.ExitDistance || absLength(altitudeSeparation) > c.ExitAltitude
+}
+
+// Merge records when a friendly and a hostile contact entered and exited the merge.
+type Merge struct {
+	// Entered is when the contacts most recently entered the merge. It is zero if they have never merged.
+	Entered time.Time
+	// Exited is when the contacts most recently exited the merge. It is zero if they have not exited since entering.
+	Exited time.Time
+}
+
+// IsMerged returns true if the contacts are currently in the merge.
+func (m *Merge) IsMerged() bool {
+	return !m.Entered.IsZero() && m.Exited.IsZero()
+}
+
+// Update records the contacts entering or exiting the merge at the given time. It returns true if the contacts newly
+// entered the merge.
+func (m *Merge) Update(criteria MergeCriteria, distance, altitudeSeparation unit.Length, t time.Time) bool {
+	if !m.IsMerged() && criteria.Enters(distance, altitudeSeparation) {
+		m.Entered = t
+		m.Exited = time.Time{}
+		return true
+	}
+	if m.IsMerged() && criteria.Exits(distance, altitudeSeparation) {
+		m.Exited = t
+	}
+	return false
+}
+
+func absLength(l unit.Length) unit.Length {
+	if l < 0 {
+		return -l
+	}
+	return l
+}
@@ -0,0 +1,88 @@
+package brevity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMergeCriteria(t *testing.T) {
+	t.Parallel()
+	criteria := NewMergeCriteria(MergeEntryDistance, MergeEntryAltitude)
+	assert.InDelta(t, DefaultMergeCriteria.ExitDistance.NauticalMiles(), criteria.ExitDistance.NauticalMiles(), 0.01)
+	assert.InDelta(t, DefaultMergeCriteria.ExitAltitude.Feet(), criteria.ExitAltitude.Feet(), 0.01)
+}
+
+func TestMergeCriteria(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		distance           unit.Length
+		altitudeSeparation unit.Length
+		expectedEnters     bool
+		expectedExits      bool
+	}{
+		{distance: 1 * unit.NauticalMile, altitudeSeparation: 0, expectedEnters: true, expectedExits: false},
+		{distance: 1 * unit.NauticalMile, altitudeSeparation: -2000 * unit.Foot, expectedEnters: true, expectedExits: false},
+		{distance: 4 * unit.NauticalMile, altitudeSeparation: 0, expectedEnters: false, expectedExits: false},
+		{distance: 6 * unit.NauticalMile, altitudeSeparation: 0, expectedEnters: false, expectedExits: true},
+		{distance: 1 * unit.NauticalMile, altitudeSeparation: 8000 * unit.Foot, expectedEnters: false, expectedExits: false},
+		{distance: 1 * unit.NauticalMile, altitudeSeparation: -15000 * unit.Foot, expectedEnters: false, expectedExits: true},
+	}
+	for _, test := range testCases {
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expectedEnters, DefaultMergeCriteria.Enters(test.distance, test.altitudeSeparation))
+			assert.Equal(t, test.expectedExits, DefaultMergeCriteria.Exits(test.distance, test.altitudeSeparation))
+		})
+	}
+}
+
+func TestMergeHysteresis(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	merge := &Merge{}
+	assert.False(t, merge.IsMerged())
+
+	steps := []struct {
+		distance       unit.Length
+		expectedEntry  bool
+		expectedMerged bool
+	}{
+		{distance: 10 * unit.NauticalMile, expectedEntry: false, expectedMerged: false},
+		{distance: 4 * unit.NauticalMile, expectedEntry: false, expectedMerged: false},
+		{distance: 2.9 * unit.NauticalMile, expectedEntry: true, expectedMerged: true},
+		// Oscillating around the entry distance must not re-enter the merge.
+		{distance: 3.1 * unit.NauticalMile, expectedEntry: false, expectedMerged: true},
+		{distance: 2.9 * unit.NauticalMile, expectedEntry: false, expectedMerged: true},
+		{distance: 4.9 * unit.NauticalMile, expectedEntry: false, expectedMerged: true},
+		{distance: 5.1 * unit.NauticalMile, expectedEntry: false, expectedMerged: false},
+		// Oscillating around the exit distance must not re-enter the merge.
+		{distance: 4.9 * unit.NauticalMile, expectedEntry: false, expectedMerged: false},
+		{distance: 5.1 * unit.NauticalMile, expectedEntry: false, expectedMerged: false},
+		{distance: 2 * unit.NauticalMile, expectedEntry: true, expectedMerged: true},
+	}
+	for i, step := range steps {
+		now := start.Add(time.Duration(i) * time.Second)
+		entered := merge.Update(DefaultMergeCriteria, step.distance, 0, now)
+		assert.Equal(t, step.expectedEntry, entered, "step %d", i)
+		assert.Equal(t, step.expectedMerged, merge.IsMerged(), "step %d", i)
+		if entered {
+			assert.Equal(t, now, merge.Entered, "step %d", i)
+			assert.True(t, merge.Exited.IsZero(), "step %d", i)
+		}
+	}
+}
+
+func TestMergeExitedTimestamp(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	merge := &Merge{}
+	merge.Update(DefaultMergeCriteria, 1*unit.NauticalMile, 0, start)
+	exit := start.Add(time.Minute)
+	merge.Update(DefaultMergeCriteria, 1*unit.NauticalMile, 20000*unit.Foot, exit)
+	assert.False(t, merge.IsMerged())
+	assert.Equal(t, start, merge.Entered)
+	assert.Equal(t, exit, merge.Exited)
+}
@@ -0,0 +1,9 @@
+package brevity
+
+// NumerousCall reports that the number of hostile contacts has reached the alert threshold.
+type NumerousCall struct {
+	// Groups is the number of hostile groups.
+	Groups int
+	// Contacts is the number of hostile contacts.
+	Contacts int
+}
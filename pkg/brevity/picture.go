@@ -1,5 +1,13 @@
 package brevity
 
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/martinlindhe/unit"
+)
+
 // PictureRequest is a request for an updated PICTURE.
 type PictureRequest struct {
 	// Callsign of the friendly aircraft requesting the PICTURE.
@@ -18,6 +26,362 @@ func (r PictureRequest) String() string {
 type PictureResponse struct {
 	// Count is the total number of groups in the PICTURE.
 	Count int
-	// Groups included in the PICTURE. This is a maximum of 3 groups.
+	// Groups included in the PICTURE. This is a maximum of [MaxPictureGroups] groups.
 	Groups []Group
+	// AdditionalGroups is the number of groups which are counted in the PICTURE but not described.
+	AdditionalGroups int
+	// Formation is the relative arrangement of the groups. This is only set for two groups, or when three or more
+	// groups are all described and match a formation.
+	Formation PictureFormation
+	// Separation is the distance between the groups in an AZIMUTH or RANGE formation.
+	Separation unit.Length
+	// Separations are the in-line distances between consecutive groups in a LADDER, from lead to trail.
+	Separations []unit.Length
+	// Depth is the in-line distance from the point group to the other groups in a VIC or CHAMPAGNE.
+	Depth unit.Length
+	// Width is the lateral distance between the two groups beside each other in a VIC or CHAMPAGNE, or between the
+	// outermost groups in a WALL.
+	Width unit.Length
+	// Labels identify each group relative to the other groups, in the same order as Groups. A label may be
+	// [UnlabeledGroup].
+	Labels []GroupLabel
+}
+
+// MaxPictureGroups is the maximum number of groups described in a PICTURE.
+const MaxPictureGroups = 3
+
+// PictureFormation is the relative arrangement of groups in a PICTURE.
+// Reference: ATP 3-52.4 Chapter IV section 9 subsection b.
+type PictureFormation string
+
+const (
+	// NoFormation is used when the groups are not labeled relative to each other.
+	NoFormation PictureFormation = ""
+	// AzimuthFormation is two groups separated laterally, relative to their track.
+	AzimuthFormation PictureFormation = "azimuth"
+	// RangeFormation is two groups separated in-line, along their track.
+	RangeFormation PictureFormation = "range"
+	// LadderFormation is three or more groups on the same azimuth, separated in-line along their track.
+	LadderFormation PictureFormation = "ladder"
+	// VicFormation is three groups with a single lead group and two trailing groups, one on each side of the track.
+	VicFormation PictureFormation = "vic"
+	// ChampagneFormation is three groups with two lead groups, one on each side of the track, and a single trailing
+	// group.
+	ChampagneFormation PictureFormation = "champagne"
+	// WallFormation is three or more groups abeam each other in a line across the requester's line of sight, separated
+	// in azimuth.
+	WallFormation PictureFormation = "wall"
+)
+
+// GroupLabel is a label which identifies a group relative to the other groups in a PICTURE. A cardinal direction may
+// be combined with lead or trail, such as "north lead" for the northernmost of two lead groups in a CHAMPAGNE.
+type GroupLabel string
+
+const (
+	// UnlabeledGroup is used when a group is not labeled.
+	UnlabeledGroup GroupLabel = ""
+	// NorthGroup is the northernmost group.
+	NorthGroup GroupLabel = "north"
+	// SouthGroup is the southernmost group.
+	SouthGroup GroupLabel = "south"
+	// EastGroup is the easternmost group.
+	EastGroup GroupLabel = "east"
+	// WestGroup is the westernmost group.
+	WestGroup GroupLabel = "west"
+	// LeadGroup is the group furthest along the track.
+	LeadGroup GroupLabel = "lead"
+	// TrailGroup is the group behind the lead group.
+	TrailGroup GroupLabel = "trail"
+	// MiddleGroup is a group between the lead and trail groups, or between the outermost groups labeled by cardinal
+	// direction.
+	MiddleGroup GroupLabel = "middle"
+)
+
+// NewPictureResponse creates a PICTURE from the given groups. count is the total number of groups, which may be larger
+// than the number of groups included in the PICTURE. The groups are ordered by [AnchorGroups] relative to the given
+// friendly aircraft, with the anchoring group first, and labeled relative to each other. At most [MaxPictureGroups]
+// groups are described; any others are counted in AdditionalGroups.
+func NewPictureResponse(count int, groups []Group, friendlies []Contact) PictureResponse {
+	return newPictureResponse(count, AnchorGroups(groups, friendlies), Contact{})
+}
+
+// NewPrioritizedPictureResponse returns a PICTURE response for a requesting aircraft. It is like [NewPictureResponse],
+// except that the groups are ordered from highest to lowest priority relative to the requester by
+// [PriorityWeights.PrioritizeGroups], so the requester hears the most important group first.
+func NewPrioritizedPictureResponse(count int, groups []Group, ownship Contact, weights PriorityWeights) PictureResponse {
+	return newPictureResponse(count, weights.PrioritizeGroups(groups, ownship), ownship)
+}
+
+// newPictureResponse returns a PICTURE response describing the groups in the given order. The ownship is the reference
+// for a WALL; if its position is unknown, no WALL is called.
+func newPictureResponse(count int, anchored []Group, ownship Contact) PictureResponse {
+	if len(anchored) > MaxPictureGroups {
+		anchored = anchored[:MaxPictureGroups]
+	}
+	response := PictureResponse{
+		Count:            count,
+		Groups:           anchored,
+		AdditionalGroups: max(count-len(anchored), 0),
+		Labels:           make([]GroupLabel, len(anchored)),
+	}
+
+	positions, ok := groupPositions(response.Groups)
+	if !ok {
+		// Groups cannot be labeled without a common reference point.
+		return response
+	}
+
+	switch len(response.Groups) {
+	case 0, 1:
+		return response
+	case 2:
+		response.Formation, response.Separation, response.Labels = labelPair(response.Groups, positions)
+	default:
+		// Only call a formation when every group is described, since the other groups might be anywhere.
+		if response.AdditionalGroups == 0 {
+			for _, classify := range formationClassifiers {
+				if classify(&response, positions, ownship) {
+					return response
+				}
+			}
+		}
+		response.Labels = labelCardinal(positions)
+	}
+	return response
+}
+
+// formationClassifiers classify three or more groups in a PICTURE, in priority order from the most to the least
+// specific formation. Each classifier returns true and sets the formation, labels and distances of the response if the
+// groups match its formation.
+var formationClassifiers = []func(*PictureResponse, []vector, Contact) bool{
+	classifyLadder,
+	classifyVic,
+	classifyChampagne,
+	classifyWall,
+}
+
+func classifyWall(response *PictureResponse, positions []vector, ownship Contact) bool {
+	labels, width, ok := DefaultWallCriteria.wall(positions, ownship)
+	if !ok {
+		return false
+	}
+	response.Formation, response.Width, response.Labels = WallFormation, width, labels
+	return true
+}
+
+func classifyLadder(response *PictureResponse, positions []vector, _ Contact) bool {
+	labels, separations, ok := DefaultLadderCriteria.ladder(response.Groups, positions)
+	if !ok {
+		return false
+	}
+	response.Formation, response.Separations, response.Labels = LadderFormation, separations, labels
+	return true
+}
+
+func classifyVic(response *PictureResponse, positions []vector, _ Contact) bool {
+	shape, ok := DefaultWedgeCriteria.vic(response.Groups, positions)
+	if !ok {
+		return false
+	}
+	response.Formation, response.Depth, response.Width, response.Labels = VicFormation, shape.depth, shape.width, shape.labels
+	return true
+}
+
+func classifyChampagne(response *PictureResponse, positions []vector, _ Contact) bool {
+	shape, ok := DefaultWedgeCriteria.champagne(response.Groups, positions)
+	if !ok {
+		return false
+	}
+	response.Formation, response.Width, response.Depth, response.Labels = ChampagneFormation, shape.width, shape.depth, shape.labels
+	return true
+}
+
+// AnchorGroups returns a copy of the groups ordered for a PICTURE broadcast to all friendly aircraft. Threat groups are
+// reported first. Otherwise, the groups are ordered by [PrioritizeGroups] relative to the friendly aircraft nearest to
+// any of the groups, so that the anchoring group is the highest priority group for the most exposed friendly aircraft.
+func AnchorGroups(groups []Group, friendlies []Contact) []Group {
+	ordered := PrioritizeGroups(groups, nearestContact(groups, friendlies))
+	slices.SortStableFunc(ordered, func(a, b Group) int {
+		if a.Threat() == b.Threat() {
+			return 0
+		}
+		if a.Threat() {
+			return -1
+		}
+		return 1
+	})
+	return ordered
+}
+
+// nearestContact returns the contact nearest to any of the groups. If no contact is near a group with a known position,
+// it returns a contact with an unknown position.
+func nearestContact(groups []Group, contacts []Contact) Contact {
+	var nearest Contact
+	nearestDistance := math.Inf(1)
+	for _, contact := range contacts {
+		if contact.Bullseye == nil {
+			continue
+		}
+		for _, group := range groups {
+			position, ok := bullseyeVector(group)
+			if !ok {
+				continue
+			}
+			offset := position.sub(contact.Bullseye.vector())
+			if distance := math.Hypot(offset.x, offset.y); distance < nearestDistance {
+				nearest, nearestDistance = contact, distance
+			}
+		}
+	}
+	return nearest
+}
+
+// lowestID returns the lowest object ID in the group, or the maximum ID if the group has no object IDs.
+func lowestID(g Group) uint64 {
+	ids := g.ObjectIDs()
+	if len(ids) == 0 {
+		return math.MaxUint64
+	}
+	return slices.Min(ids)
+}
+
+// vector is a displacement on a flat plane, in nautical miles. x is east and y is north.
+type vector struct {
+	x, y float64
+}
+
+func (v vector) add(o vector) vector {
+	return vector{x: v.x + o.x, y: v.y + o.y}
+}
+
+func (v vector) sub(o vector) vector {
+	return vector{x: v.x - o.x, y: v.y - o.y}
+}
+
+func (v vector) dot(o vector) float64 {
+	return v.x*o.x + v.y*o.y
+}
+
+// bullseyeVector returns the group's displacement from the BULLSEYE.
+func bullseyeVector(group Group) (vector, bool) {
+	bullseye := group.Bullseye()
+	if bullseye == nil {
+		return vector{}, false
+	}
+	return bullseye.vector(), true
+}
+
+// groupPositions returns each group's displacement from the BULLSEYE, or false if any group has no BULLSEYE.
+func groupPositions(groups []Group) ([]vector, bool) {
+	positions := make([]vector, 0, len(groups))
+	for _, group := range groups {
+		position, ok := bullseyeVector(group)
+		if !ok {
+			return nil, false
+		}
+		positions = append(positions, position)
+	}
+	return positions, true
+}
+
+// vector returns the displacement from the BULLSEYE.
+func (b *Bullseye) vector() vector {
+	θ := b.Bearing().Value().Radians()
+	d := b.distance.NauticalMiles()
+	return vector{x: d * math.Sin(θ), y: d * math.Cos(θ)}
+}
+
+// unitVector returns a unit vector pointing in the given compass direction.
+func unitVector(direction unit.Angle) vector {
+	return vector{x: math.Sin(direction.Radians()), y: math.Cos(direction.Radians())}
+}
+
+// trackAngle returns the compass direction of a track.
+func trackAngle(track Track) (unit.Angle, bool) {
+	directions := []Track{North, Northeast, East, Southeast, South, Southwest, West, Northwest}
+	i := slices.Index(directions, track)
+	if i < 0 {
+		return 0, false
+	}
+	return unit.Angle(i*45) * unit.Degree, true
+}
+
+// formationAxis returns a unit vector along the track axis of the groups, which is the first known track of any group.
+// If no track is known, the groups are assumed to be tracking towards the BULLSEYE from their centroid.
+func formationAxis(groups []Group, positions []vector) vector {
+	for _, group := range groups {
+		if angle, ok := trackAngle(group.Track()); ok {
+			return unitVector(angle)
+		}
+	}
+	var centroid vector
+	for _, p := range positions {
+		centroid.x += p.x / float64(len(positions))
+		centroid.y += p.y / float64(len(positions))
+	}
+	length := math.Hypot(centroid.x, centroid.y)
+	if length == 0 {
+		return vector{x: 0, y: 1}
+	}
+	return vector{x: -centroid.x / length, y: -centroid.y / length}
+}
+
+// labelPair labels two groups. The groups are compared along their track axis, as found by [formationAxis]. Groups
+// separated more laterally than in-line are in AZIMUTH and labeled by cardinal direction; otherwise they are in RANGE
+// and labeled lead and trail.
+func labelPair(groups []Group, positions []vector) (PictureFormation, unit.Length, []GroupLabel) {
+	axis := formationAxis(groups, positions)
+	perpendicular := vector{x: axis.y, y: -axis.x}
+
+	displacement := positions[1].sub(positions[0])
+	inLine := displacement.dot(axis)
+	lateral := displacement.dot(perpendicular)
+
+	if math.Abs(lateral) >= math.Abs(inLine) {
+		separation := unit.Length(math.Round(math.Abs(lateral))) * unit.NauticalMile
+		return AzimuthFormation, separation, labelCardinal(positions)
+	}
+
+	separation := unit.Length(math.Round(math.Abs(inLine))) * unit.NauticalMile
+	if inLine < 0 {
+		// The second group is behind the first group.
+		return RangeFormation, separation, []GroupLabel{LeadGroup, TrailGroup}
+	}
+	return RangeFormation, separation, []GroupLabel{TrailGroup, LeadGroup}
+}
+
+// labelCardinal labels each group by cardinal direction. The groups are labeled north and south if they are spread
+// further north-south than east-west, and east and west otherwise. Only the outermost groups are labeled by direction;
+// any groups between them are labeled middle, so that no two of three groups share a label.
+func labelCardinal(positions []vector) []GroupLabel {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, p := range positions {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	low, high, coordinate := WestGroup, EastGroup, func(v vector) float64 { return v.x }
+	if maxY-minY > maxX-minX {
+		low, high, coordinate = SouthGroup, NorthGroup, func(v vector) float64 { return v.y }
+	}
+
+	order := make([]int, len(positions))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(coordinate(positions[a]), coordinate(positions[b]))
+	})
+	labels := make([]GroupLabel, len(positions))
+	for i, j := range order {
+		switch i {
+		case 0:
+			labels[j] = low
+		case len(order) - 1:
+			labels[j] = high
+		default:
+			labels[j] = MiddleGroup
+		}
+	}
+	return labels
 }
@@ -0,0 +1,348 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupAt(bearing float64, distance float64, track Track) *testGroup {
+	return &testGroup{
+		contacts: 1,
+		bullseye: NewBullseye(
+			bearings.NewMagneticBearing(unit.Angle(bearing)*unit.Degree),
+			unit.Length(distance)*unit.NauticalMile,
+		),
+		track: track,
+	}
+}
+
+func TestNewPictureResponsePairs(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name       string
+		groups     []Group
+		formation  PictureFormation
+		separation float64
+		labels     []GroupLabel
+	}{
+		{
+			name:       "east-west groups tracking south",
+			groups:     []Group{groupAt(270, 10, South), groupAt(90, 10, South)},
+			formation:  AzimuthFormation,
+			separation: 20,
+			labels:     []GroupLabel{WestGroup, EastGroup},
+		},
+		{
+			name:       "east-west groups tracking north",
+			groups:     []Group{groupAt(90, 10, North), groupAt(270, 10, North)},
+			formation:  AzimuthFormation,
+			separation: 20,
+			labels:     []GroupLabel{EastGroup, WestGroup},
+		},
+		{
+			name:       "north-south groups tracking east",
+			groups:     []Group{groupAt(180, 5, East), groupAt(360, 15, East)},
+			formation:  AzimuthFormation,
+			separation: 20,
+			labels:     []GroupLabel{SouthGroup, NorthGroup},
+		},
+		{
+			name:       "north-south groups tracking west",
+			groups:     []Group{groupAt(360, 5, West), groupAt(180, 15, West)},
+			formation:  AzimuthFormation,
+			separation: 20,
+			labels:     []GroupLabel{NorthGroup, SouthGroup},
+		},
+		{
+			name:       "in-line groups tracking east, second group leading",
+			groups:     []Group{groupAt(270, 10, East), groupAt(90, 10, East)},
+			formation:  RangeFormation,
+			separation: 20,
+			labels:     []GroupLabel{TrailGroup, LeadGroup},
+		},
+		{
+			name:       "in-line groups tracking west, first group leading",
+			groups:     []Group{groupAt(270, 10, West), groupAt(90, 10, West)},
+			formation:  RangeFormation,
+			separation: 20,
+			labels:     []GroupLabel{LeadGroup, TrailGroup},
+		},
+		{
+			name:       "in-line groups tracking south",
+			groups:     []Group{groupAt(360, 20, South), groupAt(360, 45, South)},
+			formation:  RangeFormation,
+			separation: 25,
+			labels:     []GroupLabel{LeadGroup, TrailGroup},
+		},
+		{
+			name:       "diagonal in-line groups tracking northeast",
+			groups:     []Group{groupAt(225, 10, Northeast), groupAt(45, 10, Northeast)},
+			formation:  RangeFormation,
+			separation: 20,
+			labels:     []GroupLabel{TrailGroup, LeadGroup},
+		},
+		{
+			name:       "diagonal groups abreast tracking northeast",
+			groups:     []Group{groupAt(315, 10, Northeast), groupAt(135, 12, Northeast)},
+			formation:  AzimuthFormation,
+			separation: 22,
+			labels:     []GroupLabel{WestGroup, EastGroup},
+		},
+		{
+			name:       "mostly lateral separation with some in-line offset",
+			groups:     []Group{groupAt(270, 10, South), groupAt(135, 10, South)},
+			formation:  AzimuthFormation,
+			separation: 17,
+			labels:     []GroupLabel{WestGroup, EastGroup},
+		},
+		{
+			name:       "mostly in-line separation with some lateral offset",
+			groups:     []Group{groupAt(360, 30, South), groupAt(5, 60, South)},
+			formation:  RangeFormation,
+			separation: 30,
+			labels:     []GroupLabel{LeadGroup, TrailGroup},
+		},
+		{
+			name:       "second group track is used when first is unknown",
+			groups:     []Group{groupAt(270, 10, UnknownDirection), groupAt(90, 10, East)},
+			formation:  RangeFormation,
+			separation: 20,
+			labels:     []GroupLabel{TrailGroup, LeadGroup},
+		},
+		{
+			name:       "unknown tracks abreast, assumed inbound to bullseye",
+			groups:     []Group{groupAt(350, 40, UnknownDirection), groupAt(10, 40, UnknownDirection)},
+			formation:  AzimuthFormation,
+			separation: 14,
+			labels:     []GroupLabel{WestGroup, EastGroup},
+		},
+		{
+			name:       "unknown tracks in-line, assumed inbound to bullseye",
+			groups:     []Group{groupAt(90, 30, UnknownDirection), groupAt(90, 50, UnknownDirection)},
+			formation:  RangeFormation,
+			separation: 20,
+			labels:     []GroupLabel{LeadGroup, TrailGroup},
+		},
+		{
+			name:       "unknown tracks with groups centered on bullseye",
+			groups:     []Group{groupAt(270, 10, UnknownDirection), groupAt(90, 10, UnknownDirection)},
+			formation:  AzimuthFormation,
+			separation: 20,
+			labels:     []GroupLabel{WestGroup, EastGroup},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			response := NewPictureResponse(2, test.groups, nil)
+			assert.Equal(t, 2, response.Count)
+			assert.Equal(t, test.groups, response.Groups)
+			assert.Equal(t, test.formation, response.Formation)
+			assert.InDelta(t, test.separation, response.Separation.NauticalMiles(), 0.001)
+			assert.Equal(t, test.labels, response.Labels)
+		})
+	}
+}
+
+func TestNewPictureResponseThreeGroups(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		groups []Group
+		labels []GroupLabel
+	}{
+		{
+			name:   "spread north-south",
+			groups: []Group{groupAt(170, 10, North), groupAt(360, 30, South), groupAt(180, 30, North)},
+			labels: []GroupLabel{MiddleGroup, NorthGroup, SouthGroup},
+		},
+		{
+			name:   "spread east-west",
+			groups: []Group{groupAt(270, 5, East), groupAt(90, 40, West), groupAt(100, 50, West)},
+			labels: []GroupLabel{WestGroup, MiddleGroup, EastGroup},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			response := NewPictureResponse(5, test.groups, nil)
+			assert.Equal(t, 5, response.Count)
+			assert.Equal(t, NoFormation, response.Formation)
+			assert.Equal(t, test.groups, response.Groups)
+			assert.Equal(t, test.labels, response.Labels)
+		})
+	}
+}
+
+func TestNewPictureResponseLadder(t *testing.T) {
+	t.Parallel()
+	trail := groupAt(360, 50, South)
+	lead := groupAt(360, 20, South)
+	middle := groupAt(360, 35, South)
+	groups := []Group{trail, lead, middle}
+
+	response := NewPictureResponse(3, groups, []Contact{ownshipAtBullseye(0)})
+	assert.Equal(t, LadderFormation, response.Formation)
+	assert.Equal(t, AnchorGroups(groups, []Contact{ownshipAtBullseye(0)}), response.Groups)
+	assert.Equal(t, []GroupLabel{LeadGroup, MiddleGroup, TrailGroup}, response.Labels)
+	assert.Equal(t, []unit.Length{15 * unit.NauticalMile, 15 * unit.NauticalMile}, response.Separations)
+
+	// A LADDER is not called when some groups are not described.
+	response = NewPictureResponse(4, groups, nil)
+	assert.Equal(t, NoFormation, response.Formation)
+	assert.Empty(t, response.Separations)
+}
+
+func TestNewPictureResponseWedges(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		groups    []Group
+		formation PictureFormation
+		labels    []GroupLabel
+	}{
+		{
+			name:      "vic",
+			groups:    idealVic(),
+			formation: VicFormation,
+			labels:    []GroupLabel{LeadGroup, "east trail", "west trail"},
+		},
+		{
+			name:      "champagne",
+			groups:    idealChampagne(),
+			formation: ChampagneFormation,
+			labels:    []GroupLabel{"east lead", "west lead", TrailGroup},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			response := NewPictureResponse(3, test.groups, nil)
+			assert.Equal(t, test.formation, response.Formation)
+			labels := make(map[Group]GroupLabel)
+			for i, group := range response.Groups {
+				labels[group] = response.Labels[i]
+			}
+			for i, group := range test.groups {
+				assert.Equal(t, test.labels[i], labels[group])
+			}
+			assert.InDelta(t, 10, response.Depth.NauticalMiles(), 0.001)
+			assert.InDelta(t, 20, response.Width.NauticalMiles(), 0.001)
+		})
+	}
+}
+
+func TestNewPictureResponseWall(t *testing.T) {
+	t.Parallel()
+	east := groupAt(30, 40, South)
+	middle := groupAt(360, 40, South)
+	west := groupAt(330, 40, South)
+
+	ownship := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(0), 0)}
+	response := NewPrioritizedPictureResponse(3, []Group{east, middle, west}, ownship, DefaultPriorityWeights)
+	assert.Equal(t, WallFormation, response.Formation)
+	labels := make(map[Group]GroupLabel)
+	for i, group := range response.Groups {
+		labels[group] = response.Labels[i]
+	}
+	assert.Equal(t, map[Group]GroupLabel{east: EastGroup, middle: MiddleGroup, west: WestGroup}, labels)
+	assert.InDelta(t, 40, response.Width.NauticalMiles(), 0.001)
+
+	// Without a requester, there is no line of sight to call a WALL across.
+	response = NewPictureResponse(3, []Group{east, middle, west}, nil)
+	assert.NotEqual(t, WallFormation, response.Formation)
+
+	// Seen from far away, the groups are on nearly the same azimuth.
+	ownship = Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(180*unit.Degree), 200*unit.NauticalMile)}
+	response = NewPrioritizedPictureResponse(3, []Group{east, middle, west}, ownship, DefaultPriorityWeights)
+	assert.NotEqual(t, WallFormation, response.Formation)
+}
+
+func TestNewPictureResponseNotWall(t *testing.T) {
+	t.Parallel()
+	// Groups spread evenly around the BULLSEYE are at the same range from it, but not in a line.
+	groups := []Group{groupAt(0, 5, South), groupAt(120, 5, South), groupAt(240, 5, South)}
+	response := NewPictureResponse(3, groups, nil)
+	assert.NotEqual(t, WallFormation, response.Formation)
+
+	// An ideal VIC near the BULLSEYE is called a VIC, even from a requester who sees it across their line of sight.
+	lead := groupAt(360, 20, South)
+	east := groupAt(18.435, 31.623, South)
+	west := groupAt(341.565, 31.623, South)
+	response = NewPictureResponse(3, []Group{lead, east, west}, nil)
+	assert.Equal(t, VicFormation, response.Formation)
+	ownship := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(0), 0)}
+	response = NewPrioritizedPictureResponse(3, []Group{lead, east, west}, ownship, DefaultPriorityWeights)
+	assert.Equal(t, VicFormation, response.Formation)
+}
+
+func TestNewPictureResponseUnlabeled(t *testing.T) {
+	t.Parallel()
+
+	response := NewPictureResponse(0, []Group{}, nil)
+	assert.Empty(t, response.Groups)
+	assert.Equal(t, NoFormation, response.Formation)
+
+	single := groupAt(90, 20, West)
+	response = NewPictureResponse(1, []Group{single}, nil)
+	assert.Equal(t, []Group{single}, response.Groups)
+	assert.Equal(t, NoFormation, response.Formation)
+	assert.Equal(t, []GroupLabel{UnlabeledGroup}, response.Labels)
+
+	withoutBullseye := &testGroup{contacts: 2, track: East}
+	response = NewPictureResponse(2, []Group{groupAt(90, 20, East), withoutBullseye}, nil)
+	assert.Equal(t, NoFormation, response.Formation)
+	assert.Zero(t, response.Separation)
+	assert.Equal(t, []GroupLabel{UnlabeledGroup, UnlabeledGroup}, response.Labels)
+}
+
+func TestAnchorGroups(t *testing.T) {
+	t.Parallel()
+	near := groupAt(90, 25, West)
+	far := groupAt(270, 40, West)
+	threat := groupAt(360, 60, South)
+	threat.threat = true
+	friendlies := []Contact{
+		{Bullseye: NewBullseye(bearings.NewMagneticBearing(180*unit.Degree), 100*unit.NauticalMile)},
+		{Bullseye: NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile)},
+	}
+
+	groups := []Group{far, near, threat}
+	anchored := AnchorGroups(groups, friendlies)
+	assert.Equal(t, []Group{threat, near, far}, anchored)
+	// The input is not modified.
+	assert.Equal(t, []Group{far, near, threat}, groups)
+
+	// The anchor is reported first in the PICTURE.
+	response := NewPictureResponse(2, []Group{far, threat}, friendlies)
+	assert.Equal(t, []Group{threat, far}, response.Groups)
+}
+
+func TestNearestContact(t *testing.T) {
+	t.Parallel()
+	groups := []Group{groupAt(90, 25, West), groupAt(270, 40, West), &testGroup{contacts: 1}}
+	south := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(180*unit.Degree), 100*unit.NauticalMile)}
+	east := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile)}
+
+	assert.Equal(t, east, nearestContact(groups, []Contact{south, {}, east}))
+	assert.Equal(t, Contact{}, nearestContact(groups, nil))
+	assert.Equal(t, Contact{}, nearestContact(groups[2:], []Contact{south, east}))
+}
+
+func TestNewPictureResponseCapsGroups(t *testing.T) {
+	t.Parallel()
+	groups := []Group{groupAt(90, 40, West), groupAt(180, 30, North), groupAt(270, 20, East), groupAt(360, 10, South)}
+	response := NewPictureResponse(6, groups, []Contact{ownshipAtBullseye(0)})
+	assert.Len(t, response.Groups, MaxPictureGroups)
+	assert.Len(t, response.Labels, MaxPictureGroups)
+	assert.Equal(t, []Group{groups[3], groups[2], groups[1]}, response.Groups)
+	assert.Equal(t, 6, response.Count)
+	assert.Equal(t, 3, response.AdditionalGroups)
+
+	response = NewPictureResponse(2, groups[:2], nil)
+	assert.Zero(t, response.AdditionalGroups)
+}
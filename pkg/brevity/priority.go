@@ -0,0 +1,115 @@
+package brevity
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/martinlindhe/unit"
+)
+
+// Contact is the position of a friendly aircraft, such as the aircraft which requested a PICTURE, used as the reference
+// when prioritizing groups.
+type Contact struct {
+	// Bullseye is the aircraft's position relative to the BULLSEYE. It may be nil if the position is unknown.
+	Bullseye *Bullseye
+	// Altitude is the aircraft's altitude.
+	Altitude unit.Length
+}
+
+// PriorityWeights are the weights of each factor used to prioritize groups by [PriorityWeights.PrioritizeGroups]. Each
+// factor is between 0 and 1, so a group's priority is between 0 and the sum of the weights.
+type PriorityWeights struct {
+	// Closure rewards groups tracking towards the ownship. A group tracking directly at the ownship scores the full
+	// weight, decreasing to nothing for a group tracking directly away.
+	Closure float64
+	// Range rewards groups near the ownship. The factor is inversely proportional to range: a group at zero range
+	// scores the full weight, a group at priorityReferenceRange scores half, and so on.
+	Range float64
+	// Altitude rewards groups above the ownship. A group priorityMaxAltitudeDelta or more above the ownship scores the
+	// full weight, and a group that far below scores nothing.
+	Altitude float64
+}
+
+// DefaultPriorityWeights are the default weights used to prioritize groups.
+var DefaultPriorityWeights = PriorityWeights{
+	Closure:  0.6,
+	Range:    0.3,
+	Altitude: 0.1,
+}
+
+const (
+	priorityReferenceRange   = 10 * unit.NauticalMile
+	priorityMaxAltitudeDelta = 20000 * unit.Foot
+)
+
+// Validate returns an error if any weight is negative or all weights are zero.
+func (w PriorityWeights) Validate() error {
+	if w.Closure < 0 || w.Range < 0 || w.Altitude < 0 {
+		return errors.New("priority weights must not be negative")
+	}
+	if w.Closure+w.Range+w.Altitude == 0 {
+		return errors.New("at least one priority weight must be positive")
+	}
+	return nil
+}
+
+// Priority scores how high a priority a group is to the ownship. Higher is more important. If the position of the group
+// or the ownship is unknown, the group scores nothing for range, and its closure is read from [Group.Closure]: CLOSING
+// scores the full weight, OPENING nothing, and an unknown closure half.
+func (w PriorityWeights) Priority(group Group, ownship Contact) float64 {
+	closure := closureFactor(group.Closure())
+	rangeFactor := 0.0
+	if position, ok := bullseyeVector(group); ok && ownship.Bullseye != nil {
+		offset := ownship.Bullseye.vector().sub(position)
+		distance := math.Hypot(offset.x, offset.y)
+		rangeFactor = priorityReferenceRange.NauticalMiles() / (priorityReferenceRange.NauticalMiles() + distance)
+		if angle, ok := trackAngle(group.Track()); ok && distance > 0 {
+			cosine := unitVector(angle).dot(vector{x: offset.x / distance, y: offset.y / distance})
+			closure = (cosine + 1) / 2
+		}
+	}
+
+	delta := (group.Altitude() - ownship.Altitude).Feet() / priorityMaxAltitudeDelta.Feet()
+	altitude := clamp((delta + 1) / 2)
+
+	return w.Closure*closure + w.Range*rangeFactor + w.Altitude*altitude
+}
+
+// closureFactor scores a closure between 0 and 1.
+func closureFactor(closure Closure) float64 {
+	switch closure {
+	case Closing:
+		return 1
+	case Opening:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// PrioritizeGroups returns a copy of the groups ordered from highest to lowest [PriorityWeights.Priority] relative to
+// the ownship. Groups of equal priority are ordered by lowest object ID, so the ordering is deterministic.
+func (w PriorityWeights) PrioritizeGroups(groups []Group, ownship Contact) []Group {
+	ordered := slices.Clone(groups)
+	slices.SortStableFunc(ordered, w.compare(ownship))
+	return ordered
+}
+
+// compare returns a function which orders groups from highest to lowest priority relative to the ownship.
+func (w PriorityWeights) compare(ownship Contact) func(a, b Group) int {
+	return func(a, b Group) int {
+		if c := cmp.Compare(w.Priority(b, ownship), w.Priority(a, ownship)); c != 0 {
+			return c
+		}
+		return cmp.Compare(lowestID(a), lowestID(b))
+	}
+}
+
+// PrioritizeGroups orders groups relative to the ownship using [DefaultPriorityWeights]. A group tracking towards the
+// ownship outranks a nearer group tracking away; for example, a HOT group at 20 nautical miles is reported before a
+// COLD group at 10 nautical miles.
+func PrioritizeGroups(groups []Group, ownship Contact) []Group {
+	return DefaultPriorityWeights.PrioritizeGroups(groups, ownship)
+}
@@ -0,0 +1,173 @@
+package brevity
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ownshipAtBullseye(altitude unit.Length) Contact {
+	return Contact{
+		Bullseye: NewBullseye(bearings.NewMagneticBearing(0), 0),
+		Altitude: altitude,
+	}
+}
+
+func TestPrioritizeGroupsClosingBeforeCold(t *testing.T) {
+	t.Parallel()
+	hot := groupAt(360, 20, South)
+	hot.ids = []uint64{2}
+	cold := groupAt(90, 10, East)
+	cold.ids = []uint64{1}
+
+	ordered := PrioritizeGroups([]Group{cold, hot}, ownshipAtBullseye(20000*unit.Foot))
+	require.Len(t, ordered, 2)
+	assert.Same(t, hot, ordered[0])
+	assert.Same(t, cold, ordered[1])
+}
+
+func TestPrioritizeGroupsWeights(t *testing.T) {
+	t.Parallel()
+	hot := groupAt(360, 20, South)
+	hot.ids = []uint64{2}
+	cold := groupAt(90, 10, East)
+	cold.ids = []uint64{1}
+
+	weights := PriorityWeights{Range: 1}
+	ordered := weights.PrioritizeGroups([]Group{hot, cold}, ownshipAtBullseye(20000*unit.Foot))
+	require.Len(t, ordered, 2)
+	assert.Same(t, cold, ordered[0])
+	assert.Same(t, hot, ordered[1])
+}
+
+func TestPrioritizeGroupsAltitude(t *testing.T) {
+	t.Parallel()
+	high := groupAt(90, 20, North)
+	high.ids = []uint64{2}
+	high.stacks = []Stack{{Altitude: 35000 * unit.Foot, Count: 1}}
+	low := groupAt(270, 20, North)
+	low.ids = []uint64{1}
+	low.stacks = []Stack{{Altitude: 5000 * unit.Foot, Count: 1}}
+
+	ordered := PrioritizeGroups([]Group{low, high}, ownshipAtBullseye(20000*unit.Foot))
+	require.Len(t, ordered, 2)
+	assert.Same(t, high, ordered[0])
+	assert.Same(t, low, ordered[1])
+}
+
+func TestPrioritizeGroupsUnknownPosition(t *testing.T) {
+	t.Parallel()
+	opening := &testGroup{contacts: 1, closure: Opening, ids: []uint64{1}}
+	closing := &testGroup{contacts: 1, closure: Closing, ids: []uint64{2}}
+
+	ordered := PrioritizeGroups([]Group{opening, closing}, Contact{})
+	require.Len(t, ordered, 2)
+	assert.Same(t, closing, ordered[0])
+	assert.Same(t, opening, ordered[1])
+}
+
+func TestPrioritizeGroupsTies(t *testing.T) {
+	t.Parallel()
+	a := groupAt(90, 20, North)
+	a.ids = []uint64{2}
+	b := groupAt(270, 20, North)
+	b.ids = []uint64{1}
+
+	ordered := PrioritizeGroups([]Group{a, b}, ownshipAtBullseye(0))
+	require.Len(t, ordered, 2)
+	assert.Same(t, b, ordered[0])
+	assert.Same(t, a, ordered[1])
+}
+
+func TestPriority(t *testing.T) {
+	t.Parallel()
+	hot := groupAt(360, 20, South)
+	hot.stacks = []Stack{{Altitude: 20000 * unit.Foot, Count: 1}}
+	// Closure 1, range 10/30 and altitude 0.5.
+	assert.InDelta(t, 0.6+0.1+0.05, DefaultPriorityWeights.Priority(hot, ownshipAtBullseye(20000*unit.Foot)), 1e-6)
+}
+
+func TestNewPrioritizedPictureResponse(t *testing.T) {
+	t.Parallel()
+	hot := groupAt(360, 20, South)
+	hot.ids = []uint64{2}
+	cold := groupAt(90, 10, East)
+	cold.ids = []uint64{1}
+
+	response := NewPrioritizedPictureResponse(2, []Group{cold, hot}, ownshipAtBullseye(0), DefaultPriorityWeights)
+	require.Len(t, response.Groups, 2)
+	assert.Same(t, hot, response.Groups[0])
+	assert.Equal(t, 0, response.AdditionalGroups)
+}
+
+func TestPriorityWeightsValidate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, DefaultPriorityWeights.Validate())
+	require.NoError(t, PriorityWeights{Range: 1}.Validate())
+	require.Error(t, PriorityWeights{}.Validate())
+	require.Error(t, PriorityWeights{Closure: 1, Range: -0.5}.Validate())
+}
+
+// randomGroups generates groups on a coarse grid, so that many groups have the same priority.
+func randomGroups(r *rand.Rand, n int) []Group {
+	closures := []Closure{UnknownClosure, Opening, Closing}
+	groups := make([]Group, 0, n)
+	for i := range n {
+		var group *testGroup
+		if r.IntN(10) == 0 {
+			group = &testGroup{contacts: 1}
+		} else {
+			group = groupAt(float64(90*r.IntN(4)), float64(10*r.IntN(3)), North)
+		}
+		group.closure = closures[r.IntN(len(closures))]
+		group.ids = []uint64{uint64(i)}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func TestPrioritizeGroupsIsTotal(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewPCG(1, 2))
+	ownship := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 10*unit.NauticalMile)}
+	compare := DefaultPriorityWeights.compare(ownship)
+	for range 100 {
+		groups := randomGroups(r, 8)
+		for _, a := range groups {
+			assert.Zero(t, compare(a, a), "a group is equal to itself")
+			for _, b := range groups {
+				if a == b {
+					continue
+				}
+				assert.NotZero(t, compare(a, b), "distinct groups are ordered")
+				assert.Equal(t, -compare(a, b), compare(b, a), "ordering is antisymmetric")
+				for _, c := range groups {
+					if compare(a, b) < 0 && compare(b, c) < 0 {
+						assert.Negative(t, compare(a, c), "ordering is transitive")
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPrioritizeGroupsIsStable(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewPCG(3, 4))
+	ownship := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(0), 10*unit.NauticalMile)}
+	for range 100 {
+		groups := randomGroups(r, 8)
+		expected := PrioritizeGroups(groups, ownship)
+		for range 10 {
+			shuffled := slices.Clone(groups)
+			r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+			assert.Equal(t, expected, PrioritizeGroups(shuffled, ownship), "order does not depend on input order")
+		}
+		assert.Equal(t, expected, PrioritizeGroups(expected, ownship), "sorting is idempotent")
+	}
+}
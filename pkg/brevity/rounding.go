@@ -0,0 +1,55 @@
+package brevity
+
+import (
+	"math"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+)
+
+// roundingPrecision is the precision to which values are snapped before rounding. Unit conversions introduce floating
+// point error which can move an exact half to just below it, e.g. 450 feet converts to 449.99999999999994 feet after a
+// round trip through meters.
+const roundingPrecision = 1e-6
+
+// roundToStep rounds x to the nearest multiple of step. Halves round away from zero.
+func roundToStep(x, step float64) float64 {
+	x = math.Round(x/roundingPrecision) * roundingPrecision
+	return math.Round(x/step) * step
+}
+
+// RoundBearing rounds a bearing to the nearest degree, normalized to the range (0, 360] so that it can be spoken as
+// three digits. Halves round away from zero.
+func RoundBearing(a unit.Angle) unit.Angle {
+	return bearings.NewTrueBearing(unit.Angle(roundToStep(a.Degrees(), 1)) * unit.Degree).Value()
+}
+
+// rangeRoundingThreshold is the range beyond which contact ranges are rounded more coarsely. See
+// [UnitSystem.RoundContactRange].
+const rangeRoundingThreshold = 10 * unit.NauticalMile
+
+// lowAltitudeStep is the interval to which altitudes below one band are rounded.
+const lowAltitudeStep = 100 * unit.Foot
+
+// RoundAltitude rounds the absolute value of an altitude to the nearest 1000 feet, or the nearest 100 feet below 1000
+// feet. Halves round away from zero.
+func RoundAltitude(a unit.Length) unit.Length {
+	return roundAltitudeToBand(a, DefaultStackBand)
+}
+
+// roundAltitudeToBand rounds the absolute value of an altitude to the nearest multiple of band, or the nearest 100 feet
+// below band. Halves round away from zero.
+func roundAltitudeToBand(a unit.Length, band unit.Length) unit.Length {
+	feet := math.Abs(a.Feet())
+	if band > lowAltitudeStep {
+		if fine := roundToStep(feet, lowAltitudeStep.Feet()); fine < band.Feet() {
+			return unit.Length(fine) * unit.Foot
+		}
+	}
+	return unit.Length(roundToStep(feet, band.Feet())) * unit.Foot
+}
+
+// RoundSpeed rounds a speed to the nearest 10 knots. Halves round away from zero.
+func RoundSpeed(s unit.Speed) unit.Speed {
+	return unit.Speed(roundToStep(s.Knots(), 10)) * unit.Knot
+}
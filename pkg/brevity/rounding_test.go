@@ -0,0 +1,147 @@
+package brevity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundBearing(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input    float64
+		expected float64
+	}{
+		{0, 360},
+		{0.49, 360},
+		{0.5, 1},
+		{1, 1},
+		{44.49, 44},
+		{44.5, 45},
+		{179.5, 180},
+		{359.49, 359},
+		{359.5, 360},
+		{360, 360},
+		{360.5, 1},
+		{-0.49, 360},
+		{-0.5, 359},
+		{-90, 270},
+		{720, 360},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.2f", test.input), func(t *testing.T) {
+			t.Parallel()
+			actual := RoundBearing(unit.Angle(test.input) * unit.Degree)
+			assert.InDelta(t, test.expected, actual.Degrees(), 0.001)
+		})
+	}
+}
+
+func TestRoundContactRange(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input    float64
+		expected float64
+	}{
+		{0, 0},
+		{0.4, 0},
+		{0.5, 1},
+		{4.49, 4},
+		{4.5, 5},
+		{9.49, 9},
+		{9.5, 10},
+		{10, 10},
+		{10.49, 10},
+		{10.5, 10},
+		{12.49, 10},
+		{12.5, 15},
+		{14, 15},
+		{17.49, 15},
+		{17.5, 20},
+		{22, 20},
+		{98, 100},
+		{-0.5, -1},
+		{-4.49, -4},
+		{2.5, 3},
+		{(4630 * unit.Meter).NauticalMiles(), 3},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.2f", test.input), func(t *testing.T) {
+			t.Parallel()
+			actual := ImperialUnits.RoundContactRange(unit.Length(test.input) * unit.NauticalMile)
+			assert.InDelta(t, test.expected, actual.NauticalMiles(), 0.001)
+		})
+	}
+}
+
+func TestRoundAltitude(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input    unit.Length
+		expected float64
+	}{
+		{0, 0},
+		{49 * unit.Foot, 0},
+		{50 * unit.Foot, 100},
+		{449 * unit.Foot, 400},
+		// 450 feet is 449.99999999999994 feet after conversion through meters.
+		{450 * unit.Foot, 500},
+		{949 * unit.Foot, 900},
+		{950 * unit.Foot, 1000},
+		{999 * unit.Foot, 1000},
+		{1000 * unit.Foot, 1000},
+		{1499 * unit.Foot, 1000},
+		{1500 * unit.Foot, 2000},
+		{24499 * unit.Foot, 24000},
+		{24500 * unit.Foot, 25000},
+		{457.2 * unit.Meter, 2000},
+		{-1500 * unit.Foot, 2000},
+		{-450 * unit.Foot, 500},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.2f", test.input.Feet()), func(t *testing.T) {
+			t.Parallel()
+			actual := RoundAltitude(test.input)
+			assert.InDelta(t, test.expected, actual.Feet(), 0.001)
+		})
+	}
+}
+
+func TestRoundSpeed(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input    float64
+		expected float64
+	}{
+		{0, 0},
+		{4.9, 0},
+		{5, 10},
+		{14.9, 10},
+		{15, 20},
+		{444.9, 440},
+		{445, 450},
+		{-5, -10},
+		{-4.9, 0},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.1f", test.input), func(t *testing.T) {
+			t.Parallel()
+			actual := RoundSpeed(unit.Speed(test.input) * unit.Knot)
+			assert.InDelta(t, test.expected, actual.Knots(), 0.001)
+		})
+	}
+}
+
+func TestRoundingConsistency(t *testing.T) {
+	t.Parallel()
+	for feet := 0; feet <= 60000; feet += 50 {
+		altitude := unit.Length(feet) * unit.Foot
+		assert.InDelta(t, RoundAltitude(altitude).Feet(), ImperialUnits.RoundAltitude(altitude).Feet(), 0.001, "%d feet", feet)
+		stacks := Stacks(altitude)
+		if len(stacks) > 0 {
+			assert.InDelta(t, RoundAltitude(altitude).Feet(), stacks[0].Altitude.Feet(), 0.001, "%d feet", feet)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package brevity
+
+// SectorCleanCall reports there are no longer any hostile contacts in a sector which previously contained hostile
+// contacts.
+type SectorCleanCall struct {
+	// Sector is the name of the sector.
+	Sector string
+}
@@ -1,6 +1,14 @@
 package brevity
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+)
 
 // SnaplockRequest is an abbreviated form of DECLARE used to quickly gain infomation on a contact inside THREAT range with BEAM or hotter aspect.
 // Aspect is implied to be Beam or greater.
@@ -23,6 +31,112 @@ type SnaplockResponse struct {
 	Callsign string
 	// Declaration of the contact.
 	Declaration Declaration
-	// Group that was identified. If Declaration is Unable or Furball, this may be nil.
+	// Group that was identified. If Declaration is Unable, Furball or Clean, this is nil.
 	Group Group
+	// FillIns are the information which should be communicated about Group. See [FillIns].
+	FillIns []FillIn
+	// Nearest is the nearest group outside of the resolution cell. It is only set if Declaration is Clean, and may be
+	// nil if no group is nearby.
+	Nearest Group
+	// NearestOffset is the bearing and range from the location given in the SNAPLOCK to Nearest, and the altitude of
+	// Nearest. It is nil if Nearest is nil.
+	NearestOffset BRA
+}
+
+const (
+	// SnaplockCellRadius is the lateral radius of the resolution cell around the location given in a SNAPLOCK. Groups
+	// within the cell are the subject of the response.
+	SnaplockCellRadius = 3 * unit.NauticalMile
+	// SnaplockCellAltitudeMargin is the altitude above and below the altitude given in a SNAPLOCK which is within the
+	// resolution cell.
+	SnaplockCellAltitudeMargin = 5000 * unit.Foot
+	// SnaplockSearchRadius is the radius around the location given in a SNAPLOCK within which the nearest group is
+	// reported if the resolution cell is clean.
+	SnaplockSearchRadius = 10 * unit.NauticalMile
+)
+
+// SnaplockContact is a group found near the location given in a SNAPLOCK.
+type SnaplockContact struct {
+	// Group found near the location. Its BRAA should be relative to the requester.
+	Group Group
+	// Declaration of the group relative to the requester. This should be Friendly, Hostile, Bandit, Bogey or Neutral.
+	Declaration Declaration
+	// Bearing from the location given in the SNAPLOCK to the group's nearest contact.
+	Bearing bearings.Bearing
+	// Distance from the location given in the SNAPLOCK to the group's nearest contact.
+	Distance unit.Length
+}
+
+// inCell returns true if the contact is within the resolution cell around a location at the given altitude. An altitude
+// of zero is unknown and matches any altitude.
+func (c SnaplockContact) inCell(altitude unit.Length) bool {
+	if c.Distance > SnaplockCellRadius {
+		return false
+	}
+	if altitude == 0 {
+		return true
+	}
+	// Compare in whole feet to avoid floating point error at the boundary.
+	return math.Round(math.Abs((c.Group.Altitude() - altitude).Feet())) <= math.Round(SnaplockCellAltitudeMargin.Feet())
+}
+
+// Snaplock builds the response to a SNAPLOCK from the groups found near the given location.
+//
+//   - If the request has no location, the declaration is [Unable].
+//   - The declaration of the groups within the resolution cell is picked following [Declare]. The subject of the
+//     response is the nearest group with that declaration, preferring groups with [Hot] aspect.
+//   - If the cell is clean, the nearest group outside of the cell is included along with its offset from the
+//     location, so the requester can correct their sensors.
+func Snaplock(callsign string, request SnaplockRequest, contacts []SnaplockContact) SnaplockResponse {
+	response := SnaplockResponse{Callsign: callsign}
+	if request.BRA == nil {
+		response.Declaration = Unable
+		return response
+	}
+
+	inCell := make([]SnaplockContact, 0)
+	outside := make([]SnaplockContact, 0)
+	for _, contact := range contacts {
+		if contact.inCell(request.BRA.Altitude()) {
+			inCell = append(inCell, contact)
+		} else {
+			outside = append(outside, contact)
+		}
+	}
+
+	declareContacts := make([]DeclareContact, 0, len(inCell))
+	for _, contact := range inCell {
+		declareContacts = append(declareContacts, DeclareContact{Declaration: contact.Declaration, Distance: contact.Distance})
+	}
+	response.Declaration = Declare(declareContacts, SnaplockCellRadius)
+
+	switch response.Declaration {
+	case Clean:
+		if len(outside) > 0 {
+			nearest := slices.MinFunc(outside, func(a, b SnaplockContact) int {
+				return cmp.Compare(a.Distance, b.Distance)
+			})
+			response.Nearest = nearest.Group
+			response.NearestOffset = NewBRA(nearest.Bearing, nearest.Distance, nearest.Group.Altitude())
+		}
+	case Furball, Unable:
+		// No single group can be identified.
+	default:
+		candidates := slices.DeleteFunc(inCell, func(c SnaplockContact) bool {
+			return c.Declaration != response.Declaration
+		})
+		subject := slices.MinFunc(candidates, func(a, b SnaplockContact) int {
+			if isHot, otherIsHot := a.Group.Aspect() == Hot, b.Group.Aspect() == Hot; isHot != otherIsHot {
+				if isHot {
+					return -1
+				}
+				return 1
+			}
+			return cmp.Compare(a.Distance, b.Distance)
+		})
+		response.Group = subject.Group
+		response.Group.SetDeclaration(response.Declaration)
+		response.FillIns = FillIns(response.Group)
+	}
+	return response
 }
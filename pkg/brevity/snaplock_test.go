@@ -0,0 +1,177 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func snaplockGroup(altitude unit.Length, aspect Aspect) *testGroup {
+	return &testGroup{
+		contacts: 1,
+		stacks:   []Stack{{Altitude: altitude, Count: 1}},
+		braa:     NewBRAA(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile, []unit.Length{altitude}, aspect),
+		track:    West,
+	}
+}
+
+func TestSnaplockCellBoundaries(t *testing.T) {
+	t.Parallel()
+	requested := 20000 * unit.Foot
+	testCases := []struct {
+		name     string
+		distance unit.Length
+		altitude unit.Length
+		inCell   bool
+	}{
+		{name: "at the location", distance: 0, altitude: requested, inCell: true},
+		{name: "at the lateral boundary", distance: SnaplockCellRadius, altitude: requested, inCell: true},
+		{name: "beyond the lateral boundary", distance: SnaplockCellRadius + 10*unit.Foot, altitude: requested, inCell: false},
+		{name: "at the upper boundary", distance: 0, altitude: requested + SnaplockCellAltitudeMargin, inCell: true},
+		{name: "above the upper boundary", distance: 0, altitude: requested + SnaplockCellAltitudeMargin + 1000*unit.Foot, inCell: false},
+		{name: "at the lower boundary", distance: 0, altitude: requested - SnaplockCellAltitudeMargin, inCell: true},
+		{name: "below the lower boundary", distance: 0, altitude: requested - SnaplockCellAltitudeMargin - 1000*unit.Foot, inCell: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			request := SnaplockRequest{
+				Callsign: "eagle 1",
+				BRA:      NewBRA(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile, requested),
+			}
+			group := snaplockGroup(test.altitude, Hot)
+			contacts := []SnaplockContact{{
+				Group:       group,
+				Declaration: Hostile,
+				Bearing:     bearings.NewMagneticBearing(45 * unit.Degree),
+				Distance:    test.distance,
+			}}
+			response := Snaplock("eagle 1", request, contacts)
+			assert.Equal(t, "eagle 1", response.Callsign)
+			if test.inCell {
+				assert.Equal(t, Hostile, response.Declaration)
+				assert.Equal(t, group, response.Group)
+				assert.Equal(t, Hostile, group.Declaration())
+				assert.NotEmpty(t, response.FillIns)
+				assert.Nil(t, response.Nearest)
+			} else {
+				assert.Equal(t, Clean, response.Declaration)
+				assert.Nil(t, response.Group)
+				assert.Equal(t, group, response.Nearest)
+				require.NotNil(t, response.NearestOffset)
+				assert.InDelta(t, 45, response.NearestOffset.Bearing().Degrees(), 0.5)
+				assert.InDelta(t, test.altitude.Feet(), response.NearestOffset.Altitude().Feet(), 0.5)
+			}
+		})
+	}
+}
+
+func TestSnaplockUnknownAltitude(t *testing.T) {
+	t.Parallel()
+	request := SnaplockRequest{
+		Callsign: "eagle 1",
+		BRA:      NewBRA(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile),
+	}
+	contacts := []SnaplockContact{{
+		Group:       snaplockGroup(40000*unit.Foot, Flank),
+		Declaration: Friendly,
+		Bearing:     bearings.NewMagneticBearing(180 * unit.Degree),
+		Distance:    1 * unit.NauticalMile,
+	}}
+	response := Snaplock("eagle 1", request, contacts)
+	assert.Equal(t, Friendly, response.Declaration)
+	assert.NotNil(t, response.Group)
+}
+
+func TestSnaplock(t *testing.T) {
+	t.Parallel()
+	request := SnaplockRequest{
+		Callsign: "eagle 1",
+		BRA:      NewBRA(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile, 20000*unit.Foot),
+	}
+	near := snaplockGroup(20000*unit.Foot, Flank)
+	far := snaplockGroup(20000*unit.Foot, Flank)
+	hot := snaplockGroup(20000*unit.Foot, Hot)
+	friendly := snaplockGroup(20000*unit.Foot, Drag)
+	contact := func(group Group, declaration Declaration, distance unit.Length) SnaplockContact {
+		return SnaplockContact{
+			Group:       group,
+			Declaration: declaration,
+			Bearing:     bearings.NewMagneticBearing(270 * unit.Degree),
+			Distance:    distance,
+		}
+	}
+
+	t.Run("clean with nothing nearby", func(t *testing.T) {
+		t.Parallel()
+		response := Snaplock("eagle 1", request, []SnaplockContact{})
+		assert.Equal(t, Clean, response.Declaration)
+		assert.Nil(t, response.Group)
+		assert.Nil(t, response.Nearest)
+		assert.Nil(t, response.NearestOffset)
+	})
+
+	t.Run("clean reports nearest group", func(t *testing.T) {
+		t.Parallel()
+		response := Snaplock("eagle 1", request, []SnaplockContact{
+			contact(far, Hostile, 8*unit.NauticalMile),
+			contact(near, Hostile, 5*unit.NauticalMile),
+		})
+		assert.Equal(t, Clean, response.Declaration)
+		assert.Equal(t, near, response.Nearest)
+		require.NotNil(t, response.NearestOffset)
+		assert.InDelta(t, 5, response.NearestOffset.Range().NauticalMiles(), 0.5)
+	})
+
+	t.Run("prefers nearest group", func(t *testing.T) {
+		t.Parallel()
+		response := Snaplock("eagle 1", request, []SnaplockContact{
+			contact(far, Hostile, 2*unit.NauticalMile),
+			contact(near, Hostile, 1*unit.NauticalMile),
+		})
+		assert.Equal(t, Hostile, response.Declaration)
+		assert.Equal(t, near, response.Group)
+	})
+
+	t.Run("prefers hot group", func(t *testing.T) {
+		t.Parallel()
+		response := Snaplock("eagle 1", request, []SnaplockContact{
+			contact(near, Hostile, 1*unit.NauticalMile),
+			contact(hot, Hostile, 2*unit.NauticalMile),
+		})
+		assert.Equal(t, Hostile, response.Declaration)
+		assert.Equal(t, hot, response.Group)
+	})
+
+	t.Run("furball", func(t *testing.T) {
+		t.Parallel()
+		response := Snaplock("eagle 1", request, []SnaplockContact{
+			contact(near, Hostile, 1*unit.NauticalMile),
+			contact(friendly, Friendly, 1*unit.NauticalMile),
+		})
+		assert.Equal(t, Furball, response.Declaration)
+		assert.Nil(t, response.Group)
+	})
+
+	t.Run("unable with ambiguous contacts", func(t *testing.T) {
+		t.Parallel()
+		response := Snaplock("eagle 1", request, []SnaplockContact{
+			contact(near, Hostile, 1*unit.NauticalMile),
+			contact(far, Neutral, 1*unit.NauticalMile),
+		})
+		assert.Equal(t, Unable, response.Declaration)
+		assert.Nil(t, response.Group)
+	})
+
+	t.Run("unable without location", func(t *testing.T) {
+		t.Parallel()
+		response := Snaplock("eagle 1", SnaplockRequest{Callsign: "eagle 1"}, []SnaplockContact{
+			contact(near, Hostile, 1*unit.NauticalMile),
+		})
+		assert.Equal(t, Unable, response.Declaration)
+		assert.Nil(t, response.Group)
+	})
+}
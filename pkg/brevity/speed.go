@@ -0,0 +1,74 @@
+package brevity
+
+import (
+	"math"
+
+	"github.com/martinlindhe/unit"
+)
+
+// SpeedDescriptor describes a group's speed, if it is notable.
+// Reference: ATP 3-52.4 Chapter IV section 3.
+type SpeedDescriptor int
+
+const (
+	// NormalSpeed is not described.
+	NormalSpeed SpeedDescriptor = iota
+	// FastSpeed is at least 600 knots ground speed or Mach 1.0.
+	FastSpeed
+	// VeryFastSpeed is at least Mach 1.5.
+	VeryFastSpeed
+)
+
+const (
+	// FastThreshold is the minimum ground speed of a FAST group.
+	FastThreshold = 600 * unit.Knot
+	// FastMachThreshold is the minimum Mach number of a FAST group.
+	FastMachThreshold = 1.0
+	// VeryFastMachThreshold is the minimum Mach number of a VERY FAST group.
+	VeryFastMachThreshold = 1.5
+)
+
+func (d SpeedDescriptor) String() string {
+	switch d {
+	case FastSpeed:
+		return "fast"
+	case VeryFastSpeed:
+		return "very fast"
+	default:
+		return ""
+	}
+}
+
+// ISA constants used to estimate the speed of sound.
+const (
+	seaLevelTemperature   = 288.15  // Kelvin
+	tropopauseTemperature = 216.65  // Kelvin
+	temperatureLapseRate  = 0.0065  // Kelvin per meter
+	seaLevelSpeedOfSound  = 340.294 // meters per second
+	tropopauseAltitude    = 11000.0 // meters
+)
+
+// SpeedOfSound estimates the speed of sound at the given altitude in the International Standard Atmosphere.
+func SpeedOfSound(altitude unit.Length) unit.Speed {
+	meters := math.Min(math.Max(altitude.Meters(), 0), tropopauseAltitude)
+	temperature := math.Max(seaLevelTemperature-temperatureLapseRate*meters, tropopauseTemperature)
+	return unit.Speed(seaLevelSpeedOfSound*math.Sqrt(temperature/seaLevelTemperature)) * unit.MetersPerSecond
+}
+
+// Mach returns the Mach number of the given speed at the given altitude.
+func Mach(speed unit.Speed, altitude unit.Length) float64 {
+	return speed.MetersPerSecond() / SpeedOfSound(altitude).MetersPerSecond()
+}
+
+// DescribeSpeed returns the speed descriptor for a contact with the given ground speed at the given altitude. Since
+// wind data is not available, ground speed is used in place of true airspeed to compute the Mach number.
+func DescribeSpeed(groundSpeed unit.Speed, altitude unit.Length) SpeedDescriptor {
+	mach := Mach(groundSpeed, altitude)
+	if mach >= VeryFastMachThreshold {
+		return VeryFastSpeed
+	}
+	if groundSpeed >= FastThreshold || mach >= FastMachThreshold {
+		return FastSpeed
+	}
+	return NormalSpeed
+}
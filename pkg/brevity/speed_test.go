@@ -0,0 +1,43 @@
+package brevity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpeedOfSound(t *testing.T) {
+	t.Parallel()
+	assert.InDelta(t, 661.5, SpeedOfSound(0).Knots(), 0.5)
+	assert.InDelta(t, 573.6, SpeedOfSound(40000*unit.Foot).Knots(), 0.5)
+	assert.Equal(t, SpeedOfSound(40000*unit.Foot), SpeedOfSound(60000*unit.Foot))
+}
+
+func TestDescribeSpeed(t *testing.T) {
+	t.Parallel()
+	high := 40000 * unit.Foot
+	testCases := []struct {
+		speed    unit.Speed
+		altitude unit.Length
+		expected SpeedDescriptor
+	}{
+		{speed: 450 * unit.Knot, altitude: 20000 * unit.Foot, expected: NormalSpeed},
+		{speed: 599 * unit.Knot, altitude: 0, expected: NormalSpeed},
+		{speed: 600 * unit.Knot, altitude: 0, expected: FastSpeed},
+		{speed: 0.99 * SpeedOfSound(high), altitude: high, expected: NormalSpeed},
+		{speed: 1.0 * SpeedOfSound(high), altitude: high, expected: FastSpeed},
+		{speed: 1.49 * SpeedOfSound(high), altitude: high, expected: FastSpeed},
+		{speed: 1.5 * SpeedOfSound(high), altitude: high, expected: VeryFastSpeed},
+		{speed: 2.8 * SpeedOfSound(high), altitude: 70000 * unit.Foot, expected: VeryFastSpeed},
+		{speed: 950 * unit.Knot, altitude: 0, expected: FastSpeed},
+		{speed: 1000 * unit.Knot, altitude: 0, expected: VeryFastSpeed},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.0fkts at %.0fft", test.speed.Knots(), test.altitude.Feet()), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, DescribeSpeed(test.speed, test.altitude))
+		})
+	}
+}
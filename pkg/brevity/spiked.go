@@ -2,6 +2,7 @@ package brevity
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/martinlindhe/unit"
@@ -41,4 +42,56 @@ type SpikedResponse struct {
 	Contacts int
 	// Reported spike bearing. This is used if the response did not correlate to a group.
 	Bearing bearings.Bearing
+	// Stacks of the correlated group. If Status is false, this is nil.
+	Stacks []Stack
+	// BearingError is the angle between the reported spike bearing and the bearing to the correlated group, in the range
+	// [0, 180] degrees. If Status is false, this is 0.
+	BearingError unit.Angle
+	// NearestGroup is the nearest hostile group regardless of bearing, with Bullseye set. This is only set if Status is
+	// false, and may be nil if there are no hostile groups nearby.
+	NearestGroup Group
+}
+
+// NewSpikedResponse creates a response to a SPIKED call. If correlated is not nil, the response is positive and
+// describes the correlated group, which must have BRAA set. Otherwise, the response is negative and includes the nearest
+// group, which may be nil.
+func NewSpikedResponse(callsign string, bearing bearings.Bearing, correlated Group, nearest Group) SpikedResponse {
+	if correlated == nil {
+		return SpikedResponse{
+			Callsign:     callsign,
+			Status:       false,
+			Bearing:      bearing,
+			Declaration:  Clean,
+			Aspect:       UnknownAspect,
+			Track:        UnknownDirection,
+			NearestGroup: nearest,
+		}
+	}
+
+	braa := correlated.BRAA()
+	return SpikedResponse{
+		Callsign:     callsign,
+		Status:       true,
+		Bearing:      bearing,
+		Range:        braa.Range(),
+		Altitude:     braa.Altitude(),
+		Stacks:       correlated.Stacks(),
+		Aspect:       braa.Aspect(),
+		Track:        correlated.Track(),
+		Declaration:  correlated.Declaration(),
+		Contacts:     correlated.Contacts(),
+		BearingError: bearingError(bearing, braa.Bearing()),
+	}
+}
+
+// bearingError returns the absolute angle between two bearings, in the range [0, 180] degrees.
+func bearingError(a, b bearings.Bearing) unit.Angle {
+	θ := math.Mod(b.Degrees()-a.Degrees(), 360)
+	if θ < 0 {
+		θ += 360
+	}
+	if θ > 180 {
+		θ = 360 - θ
+	}
+	return unit.Angle(θ) * unit.Degree
 }
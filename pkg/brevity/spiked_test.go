@@ -0,0 +1,142 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func groupWithBRAA(bearing float64, rangeNM float64, altitude float64, aspect Aspect, track Track, contacts int) *testGroup {
+	altitudes := []unit.Length{unit.Length(altitude) * unit.Foot}
+	return &testGroup{
+		contacts:    contacts,
+		braa:        NewBRAA(bearings.NewMagneticBearing(unit.Angle(bearing)*unit.Degree), unit.Length(rangeNM)*unit.NauticalMile, altitudes, aspect),
+		stacks:      []Stack{{Altitude: altitudes[0], Count: contacts}},
+		track:       track,
+		declaration: Hostile,
+	}
+}
+
+func TestNewSpikedResponsePositive(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		spike        float64
+		group        *testGroup
+		rangeNM      float64
+		altitude     float64
+		aspect       Aspect
+		track        Track
+		contacts     int
+		bearingError float64
+	}{
+		{
+			name:     "hot single contact on the spike bearing",
+			spike:    90,
+			group:    groupWithBRAA(90, 30, 25000, Hot, West, 1),
+			rangeNM:  30,
+			altitude: 25000,
+			aspect:   Hot,
+			track:    West,
+			contacts: 1,
+		},
+		{
+			name:         "flanking pair left of the spike bearing",
+			spike:        90,
+			group:        groupWithBRAA(70, 42, 18000, Flank, Northwest, 2),
+			rangeNM:      40,
+			altitude:     18000,
+			aspect:       Flank,
+			track:        Northwest,
+			contacts:     2,
+			bearingError: 20,
+		},
+		{
+			name:         "beaming group right of the spike bearing across north",
+			spike:        350,
+			group:        groupWithBRAA(15, 8, 5000, Beam, East, 4),
+			rangeNM:      8,
+			altitude:     5000,
+			aspect:       Beam,
+			track:        East,
+			contacts:     4,
+			bearingError: 25,
+		},
+		{
+			name:         "dragging group at the edge of the spike cone",
+			spike:        10,
+			group:        groupWithBRAA(340, 60, 32000, Drag, North, 1),
+			rangeNM:      60,
+			altitude:     32000,
+			aspect:       Drag,
+			track:        North,
+			contacts:     1,
+			bearingError: 30,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			spike := bearings.NewMagneticBearing(unit.Angle(test.spike) * unit.Degree)
+			response := NewSpikedResponse("Mobius 1", spike, test.group, nil)
+			assert.Equal(t, "Mobius 1", response.Callsign)
+			assert.True(t, response.Status)
+			assert.Equal(t, spike, response.Bearing)
+			assert.InDelta(t, test.rangeNM, response.Range.NauticalMiles(), 0.01)
+			assert.InDelta(t, test.altitude, response.Altitude.Feet(), 1)
+			assert.Equal(t, test.group.stacks, response.Stacks)
+			assert.Equal(t, test.aspect, response.Aspect)
+			assert.Equal(t, test.track, response.Track)
+			assert.Equal(t, Hostile, response.Declaration)
+			assert.Equal(t, test.contacts, response.Contacts)
+			assert.InDelta(t, test.bearingError, response.BearingError.Degrees(), 0.001)
+			assert.Nil(t, response.NearestGroup)
+		})
+	}
+}
+
+func TestNewSpikedResponseNegative(t *testing.T) {
+	t.Parallel()
+	spike := bearings.NewMagneticBearing(180 * unit.Degree)
+
+	nearest := groupAt(270, 20, East)
+	response := NewSpikedResponse("Mobius 1", spike, nil, nearest)
+	assert.False(t, response.Status)
+	assert.Equal(t, spike, response.Bearing)
+	assert.Equal(t, Clean, response.Declaration)
+	assert.Equal(t, UnknownAspect, response.Aspect)
+	assert.Equal(t, UnknownDirection, response.Track)
+	assert.Zero(t, response.Range)
+	assert.Zero(t, response.Contacts)
+	assert.Zero(t, response.BearingError)
+	require.NotNil(t, response.NearestGroup)
+	assert.Equal(t, nearest, response.NearestGroup)
+
+	response = NewSpikedResponse("Mobius 1", spike, nil, nil)
+	assert.False(t, response.Status)
+	assert.Nil(t, response.NearestGroup)
+}
+
+func TestBearingError(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		a, b     float64
+		expected float64
+	}{
+		{a: 90, b: 90, expected: 0},
+		{a: 90, b: 120, expected: 30},
+		{a: 120, b: 90, expected: 30},
+		{a: 350, b: 10, expected: 20},
+		{a: 10, b: 350, expected: 20},
+		{a: 1, b: 181, expected: 180},
+		{a: 45, b: 270, expected: 135},
+	}
+	for _, test := range testCases {
+		a := bearings.NewMagneticBearing(unit.Angle(test.a) * unit.Degree)
+		b := bearings.NewMagneticBearing(unit.Angle(test.b) * unit.Degree)
+		assert.InDelta(t, test.expected, bearingError(a, b).Degrees(), 0.001)
+	}
+}
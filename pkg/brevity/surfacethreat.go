@@ -0,0 +1,49 @@
+package brevity
+
+import (
+	"fmt"
+
+	"github.com/martinlindhe/unit"
+)
+
+// SurfaceThreat describes a surface-to-air threat, such as a SAM radar, for MUD SPIKE correlation and threat ring
+// warnings.
+type SurfaceThreat struct {
+	// System is the designation of the weapon system, e.g. "SA-10".
+	System string
+	// NATOName is the NATO reporting name of the system's radar, e.g. "Flap Lid". This may be empty for systems which
+	// have no NATO reporting name.
+	NATOName string
+	// Bullseye is the location of the threat.
+	Bullseye Bullseye
+	// Range from the friendly aircraft to the threat.
+	Range unit.Length
+	// RingRadius is the nominal engagement radius of the system.
+	RingRadius unit.Length
+}
+
+// Name returns the name a controller should speak for the threat. This is the NATO reporting name if the system has
+// one, or the system designation otherwise.
+func (t SurfaceThreat) Name() string {
+	if t.NATOName != "" {
+		return t.NATOName
+	}
+	return t.System
+}
+
+// Within returns true if the friendly aircraft is inside the threat's engagement ring.
+func (t SurfaceThreat) Within() bool {
+	return t.Range <= t.RingRadius
+}
+
+func (t SurfaceThreat) String() string {
+	return fmt.Sprintf(
+		"%s (%s) bullseye %s/%.0f, range %.0f NM, ring %.0f NM",
+		t.System,
+		t.Name(),
+		t.Bullseye.Bearing(),
+		t.Bullseye.Distance().NauticalMiles(),
+		t.Range.NauticalMiles(),
+		t.RingRadius.NauticalMiles(),
+	)
+}
@@ -17,3 +17,69 @@ type ThreatCall struct {
 // MandatoryThreatDistance is the distance at which a contact is considered a threat regardless of aspect.
 // Reference: ATP 3-52.4 Chapter V section 18 subsection c.
 const MandatoryThreatDistance = 35 * unit.NauticalMile
+
+// ThreatCriteria decide whether a hostile group is a threat to a friendly aircraft.
+type ThreatCriteria struct {
+	// Ranges are the threat ranges for each platform class. A hostile group within this range of a friendly aircraft may
+	// be a threat.
+	Ranges map[ContactCategory]unit.Length
+	// DefaultRange is the threat range for platform classes which are not in Ranges.
+	DefaultRange unit.Length
+	// MandatoryRange is the range within which a hostile group is a threat regardless of aspect. Zero disables this.
+	MandatoryRange unit.Length
+	// RequireHot requires a hostile group outside MandatoryRange to be hot to be a threat.
+	RequireHot bool
+	// MaxAltitudeSeparation is the maximum altitude separation between a threatening group and a friendly aircraft.
+	// Zero disables this.
+	MaxAltitudeSeparation unit.Length
+}
+
+// DefaultThreatRange is the default threat range for fighters.
+const DefaultThreatRange = 25 * unit.NauticalMile
+
+// DefaultThreatCriteria are reasonable threat criteria for a modern setting.
+var DefaultThreatCriteria = ThreatCriteria{
+	Ranges: map[ContactCategory]unit.Length{
+		FixedWing:  DefaultThreatRange,
+		RotaryWing: 10 * unit.NauticalMile,
+	},
+	DefaultRange: DefaultThreatRange,
+	RequireHot:   true,
+}
+
+// Range returns the threat range for the given platform class.
+func (c ThreatCriteria) Range(category ContactCategory) unit.Length {
+	if r, ok := c.Ranges[category]; ok {
+		return r
+	}
+	return c.DefaultRange
+}
+
+// IsThreat returns true if the hostile group meets the threat criteria against a friendly aircraft at the given
+// altitude. The hostile group's BRAA must be relative to the friendly aircraft; if it is nil, the group is not a
+// threat. Ranges are compared against the BRAA's reported range, as it would be called on the radio. The category is the
+// hostile group's platform class.
+func IsThreat(hostile Group, category ContactCategory, friendlyAltitude unit.Length, criteria ThreatCriteria) bool {
+	if hostile == nil || hostile.BRAA() == nil {
+		return false
+	}
+	braa := hostile.BRAA()
+
+	if criteria.MaxAltitudeSeparation > 0 {
+		separation := braa.Altitude() - friendlyAltitude
+		if separation < 0 {
+			separation = -separation
+		}
+		if separation > criteria.MaxAltitudeSeparation {
+			return false
+		}
+	}
+
+	if criteria.MandatoryRange > 0 && braa.Range() <= criteria.MandatoryRange {
+		return true
+	}
+	if braa.Range() > criteria.Range(category) {
+		return false
+	}
+	return !criteria.RequireHot || braa.Aspect() == Hot
+}
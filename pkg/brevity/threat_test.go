@@ -0,0 +1,108 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsThreat(t *testing.T) {
+	t.Parallel()
+	hostile := func(r unit.Length, altitude unit.Length, aspect Aspect) *testGroup {
+		return &testGroup{braa: NewBRAA(bearings.NewMagneticBearing(90*unit.Degree), r, []unit.Length{altitude}, aspect)}
+	}
+	withMandatory := DefaultThreatCriteria
+	withMandatory.MandatoryRange = 10 * unit.NauticalMile
+	withAltitude := DefaultThreatCriteria
+	withAltitude.MaxAltitudeSeparation = 15000 * unit.Foot
+	anyAspect := DefaultThreatCriteria
+	anyAspect.RequireHot = false
+
+	testCases := []struct {
+		name     string
+		group    Group
+		category ContactCategory
+		criteria ThreatCriteria
+		expected bool
+	}{
+		{
+			name:     "hot inside range",
+			group:    hostile(20*unit.NauticalMile, 20000*unit.Foot, Hot),
+			category: FixedWing,
+			criteria: DefaultThreatCriteria,
+			expected: true,
+		},
+		{
+			name:     "drag inside range",
+			group:    hostile(20*unit.NauticalMile, 20000*unit.Foot, Drag),
+			category: FixedWing,
+			criteria: DefaultThreatCriteria,
+			expected: false,
+		},
+		{
+			name:     "flank inside range",
+			group:    hostile(20*unit.NauticalMile, 20000*unit.Foot, Flank),
+			category: FixedWing,
+			criteria: DefaultThreatCriteria,
+			expected: false,
+		},
+		{
+			// BRAA ranges beyond the rounding threshold are rounded to 5 NM, so this is the closest range reported as 30.
+			name:     "hot just outside range",
+			group:    hostile(27.6*unit.NauticalMile, 20000*unit.Foot, Hot),
+			category: FixedWing,
+			criteria: DefaultThreatCriteria,
+			expected: false,
+		},
+		{
+			name:     "hot helicopter outside helicopter range",
+			group:    hostile(15*unit.NauticalMile, 2000*unit.Foot, Hot),
+			category: RotaryWing,
+			criteria: DefaultThreatCriteria,
+			expected: false,
+		},
+		{
+			name:     "drag inside mandatory range",
+			group:    hostile(8*unit.NauticalMile, 20000*unit.Foot, Drag),
+			category: FixedWing,
+			criteria: withMandatory,
+			expected: true,
+		},
+		{
+			name:     "drag inside range without hot requirement",
+			group:    hostile(20*unit.NauticalMile, 20000*unit.Foot, Drag),
+			category: FixedWing,
+			criteria: anyAspect,
+			expected: true,
+		},
+		{
+			name:     "hot inside range but far above",
+			group:    hostile(20*unit.NauticalMile, 40000*unit.Foot, Hot),
+			category: FixedWing,
+			criteria: withAltitude,
+			expected: false,
+		},
+		{
+			name:     "hot inside range within altitude separation",
+			group:    hostile(20*unit.NauticalMile, 30000*unit.Foot, Hot),
+			category: FixedWing,
+			criteria: withAltitude,
+			expected: true,
+		},
+		{
+			name:     "no BRAA",
+			group:    &testGroup{},
+			category: FixedWing,
+			criteria: DefaultThreatCriteria,
+			expected: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, IsThreat(test.group, test.category, 20000*unit.Foot, test.criteria))
+		})
+	}
+}
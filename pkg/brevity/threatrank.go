@@ -0,0 +1,104 @@
+package brevity
+
+import (
+	"cmp"
+	"math"
+
+	"github.com/martinlindhe/unit"
+)
+
+// Weights of each factor in [ThreatRank]. The weights sum to 100, so a rank is between 0 and 100.
+const (
+	// threatRankRangeWeight rewards groups closer to the requester. A group at zero range scores the full weight,
+	// decreasing linearly to zero at threatRankMaxRange.
+	threatRankRangeWeight = 40
+	// threatRankPlatformWeight rewards groups with longer threat radii, i.e. armed fighters over attack aircraft,
+	// helicopters and unarmed transports. A group with a threat radius of at least threatRankReferenceRadius scores the
+	// full weight.
+	threatRankPlatformWeight = 25
+	// threatRankAspectWeight rewards groups pointed at the requester. HOT scores the full weight, FLANK half, BEAM a
+	// quarter and DRAG nothing. An unknown aspect scores half.
+	threatRankAspectWeight = 20
+	// threatRankClosureWeight rewards CLOSING groups. CLOSING scores the full weight, OPENING nothing and an unknown
+	// closure half.
+	threatRankClosureWeight = 10
+	// threatRankAltitudeWeight rewards groups above the requester. A group threatRankMaxAltitudeDelta or more above the
+	// requester scores the full weight, and a group that far below scores nothing.
+	threatRankAltitudeWeight = 5
+)
+
+const (
+	threatRankMaxRange         = 100 * unit.NauticalMile
+	threatRankReferenceRadius  = 25 * unit.NauticalMile
+	threatRankMaxAltitudeDelta = 20000 * unit.Foot
+)
+
+// ThreatRank scores how threatening a hostile group is to a requesting friendly aircraft at the given altitude, from 0
+// to 100. Higher is more threatening. The group's range, aspect and closure are read from its BRAA, which must be
+// relative to the requester; if the group has no BRAA, it scores nothing for range.
+//
+// The score combines range (40%), platform threat radius (25%), aspect (20%), closure (10%) and altitude advantage
+// (5%). For example, a HOT fighter at 30 nautical miles outranks a DRAG transport at 20 nautical miles.
+func ThreatRank(group Group, requesterAltitude unit.Length) float64 {
+	rank := 0.0
+
+	var altitude unit.Length
+	var aspect Aspect
+	if braa := group.BRAA(); braa != nil {
+		rank += threatRankRangeWeight * (1 - clamp(braa.Range().NauticalMiles()/threatRankMaxRange.NauticalMiles()))
+		altitude = braa.Altitude()
+		aspect = braa.Aspect()
+	} else {
+		altitude = group.Altitude()
+		aspect = group.Aspect()
+	}
+
+	rank += threatRankPlatformWeight * clamp(group.ThreatRadius().NauticalMiles()/threatRankReferenceRadius.NauticalMiles())
+
+	switch aspect {
+	case Hot:
+		rank += threatRankAspectWeight
+	case Flank:
+		rank += threatRankAspectWeight / 2.0
+	case Beam:
+		rank += threatRankAspectWeight / 4.0
+	case Drag:
+	default:
+		rank += threatRankAspectWeight / 2.0
+	}
+
+	switch group.Closure() {
+	case Closing:
+		rank += threatRankClosureWeight
+	case Opening:
+	default:
+		rank += threatRankClosureWeight / 2.0
+	}
+
+	delta := (altitude - requesterAltitude).Feet() / threatRankMaxAltitudeDelta.Feet()
+	rank += threatRankAltitudeWeight * clamp((delta+1)/2)
+
+	return rank
+}
+
+// CompareThreatRank orders groups from most to least threatening by [ThreatRank]. Equally ranked groups are ordered by
+// lowest object ID, so the ordering is deterministic.
+func CompareThreatRank(requesterAltitude unit.Length) func(a, b Group) int {
+	return CompareThreatRankBy(func(Group) unit.Length { return requesterAltitude })
+}
+
+// CompareThreatRankBy is like [CompareThreatRank], except that each group is ranked relative to a requester at the
+// altitude returned for that group. This orders groups which threaten different aircraft.
+func CompareThreatRankBy(requesterAltitude func(Group) unit.Length) func(a, b Group) int {
+	return func(a, b Group) int {
+		if c := cmp.Compare(ThreatRank(b, requesterAltitude(b)), ThreatRank(a, requesterAltitude(a))); c != 0 {
+			return c
+		}
+		return cmp.Compare(lowestID(a), lowestID(b))
+	}
+}
+
+// clamp limits x to the range [0, 1].
+func clamp(x float64) float64 {
+	return math.Min(math.Max(x, 0), 1)
+}
@@ -0,0 +1,128 @@
+package brevity
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+// rankedGroup creates a group at the given range, altitude and aspect from the requester.
+func rankedGroup(id uint64, rangeNM float64, altitude unit.Length, aspect Aspect, closure Closure, threatRadius unit.Length) *testGroup {
+	return &testGroup{
+		contacts:     1,
+		braa:         NewBRAA(bearings.NewMagneticBearing(90*unit.Degree), unit.Length(rangeNM)*unit.NauticalMile, []unit.Length{altitude}, aspect),
+		closure:      closure,
+		threatRadius: threatRadius,
+		ids:          []uint64{id},
+	}
+}
+
+func TestThreatRank(t *testing.T) {
+	t.Parallel()
+	const fighter = 25 * unit.NauticalMile
+	const attack = 15 * unit.NauticalMile
+	const transport = 0
+	testCases := []struct {
+		name     string
+		groups   []*testGroup
+		expected uint64
+	}{
+		{
+			name: "hot fighter outranks nearer cold transport",
+			groups: []*testGroup{
+				rankedGroup(1, 20, 20000*unit.Foot, Drag, Opening, transport),
+				rankedGroup(2, 30, 20000*unit.Foot, Hot, Closing, fighter),
+			},
+			expected: 2,
+		},
+		{
+			name: "nearer group outranks identical further group",
+			groups: []*testGroup{
+				rankedGroup(1, 40, 20000*unit.Foot, Hot, Closing, fighter),
+				rankedGroup(2, 20, 20000*unit.Foot, Hot, Closing, fighter),
+			},
+			expected: 2,
+		},
+		{
+			name: "hot outranks beam at the same range",
+			groups: []*testGroup{
+				rankedGroup(1, 30, 20000*unit.Foot, Beam, UnknownClosure, fighter),
+				rankedGroup(2, 30, 20000*unit.Foot, Hot, UnknownClosure, fighter),
+			},
+			expected: 2,
+		},
+		{
+			name: "closing outranks opening",
+			groups: []*testGroup{
+				rankedGroup(1, 30, 20000*unit.Foot, Flank, Opening, fighter),
+				rankedGroup(2, 30, 20000*unit.Foot, Flank, Closing, fighter),
+			},
+			expected: 2,
+		},
+		{
+			name: "fighter outranks attack aircraft",
+			groups: []*testGroup{
+				rankedGroup(1, 30, 20000*unit.Foot, Hot, Closing, attack),
+				rankedGroup(2, 30, 20000*unit.Foot, Hot, Closing, fighter),
+			},
+			expected: 2,
+		},
+		{
+			name: "higher group outranks lower group",
+			groups: []*testGroup{
+				rankedGroup(1, 30, 5000*unit.Foot, Hot, Closing, fighter),
+				rankedGroup(2, 30, 35000*unit.Foot, Hot, Closing, fighter),
+			},
+			expected: 2,
+		},
+		{
+			name: "ties are broken by lowest ID",
+			groups: []*testGroup{
+				rankedGroup(7, 30, 20000*unit.Foot, Hot, Closing, fighter),
+				rankedGroup(3, 30, 20000*unit.Foot, Hot, Closing, fighter),
+			},
+			expected: 3,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			groups := make([]Group, 0, len(test.groups))
+			for _, g := range test.groups {
+				groups = append(groups, g)
+			}
+			compare := CompareThreatRank(20000 * unit.Foot)
+			assert.Equal(t, []uint64{test.expected}, slices.MinFunc(groups, compare).ObjectIDs())
+			slices.Reverse(groups)
+			assert.Equal(t, []uint64{test.expected}, slices.MinFunc(groups, compare).ObjectIDs())
+		})
+	}
+}
+
+func TestCompareThreatRankBy(t *testing.T) {
+	t.Parallel()
+	// The same group is more threatening to a lower aircraft.
+	high := rankedGroup(1, 30, 20000*unit.Foot, Hot, Closing, 25*unit.NauticalMile)
+	low := rankedGroup(2, 30, 20000*unit.Foot, Hot, Closing, 25*unit.NauticalMile)
+	altitudes := map[Group]unit.Length{high: 30000 * unit.Foot, low: 10000 * unit.Foot}
+	compare := CompareThreatRankBy(func(g Group) unit.Length { return altitudes[g] })
+	assert.Equal(t, 1, compare(high, low))
+
+	// Groups without object IDs are ordered last among equally ranked groups.
+	withoutIDs := rankedGroup(0, 30, 20000*unit.Foot, Hot, Closing, 25*unit.NauticalMile)
+	withoutIDs.ids = nil
+	groups := []Group{withoutIDs, high}
+	slices.SortFunc(groups, CompareThreatRank(20000*unit.Foot))
+	assert.Equal(t, []Group{high, withoutIDs}, groups)
+}
+
+func TestThreatRankBounds(t *testing.T) {
+	t.Parallel()
+	highest := rankedGroup(1, 0, 40000*unit.Foot, Hot, Closing, 50*unit.NauticalMile)
+	assert.InDelta(t, 100, ThreatRank(highest, 0), 0.001)
+	lowest := rankedGroup(2, 200, 0, Drag, Opening, 0)
+	assert.InDelta(t, 0, ThreatRank(lowest, 40000*unit.Foot), 0.001)
+}
@@ -1,7 +1,10 @@
 package brevity
 
 import (
+	"math"
+
 	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
@@ -25,8 +28,20 @@ func TrackFromBearing(bearing bearings.Bearing) Track {
 	if !bearing.IsMagnetic() {
 		log.Warn().Stringer("bearing", bearing).Msg("bearing provided to TrackFromBearing should be magnetic")
 	}
-	θ := bearing.Degrees()
+	return TrackFromHeading(bearing.Value())
+}
+
+// TrackFromHeading computes a track direction based on the given heading. Each direction covers a 45° sector centered on
+// that direction. A heading exactly on the boundary between two sectors belongs to the sector clockwise of the boundary,
+// e.g. 22.5° is northeast and 337.5° is north. The heading may be any angle; it is normalized to [0, 360).
+func TrackFromHeading(heading unit.Angle) Track {
+	θ := math.Mod(heading.Degrees(), 360)
+	if θ < 0 {
+		θ += 360
+	}
 	switch {
+	case math.IsNaN(θ):
+		return UnknownDirection
 	case θ >= 337.5 || θ < 22.5:
 		return North
 	case θ < 67.5:
@@ -41,9 +56,7 @@ func TrackFromBearing(bearing bearings.Bearing) Track {
 		return Southwest
 	case θ < 292.5:
 		return West
-	case θ < 337.5:
-		return Northwest
 	default:
-		return UnknownDirection
+		return Northwest
 	}
 }
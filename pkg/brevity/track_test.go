@@ -1,6 +1,7 @@
 package brevity
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
@@ -32,3 +33,106 @@ func TestTrackFromBearing(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackFromHeading(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		heading  float64
+		expected Track
+	}{
+		{0, North},
+		{5, North},
+		{10, North},
+		{15, North},
+		{20, North},
+		{25, Northeast},
+		{30, Northeast},
+		{35, Northeast},
+		{40, Northeast},
+		{45, Northeast},
+		{50, Northeast},
+		{55, Northeast},
+		{60, Northeast},
+		{65, Northeast},
+		{70, East},
+		{75, East},
+		{80, East},
+		{85, East},
+		{90, East},
+		{95, East},
+		{100, East},
+		{105, East},
+		{110, East},
+		{115, Southeast},
+		{120, Southeast},
+		{125, Southeast},
+		{130, Southeast},
+		{135, Southeast},
+		{140, Southeast},
+		{145, Southeast},
+		{150, Southeast},
+		{155, Southeast},
+		{160, South},
+		{165, South},
+		{170, South},
+		{175, South},
+		{180, South},
+		{185, South},
+		{190, South},
+		{195, South},
+		{200, South},
+		{205, Southwest},
+		{210, Southwest},
+		{215, Southwest},
+		{220, Southwest},
+		{225, Southwest},
+		{230, Southwest},
+		{235, Southwest},
+		{240, Southwest},
+		{245, Southwest},
+		{250, West},
+		{255, West},
+		{260, West},
+		{265, West},
+		{270, West},
+		{275, West},
+		{280, West},
+		{285, West},
+		{290, West},
+		{295, Northwest},
+		{300, Northwest},
+		{305, Northwest},
+		{310, Northwest},
+		{315, Northwest},
+		{320, Northwest},
+		{325, Northwest},
+		{330, Northwest},
+		{335, Northwest},
+		{340, North},
+		{345, North},
+		{350, North},
+		{355, North},
+		{360, North},
+		// Boundaries belong to the sector clockwise of the boundary.
+		{22.5, Northeast},
+		{67.5, East},
+		{112.5, Southeast},
+		{157.5, South},
+		{202.5, Southwest},
+		{247.5, West},
+		{292.5, Northwest},
+		{337.5, North},
+		// Headings outside [0, 360) are normalized.
+		{-45, Northwest},
+		{-180, South},
+		{405, Northeast},
+		{720, North},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.1f", test.heading), func(t *testing.T) {
+			t.Parallel()
+			actual := TrackFromHeading(unit.Angle(test.heading) * unit.Degree)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
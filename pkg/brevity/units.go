@@ -0,0 +1,82 @@
+package brevity
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/martinlindhe/unit"
+)
+
+// UnitSystem is a system of units for reporting ranges and altitudes.
+type UnitSystem int
+
+const (
+	// ImperialUnits reports ranges in nautical miles and altitudes in feet.
+	ImperialUnits UnitSystem = iota
+	// MetricUnits reports ranges in kilometers and altitudes in meters.
+	MetricUnits
+)
+
+func (s UnitSystem) String() string {
+	if s == MetricUnits {
+		return "metric"
+	}
+	return "imperial"
+}
+
+// MetricAltitudeStep is the interval to which metric altitudes are rounded.
+const MetricAltitudeStep = 500 * unit.Meter
+
+// rangeUnit returns the unit in which ranges are reported.
+func (s UnitSystem) rangeUnit() unit.Length {
+	if s == MetricUnits {
+		return unit.Kilometer
+	}
+	return unit.NauticalMile
+}
+
+// RoundRange rounds a range to a spoken value, as for a BULLSEYE or BRA: to the nearest nautical mile in imperial units,
+// or the nearest kilometer in metric units. Halves round away from zero.
+func (s UnitSystem) RoundRange(r unit.Length) unit.Length {
+	step := s.rangeUnit()
+	return unit.Length(roundToStep(float64(r/step), 1)) * step
+}
+
+// RoundContactRange rounds the range to a contact following GCI conventions, as for a BRAA: as by
+// [UnitSystem.RoundRange] within 10 nautical miles, and to the nearest 5 nautical miles or kilometers beyond. Halves
+// round away from zero.
+func (s UnitSystem) RoundContactRange(r unit.Length) unit.Length {
+	rounded := s.RoundRange(r)
+	if rounded <= rangeRoundingThreshold {
+		return rounded
+	}
+	step := 5 * s.rangeUnit()
+	return unit.Length(roundToStep(float64(r/step), 1)) * step
+}
+
+// RoundAltitude rounds an altitude to a spoken value: as by [RoundAltitude] in imperial units, or to the
+// nearest [MetricAltitudeStep] in metric units. Halves round away from zero.
+func (s UnitSystem) RoundAltitude(a unit.Length) unit.Length {
+	if s == MetricUnits {
+		return unit.Length(roundToStep(math.Abs(a.Meters()), MetricAltitudeStep.Meters())) * unit.Meter
+	}
+	return RoundAltitude(a)
+}
+
+// FormatRange formats a range which has already been rounded. Imperial ranges are formatted as a bare number of
+// nautical miles, following GCI convention, while metric ranges include the unit.
+func (s UnitSystem) FormatRange(r unit.Length) string {
+	if s == MetricUnits {
+		return fmt.Sprintf("%.0f km", r.Kilometers())
+	}
+	return fmt.Sprintf("%.0f", r.NauticalMiles())
+}
+
+// FormatAltitude formats an altitude which has already been rounded. Imperial altitudes are formatted as a bare number
+// of feet, following GCI convention, while metric altitudes include the unit.
+func (s UnitSystem) FormatAltitude(a unit.Length) string {
+	if s == MetricUnits {
+		return fmt.Sprintf("%.0f m", a.Meters())
+	}
+	return fmt.Sprintf("%.0f", a.Feet())
+}
@@ -0,0 +1,115 @@
+package brevity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitSystemRoundRange(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		system   UnitSystem
+		input    unit.Length
+		expected unit.Length
+	}{
+		{ImperialUnits, 0, 0},
+		{ImperialUnits, 0.49 * unit.NauticalMile, 0},
+		{ImperialUnits, 0.5 * unit.NauticalMile, 1 * unit.NauticalMile},
+		{ImperialUnits, 44.49 * unit.NauticalMile, 44 * unit.NauticalMile},
+		{ImperialUnits, 44.5 * unit.NauticalMile, 45 * unit.NauticalMile},
+		{ImperialUnits, 45.49 * unit.NauticalMile, 45 * unit.NauticalMile},
+		{MetricUnits, 0, 0},
+		{MetricUnits, 499 * unit.Meter, 0},
+		{MetricUnits, 500 * unit.Meter, 1 * unit.Kilometer},
+		{MetricUnits, 1499 * unit.Meter, 1 * unit.Kilometer},
+		{MetricUnits, 1500 * unit.Meter, 2 * unit.Kilometer},
+		{MetricUnits, 82.49 * unit.Kilometer, 82 * unit.Kilometer},
+		{MetricUnits, 82.5 * unit.Kilometer, 83 * unit.Kilometer},
+		{MetricUnits, 45 * unit.NauticalMile, 83 * unit.Kilometer},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%s %.1fm", test.system, test.input.Meters()), func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, test.expected.Meters(), test.system.RoundRange(test.input).Meters(), 0.001)
+		})
+	}
+}
+
+func TestUnitSystemRoundContactRange(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		system   UnitSystem
+		input    unit.Length
+		expected unit.Length
+	}{
+		{ImperialUnits, 9.49 * unit.NauticalMile, 9 * unit.NauticalMile},
+		{ImperialUnits, 44 * unit.NauticalMile, 45 * unit.NauticalMile},
+		{MetricUnits, 9.49 * unit.Kilometer, 9 * unit.Kilometer},
+		{MetricUnits, 18.49 * unit.Kilometer, 18 * unit.Kilometer},
+		{MetricUnits, 19.5 * unit.Kilometer, 20 * unit.Kilometer},
+		{MetricUnits, 82.49 * unit.Kilometer, 80 * unit.Kilometer},
+		{MetricUnits, 82.5 * unit.Kilometer, 85 * unit.Kilometer},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%s %.1fm", test.system, test.input.Meters()), func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, test.expected.Meters(), test.system.RoundContactRange(test.input).Meters(), 0.001)
+		})
+	}
+}
+
+func TestUnitSystemRoundAltitude(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		system   UnitSystem
+		input    unit.Length
+		expected unit.Length
+	}{
+		{ImperialUnits, 0, 0},
+		{ImperialUnits, 449 * unit.Foot, 400 * unit.Foot},
+		{ImperialUnits, 950 * unit.Foot, 1000 * unit.Foot},
+		{ImperialUnits, 19499 * unit.Foot, 19000 * unit.Foot},
+		{ImperialUnits, 19500 * unit.Foot, 20000 * unit.Foot},
+		{ImperialUnits, -20000 * unit.Foot, 20000 * unit.Foot},
+		{MetricUnits, 0, 0},
+		{MetricUnits, 249 * unit.Meter, 0},
+		{MetricUnits, 250 * unit.Meter, 500 * unit.Meter},
+		{MetricUnits, 749 * unit.Meter, 500 * unit.Meter},
+		{MetricUnits, 750 * unit.Meter, 1000 * unit.Meter},
+		{MetricUnits, 5749 * unit.Meter, 5500 * unit.Meter},
+		{MetricUnits, 5750 * unit.Meter, 6000 * unit.Meter},
+		{MetricUnits, 20000 * unit.Foot, 6000 * unit.Meter},
+		{MetricUnits, -6000 * unit.Meter, 6000 * unit.Meter},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%s %.1fm", test.system, test.input.Meters()), func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, test.expected.Meters(), test.system.RoundAltitude(test.input).Meters(), 0.001)
+		})
+	}
+}
+
+func TestUnitSystemFormat(t *testing.T) {
+	t.Parallel()
+	bearing := bearings.NewMagneticBearing(90 * unit.Degree)
+
+	bullseye := NewBullseye(bearing, 45*unit.NauticalMile)
+	assert.Equal(t, "090/45", bullseye.Format(ImperialUnits))
+	assert.Equal(t, "090/83 km", bullseye.Format(MetricUnits))
+
+	stack := Stack{Altitude: 20000 * unit.Foot, Count: 2}
+	assert.Equal(t, "20000", stack.Format(ImperialUnits))
+	assert.Equal(t, "6000 m", stack.Format(MetricUnits))
+
+	bra := NewBRA(bearing, 45*unit.NauticalMile, 20000*unit.Foot)
+	assert.Equal(t, "090/45 20000", bra.Format(ImperialUnits))
+	assert.Equal(t, "090/83 km 6000 m", bra.Format(MetricUnits))
+
+	braa := NewBRAA(bearing, 43*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, Hot)
+	assert.Equal(t, "090/45 20000 hot", braa.Format(ImperialUnits))
+	assert.Equal(t, "090/80 km 6000 m hot", braa.Format(MetricUnits))
+}
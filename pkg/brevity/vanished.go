@@ -0,0 +1,8 @@
+package brevity
+
+// VanishedCall reports a previously faded group has not been updated by on or off-board sensors for long enough that it
+// is no longer expected to reappear.
+type VanishedCall struct {
+	// Group which has vanished.
+	Group Group
+}
@@ -0,0 +1,103 @@
+package brevity
+
+import (
+	"math"
+	"slices"
+
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// WallRangeTolerance is the default maximum depth of a WALL along the line of sight from the ownship.
+	WallRangeTolerance = 15 * unit.NauticalMile
+	// WallMinSpread is the default minimum arc of azimuth from the ownship which the groups in a WALL are spread across.
+	WallMinSpread = 30 * unit.Degree
+)
+
+// WallCriteria are the thresholds used to decide when groups are in a WALL.
+type WallCriteria struct {
+	// RangeTolerance is the maximum difference in range along the line of sight from the ownship between the nearest
+	// and furthest groups.
+	RangeTolerance unit.Length
+	// MinSpread is the minimum arc of azimuth from the ownship which the groups are spread across.
+	MinSpread unit.Angle
+}
+
+// DefaultWallCriteria are the default thresholds for a WALL.
+var DefaultWallCriteria = WallCriteria{
+	RangeTolerance: WallRangeTolerance,
+	MinSpread:      WallMinSpread,
+}
+
+// ClassifyWall returns true if the groups are in a WALL relative to the ownship by [DefaultWallCriteria].
+func ClassifyWall(groups []Group, ownship Contact) bool {
+	return DefaultWallCriteria.ClassifyWall(groups, ownship)
+}
+
+// ClassifyWall returns true if the groups are in a WALL relative to the ownship: three or more groups abeam each other
+// in a line across the ownship's line of sight, separated in azimuth. A WALL is only called relative to an ownship, so
+// this returns false if the ownship's position is unknown. All groups must have a BULLSEYE position.
+func (c WallCriteria) ClassifyWall(groups []Group, ownship Contact) bool {
+	positions, ok := groupPositions(groups)
+	if !ok {
+		return false
+	}
+	_, _, ok = c.wall(positions, ownship)
+	return ok
+}
+
+// wall checks if the groups at the given positions are in a WALL relative to the ownship. If they are, it returns the
+// label of each group in the given order, and the distance between the outermost groups.
+func (c WallCriteria) wall(positions []vector, ownship Contact) ([]GroupLabel, unit.Length, bool) {
+	if len(positions) < 3 || ownship.Bullseye == nil {
+		return nil, 0, false
+	}
+	reference := ownship.Bullseye.vector()
+
+	// The line of sight runs from the ownship to the middle of the groups.
+	var sight vector
+	for _, p := range positions {
+		sight = sight.add(p.sub(reference))
+	}
+	length := math.Hypot(sight.x, sight.y)
+	if length == 0 {
+		// The groups surround the ownship.
+		return nil, 0, false
+	}
+	sight = vector{x: sight.x / length, y: sight.y / length}
+
+	// The groups must all be ahead of the ownship, in a line across the line of sight.
+	depths := make([]float64, 0, len(positions))
+	azimuths := make([]float64, 0, len(positions))
+	for _, p := range positions {
+		offset := p.sub(reference)
+		depth := offset.dot(sight)
+		if depth <= 0 {
+			return nil, 0, false
+		}
+		depths = append(depths, depth)
+		azimuth := unit.Angle(math.Atan2(offset.x, offset.y)) * unit.Radian
+		azimuths = append(azimuths, math.Mod(azimuth.Degrees()+360, 360))
+	}
+	if slices.Max(depths)-slices.Min(depths) > c.RangeTolerance.NauticalMiles() {
+		return nil, 0, false
+	}
+
+	// The groups are spread across the whole circle except for the largest gap between adjacent azimuths.
+	slices.Sort(azimuths)
+	gap := azimuths[0] + 360 - azimuths[len(azimuths)-1]
+	for i := 1; i < len(azimuths); i++ {
+		gap = math.Max(gap, azimuths[i]-azimuths[i-1])
+	}
+	if 360-gap < c.MinSpread.Degrees() {
+		return nil, 0, false
+	}
+
+	// The width is measured across the line of sight, between the outermost groups.
+	minWidth, maxWidth := math.Inf(1), math.Inf(-1)
+	for _, p := range positions {
+		across := p.x*sight.y - p.y*sight.x
+		minWidth, maxWidth = math.Min(minWidth, across), math.Max(maxWidth, across)
+	}
+	return labelCardinal(positions), unit.Length(math.Round(maxWidth-minWidth)) * unit.NauticalMile, true
+}
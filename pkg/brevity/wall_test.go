@@ -0,0 +1,95 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyWall(t *testing.T) {
+	t.Parallel()
+	atBullseye := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(0), 0)}
+	farSouth := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(180*unit.Degree), 200*unit.NauticalMile)}
+	testCases := []struct {
+		name     string
+		groups   []Group
+		ownship  Contact
+		expected bool
+	}{
+		{
+			name:     "abeam at the same range",
+			groups:   []Group{groupAt(330, 40, South), groupAt(360, 40, South), groupAt(30, 40, South)},
+			ownship:  atBullseye,
+			expected: true,
+		},
+		{
+			name:     "ownship position unknown",
+			groups:   []Group{groupAt(330, 40, South), groupAt(360, 40, South), groupAt(30, 40, South)},
+			ownship:  Contact{},
+			expected: false,
+		},
+		{
+			name:     "surrounding the ownship",
+			groups:   []Group{groupAt(0, 5, South), groupAt(120, 5, South), groupAt(240, 5, South)},
+			ownship:  atBullseye,
+			expected: false,
+		},
+		{
+			name:     "around the ownship",
+			groups:   []Group{groupAt(0, 20, South), groupAt(90, 20, South), groupAt(180, 20, South)},
+			ownship:  atBullseye,
+			expected: false,
+		},
+		{
+			name:     "ranges within tolerance",
+			groups:   []Group{groupAt(330, 36, South), groupAt(360, 45, South), groupAt(30, 40, South)},
+			ownship:  atBullseye,
+			expected: true,
+		},
+		{
+			name:     "ranges too far apart",
+			groups:   []Group{groupAt(330, 40, South), groupAt(360, 60, South), groupAt(30, 40, South)},
+			ownship:  atBullseye,
+			expected: false,
+		},
+		{
+			name:     "azimuths too close together",
+			groups:   []Group{groupAt(350, 40, South), groupAt(360, 40, South), groupAt(10, 40, South)},
+			ownship:  atBullseye,
+			expected: false,
+		},
+		{
+			name:     "far from the ownship",
+			groups:   []Group{groupAt(330, 40, South), groupAt(360, 40, South), groupAt(30, 40, South)},
+			ownship:  farSouth,
+			expected: false,
+		},
+		{
+			name:     "two groups",
+			groups:   []Group{groupAt(330, 40, South), groupAt(30, 40, South)},
+			ownship:  atBullseye,
+			expected: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, ClassifyWall(test.groups, test.ownship))
+		})
+	}
+}
+
+func TestWallCriteria(t *testing.T) {
+	t.Parallel()
+	groups := []Group{groupAt(330, 36, South), groupAt(360, 45, South), groupAt(30, 40, South)}
+	ownship := Contact{Bullseye: NewBullseye(bearings.NewMagneticBearing(0), 0)}
+	assert.True(t, DefaultWallCriteria.ClassifyWall(groups, ownship))
+
+	narrow := WallCriteria{RangeTolerance: 5 * unit.NauticalMile, MinSpread: WallMinSpread}
+	assert.False(t, narrow.ClassifyWall(groups, ownship))
+
+	wide := WallCriteria{RangeTolerance: WallRangeTolerance, MinSpread: 90 * unit.Degree}
+	assert.False(t, wide.ClassifyWall(groups, ownship))
+}
@@ -0,0 +1,147 @@
+package brevity
+
+import (
+	"math"
+
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// WedgeAngle is the ideal angle off track from the point group of a VIC or CHAMPAGNE to each of the other groups.
+	WedgeAngle = 45 * unit.Degree
+	// WedgeAngleTolerance is the default maximum difference from [WedgeAngle] in a VIC or CHAMPAGNE.
+	WedgeAngleTolerance = 15 * unit.Degree
+	// WedgeMinDepth is the default minimum in-line distance from the point group of a VIC or CHAMPAGNE to each of the
+	// other groups.
+	WedgeMinDepth = 5 * unit.NauticalMile
+)
+
+// WedgeCriteria are the thresholds used to decide when three groups are in a VIC or CHAMPAGNE. Both formations are
+// wedges with a single point group; in a VIC the point group leads, and in a CHAMPAGNE it trails.
+type WedgeCriteria struct {
+	// AngleTolerance is the maximum difference from [WedgeAngle] between the track axis and the line from the point
+	// group to each other group.
+	AngleTolerance unit.Angle
+	// MinDepth is the minimum in-line distance from the point group to each other group.
+	MinDepth unit.Length
+}
+
+// DefaultWedgeCriteria are the default thresholds for a VIC or CHAMPAGNE.
+var DefaultWedgeCriteria = WedgeCriteria{
+	AngleTolerance: WedgeAngleTolerance,
+	MinDepth:       WedgeMinDepth,
+}
+
+// ClassifyVic returns true if the groups are in a VIC by [DefaultWedgeCriteria].
+func ClassifyVic(groups []Group) bool {
+	return DefaultWedgeCriteria.ClassifyVic(groups)
+}
+
+// ClassifyChampagne returns true if the groups are in a CHAMPAGNE by [DefaultWedgeCriteria].
+func ClassifyChampagne(groups []Group) bool {
+	return DefaultWedgeCriteria.ClassifyChampagne(groups)
+}
+
+// ClassifyVic returns true if the groups are in a VIC: a lead group with two trailing groups, one on each side of the
+// track, about 45° off track from the lead group. The track axis is found as for a pair of groups in a PICTURE. All
+// groups must have a BULLSEYE position.
+func (c WedgeCriteria) ClassifyVic(groups []Group) bool {
+	positions, ok := groupPositions(groups)
+	if !ok {
+		return false
+	}
+	_, ok = c.vic(groups, positions)
+	return ok
+}
+
+// ClassifyChampagne returns true if the groups are in a CHAMPAGNE: two lead groups, one on each side of the track, with
+// a trailing group about 45° off track behind both of them. The track axis is found as for a pair of groups in a
+// PICTURE. All groups must have a BULLSEYE position.
+func (c WedgeCriteria) ClassifyChampagne(groups []Group) bool {
+	positions, ok := groupPositions(groups)
+	if !ok {
+		return false
+	}
+	_, ok = c.champagne(groups, positions)
+	return ok
+}
+
+// wedgeShape describes three groups in a wedge.
+type wedgeShape struct {
+	// labels of each group, in the same order as the groups.
+	labels []GroupLabel
+	// depth is the in-line distance from the point group to the other groups.
+	depth unit.Length
+	// width is the lateral distance between the other groups.
+	width unit.Length
+}
+
+// vic checks if the groups at the given positions are in a VIC.
+func (c WedgeCriteria) vic(groups []Group, positions []vector) (wedgeShape, bool) {
+	if len(groups) != 3 || len(positions) != len(groups) {
+		return wedgeShape{}, false
+	}
+	// The trailing groups are behind the lead group, so the wedge opens against the track.
+	axis := formationAxis(groups, positions)
+	return c.wedge(positions, vector{x: -axis.x, y: -axis.y}, LeadGroup, TrailGroup)
+}
+
+// champagne checks if the groups at the given positions are in a CHAMPAGNE.
+func (c WedgeCriteria) champagne(groups []Group, positions []vector) (wedgeShape, bool) {
+	if len(groups) != 3 || len(positions) != len(groups) {
+		return wedgeShape{}, false
+	}
+	// The lead groups are ahead of the trailing group, so the wedge opens along the track.
+	axis := formationAxis(groups, positions)
+	return c.wedge(positions, axis, TrailGroup, LeadGroup)
+}
+
+// wedge checks if three groups form a wedge which opens in the given direction. The point group is labeled with
+// pointLabel, and the other groups are labeled with their cardinal direction relative to each other followed by
+// sideLabel.
+func (c WedgeCriteria) wedge(positions []vector, direction vector, pointLabel, sideLabel GroupLabel) (wedgeShape, bool) {
+	point := 0
+	for i, p := range positions {
+		if p.dot(direction) < positions[point].dot(direction) {
+			point = i
+		}
+	}
+
+	var sides []int
+	var depth float64
+	var offsets []float64
+	for i, p := range positions {
+		if i == point {
+			continue
+		}
+		displacement := p.sub(positions[point])
+		ahead := displacement.dot(direction)
+		beside := displacement.x*direction.y - displacement.y*direction.x
+		if ahead < c.MinDepth.NauticalMiles() {
+			return wedgeShape{}, false
+		}
+		offTrack := unit.Angle(math.Atan2(math.Abs(beside), ahead)) * unit.Radian
+		if math.Abs((offTrack - WedgeAngle).Degrees()) > c.AngleTolerance.Degrees() {
+			return wedgeShape{}, false
+		}
+		sides = append(sides, i)
+		depth += ahead / 2
+		offsets = append(offsets, beside)
+	}
+	if math.Signbit(offsets[0]) == math.Signbit(offsets[1]) {
+		// Both groups are on the same side of the point group.
+		return wedgeShape{}, false
+	}
+
+	labels := make([]GroupLabel, len(positions))
+	labels[point] = pointLabel
+	cardinal := labelCardinal([]vector{positions[sides[0]], positions[sides[1]]})
+	for i, j := range sides {
+		labels[j] = cardinal[i] + " " + sideLabel
+	}
+	return wedgeShape{
+		labels: labels,
+		depth:  unit.Length(math.Round(depth)) * unit.NauticalMile,
+		width:  unit.Length(math.Round(math.Abs(offsets[0]-offsets[1]))) * unit.NauticalMile,
+	}, true
+}
@@ -0,0 +1,120 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+// Idealized wedges tracking south. In the VIC the lead group is 60 nm north of the BULLSEYE and the trailing groups are
+// 10 nm behind and 10 nm to either side. The CHAMPAGNE is the same wedge reversed. The wedges are far enough from the
+// BULLSEYE that they are not also a WALL relative to it.
+func idealVic() []Group {
+	return []Group{groupAt(360, 60, South), groupAt(8.130, 70.711, South), groupAt(351.870, 70.711, South)}
+}
+
+func idealChampagne() []Group {
+	return []Group{groupAt(9.462, 60.828, South), groupAt(350.538, 60.828, South), groupAt(360, 70, South)}
+}
+
+func TestClassifyVic(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		groups   []Group
+		expected bool
+	}{
+		{
+			name:     "ideal vic",
+			groups:   idealVic(),
+			expected: true,
+		},
+		{
+			name:     "ideal vic tracking east",
+			groups:   []Group{groupAt(270, 20, East), groupAt(288.435, 31.623, East), groupAt(251.565, 31.623, East)},
+			expected: true,
+		},
+		{
+			name:     "champagne",
+			groups:   idealChampagne(),
+			expected: false,
+		},
+		{
+			name:     "ladder",
+			groups:   []Group{groupAt(360, 20, South), groupAt(360, 35, South), groupAt(360, 50, South)},
+			expected: false,
+		},
+		{
+			name:     "trailing groups on the same side",
+			groups:   []Group{groupAt(360, 20, South), groupAt(18.435, 31.623, South), groupAt(26.565, 44.721, South)},
+			expected: false,
+		},
+		{
+			name:     "trailing groups abeam the lead group",
+			groups:   []Group{groupAt(360, 20, South), groupAt(26.565, 22.361, South), groupAt(333.435, 22.361, South)},
+			expected: false,
+		},
+		{
+			name:     "two groups",
+			groups:   []Group{groupAt(360, 20, South), groupAt(18.435, 31.623, South)},
+			expected: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, ClassifyVic(test.groups))
+		})
+	}
+}
+
+func TestClassifyChampagne(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		groups   []Group
+		expected bool
+	}{
+		{
+			name:     "ideal champagne",
+			groups:   idealChampagne(),
+			expected: true,
+		},
+		{
+			name:     "ideal champagne tracking north",
+			groups:   []Group{groupAt(180, 30, North), groupAt(153.435, 22.361, North), groupAt(206.565, 22.361, North)},
+			expected: true,
+		},
+		{
+			name:     "vic",
+			groups:   idealVic(),
+			expected: false,
+		},
+		{
+			name:     "lead groups on the same side",
+			groups:   []Group{groupAt(26.565, 22.361, South), groupAt(45, 14.142, South), groupAt(360, 30, South)},
+			expected: false,
+		},
+		{
+			name:     "group without bullseye",
+			groups:   []Group{groupAt(26.565, 22.361, South), groupAt(333.435, 22.361, South), &testGroup{contacts: 1, track: South}},
+			expected: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, ClassifyChampagne(test.groups))
+		})
+	}
+}
+
+func TestWedgeCriteria(t *testing.T) {
+	t.Parallel()
+	groups := idealVic()
+	assert.True(t, DefaultWedgeCriteria.ClassifyVic(groups))
+
+	deep := WedgeCriteria{AngleTolerance: WedgeAngleTolerance, MinDepth: 15 * unit.NauticalMile}
+	assert.False(t, deep.ClassifyVic(groups))
+}
@@ -1,6 +1,11 @@
 // package coalitions defines the coalitions in DCS World.
 package coalitions
 
+import (
+	"errors"
+	"fmt"
+)
+
 // Coalition is the ID of a coalition in DCS World.
 type Coalition int
 
@@ -13,15 +18,18 @@ const (
 	Neutrals = 3
 )
 
-// String returns the string representation of a coalition.
+// String returns the string representation of a coalition: "Red", "Blue", "Neutrals", or "Unknown(N)" for any other
+// ID, such as the -1 which DCS sometimes reports for uninitialized aircraft.
 func (c Coalition) String() string {
 	switch c {
 	case Red:
 		return "Red"
 	case Blue:
 		return "Blue"
-	default:
+	case Neutrals:
 		return "Neutrals"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(c))
 	}
 }
 
@@ -40,3 +48,19 @@ func (c Coalition) Opposite() Coalition {
 		return Neutrals
 	}
 }
+
+// ErrNoOpposingCoalition is returned by [Opposing] for a coalition which has no opposing coalition.
+var ErrNoOpposingCoalition = errors.New("only red and blue have an opposing coalition")
+
+// Opposing returns the coalition which opposes the given coalition: Red for Blue, and Blue for Red. Unlike
+// [Coalition.Opposite], it returns [ErrNoOpposingCoalition] for any other coalition, such as neutrals and spectators.
+func Opposing(c Coalition) (Coalition, error) {
+	switch c {
+	case Red:
+		return Blue, nil
+	case Blue:
+		return Red, nil
+	default:
+		return c, fmt.Errorf("%w: %s", ErrNoOpposingCoalition, c)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOpposite(t *testing.T) {
@@ -26,3 +27,41 @@ func TestOpposite(t *testing.T) {
 		})
 	}
 }
+
+func TestString(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input    Coalition
+		expected string
+	}{
+		{Red, "Red"},
+		{Blue, "Blue"},
+		{Neutrals, "Neutrals"},
+		{0, "Unknown(0)"},
+		{4, "Unknown(4)"},
+		{-1, "Unknown(-1)"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, test.input.String())
+		})
+	}
+}
+
+func TestOpposing(t *testing.T) {
+	t.Parallel()
+	opposing, err := Opposing(Blue)
+	require.NoError(t, err)
+	assert.Equal(t, Coalition(Red), opposing)
+
+	opposing, err = Opposing(Red)
+	require.NoError(t, err)
+	assert.Equal(t, Coalition(Blue), opposing)
+
+	for _, c := range []Coalition{Neutrals, 0, -1} {
+		_, err := Opposing(c)
+		require.ErrorIs(t, err, ErrNoOpposingCoalition, "coalition %d", int(c))
+	}
+}
@@ -14,7 +14,7 @@ func (c *composer) ComposeAlphaCheckResponse(response brevity.AlphaCheckResponse
 		if !response.Location.Bearing().IsMagnetic() {
 			log.Error().Stringer("bearing", response.Location.Bearing()).Msg("bearing provided to ComposeAlphaCheckResponse should be magnetic")
 		}
-		return NaturalLanguageResponse{
+		reply := NaturalLanguageResponse{
 			Subtitle: fmt.Sprintf(
 				"%s, %s, contact, alpha check bullseye %s/%d",
 				strings.ToUpper(response.Callsign),
@@ -30,6 +30,17 @@ func (c *composer) ComposeAlphaCheckResponse(response brevity.AlphaCheckResponse
 				int(response.Location.Distance().NauticalMiles()),
 			),
 		}
+		if response.Altitude > 0 {
+			altitude := ", " + c.ComposeAltitude(response.Altitude, brevity.Friendly)
+			reply.Subtitle += altitude
+			reply.Speech += altitude
+		}
+		if response.Track != brevity.UnknownDirection {
+			track := fmt.Sprintf(", tracking %s", response.Track)
+			reply.Subtitle += track
+			reply.Speech += track
+		}
+		return reply
 	}
 
 	reply := response.Callsign + ", negative contact"
@@ -0,0 +1,24 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeAlphaCheckResponse(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Anyface"}
+	response := c.ComposeAlphaCheckResponse(brevity.AlphaCheckResponse{
+		Callsign: "eagle 1",
+		Status:   true,
+		Location: *brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 45*unit.NauticalMile),
+		Altitude: 20000 * unit.Foot,
+		Track:    brevity.West,
+	})
+	assert.Equal(t, "EAGLE 1, ANYFACE, contact, alpha check bullseye 090/45, angels 20, tracking west", response.Subtitle)
+	assert.Equal(t, "EAGLE 1, ANYFACE, contact, alpha check bullseye 0 9 0, 45, angels 20, tracking west", response.Speech)
+}
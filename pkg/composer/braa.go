@@ -18,7 +18,10 @@ func (c *composer) ComposeBRAA(braa brevity.BRAA, declaration brevity.Declaratio
 		aspect = string(braa.Aspect())
 	}
 	_range := int(braa.Range().NauticalMiles())
-	altitude := c.ComposeAltitude(braa.Altitude(), declaration)
+	altitude := "altitude unknown"
+	if len(braa.Stacks()) > 0 {
+		altitude = c.composeKnownAltitude(braa.Altitude(), declaration)
+	}
 	return NaturalLanguageResponse{
 		Subtitle: fmt.Sprintf("BRAA %s/%d, %s, %s", braa.Bearing().String(), _range, altitude, aspect),
 		Speech:   fmt.Sprintf("BRAA %s, %d, %s, %s", bearing, _range, altitude, aspect),
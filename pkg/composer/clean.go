@@ -0,0 +1,29 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeCleanResponse implements [Composer.ComposeCleanResponse].
+func (c *composer) ComposeCleanResponse(response brevity.CleanResponse) NaturalLanguageResponse {
+	reply := fmt.Sprintf("%s, %s, %s.", strings.ToUpper(response.Callsign), strings.ToUpper(c.callsign), brevity.Clean)
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
+
+// ComposeNoRadarDataResponse implements [Composer.ComposeNoRadarDataResponse].
+func (c *composer) ComposeNoRadarDataResponse(response brevity.NoRadarDataResponse) NaturalLanguageResponse {
+	reply := fmt.Sprintf("%s, unable, no radar data.", strings.ToUpper(c.callsign))
+	if response.Callsign != "" {
+		reply = fmt.Sprintf("%s, %s", strings.ToUpper(response.Callsign), reply)
+	}
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
@@ -0,0 +1,26 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeCleanResponse(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Anyface"}
+	response := c.ComposeCleanResponse(brevity.CleanResponse{Callsign: "eagle 1"})
+	assert.Equal(t, "EAGLE 1, ANYFACE, clean.", response.Subtitle)
+	assert.Equal(t, "EAGLE 1, ANYFACE, clean.", response.Speech)
+}
+
+func TestComposeNoRadarDataResponse(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Anyface"}
+	response := c.ComposeNoRadarDataResponse(brevity.NoRadarDataResponse{Callsign: "eagle 1"})
+	assert.Equal(t, "EAGLE 1, ANYFACE, unable, no radar data.", response.Speech)
+
+	response = c.ComposeNoRadarDataResponse(brevity.NoRadarDataResponse{})
+	assert.Equal(t, "ANYFACE, unable, no radar data.", response.Speech)
+}
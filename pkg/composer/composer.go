@@ -16,6 +16,12 @@ type Composer interface {
 	ComposeDeclareResponse(brevity.DeclareResponse) NaturalLanguageResponse
 	// ComposeFadedCall constructs natural language brevity for announcing a contact has faded.
 	ComposeFadedCall(brevity.FadedCall) NaturalLanguageResponse
+	// ComposeVanishedCall constructs natural language brevity for announcing a faded contact has vanished.
+	ComposeVanishedCall(brevity.VanishedCall) NaturalLanguageResponse
+	// ComposeCleanResponse constructs natural language brevity for saying there are no hostile groups matching a request.
+	ComposeCleanResponse(brevity.CleanResponse) NaturalLanguageResponse
+	// ComposeNoRadarDataResponse constructs natural language brevity for saying the controller has no radar data.
+	ComposeNoRadarDataResponse(brevity.NoRadarDataResponse) NaturalLanguageResponse
 	// ComposeNegativeRadarContactResponse constructs natural language brevity for saying the controller cannot find a contact on the radar.
 	ComposeNegativeRadarContactResponse(brevity.NegativeRadarContactResponse) NaturalLanguageResponse
 	// ComposePictureResponse constructs natural language brevity for responding to a PICTURE call.
@@ -26,6 +32,10 @@ type Composer interface {
 	ComposeSnaplockResponse(brevity.SnaplockResponse) NaturalLanguageResponse
 	// ComposeSpikedResponse constructs natural language brevity for responding to a SPIKED call.
 	ComposeSpikedResponse(brevity.SpikedResponse) NaturalLanguageResponse
+	// ComposeNumerousCall constructs natural language brevity for announcing a large number of hostile contacts.
+	ComposeNumerousCall(brevity.NumerousCall) NaturalLanguageResponse
+	// ComposeSectorCleanCall constructs natural language brevity for announcing a sector is clear of hostile contacts.
+	ComposeSectorCleanCall(brevity.SectorCleanCall) NaturalLanguageResponse
 	// ComposeSunriseCall constructs natural language brevity for announcing GCI services are online.
 	ComposeSunriseCall(brevity.SunriseCall) NaturalLanguageResponse
 	// ComposeThreatCall constructs natural language brevity for announcing a threat.
@@ -49,8 +59,12 @@ type NaturalLanguageResponse struct {
 type composer struct {
 	// callsign of the GCI controller
 	callsign string
+	// gorillaThreshold is the minimum number of contacts in a group which is described as a GORILLA.
+	gorillaThreshold int
 }
 
-func New(callsign string) Composer {
-	return &composer{callsign: callsign}
+// New creates a new [Composer]. Groups with at least gorillaThreshold contacts are described as a GORILLA instead of
+// HEAVY; see [brevity.IsGorilla].
+func New(callsign string, gorillaThreshold int) Composer {
+	return &composer{callsign: callsign, gorillaThreshold: gorillaThreshold}
 }
@@ -18,6 +18,10 @@ func (c *composer) ComposeDeclareResponse(response brevity.DeclareResponse) Natu
 		}
 	}
 	info := c.ComposeCoreInformationFormat(response.Group)
+	if response.GridPosition != "" {
+		info.Subtitle = fmt.Sprintf("grid %s, %s", response.GridPosition, info.Subtitle)
+		info.Speech = fmt.Sprintf("grid %s, %s", PronounceGridPosition(response.GridPosition), info.Speech)
+	}
 	return NaturalLanguageResponse{
 		Subtitle: fmt.Sprintf("%s, %s", strings.ToUpper(response.Callsign), info.Subtitle),
 		Speech:   fmt.Sprintf("%s, %s", strings.ToUpper(response.Callsign), info.Speech),
@@ -9,6 +9,16 @@ import (
 
 // ComposeFadedCall implements [Composer.ComposeFadedCall].
 func (c *composer) ComposeFadedCall(call brevity.FadedCall) NaturalLanguageResponse {
+	return c.composeLostGroup(call.Group, "faded")
+}
+
+// ComposeVanishedCall implements [Composer.ComposeVanishedCall].
+func (c *composer) ComposeVanishedCall(call brevity.VanishedCall) NaturalLanguageResponse {
+	return c.composeLostGroup(call.Group, "vanished")
+}
+
+// composeLostGroup describes the last known position of a group which is no longer updated by sensors.
+func (c *composer) composeLostGroup(group brevity.Group, status string) NaturalLanguageResponse {
 	var subtitle, speech strings.Builder
 	writeBoth := func(s string) {
 		subtitle.WriteString(s)
@@ -16,27 +26,26 @@ func (c *composer) ComposeFadedCall(call brevity.FadedCall) NaturalLanguageRespo
 	}
 
 	writeBoth(c.callsign + ", ")
-	if call.Group.Contacts() == 1 {
-		writeBoth("single contact faded,")
-	} else {
-		writeBoth(fmt.Sprintf("%d contacts faded,", call.Group.Contacts()))
+	if contacts, ok := group.SpokenContacts(); ok {
+		writeBoth(contacts + " ")
 	}
+	writeBoth(status + ",")
 
-	if bullseye := call.Group.Bullseye(); bullseye != nil {
+	if bullseye := group.Bullseye(); bullseye != nil {
 		bullseye := c.ComposeBullseye(*bullseye)
 		subtitle.WriteString(" " + bullseye.Subtitle)
 		speech.WriteString(" " + bullseye.Speech)
 	}
 
-	if call.Group.Track() != brevity.UnknownDirection {
-		writeBoth(fmt.Sprintf(", track %s", call.Group.Track()))
+	if group.Track() != brevity.UnknownDirection {
+		writeBoth(fmt.Sprintf(", track %s", group.Track()))
 	}
 
-	if call.Group.Declaration() != brevity.Unable {
-		writeBoth(fmt.Sprintf(", %s", call.Group.Declaration()))
+	if group.Declaration() != brevity.Unable {
+		writeBoth(fmt.Sprintf(", %s", group.Declaration()))
 	}
 
-	for _, platform := range call.Group.Platforms() {
+	for _, platform := range group.Platforms() {
 		writeBoth(", " + platform)
 	}
 
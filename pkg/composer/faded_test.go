@@ -0,0 +1,29 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeFadedCall(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye"}
+	testCases := []struct {
+		contacts int
+		expected string
+	}{
+		{contacts: 0, expected: "Skyeye, faded,"},
+		{contacts: 1, expected: "Skyeye, single contact faded,"},
+		{contacts: 3, expected: "Skyeye, 3 contacts faded,"},
+	}
+	for _, test := range testCases {
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			response := c.ComposeFadedCall(brevity.FadedCall{Group: &testGroup{contacts: test.contacts}})
+			assert.Contains(t, response.Subtitle, test.expected)
+			assert.NotContains(t, response.Subtitle, "0 contacts")
+		})
+	}
+}
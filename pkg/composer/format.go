@@ -76,3 +76,29 @@ func PronounceNumbers(s string) string {
 	}
 	return builder.String()
 }
+
+// phoneticAlphabet is the NATO phonetic alphabet.
+var phoneticAlphabet = map[rune]string{
+	'A': "alpha", 'B': "bravo", 'C': "charlie", 'D': "delta", 'E': "echo", 'F': "foxtrot", 'G': "golf",
+	'H': "hotel", 'I': "india", 'J': "juliett", 'K': "kilo", 'L': "lima", 'M': "mike", 'N': "november",
+	'O': "oscar", 'P': "papa", 'Q': "quebec", 'R': "romeo", 'S': "sierra", 'T': "tango", 'U': "uniform",
+	'V': "victor", 'W': "whiskey", 'X': "x-ray", 'Y': "yankee", 'Z': "zulu",
+}
+
+// PronounceGridPosition composes a text representation of an MGRS grid reference, such as "38T LM 123 456". Digits are
+// pronounced individually and letters using the NATO phonetic alphabet, with a pause between each part.
+func PronounceGridPosition(grid string) string {
+	parts := strings.Fields(strings.ToUpper(grid))
+	for i, part := range parts {
+		words := make([]string, 0, len(part))
+		for _, r := range part {
+			if word, ok := phoneticAlphabet[r]; ok {
+				words = append(words, word)
+			} else {
+				words = append(words, string(r))
+			}
+		}
+		parts[i] = strings.Join(words, " ")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -71,3 +71,20 @@ func TestPronounceDecimal(t *testing.T) {
 		})
 	}
 }
+
+func TestPronounceGridPosition(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		arg    string
+		expect string
+	}{
+		{arg: "38T LM 123 456", expect: "3 8 tango, lima mike, 1 2 3, 4 5 6"},
+		{arg: "lm 12 34", expect: "lima mike, 1 2, 3 4"},
+	}
+	for _, test := range testCases {
+		t.Run(test.arg, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, test.expect, PronounceGridPosition(test.arg))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package composer
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"slices"
@@ -37,6 +38,19 @@ func (c *composer) ComposeCoreInformationFormat(groups ...brevity.Group) Natural
 }
 
 func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
+	return c.composeNamedGroup(group, "Group")
+}
+
+// composeLabeledGroup communicates information about a group, identifying it with the given label.
+func (c *composer) composeLabeledGroup(group brevity.Group, groupLabel brevity.GroupLabel) NaturalLanguageResponse {
+	if groupLabel == brevity.UnlabeledGroup {
+		return c.ComposeGroup(group)
+	}
+	return c.composeNamedGroup(group, strings.ToUpper(string(groupLabel[:1]))+string(groupLabel[1:])+" group")
+}
+
+// composeNamedGroup communicates information about a group, introducing it with the given name.
+func (c *composer) composeNamedGroup(group brevity.Group, name string) NaturalLanguageResponse {
 	if group.BRAA() != nil && !group.BRAA().Bearing().IsMagnetic() {
 		log.Error().Stringer("bearing", group.BRAA().Bearing()).Msg("bearing provided to ComposeGroup should be magnetic")
 	}
@@ -49,76 +63,69 @@ func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
 		subtitle.WriteString(s)
 	}
 
-	label := "Group"
+	label := name
+	if group.Popup() {
+		label = "Popup " + strings.ToLower(label[:1]) + label[1:]
+	}
 	if group.Threat() {
-		label = "Group threat"
+		label += " threat"
 	}
 
-	// Group location, altitude, and track direction or specific aspect
 	stacks := group.Stacks()
-	if bullseye := group.Bullseye(); bullseye != nil {
-		bullseye := c.ComposeBullseye(*bullseye)
-		altitude := c.ComposeAltitudeStacks(stacks, group.Declaration())
-		speech.WriteString(fmt.Sprintf("%s %s, %s", label, bullseye.Speech, altitude))
-		subtitle.WriteString(fmt.Sprintf("%s %s, %s", label, bullseye.Subtitle, altitude))
-		if group.Track() != brevity.UnknownDirection {
-			writeBoth(fmt.Sprintf(", track %s", group.Track()))
-		}
-	} else if group.BRAA() != nil {
-		braa := c.ComposeBRAA(group.BRAA(), group.Declaration())
-		speech.WriteString(fmt.Sprintf("%s %s", label, braa.Speech))
-		subtitle.WriteString(fmt.Sprintf("%s %s", label, braa.Subtitle))
-		isCardinalAspect := slices.Contains([]brevity.Aspect{brevity.Flank, brevity.Beam, brevity.Drag}, group.BRAA().Aspect())
-		isTrackKnown := group.Track() != brevity.UnknownDirection
-		isFurball := group.Declaration() == brevity.Furball
-		if isCardinalAspect && isTrackKnown && !isFurball {
-			writeBoth(fmt.Sprintf(" %s", group.Track()))
-		}
-	}
-
-	// Declaration
-	writeBoth(fmt.Sprintf(", %s", group.Declaration()))
-	if group.MergedWith() == 1 {
-		writeBoth(", merged with 1 friendly")
-	}
-	if group.MergedWith() > 1 {
-		writeBoth(fmt.Sprintf(", merged with %d friendlies", group.MergedWith()))
-	}
-
-	// Fill-in information
-
-	// Heavy and number of contacts
-	if group.Heavy() {
-		writeBoth(", heavy")
-	}
-	contacts := c.ComposeContacts(group.Contacts())
-	subtitle.WriteString(contacts.Subtitle)
-	speech.WriteString(contacts.Speech)
-
-	if !group.High() {
-		if len(stacks) > 1 {
+	for _, fillIn := range brevity.FillIns(group) {
+		switch fillIn {
+		case brevity.PositionFillIn:
+			var location NaturalLanguageResponse
+			if bullseye := group.Bullseye(); bullseye != nil {
+				location = c.ComposeBullseye(*bullseye)
+			} else {
+				location = c.ComposeBRAA(group.BRAA(), group.Declaration())
+			}
+			speech.WriteString(fmt.Sprintf("%s %s", label, location.Speech))
+			subtitle.WriteString(fmt.Sprintf("%s %s", label, location.Subtitle))
+		case brevity.EstimatedFillIn:
+			writeBoth(", estimated position")
+		case brevity.AltitudeFillIn:
+			writeBoth(", " + c.ComposeAltitudeStacks(stacks, group.Declaration()))
+		case brevity.TrackFillIn:
+			if group.Bullseye() != nil {
+				writeBoth(fmt.Sprintf(", track %s", group.Track()))
+			} else {
+				writeBoth(fmt.Sprintf(" %s", group.Track()))
+			}
+		case brevity.ClosureFillIn:
+			writeBoth(fmt.Sprintf(", %s", group.Closure()))
+		case brevity.DeclarationFillIn:
+			writeBoth(fmt.Sprintf(", %s", group.Declaration()))
+		case brevity.MergedFillIn:
+			if group.MergedWith() == 1 {
+				writeBoth(", merged with 1 friendly")
+			} else {
+				writeBoth(fmt.Sprintf(", merged with %d friendlies", group.MergedWith()))
+			}
+		case brevity.HeavyFillIn:
+			if brevity.IsGorilla(group.Contacts(), c.gorillaThreshold) {
+				writeBoth(", gorilla")
+			} else {
+				writeBoth(", heavy")
+			}
+		case brevity.ContactsFillIn:
+			contacts := c.ComposeContacts(group.Contacts())
+			subtitle.WriteString(contacts.Subtitle)
+			speech.WriteString(contacts.Speech)
+		case brevity.StackFillIn:
 			writeBoth(", " + c.ComposeAltitudeFillIns(stacks))
+		case brevity.PlatformFillIn:
+			writeBoth(", " + strings.Join(group.Platforms(), ", "))
+		case brevity.HighFillIn:
+			writeBoth(", high")
+		case brevity.FastFillIn:
+			writeBoth(", fast")
+		case brevity.VeryFastFillIn:
+			writeBoth(", very fast")
 		}
 	}
 
-	// Platform
-	if len(group.Platforms()) > 0 {
-		writeBoth(", ")
-		writeBoth(strings.Join(group.Platforms(), ", "))
-	}
-
-	// High
-	if group.High() {
-		writeBoth(", high")
-	}
-
-	// Fast or very fast
-	if group.Fast() {
-		writeBoth(", fast")
-	} else if group.VeryFast() {
-		writeBoth(", very fast")
-	}
-
 	writeBoth(".")
 
 	return NaturalLanguageResponse{
@@ -132,8 +139,8 @@ func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
 func (c *composer) ComposeContacts(n int) NaturalLanguageResponse {
 	// single contact is assumed if unspecified
 	s := ""
-	if n > 1 {
-		s = fmt.Sprintf(", %d contacts", n)
+	if contacts, ok := brevity.SpokenContacts(n); ok && n > 1 {
+		s = ", " + contacts
 	}
 	return NaturalLanguageResponse{
 		Subtitle: s,
@@ -141,41 +148,65 @@ func (c *composer) ComposeContacts(n int) NaturalLanguageResponse {
 	}
 }
 
+// ComposeAltitudeStacks describes the altitude STACKS of a group, from the highest layer to the lowest regardless of the
+// order of the given stacks:
+//   - A single layer is described by its altitude, e.g. "15000"
+//   - Two layers are described by their altitudes, e.g. "stack 20000 and 12000"
+//   - Three or more layers include the number of contacts in each layer, e.g. "stack 24000, 3 contacts, 18000, 2
+//     contacts, 6000, single contact"
 func (c *composer) ComposeAltitudeStacks(stacks []brevity.Stack, declaration brevity.Declaration) string {
 	if len(stacks) == 0 {
 		return "altitude unknown"
 	}
+	stacks = slices.Clone(stacks)
+	slices.SortStableFunc(stacks, func(a, b brevity.Stack) int {
+		return cmp.Compare(b.Altitude, a.Altitude)
+	})
 
 	if len(stacks) == 1 {
-		return c.ComposeAltitude(stacks[0].Altitude, declaration)
+		return c.composeKnownAltitude(stacks[0].Altitude, declaration)
 	}
 
-	s := "stack " + c.ComposeAltitude(stacks[0].Altitude, declaration)
-	for i := 1; i < len(stacks)-1; i++ {
-		s += ", " + c.ComposeAltitude(stacks[i].Altitude, declaration)
+	if len(stacks) == 2 {
+		return fmt.Sprintf(
+			"stack %s and %s",
+			c.composeKnownAltitude(stacks[0].Altitude, declaration),
+			c.composeKnownAltitude(stacks[1].Altitude, declaration),
+		)
+	}
+
+	layers := make([]string, 0, len(stacks))
+	for _, stack := range stacks {
+		layer := c.composeKnownAltitude(stack.Altitude, declaration)
+		if contacts, ok := brevity.SpokenContacts(stack.Count); ok {
+			layer += ", " + contacts
+		}
+		layers = append(layers, layer)
 	}
-	s += " and " + c.ComposeAltitude(stacks[len(stacks)-1].Altitude, declaration)
-	return s
+	return "stack " + strings.Join(layers, ", ")
 }
 
+// ComposeAltitudeFillIns describes the number of contacts in each of two altitude STACKS. Three or more STACKS are
+// described with their number of contacts by [composer.ComposeAltitudeStacks] instead.
 func (c *composer) ComposeAltitudeFillIns(stacks []brevity.Stack) string {
 	if len(stacks) == 2 {
 		return fmt.Sprintf("%d high, %d low", stacks[0].Count, stacks[1].Count)
 	}
-
-	if len(stacks) == 3 {
-		return fmt.Sprintf("%d high, %d medium, %d low", stacks[0].Count, stacks[1].Count, stacks[2].Count)
-	}
 	return ""
 }
 
+// ComposeAltitude describes an altitude. An altitude of zero is treated as unknown.
 func (c *composer) ComposeAltitude(altitude unit.Length, declaration brevity.Declaration) string {
-	hundreds := int(math.Round(altitude.Feet() / 100))
-	thousands := int(math.Round(altitude.Feet() / 1000))
-	if hundreds == 0 {
+	if int(math.Round(altitude.Feet()/100)) == 0 {
 		return "altitude unknown"
 	}
+	return c.composeKnownAltitude(altitude, declaration)
+}
 
+// composeKnownAltitude describes an altitude which is known, such as a STACK layer. An altitude of zero is on the deck.
+func (c *composer) composeKnownAltitude(altitude unit.Length, declaration brevity.Declaration) string {
+	hundreds := int(math.Round(altitude.Feet() / 100))
+	thousands := int(math.Round(altitude.Feet() / 1000))
 	if declaration == brevity.Friendly {
 		if altitude < 1000*unit.Foot {
 			return fmt.Sprintf("cherubs %d", hundreds)
@@ -183,8 +214,8 @@ func (c *composer) ComposeAltitude(altitude unit.Length, declaration brevity.Dec
 		return fmt.Sprintf("angels %d", thousands)
 	}
 
-	if altitude < 1000*unit.Foot {
-		return strconv.Itoa(hundreds * 100)
+	if altitude < brevity.DeckAltitude {
+		return "on the deck"
 	}
 	return strconv.Itoa(thousands * 1000)
 }
@@ -0,0 +1,177 @@
+package composer
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+type testGroup struct {
+	brevity.Group
+	contacts  int
+	estimated bool
+	popup     bool
+}
+
+func (g *testGroup) Contacts() int { return g.contacts }
+func (g *testGroup) SpokenContacts() (string, bool) {
+	return brevity.SpokenContacts(g.contacts)
+}
+func (g *testGroup) Bullseye() *brevity.Bullseye {
+	return brevity.NewBullseye(bearings.NewMagneticBearing(0), 0)
+}
+func (g *testGroup) BRAA() brevity.BRAA { return nil }
+func (g *testGroup) Threat() bool       { return false }
+func (g *testGroup) Stacks() []brevity.Stack {
+	return []brevity.Stack{{Altitude: 20000 * unit.Foot, Count: g.contacts}}
+}
+func (g *testGroup) Track() brevity.Track             { return brevity.UnknownDirection }
+func (g *testGroup) Declaration() brevity.Declaration { return brevity.Hostile }
+func (g *testGroup) Heavy() bool                      { return brevity.IsHeavy(g.contacts) }
+func (g *testGroup) Platforms() []string              { return nil }
+func (g *testGroup) High() bool                       { return false }
+func (g *testGroup) Fast() bool                       { return false }
+func (g *testGroup) VeryFast() bool                   { return false }
+func (g *testGroup) MergedWith() int                  { return 0 }
+func (g *testGroup) Extrapolated() bool               { return g.estimated }
+func (g *testGroup) Popup() bool                      { return g.popup }
+
+func TestComposeGroupQualifiers(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		contacts int
+		expected string
+	}{
+		{contacts: 1, expected: "Group at bullseye, 20000, hostile."},
+		{contacts: 2, expected: "Group at bullseye, 20000, hostile, 2 contacts."},
+		{contacts: 3, expected: "Group at bullseye, 20000, hostile, heavy, 3 contacts."},
+		{contacts: 4, expected: "Group at bullseye, 20000, hostile, heavy, 4 contacts."},
+		{contacts: 5, expected: "Group at bullseye, 20000, hostile, heavy, 5 contacts."},
+		{contacts: 6, expected: "Group at bullseye, 20000, hostile, gorilla, 6 contacts."},
+		{contacts: 10, expected: "Group at bullseye, 20000, hostile, gorilla, 10 contacts."},
+	}
+	c := &composer{callsign: "Skyeye", gorillaThreshold: brevity.DefaultGorillaThreshold}
+	for _, test := range testCases {
+		t.Run(strconv.Itoa(test.contacts), func(t *testing.T) {
+			t.Parallel()
+			response := c.ComposeGroup(&testGroup{contacts: test.contacts})
+			assert.Equal(t, test.expected, response.Subtitle)
+			assert.Equal(t, test.expected, response.Speech)
+		})
+	}
+}
+
+func TestComposeGroupGorillaDisabled(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye", gorillaThreshold: 0}
+	response := c.ComposeGroup(&testGroup{contacts: 10})
+	assert.Equal(t, "Group at bullseye, 20000, hostile, heavy, 10 contacts.", response.Subtitle)
+}
+
+func TestComposeGroupEstimatedPosition(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye", gorillaThreshold: brevity.DefaultGorillaThreshold}
+	response := c.ComposeGroup(&testGroup{contacts: 1, estimated: true})
+	assert.Equal(t, "Group at bullseye, estimated position, 20000, hostile.", response.Subtitle)
+}
+
+func TestComposeGroupPopup(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye", gorillaThreshold: brevity.DefaultGorillaThreshold}
+	response := c.ComposeGroup(&testGroup{contacts: 2, popup: true})
+	assert.Equal(t, "Popup group at bullseye, 20000, hostile, 2 contacts.", response.Subtitle)
+	assert.Equal(t, "Popup group at bullseye, 20000, hostile, 2 contacts.", response.Speech)
+}
+
+func TestComposeAltitudeStacksOnTheDeck(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye"}
+	stacks := brevity.Stacks(0, 20000*unit.Foot)
+	assert.Equal(t, "stack 20000 and on the deck", c.ComposeAltitudeStacks(stacks, brevity.Hostile))
+	assert.Equal(t, "on the deck", c.ComposeAltitudeStacks(brevity.Stacks(0), brevity.Hostile))
+	assert.Equal(t, "altitude unknown", c.ComposeAltitudeStacks(brevity.Stacks(), brevity.Hostile))
+
+	braa := brevity.NewBRAA(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile, []unit.Length{0}, brevity.Hot)
+	assert.Equal(t, "BRAA 090/20, on the deck, hot", c.ComposeBRAA(braa, brevity.Hostile).Subtitle)
+}
+
+func TestComposeAltitudeStacks(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		stacks   []brevity.Stack
+		expected string
+	}{
+		{
+			name:     "1 layer",
+			stacks:   []brevity.Stack{{Altitude: 15000 * unit.Foot, Count: 2}},
+			expected: "15000",
+		},
+		{
+			name: "2 layers",
+			stacks: []brevity.Stack{
+				{Altitude: 20000 * unit.Foot, Count: 2},
+				{Altitude: 12000 * unit.Foot, Count: 1},
+			},
+			expected: "stack 20000 and 12000",
+		},
+		{
+			name: "2 layers given low to high",
+			stacks: []brevity.Stack{
+				{Altitude: 12000 * unit.Foot, Count: 1},
+				{Altitude: 20000 * unit.Foot, Count: 2},
+			},
+			expected: "stack 20000 and 12000",
+		},
+		{
+			name: "3 layers",
+			stacks: []brevity.Stack{
+				{Altitude: 24000 * unit.Foot, Count: 3},
+				{Altitude: 18000 * unit.Foot, Count: 2},
+				{Altitude: 6000 * unit.Foot, Count: 1},
+			},
+			expected: "stack 24000, 3 contacts, 18000, 2 contacts, 6000, single contact",
+		},
+		{
+			name: "3 layers given out of order",
+			stacks: []brevity.Stack{
+				{Altitude: 6000 * unit.Foot, Count: 1},
+				{Altitude: 36000 * unit.Foot, Count: 2},
+				{Altitude: 22000 * unit.Foot, Count: 1},
+			},
+			expected: "stack 36000, 2 contacts, 22000, single contact, 6000, single contact",
+		},
+		{
+			name: "5 layers",
+			stacks: []brevity.Stack{
+				{Altitude: 3000 * unit.Foot, Count: 1},
+				{Altitude: 41000 * unit.Foot, Count: 2},
+				{Altitude: 12000 * unit.Foot, Count: 4},
+				{Altitude: 0, Count: 2},
+				{Altitude: 25000 * unit.Foot, Count: 1},
+			},
+			expected: "stack 41000, 2 contacts, 25000, single contact, 12000, 4 contacts, 3000, single contact, on the deck, 2 contacts",
+		},
+	}
+	c := &composer{callsign: "Skyeye"}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, c.ComposeAltitudeStacks(test.stacks, brevity.Hostile))
+		})
+	}
+}
+
+func TestComposeAltitudeStacksMatchesStacksOrder(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye"}
+	stacks := brevity.Stacks(6000*unit.Foot, 36000*unit.Foot, 22000*unit.Foot, 36000*unit.Foot)
+	for i := 1; i < len(stacks); i++ {
+		assert.Greater(t, stacks[i-1].Altitude, stacks[i].Altitude, "Stacks should be ordered from highest to lowest")
+	}
+	assert.Equal(t, "stack 36000, 2 contacts, 22000, single contact, 6000, single contact", c.ComposeAltitudeStacks(stacks, brevity.Hostile))
+}
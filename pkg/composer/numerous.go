@@ -0,0 +1,20 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeNumerousCall implements [Composer.ComposeNumerousCall].
+func (c *composer) ComposeNumerousCall(call brevity.NumerousCall) NaturalLanguageResponse {
+	groups := fmt.Sprintf("%d groups", call.Groups)
+	if call.Groups == 1 {
+		groups = "single group"
+	}
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, PICTURE, %s, NUMEROUS.", c.callsign, strings.ToUpper(groups)),
+		Speech:   fmt.Sprintf("%s, picture, %s, numerous.", c.callsign, groups),
+	}
+}
@@ -0,0 +1,19 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeNumerousCall(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye"}
+	response := c.ComposeNumerousCall(brevity.NumerousCall{Groups: 7, Contacts: 20})
+	assert.Equal(t, "Skyeye, picture, 7 groups, numerous.", response.Speech)
+	assert.Equal(t, "Skyeye, PICTURE, 7 GROUPS, NUMEROUS.", response.Subtitle)
+
+	response = c.ComposeNumerousCall(brevity.NumerousCall{Groups: 1, Contacts: 20})
+	assert.Equal(t, "Skyeye, picture, single group, numerous.", response.Speech)
+}
@@ -2,6 +2,7 @@ package composer
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
@@ -9,7 +10,6 @@ import (
 
 // ComposePictureResponse implements [Composer.ComposePictureResponse].
 func (c *composer) ComposePictureResponse(response brevity.PictureResponse) NaturalLanguageResponse {
-	info := c.ComposeCoreInformationFormat(response.Groups...)
 	if response.Count == 0 {
 		return NaturalLanguageResponse{
 			Subtitle: fmt.Sprintf("%s, %s.", strings.ToUpper(c.callsign), brevity.Clean),
@@ -21,6 +21,42 @@ func (c *composer) ComposePictureResponse(response brevity.PictureResponse) Natu
 	if response.Count > 1 {
 		groupCountFillIn = fmt.Sprintf("%d groups.", response.Count)
 	}
+	switch response.Formation {
+	case brevity.NoFormation:
+	case brevity.LadderFormation:
+		separations := make([]string, 0, len(response.Separations))
+		for _, separation := range response.Separations {
+			separations = append(separations, strconv.Itoa(int(separation.NauticalMiles())))
+		}
+		groupCountFillIn = fmt.Sprintf("%s, %d groups, %s.", response.Formation, response.Count, strings.Join(separations, ", "))
+	case brevity.VicFormation:
+		groupCountFillIn = fmt.Sprintf(
+			"%d groups, %s, %d deep, %d wide.",
+			response.Count,
+			response.Formation,
+			int(response.Depth.NauticalMiles()),
+			int(response.Width.NauticalMiles()),
+		)
+	case brevity.ChampagneFormation:
+		groupCountFillIn = fmt.Sprintf(
+			"%d groups, %s, %d wide, %d deep.",
+			response.Count,
+			response.Formation,
+			int(response.Width.NauticalMiles()),
+			int(response.Depth.NauticalMiles()),
+		)
+	case brevity.WallFormation:
+		groupCountFillIn = fmt.Sprintf("%d groups, %s, %d wide.", response.Count, response.Formation, int(response.Width.NauticalMiles()))
+	default:
+		groupCountFillIn = fmt.Sprintf(
+			"%d groups, %s %d.",
+			response.Count,
+			response.Formation,
+			int(response.Separation.NauticalMiles()),
+		)
+	}
+
+	info := c.composeLabeledGroups(response.Groups, response.Labels)
 
 	info.Speech = strings.TrimSpace(info.Speech)
 	info.Subtitle = strings.TrimSpace(info.Subtitle)
@@ -30,3 +66,18 @@ func (c *composer) ComposePictureResponse(response brevity.PictureResponse) Natu
 		Speech:   fmt.Sprintf("%s, %s %s", strings.ToUpper(c.callsign), groupCountFillIn, info.Speech),
 	}
 }
+
+// composeLabeledGroups communicates information about each group, identifying them with the corresponding labels.
+func (c *composer) composeLabeledGroups(groups []brevity.Group, labels []brevity.GroupLabel) NaturalLanguageResponse {
+	response := NaturalLanguageResponse{}
+	for i, group := range groups {
+		label := brevity.UnlabeledGroup
+		if i < len(labels) {
+			label = labels[i]
+		}
+		groupResponse := c.composeLabeledGroup(group, label)
+		response.Speech += groupResponse.Speech + " "
+		response.Subtitle += groupResponse.Subtitle + " "
+	}
+	return response
+}
@@ -0,0 +1,118 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposePictureResponse(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye", gorillaThreshold: brevity.DefaultGorillaThreshold}
+	testCases := []struct {
+		name     string
+		response brevity.PictureResponse
+		expected string
+	}{
+		{
+			name:     "clean",
+			response: brevity.PictureResponse{},
+			expected: "SKYEYE, clean.",
+		},
+		{
+			name: "single group",
+			response: brevity.PictureResponse{
+				Count:  1,
+				Groups: []brevity.Group{&testGroup{contacts: 1}},
+				Labels: []brevity.GroupLabel{brevity.UnlabeledGroup},
+			},
+			expected: "SKYEYE, single group. Group at bullseye, 20000, hostile.",
+		},
+		{
+			name: "azimuth",
+			response: brevity.PictureResponse{
+				Count:      2,
+				Groups:     []brevity.Group{&testGroup{contacts: 1}, &testGroup{contacts: 2}},
+				Formation:  brevity.AzimuthFormation,
+				Separation: 12 * unit.NauticalMile,
+				Labels:     []brevity.GroupLabel{brevity.NorthGroup, brevity.SouthGroup},
+			},
+			expected: "SKYEYE, 2 groups, azimuth 12. North group at bullseye, 20000, hostile. South group at bullseye, 20000, hostile, 2 contacts.",
+		},
+		{
+			name: "range",
+			response: brevity.PictureResponse{
+				Count:      2,
+				Groups:     []brevity.Group{&testGroup{contacts: 1}, &testGroup{contacts: 1}},
+				Formation:  brevity.RangeFormation,
+				Separation: 20 * unit.NauticalMile,
+				Labels:     []brevity.GroupLabel{brevity.LeadGroup, brevity.TrailGroup},
+			},
+			expected: "SKYEYE, 2 groups, range 20. Lead group at bullseye, 20000, hostile. Trail group at bullseye, 20000, hostile.",
+		},
+		{
+			name: "ladder",
+			response: brevity.PictureResponse{
+				Count:       3,
+				Groups:      []brevity.Group{&testGroup{contacts: 1}, &testGroup{contacts: 2}, &testGroup{contacts: 1}},
+				Formation:   brevity.LadderFormation,
+				Separations: []unit.Length{15 * unit.NauticalMile, 12 * unit.NauticalMile},
+				Labels:      []brevity.GroupLabel{brevity.LeadGroup, brevity.MiddleGroup, brevity.TrailGroup},
+			},
+			expected: "SKYEYE, ladder, 3 groups, 15, 12. Lead group at bullseye, 20000, hostile. Middle group at bullseye, 20000, hostile, 2 contacts. Trail group at bullseye, 20000, hostile.",
+		},
+		{
+			name: "vic",
+			response: brevity.PictureResponse{
+				Count:     3,
+				Groups:    []brevity.Group{&testGroup{contacts: 1}, &testGroup{contacts: 1}, &testGroup{contacts: 1}},
+				Formation: brevity.VicFormation,
+				Depth:     10 * unit.NauticalMile,
+				Width:     20 * unit.NauticalMile,
+				Labels:    []brevity.GroupLabel{brevity.LeadGroup, "north trail", "south trail"},
+			},
+			expected: "SKYEYE, 3 groups, vic, 10 deep, 20 wide. Lead group at bullseye, 20000, hostile. North trail group at bullseye, 20000, hostile. South trail group at bullseye, 20000, hostile.",
+		},
+		{
+			name: "champagne",
+			response: brevity.PictureResponse{
+				Count:     3,
+				Groups:    []brevity.Group{&testGroup{contacts: 1}, &testGroup{contacts: 1}, &testGroup{contacts: 1}},
+				Formation: brevity.ChampagneFormation,
+				Depth:     15 * unit.NauticalMile,
+				Width:     10 * unit.NauticalMile,
+				Labels:    []brevity.GroupLabel{"east lead", "west lead", brevity.TrailGroup},
+			},
+			expected: "SKYEYE, 3 groups, champagne, 10 wide, 15 deep. East lead group at bullseye, 20000, hostile. West lead group at bullseye, 20000, hostile. Trail group at bullseye, 20000, hostile.",
+		},
+		{
+			name: "wall",
+			response: brevity.PictureResponse{
+				Count:     3,
+				Groups:    []brevity.Group{&testGroup{contacts: 1}, &testGroup{contacts: 1}, &testGroup{contacts: 1}},
+				Formation: brevity.WallFormation,
+				Width:     40 * unit.NauticalMile,
+				Labels:    []brevity.GroupLabel{brevity.NorthGroup, brevity.MiddleGroup, brevity.SouthGroup},
+			},
+			expected: "SKYEYE, 3 groups, wall, 40 wide. North group at bullseye, 20000, hostile. Middle group at bullseye, 20000, hostile. South group at bullseye, 20000, hostile.",
+		},
+		{
+			name: "three groups",
+			response: brevity.PictureResponse{
+				Count:  4,
+				Groups: []brevity.Group{&testGroup{contacts: 1}, &testGroup{contacts: 1}, &testGroup{contacts: 1}},
+				Labels: []brevity.GroupLabel{brevity.WestGroup, brevity.EastGroup, brevity.EastGroup},
+			},
+			expected: "SKYEYE, 4 groups. West group at bullseye, 20000, hostile. East group at bullseye, 20000, hostile. East group at bullseye, 20000, hostile.",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			response := c.ComposePictureResponse(test.response)
+			assert.Equal(t, test.expected, response.Subtitle)
+		})
+	}
+}
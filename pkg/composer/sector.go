@@ -0,0 +1,15 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeSectorCleanCall implements [Composer.ComposeSectorCleanCall].
+func (c *composer) ComposeSectorCleanCall(call brevity.SectorCleanCall) NaturalLanguageResponse {
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, PICTURE CLEAN, SECTOR %s.", c.callsign, call.Sector),
+		Speech:   fmt.Sprintf("%s, picture clean, sector %s.", c.callsign, call.Sector),
+	}
+}
@@ -0,0 +1,16 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeSectorCleanCall(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye"}
+	response := c.ComposeSectorCleanCall(brevity.SectorCleanCall{Sector: "North"})
+	assert.Equal(t, "Skyeye, picture clean, sector North.", response.Speech)
+	assert.Equal(t, "Skyeye, PICTURE CLEAN, SECTOR North.", response.Subtitle)
+}
@@ -18,6 +18,14 @@ func (c *composer) ComposeSnaplockResponse(response brevity.SnaplockResponse) Na
 	}
 
 	reply := fmt.Sprintf("%s, %s", strings.ToUpper(response.Callsign), response.Declaration)
+	if response.Declaration == brevity.Clean && response.Nearest != nil && response.NearestOffset != nil {
+		offset := response.NearestOffset
+		_range := int(offset.Range().NauticalMiles())
+		return NaturalLanguageResponse{
+			Subtitle: fmt.Sprintf("%s. Nearest group %s/%d from your point.", reply, offset.Bearing().String(), _range),
+			Speech:   fmt.Sprintf("%s. Nearest group %s, %d from your point.", reply, PronounceBearing(offset.Bearing()), _range),
+		}
+	}
 	return NaturalLanguageResponse{
 		Subtitle: reply,
 		Speech:   reply,
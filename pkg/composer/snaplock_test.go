@@ -0,0 +1,34 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeSnaplockResponseClean(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Anyface"}
+	response := c.ComposeSnaplockResponse(brevity.SnaplockResponse{Callsign: "eagle 1", Declaration: brevity.Clean})
+	assert.Equal(t, "EAGLE 1, clean", response.Subtitle)
+	assert.Equal(t, "EAGLE 1, clean", response.Speech)
+
+	response = c.ComposeSnaplockResponse(brevity.SnaplockResponse{
+		Callsign:      "eagle 1",
+		Declaration:   brevity.Clean,
+		Nearest:       &testGroup{contacts: 1},
+		NearestOffset: brevity.NewBRA(bearings.NewMagneticBearing(45*unit.Degree), 5*unit.NauticalMile, 20000*unit.Foot),
+	})
+	assert.Equal(t, "EAGLE 1, clean. Nearest group 045/5 from your point.", response.Subtitle)
+	assert.Equal(t, "EAGLE 1, clean. Nearest group 0 4 5, 5 from your point.", response.Speech)
+}
+
+func TestComposeSnaplockResponseUnable(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Anyface"}
+	response := c.ComposeSnaplockResponse(brevity.SnaplockResponse{Callsign: "eagle 1", Declaration: brevity.Unable})
+	assert.Equal(t, "EAGLE 1, unable", response.Speech)
+}
@@ -23,10 +23,8 @@ func (c *composer) ComposeSpikedResponse(response brevity.SpikedResponse) Natura
 			reply = fmt.Sprintf("%s %s", reply, response.Track)
 		}
 		reply = fmt.Sprintf("%s, %s", reply, response.Declaration)
-		if response.Contacts == 1 {
-			reply += ", single contact."
-		} else if response.Contacts > 1 {
-			reply = fmt.Sprintf("%s, %d contacts.", reply, response.Contacts)
+		if contacts, ok := brevity.SpokenContacts(response.Contacts); ok {
+			reply = fmt.Sprintf("%s, %s.", reply, contacts)
 		}
 		return NaturalLanguageResponse{
 			Subtitle: reply,
@@ -40,8 +38,14 @@ func (c *composer) ComposeSpikedResponse(response brevity.SpikedResponse) Natura
 			Speech:   message,
 		}
 	}
-	return NaturalLanguageResponse{
+	reply := NaturalLanguageResponse{
 		Subtitle: fmt.Sprintf("%s, %s clean %d.", strings.ToUpper(response.Callsign), strings.ToUpper(c.callsign), int(response.Bearing.Degrees())),
 		Speech:   fmt.Sprintf("%s, %s, clean - %s", strings.ToUpper(response.Callsign), strings.ToUpper(c.callsign), PronounceBearing(response.Bearing)),
 	}
+	if response.NearestGroup != nil && response.NearestGroup.Bullseye() != nil {
+		nearest := c.composeNamedGroup(response.NearestGroup, "Closest group")
+		reply.Subtitle += " " + nearest.Subtitle
+		reply.Speech += ". " + nearest.Speech
+	}
+	return reply
 }
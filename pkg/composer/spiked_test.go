@@ -0,0 +1,22 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeSpikedResponseNegative(t *testing.T) {
+	t.Parallel()
+	c := &composer{callsign: "Skyeye", gorillaThreshold: brevity.DefaultGorillaThreshold}
+	spike := bearings.NewMagneticBearing(90 * unit.Degree)
+
+	response := c.ComposeSpikedResponse(brevity.NewSpikedResponse("Mobius 1", spike, nil, nil))
+	assert.Equal(t, "MOBIUS 1, SKYEYE clean 90.", response.Subtitle)
+
+	response = c.ComposeSpikedResponse(brevity.NewSpikedResponse("Mobius 1", spike, nil, &testGroup{contacts: 2}))
+	assert.Equal(t, "MOBIUS 1, SKYEYE clean 90. Closest group at bullseye, 20000, hostile, 2 contacts.", response.Subtitle)
+}
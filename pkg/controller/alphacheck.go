@@ -21,10 +21,18 @@ func (c *controller) HandleAlphaCheck(ctx context.Context, request *brevity.Alph
 		return
 	}
 	bullseye := c.scope.Bullseye(trackfile.Contact.Coalition)
-	location := trackfile.Bullseye(bullseye)
-	c.calls <- NewCall(ctx, brevity.AlphaCheckResponse{
-		Callsign: foundCallsign,
-		Status:   true,
-		Location: location,
-	})
+	variation := c.scope.MagneticVariation()
+	latest := trackfile.LastKnown()
+	response := brevity.NewAlphaCheckResponse(
+		foundCallsign,
+		latest.Point,
+		latest.Altitude,
+		trackfile.Course(variation),
+		bullseye,
+		variation.Declination(bullseye),
+	)
+	if trackfile.Direction(variation) == brevity.UnknownDirection {
+		response.Track = brevity.UnknownDirection
+	}
+	c.calls <- NewCall(ctx, response)
 }
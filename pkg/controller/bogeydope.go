@@ -2,8 +2,12 @@ package controller
 
 import (
 	"context"
+	"slices"
 
+	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
@@ -22,27 +26,56 @@ func (c *controller) HandleBogeyDope(ctx context.Context, request *brevity.Bogey
 
 	origin := trackfile.LastKnown().Point
 	radius := 300 * unit.NauticalMile
-	nearestGroup := c.scope.FindNearestGroupWithBRAA(
+	groups := c.scope.FindNearbyGroupsWithBRAA(
+		origin,
 		origin,
 		lowestAltitude,
 		highestAltitude,
 		radius,
 		c.coalition.Opposite(),
 		request.Filter,
+		c.outsideGeoFences(c.scope.Positions(c.coalition.Opposite())),
 	)
 
-	if nearestGroup == nil {
+	if len(groups) == 0 {
 		logger.Info().Msg("no hostile groups found")
-		c.calls <- NewCall(ctx, brevity.BogeyDopeResponse{Callsign: foundCallsign, Group: nil})
+		c.calls <- NewCall(ctx, noHostilesResponse(foundCallsign, c.scope.ContactCount()))
 		return
 	}
 
-	nearestGroup.SetDeclaration(brevity.Hostile)
-	c.fillInMergeDetails(nearestGroup)
+	for _, group := range groups {
+		group.SetThreat(group.BRAA().Range() < c.scope.MandatoryThreatRadius())
+		group.SetClosure(c.closure(trackfile, group))
+	}
+	highestThreat := slices.MinFunc(groups, brevity.CompareThreatRank(trackfile.LastKnown().Altitude))
+	highestThreat.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(highestThreat)
 
 	logger.Info().
-		Strs("platforms", nearestGroup.Platforms()).
-		Str("aspect", string(nearestGroup.Aspect())).
-		Msg("found nearest hostile group")
-	c.calls <- NewCall(ctx, brevity.BogeyDopeResponse{Callsign: foundCallsign, Group: nearestGroup})
+		Strs("platforms", highestThreat.Platforms()).
+		Str("aspect", string(highestThreat.BRAA().Aspect())).
+		Float64("rank", brevity.ThreatRank(highestThreat, trackfile.LastKnown().Altitude)).
+		Msg("found highest threat hostile group")
+	c.calls <- NewCall(ctx, brevity.BogeyDopeResponse{Callsign: foundCallsign, Group: highestThreat})
+}
+
+// closure returns whether the given group is OPENING or CLOSING relative to the given friendly trackfile.
+func (c *controller) closure(trackfile *trackfiles.Trackfile, group brevity.Group) brevity.Closure {
+	ids := group.ObjectIDs()
+	if len(ids) == 0 {
+		return brevity.UnknownClosure
+	}
+	contact := c.scope.FindUnit(ids[0])
+	if contact == nil {
+		return brevity.UnknownClosure
+	}
+	rate := spatial.RangeRate(
+		trackfile.LastKnown().Point,
+		bearings.NewTrueBearing(trackfile.Heading()),
+		trackfile.Speed(),
+		contact.LastKnown().Point,
+		bearings.NewTrueBearing(contact.Heading()),
+		contact.Speed(),
+	)
+	return brevity.ClosureFromRangeRate(rate)
 }
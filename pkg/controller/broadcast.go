@@ -3,7 +3,6 @@ package controller
 import (
 	"slices"
 
-	"github.com/dharmab/skyeye/pkg/parser"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/rs/zerolog/log"
 )
@@ -19,7 +18,7 @@ func (c *controller) addFriendlyToBroadcast(callsigns []string, friendly *trackf
 	if !shouldBroadcast {
 		return callsigns
 	}
-	if callsign, ok := parser.ParsePilotCallsign(friendly.Contact.Name); ok {
+	if callsign, ok := c.pilots.Callsign(friendly.Contact.Name); ok {
 		if !slices.Contains(callsigns, callsign) {
 			callsigns = append(callsigns, callsign)
 		}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// responseCacheThreshold is how far any contact may move before cached responses involving it are invalidated.
+var responseCacheThreshold = 1 * unit.NauticalMile
+
+// cachedResponse is a response and the contact positions it was computed from.
+type cachedResponse struct {
+	// response is the cached response.
+	response any
+	// positions maps the IDs of contacts on the scope when the response was computed to their positions.
+	positions map[uint64]orb.Point
+	// expires is when the response is no longer valid.
+	expires time.Time
+}
+
+// responseCache remembers recent responses, so that identical requests made while the contacts on the scope have not
+// changed can be answered without recomputing the response.
+type responseCache struct {
+	// ttl is how long a response remains valid.
+	ttl time.Duration
+	// threshold is how far any contact may move before a response is invalidated.
+	threshold unit.Length
+	// responses maps cache keys to responses.
+	responses map[string]cachedResponse
+	// lock protects responses.
+	lock sync.Mutex
+}
+
+func newResponseCache(ttl time.Duration, threshold unit.Length) *responseCache {
+	return &responseCache{
+		ttl:       ttl,
+		threshold: threshold,
+		responses: make(map[string]cachedResponse),
+	}
+}
+
+// get returns the cached response for the given key, if it has not expired and the given contact positions match the
+// positions the response was computed from. A contact matches if it has not moved further than the threshold. Any
+// contact appearing or disappearing invalidates the response.
+func (c *responseCache) get(key string, positions map[uint64]orb.Point, now time.Time) (any, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, ok := c.responses[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(cached.expires) || !c.isUnchanged(cached.positions, positions) {
+		delete(c.responses, key)
+		return nil, false
+	}
+	return cached.response, true
+}
+
+// isUnchanged returns true if the same contacts are in both maps and none have moved further than the threshold.
+func (c *responseCache) isUnchanged(before, after map[uint64]orb.Point) bool {
+	if len(before) != len(after) {
+		return false
+	}
+	for id, a := range before {
+		b, ok := after[id]
+		if !ok {
+			return false
+		}
+		if spatial.Distance(a, b) > c.threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// put caches a response computed from the given contact positions. A TTL of zero or less disables caching.
+func (c *responseCache) put(key string, positions map[uint64]orb.Point, response any, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.responses[key] = cachedResponse{
+		response:  response,
+		positions: positions,
+		expires:   now.Add(c.ttl),
+	}
+}
+
+func (c *responseCache) reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.responses = make(map[string]cachedResponse)
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCacheHit(t *testing.T) {
+	t.Parallel()
+	cache := newResponseCache(10*time.Second, responseCacheThreshold)
+	now := time.Now()
+	positions := map[uint64]orb.Point{1: {-115.0, 36.0}, 2: {-115.1, 36.1}}
+	cache.put(pictureCacheKey, positions, "response", now)
+
+	response, ok := cache.get(pictureCacheKey, positions, now.Add(5*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, "response", response)
+
+	_, ok = cache.get("other", positions, now.Add(5*time.Second))
+	assert.False(t, ok)
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	t.Parallel()
+	cache := newResponseCache(10*time.Second, responseCacheThreshold)
+	now := time.Now()
+	positions := map[uint64]orb.Point{1: {-115.0, 36.0}}
+	cache.put(pictureCacheKey, positions, "response", now)
+	_, ok := cache.get(pictureCacheKey, positions, now.Add(10*time.Second))
+	assert.False(t, ok)
+}
+
+func TestResponseCacheInvalidatedByMovement(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{-115.0, 36.0}
+	north := bearings.NewTrueBearing(0)
+	testCases := []struct {
+		name     string
+		moved    unit.Length
+		expected bool
+	}{
+		{name: "stationary", moved: 0, expected: true},
+		{name: "within threshold", moved: 0.5 * unit.NauticalMile, expected: true},
+		{name: "beyond threshold", moved: 1.5 * unit.NauticalMile, expected: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			cache := newResponseCache(10*time.Second, responseCacheThreshold)
+			now := time.Now()
+			cache.put(pictureCacheKey, map[uint64]orb.Point{1: origin, 2: origin}, "response", now)
+			moved := map[uint64]orb.Point{1: origin, 2: spatial.PointAtBearingAndDistance(origin, north, test.moved)}
+			_, ok := cache.get(pictureCacheKey, moved, now.Add(time.Second))
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
+
+func TestResponseCacheInvalidatedByContactChange(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{-115.0, 36.0}
+	for name, after := range map[string]map[uint64]orb.Point{
+		"contact appeared":    {1: origin, 2: origin},
+		"contact disappeared": {},
+		"contact replaced":    {2: origin},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cache := newResponseCache(10*time.Second, responseCacheThreshold)
+			now := time.Now()
+			cache.put(pictureCacheKey, map[uint64]orb.Point{1: origin}, "response", now)
+			_, ok := cache.get(pictureCacheKey, after, now.Add(time.Second))
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	t.Parallel()
+	cache := newResponseCache(0, responseCacheThreshold)
+	now := time.Now()
+	positions := map[uint64]orb.Point{1: {-115.0, 36.0}}
+	cache.put(pictureCacheKey, positions, "response", now)
+	_, ok := cache.get(pictureCacheKey, positions, now)
+	assert.False(t, ok)
+}
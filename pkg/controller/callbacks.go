@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
 	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
@@ -12,42 +15,44 @@ import (
 
 var fadeBroadcastRadius = 55 * unit.NauticalMile
 
+// handleStarted clears the controller's state when the mission (re)starts and the scope clears all trackfiles.
 func (c *controller) handleStarted() {
-	c.merges.reset()
-	c.threatCooldowns.reset()
-	c.wasLastPictureClean = false
+	c.reset()
+	c.wasLastPictureClean.Store(false)
 }
 
+// handleFaded starts tracking a group which is no longer updated by sensors, so that FADED and VANISHED calls can be
+// broadcast for it.
 func (c *controller) handleFaded(location orb.Point, group brevity.Group, coalition coalitions.Coalition) {
 	for _, id := range group.ObjectIDs() {
 		c.remove(id)
 	}
-	isHostile := coalition == c.coalition.Opposite()
-	areHumansOnFrequency := c.srsClient.HumansOnFrequency() > 0
-	nearbyFriendlies := c.scope.FindNearbyGroupsWithBullseye(
-		location,
-		lowestAltitude,
-		highestAltitude,
-		fadeBroadcastRadius,
-		c.coalition,
-		brevity.Aircraft,
-		[]uint64{},
-	)
-	isNearFriendly := len(nearbyFriendlies) > 0
-
-	if isHostile && isNearFriendly && areHumansOnFrequency {
-		log.Info().Stringer("group", group).Msg("broadcasting FADED call")
-		group.SetDeclaration(brevity.Hostile)
-		c.calls <- NewCall(traces.NewRequestContext(), brevity.FadedCall{Group: group})
-	} else {
-		log.Debug().
-			Bool("isHostile", isHostile).
-			Bool("isNearFriendly", isNearFriendly).
-			Bool("areHumansOnFrequency", areHumansOnFrequency).
-			Msg("skipping FADED call because broadcast criteria are not met")
-	}
+	c.fades.update(location, group, coalition, time.Now())
+	c.broadcastFades(traces.NewRequestContext())
+}
+
+// handleUpdated stops tracking any group containing a trackfile which sensors have updated or reacquired, so that
+// FADED and VANISHED calls are not broadcast for aircraft which are still on scope. It also checks whether a NUMEROUS
+// call should be broadcast.
+func (c *controller) handleUpdated(id uint64) {
+	c.fades.remove(id)
+	c.broadcastNumerous(traces.NewRequestContext())
 }
 
 func (c *controller) handleRemoved(trackfile *trackfiles.Trackfile) {
 	c.remove(trackfile.Contact.ID)
 }
+
+// handleSectorClean broadcasts a PICTURE CLEAN call when the last hostile contact leaves or is removed from a sector.
+func (c *controller) handleSectorClean(event radar.SectorClean) {
+	logger := log.With().Str("sector", event.Sector).Stringer("coalition", event.Coalition).Logger()
+	if event.Coalition != c.coalition.Opposite() {
+		return
+	}
+	if c.srsClient.HumansOnFrequency() == 0 {
+		logger.Debug().Msg("skipping sector clean call because no humans are on frequency")
+		return
+	}
+	logger.Info().Msg("broadcasting sector clean call")
+	c.calls <- NewCall(traces.NewRequestContext(), brevity.SectorCleanCall{Sector: event.Sector})
+}
@@ -0,0 +1,13 @@
+package controller
+
+import "github.com/dharmab/skyeye/pkg/brevity"
+
+// noHostilesResponse decides how to respond to a caller when no hostile groups match their request, given the total
+// number of contacts on the radar scope. If the scope has no contacts at all, the radar has no data and the controller
+// is unable to respond; otherwise the picture is CLEAN.
+func noHostilesResponse(callsign string, contacts int) any {
+	if contacts == 0 {
+		return brevity.NoRadarDataResponse{Callsign: callsign}
+	}
+	return brevity.CleanResponse{Callsign: callsign}
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoHostilesResponse(t *testing.T) {
+	t.Parallel()
+	// Empty telemetry.
+	assert.Equal(t, brevity.NoRadarDataResponse{Callsign: "eagle 1"}, noHostilesResponse("eagle 1", 0))
+	// Telemetry with only friendly contacts.
+	assert.Equal(t, brevity.CleanResponse{Callsign: "eagle 1"}, noHostilesResponse("eagle 1", 2))
+}
+
+func TestHandlersWithoutHostiles(t *testing.T) {
+	t.Parallel()
+	requester := trackfiles.NewTrackfile(trackfiles.Labels{ID: 1, Name: "Eagle 1", Coalition: coalitions.Blue})
+	requester.Update(trackfiles.Frame{Time: time.Now(), Point: orb.Point{33, 44}})
+
+	testCases := []struct {
+		name     string
+		scope    *testRadar
+		handle   func(*controller)
+		expected any
+	}{
+		{
+			name:  "PICTURE with empty telemetry",
+			scope: &testRadar{},
+			handle: func(c *controller) {
+				c.HandlePicture(context.Background(), &brevity.PictureRequest{Callsign: "eagle 1"})
+			},
+			expected: brevity.NoRadarDataResponse{Callsign: "eagle 1"},
+		},
+		{
+			name:  "PICTURE with only friendly contacts",
+			scope: &testRadar{contacts: 2, requester: requester},
+			handle: func(c *controller) {
+				c.HandlePicture(context.Background(), &brevity.PictureRequest{Callsign: "eagle 1"})
+			},
+			expected: brevity.CleanResponse{Callsign: "eagle 1"},
+		},
+		{
+			name:  "BOGEY DOPE with only friendly contacts",
+			scope: &testRadar{contacts: 2, requester: requester},
+			handle: func(c *controller) {
+				c.HandleBogeyDope(context.Background(), &brevity.BogeyDopeRequest{Callsign: "eagle 1", Filter: brevity.Aircraft})
+			},
+			expected: brevity.CleanResponse{Callsign: "eagle 1"},
+		},
+		{
+			name:  "BOGEY DOPE without a trackfile",
+			scope: &testRadar{},
+			handle: func(c *controller) {
+				c.HandleBogeyDope(context.Background(), &brevity.BogeyDopeRequest{Callsign: "eagle 1", Filter: brevity.Aircraft})
+			},
+			expected: brevity.NegativeRadarContactResponse{Callsign: "eagle 1"},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			calls := make(chan Call, 1)
+			c := &controller{coalition: coalitions.Blue, scope: test.scope, calls: calls}
+			test.handle(c)
+			require.Len(t, calls, 1)
+			assert.Equal(t, test.expected, (<-calls).Call)
+		})
+	}
+}
@@ -3,10 +3,15 @@ package controller
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/geofences"
+	"github.com/dharmab/skyeye/pkg/metrics"
+	"github.com/dharmab/skyeye/pkg/pilots"
 	"github.com/dharmab/skyeye/pkg/radar"
 	"github.com/dharmab/skyeye/pkg/simpleradio"
 	"github.com/dharmab/skyeye/pkg/traces"
@@ -59,6 +64,8 @@ type Controller interface {
 }
 
 type controller struct {
+	// callsign is the GCI callsign, used to label metrics.
+	callsign string
 	// coalition this controller serves.
 	coalition coalitions.Coalition
 
@@ -75,8 +82,11 @@ type controller struct {
 	// pictureBroadcastDeadline is the time at which the controller will broadcast the next tactical air picture.
 	pictureBroadcastDeadline time.Time
 	// wasLastPictureClean tracks if the most recently broadcast picture was clean, so that the controller can avoid
-	// repeatedly broadcasting clean pictures.
-	wasLastPictureClean bool
+	// repeatedly broadcasting clean pictures. It is cleared by the started callback, which runs on the scope's goroutine.
+	wasLastPictureClean atomic.Bool
+
+	// numerous decides when to alert that the number of hostile contacts has reached a threshold.
+	numerous *numerousAlert
 
 	// enableThreatMonitoring enables automatic threat calls.
 	enableThreatMonitoring bool
@@ -89,33 +99,107 @@ type controller struct {
 
 	// merges tracks which contacts are in the merge.
 	merges *mergeTracker
+	// mergeCriteria are the thresholds for entering and exiting the merge.
+	mergeCriteria brevity.MergeCriteria
+
+	// pictureWeights are the weights used to order groups in a requested PICTURE.
+	pictureWeights brevity.PriorityWeights
+
+	// geoFences restrict PICTURE and BOGEY DOPE responses to contacts within at least one geo-fence. If empty, all
+	// contacts are reported.
+	geoFences []geofences.GeoFence
+
+	// responses caches recent responses.
+	responses *responseCache
+
+	// fades tracks groups which are no longer updated by sensors.
+	fades *fadeTracker
+	// contactTTL is how long a trackfile may go without sensor updates before it is pruned from the scope. If zero,
+	// stale trackfiles are not pruned.
+	contactTTL time.Duration
+
+	// iffRules declare aircraft by type, overriding declarations based on coalition.
+	iffRules brevity.IFFRules
+
+	// pilots maps the names of human pilots to callsigns used in callouts. It may be nil.
+	pilots *pilots.PilotRegistry
 
 	// calls is the channel to publish responses and calls to.
 	calls chan<- Call
 }
 
-func New(
-	rdr radar.Radar,
-	srsClient simpleradio.Client,
-	coalition coalitions.Coalition,
-	enableAutomaticPicture bool,
-	pictureBroadcastInterval time.Duration,
-	enableThreatMonitoring bool,
-	threatMonitoringCooldown time.Duration,
-	threatMonitoringRequiresSRS bool,
-) Controller {
+// Options configure a controller.
+type Options struct {
+	// Callsign is the GCI callsign. It labels the controller's metrics, so that several controllers running in the same
+	// process can be told apart.
+	Callsign string
+	// Coalition is the coalition the controller serves.
+	Coalition coalitions.Coalition
+	// EnableAutomaticPicture enables automatic PICTURE broadcasts.
+	EnableAutomaticPicture bool
+	// PictureBroadcastInterval is the interval between automatic PICTURE broadcasts.
+	PictureBroadcastInterval time.Duration
+	// PictureThreshold is the number of hostile contacts at which a NUMEROUS call is broadcast. Zero disables NUMEROUS
+	// calls.
+	PictureThreshold int
+	// PictureThresholdCooldown is the minimum interval between NUMEROUS calls.
+	PictureThresholdCooldown time.Duration
+	// RadarSweepInterval is the interval at which the radar updates trackfiles. The NUMEROUS threshold is checked at
+	// most once per sweep.
+	RadarSweepInterval time.Duration
+	// EnableThreatMonitoring enables automatic THREAT calls.
+	EnableThreatMonitoring bool
+	// ThreatMonitoringCooldown is the interval between THREAT calls for the same threat.
+	ThreatMonitoringCooldown time.Duration
+	// ThreatMonitoringRequiresSRS enforces that THREAT calls are only broadcast when the relevant friendly aircraft are
+	// on frequency.
+	ThreatMonitoringRequiresSRS bool
+	// MergeCriteria are the thresholds for entering and exiting the merge.
+	MergeCriteria brevity.MergeCriteria
+	// PictureWeights are the weights used to order groups in a requested PICTURE.
+	PictureWeights brevity.PriorityWeights
+	// FadeDelay is how long a group must go without sensor updates before it is called FADED.
+	FadeDelay time.Duration
+	// VanishTimeout is how long a group must go without sensor updates before it is called VANISHED.
+	VanishTimeout time.Duration
+	// ContactTTL is how long a trackfile may go without sensor updates before it is pruned from the scope. If zero,
+	// stale trackfiles are not pruned.
+	ContactTTL time.Duration
+	// ResponseCacheTTL is how long responses are cached. Zero disables caching.
+	ResponseCacheTTL time.Duration
+	// IFFRules declare aircraft by type, overriding declarations based on coalition.
+	IFFRules brevity.IFFRules
+	// GeoFences restrict PICTURE and BOGEY DOPE responses to contacts within at least one geo-fence. If empty, all
+	// contacts are reported.
+	GeoFences []geofences.GeoFence
+	// Pilots maps the names of human pilots to callsigns used in callouts. It may be nil.
+	Pilots *pilots.PilotRegistry
+}
+
+// New creates a controller which uses the given radar scope and SRS client.
+func New(rdr radar.Radar, srsClient simpleradio.Client, opts Options) Controller {
 	return &controller{
-		coalition:                   coalition,
+		callsign:                    opts.Callsign,
+		coalition:                   opts.Coalition,
 		scope:                       rdr,
 		srsClient:                   srsClient,
-		enableAutomaticPicture:      enableAutomaticPicture,
-		pictureBroadcastInterval:    pictureBroadcastInterval,
-		pictureBroadcastDeadline:    time.Now().Add(pictureBroadcastInterval),
-		enableThreatMonitoring:      enableThreatMonitoring,
-		threatMonitoringCooldown:    threatMonitoringCooldown,
-		threatCooldowns:             newCooldownTracker(threatMonitoringCooldown),
-		threatMonitoringRequiresSRS: threatMonitoringRequiresSRS,
+		enableAutomaticPicture:      opts.EnableAutomaticPicture,
+		pictureBroadcastInterval:    opts.PictureBroadcastInterval,
+		pictureBroadcastDeadline:    time.Now().Add(opts.PictureBroadcastInterval),
+		numerous:                    newNumerousAlert(opts.PictureThreshold, opts.PictureThresholdCooldown, opts.RadarSweepInterval),
+		enableThreatMonitoring:      opts.EnableThreatMonitoring,
+		threatMonitoringCooldown:    opts.ThreatMonitoringCooldown,
+		threatCooldowns:             newCooldownTracker(opts.ThreatMonitoringCooldown),
+		threatMonitoringRequiresSRS: opts.ThreatMonitoringRequiresSRS,
 		merges:                      newMergeTracker(),
+		mergeCriteria:               opts.MergeCriteria,
+		pictureWeights:              opts.PictureWeights,
+		fades:                       newFadeTracker(opts.FadeDelay, opts.VanishTimeout),
+		contactTTL:                  opts.ContactTTL,
+		responses:                   newResponseCache(opts.ResponseCacheTTL, responseCacheThreshold),
+		iffRules:                    opts.IFFRules,
+		geoFences:                   opts.GeoFences,
+		pilots:                      opts.Pilots,
 	}
 }
 
@@ -124,8 +208,11 @@ func (c *controller) Run(ctx context.Context, calls chan<- Call) {
 	c.calls = calls
 
 	log.Info().Msg("attaching callbacks")
+	c.scope.SetStartedCallback(c.handleStarted)
+	c.scope.SetUpdatedCallback(c.handleUpdated)
 	c.scope.SetFadedCallback(c.handleFaded)
 	c.scope.SetRemovedCallback(c.handleRemoved)
+	c.scope.SetSectorCleanCallback(c.handleSectorClean)
 
 	c.broadcastSunrise(ctx)
 
@@ -136,15 +223,20 @@ func (c *controller) Run(ctx context.Context, calls chan<- Call) {
 		select {
 		case <-ctx.Done():
 			log.Info().Msg("detaching callbacks")
+			c.scope.SetUpdatedCallback(nil)
 			c.scope.SetFadedCallback(nil)
 			c.scope.SetRemovedCallback(nil)
+			c.scope.SetSectorCleanCallback(nil)
 			c.scope.SetStartedCallback(nil)
 			return
 		case <-ticker.C:
+			c.updateMetrics()
+			c.pruneStaleContacts()
 			c.broadcastMerges(traces.WithTraceID(ctx, shortuuid.New()))
+			c.broadcastFades(traces.WithTraceID(ctx, shortuuid.New()))
 			c.broadcastThreats(traces.WithTraceID(ctx, shortuuid.New()))
 			if c.enableAutomaticPicture && time.Now().After(c.pictureBroadcastDeadline) {
-				c.broadcastPicture(traces.WithTraceID(ctx, shortuuid.New()), &log.Logger, false)
+				c.broadcastPicture(traces.WithTraceID(ctx, shortuuid.New()), &log.Logger, false, nil)
 			}
 		}
 	}
@@ -181,9 +273,35 @@ func (c *controller) remove(id uint64) {
 	log.Debug().Uint64("id", id).Msg("removing ID from controller state tracking")
 	c.threatCooldowns.remove(id)
 	c.merges.remove(id)
+	c.fades.remove(id)
 }
 
 func (c *controller) reset() {
 	c.threatCooldowns.reset()
 	c.merges.reset()
+	c.fades.reset()
+	c.responses.reset()
+	c.numerous.reset()
+}
+
+// pruneStaleContacts removes trackfiles which have not been updated within the contact TTL. The scope calls the faded
+// callback for the removed trackfiles, so FADED calls are broadcast for them.
+func (c *controller) pruneStaleContacts() {
+	if c.contactTTL <= 0 {
+		return
+	}
+	missionTime := c.scope.MissionTime()
+	if missionTime.IsZero() {
+		return
+	}
+	if n := c.scope.PruneStale(missionTime.Add(-c.contactTTL)); n > 0 {
+		log.Info().Int("count", n).Stringer("ttl", c.contactTTL).Msg("pruned stale trackfiles")
+	}
+}
+
+// updateMetrics updates operational metrics which are sampled by the control loop.
+func (c *controller) updateMetrics() {
+	coalition := strings.ToLower(c.coalition.String())
+	metrics.ActiveContacts.WithLabelValues(c.callsign, coalition).Set(float64(c.scope.ContactCount()))
+	metrics.SRSConnectionStatus.WithLabelValues(c.callsign, coalition).Set(float64(c.srsClient.HealthStatus()))
 }
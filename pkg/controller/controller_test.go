@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/metrics"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/simpleradio/mock"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRadar is a radar scope with fixed contacts. Methods which are not overridden panic.
+type testRadar struct {
+	radar.Radar
+	// positions are the positions of hostile contacts.
+	positions map[uint64]orb.Point
+	// contacts is the total number of contacts on the scope.
+	contacts int
+	// requester is the trackfile found for any callsign. It may be nil.
+	requester *trackfiles.Trackfile
+	// pictures counts calls to GetPicture.
+	pictures int
+	// pictureGroups and pictureContacts are the size of the picture returned by CountPicture.
+	pictureGroups, pictureContacts int
+}
+
+func (r *testRadar) Positions(coalitions.Coalition) map[uint64]orb.Point {
+	return r.positions
+}
+
+func (r *testRadar) ContactCount() int {
+	return r.contacts
+}
+
+func (r *testRadar) FindCallsign(callsign string, _ coalitions.Coalition) (string, *trackfiles.Trackfile) {
+	return callsign, r.requester
+}
+
+func (r *testRadar) GetPicture(unit.Length, coalitions.Coalition, brevity.ContactCategory, []uint64) (int, []brevity.Group) {
+	r.pictures++
+	return 0, []brevity.Group{}
+}
+
+func (r *testRadar) CountPicture(unit.Length, coalitions.Coalition, brevity.ContactCategory, []uint64) (int, int) {
+	return r.pictureGroups, r.pictureContacts
+}
+
+func (r *testRadar) FindNearbyGroupsWithBRAA(orb.Point, orb.Point, unit.Length, unit.Length, unit.Length, coalitions.Coalition, brevity.ContactCategory, []uint64) []brevity.Group {
+	return []brevity.Group{}
+}
+
+// TestControllersDoNotShareContacts runs a controller for each configuration in a multi-controller configuration, and
+// verifies that each controller's radar scope and metrics are independent.
+func TestControllersDoNotShareContacts(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+
+	config := conf.Configuration{
+		Callsign:  "Focus",
+		Coalition: coalitions.Blue,
+		Controllers: []conf.Controller{
+			{Coalition: coalitions.Blue},
+			{Callsign: "Wizard", Coalition: coalitions.Red},
+		},
+	}
+	configs := config.ControllerConfigurations()
+	require.Len(t, configs, 2)
+
+	scopes := make([]radar.Radar, 0, len(configs))
+	controllers := make([]*controller, 0, len(configs))
+	updates := make([]chan sim.Updated, 0, len(configs))
+	for _, cfg := range configs {
+		updated := make(chan sim.Updated)
+		rdr := radar.New(make(chan sim.Started), updated, make(chan sim.Faded), radar.Options{Coalition: cfg.Coalition})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rdr.Run(ctx, &wg)
+		}()
+		c := New(rdr, mock.NewMockClient(), Options{Callsign: cfg.Callsign, Coalition: cfg.Coalition}).(*controller)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Run(ctx, make(chan Call, 10))
+		}()
+		scopes = append(scopes, rdr)
+		controllers = append(controllers, c)
+		updates = append(updates, updated)
+	}
+
+	// Focus sees one contact and Wizard sees two.
+	for i, id := range []uint64{1, 2, 3} {
+		updates[min(i, 1)] <- sim.Updated{
+			Labels: trackfiles.Labels{ID: id, Name: "Mobius 1", Coalition: coalitions.Red, ACMIName: "F-15C"},
+			Frame:  trackfiles.Frame{Time: time.Now(), Point: orb.Point{42, 42}, Altitude: 20000 * unit.Foot},
+		}
+	}
+	require.Eventually(t, func() bool {
+		return scopes[0].ContactCount() == 1 && scopes[1].ContactCount() == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Nil(t, scopes[0].FindUnit(2))
+	assert.Nil(t, scopes[1].FindUnit(1))
+
+	for _, c := range controllers {
+		c.updateMetrics()
+	}
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.ActiveContacts.WithLabelValues("Focus", "blue")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.ActiveContacts.WithLabelValues("Wizard", "red")), 0)
+}
@@ -3,7 +3,6 @@ package controller
 import (
 	"context"
 
-	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
@@ -16,7 +15,9 @@ func (c *controller) HandleDeclare(ctx context.Context, request *brevity.Declare
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
 
-	if request.IsBRAA {
+	if request.GridPosition != "" {
+		logger = logger.With().Str("grid", request.GridPosition).Logger()
+	} else if request.IsBRAA {
 		logger = logger.With().
 			Float64("bearingDegrees", request.Bearing.Degrees()).
 			Float64("rangeNM", request.Range.NauticalMiles()).
@@ -39,18 +40,24 @@ func (c *controller) HandleDeclare(ctx context.Context, request *brevity.Declare
 		return
 	}
 
-	var origin orb.Point
-	var bearing bearings.Bearing
-	var distance unit.Length
-	if request.IsBRAA {
+	var pointOfInterest orb.Point
+	if request.GridPosition != "" {
+		logger.Debug().Msg("locating point of interest using grid reference")
+		point, err := gridPositionPoint(request.GridPosition, trackfile.LastKnown().Point)
+		if err != nil {
+			logger.Warn().Err(err).Msg("failed to locate grid reference")
+			c.calls <- NewCall(ctx, brevity.SayAgainResponse{Callsign: foundCallsign})
+			return
+		}
+		pointOfInterest = point
+	} else if request.IsBRAA {
 		logger.Debug().Msg("locating point of interest using BRAA")
 		if !request.Bearing.IsMagnetic() {
 			logger.Warn().Stringer("bearing", request.Bearing).Msg("bearing provided to HandleDeclare should be magnetic")
 		}
-		origin = trackfile.LastKnown().Point
-		declination := c.scope.Declination(origin)
-		bearing = request.Bearing.True(declination)
-		distance = request.Range
+		origin := trackfile.LastKnown().Point
+		bearing := c.scope.MagneticVariation().True(request.Bearing, origin)
+		pointOfInterest = spatial.PointAtBearingAndDistance(origin, bearing, request.Range)
 	} else {
 		logger.Debug().Msg("locating point of interest using bullseye")
 		if request == nil {
@@ -61,12 +68,10 @@ func (c *controller) HandleDeclare(ctx context.Context, request *brevity.Declare
 		if !request.Bullseye.Bearing().IsMagnetic() {
 			logger.Warn().Stringer("bearing", request.Bullseye.Bearing()).Msg("bearing provided to HandleDeclare should be magnetic")
 		}
-		origin = c.scope.Bullseye(trackfile.Contact.Coalition)
-		declination := c.scope.Declination(origin)
-		bearing = request.Bullseye.Bearing().True(declination)
-		distance = request.Bullseye.Distance()
+		origin := c.scope.Bullseye(trackfile.Contact.Coalition)
+		bearing := c.scope.MagneticVariation().True(request.Bullseye.Bearing(), origin)
+		pointOfInterest = spatial.PointAtBearingAndDistance(origin, bearing, request.Bullseye.Distance())
 	}
-	pointOfInterest := spatial.PointAtBearingAndDistance(origin, bearing, distance)
 
 	radius := 7 * unit.NauticalMile
 
@@ -82,30 +87,81 @@ func (c *controller) HandleDeclare(ctx context.Context, request *brevity.Declare
 	hostileGroups := c.scope.FindNearbyGroupsWithBullseye(pointOfInterest, minAltitude, maxAltitude, radius, c.coalition.Opposite(), brevity.Aircraft, []uint64{trackfile.Contact.ID})
 	logger.Debug().Int("friendly", len(friendlyGroups)).Int("hostile", len(hostileGroups)).Msg("queried groups near declared location")
 
-	response := brevity.DeclareResponse{Callsign: foundCallsign}
-	if len(friendlyGroups)+len(hostileGroups) == 0 {
-		logger.Debug().Msg("no groups found")
-		response.Declaration = brevity.Clean
-	} else if len(friendlyGroups) > 0 && len(hostileGroups) == 0 {
+	contacts := make([]brevity.DeclareContact, 0)
+	contacts = append(contacts, c.declareContacts(pointOfInterest, friendlyGroups)...)
+	contacts = append(contacts, c.declareContacts(pointOfInterest, hostileGroups)...)
+
+	response := brevity.DeclareResponse{
+		Callsign:    foundCallsign,
+		Declaration: brevity.Declare(contacts, radius),
+	}
+	switch response.Declaration {
+	case brevity.Friendly:
 		logger.Debug().Msg("friendly groups found")
-		response.Declaration = brevity.Friendly
 		response.Group = friendlyGroups[0]
-	} else if len(friendlyGroups) == 0 && len(hostileGroups) > 0 {
+	case brevity.Hostile:
 		logger.Debug().Msg("hostile groups found")
-		response.Declaration = brevity.Hostile
 		response.Group = hostileGroups[0]
-	} else if len(friendlyGroups) > 0 && len(hostileGroups) > 0 {
+	case brevity.Furball:
 		logger.Debug().Msg("both friendly and hostile groups found")
-		response.Declaration = brevity.Furball
+	default:
+		logger.Debug().Msg("no groups found")
 	}
 
 	if response.Group != nil {
+		response.Declaration = c.classify(response.Group, response.Declaration)
 		response.Group.SetDeclaration(response.Declaration)
 		if response.Group.Declaration() == brevity.Hostile {
 			c.fillInMergeDetails(response.Group)
 		}
+		if request.GridPosition != "" {
+			response.GridPosition = c.groupGridPosition(response.Group, gridPrecision(request.GridPosition))
+		}
 	}
 
 	logger.Debug().Any("declaration", response.Declaration).Msg("responding to DECLARE request")
 	c.calls <- NewCall(ctx, response)
 }
+
+// declareContacts returns a contact for each aircraft in the groups, with its declaration relative to the controller's
+// coalition and its distance from the point of interest.
+func (c *controller) declareContacts(pointOfInterest orb.Point, groups []brevity.Group) []brevity.DeclareContact {
+	contacts := make([]brevity.DeclareContact, 0)
+	for _, group := range groups {
+		for _, id := range group.ObjectIDs() {
+			trackfile := c.scope.FindUnit(id)
+			if trackfile == nil {
+				continue
+			}
+			contacts = append(contacts, brevity.DeclareContact{
+				Declaration: brevity.CoalitionDeclaration(c.coalition, trackfile.Contact.Coalition),
+				Distance:    spatial.Distance(pointOfInterest, trackfile.LastKnown().Point),
+			})
+		}
+	}
+	return contacts
+}
+
+// classify applies the IFF rules to the aircraft in the group. The first aircraft whose type matches a rule determines
+// the declaration. If no aircraft matches, the given declaration is returned.
+func (c *controller) classify(group brevity.Group, declaration brevity.Declaration) brevity.Declaration {
+	if len(c.iffRules) == 0 {
+		return declaration
+	}
+	for _, id := range group.ObjectIDs() {
+		trackfile := c.scope.FindUnit(id)
+		if trackfile == nil {
+			continue
+		}
+		result := brevity.ClassifyIFF(trackfile.Contact.ACMIName, trackfile.Contact.Coalition, c.iffRules)
+		if result.Matched() {
+			log.Debug().
+				Str("aircraftType", trackfile.Contact.ACMIName).
+				Str("rule", result.Rule).
+				Any("declaration", result.Declaration).
+				Msg("declared group using IFF rule")
+			return result.Declaration
+		}
+	}
+	return declaration
+}
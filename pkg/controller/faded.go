@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// fadeStatus is the stage of a group which is no longer updated by sensors.
+type fadeStatus int
+
+const (
+	// active groups are still expected to be updated.
+	active fadeStatus = iota
+	// faded groups have not been updated for the fade delay.
+	faded
+	// vanished groups have not been updated for the vanish timeout.
+	vanished
+)
+
+// fadingGroup is a group tracked by a [fadeTracker].
+type fadingGroup struct {
+	// group is the group as it was last known.
+	group brevity.Group
+	// location is the group's last known location.
+	location orb.Point
+	// coalition is the group's coalition.
+	coalition coalitions.Coalition
+	// lastUpdated is when the group was last updated by sensors.
+	lastUpdated time.Time
+	// status is the group's current stage.
+	status fadeStatus
+}
+
+// fadeTracker promotes groups which are no longer updated by sensors from active, to faded, to vanished.
+type fadeTracker struct {
+	// fadeAfter is how long a group must go without updates before it is faded.
+	fadeAfter time.Duration
+	// vanishAfter is how long a group must go without updates before it is vanished. If this is not longer than
+	// fadeAfter, groups never vanish.
+	vanishAfter time.Duration
+	// groups maps the first object ID of each group to the group.
+	groups map[uint64]*fadingGroup
+	// keys maps every object ID of each group to the group's key in groups.
+	keys map[uint64]uint64
+	// lock protects groups and keys.
+	lock sync.Mutex
+}
+
+func newFadeTracker(fadeAfter, vanishAfter time.Duration) *fadeTracker {
+	return &fadeTracker{
+		fadeAfter:   fadeAfter,
+		vanishAfter: vanishAfter,
+		groups:      make(map[uint64]*fadingGroup),
+		keys:        make(map[uint64]uint64),
+	}
+}
+
+// update records that the given group was last updated by sensors at the given time. The group becomes active again.
+func (t *fadeTracker) update(location orb.Point, group brevity.Group, coalition coalitions.Coalition, lastUpdated time.Time) {
+	ids := group.ObjectIDs()
+	if len(ids) == 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.forget(ids[0])
+	for _, id := range ids {
+		t.keys[id] = ids[0]
+	}
+	t.groups[ids[0]] = &fadingGroup{
+		group:       group,
+		location:    location,
+		coalition:   coalition,
+		lastUpdated: lastUpdated,
+		status:      active,
+	}
+}
+
+// check promotes groups according to how long they have gone without updates as of the given time. Each group which
+// changed stage is returned exactly once per stage. Vanished groups, and faded groups which will never vanish, are no
+// longer tracked after they are returned.
+func (t *fadeTracker) check(now time.Time) []fadingGroup {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	promoted := make([]fadingGroup, 0)
+	for id, g := range t.groups {
+		elapsed := now.Sub(g.lastUpdated)
+		status := g.status
+		if status == active && elapsed >= t.fadeAfter {
+			status = faded
+		}
+		canVanish := t.vanishAfter > t.fadeAfter
+		if status == faded && canVanish && elapsed >= t.vanishAfter {
+			status = vanished
+		}
+		if status != g.status {
+			g.status = status
+			promoted = append(promoted, *g)
+		}
+		if status == vanished || (status == faded && !canVanish) {
+			t.forget(id)
+		}
+	}
+	return promoted
+}
+
+// remove stops tracking any group containing the given ID.
+func (t *fadeTracker) remove(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if key, ok := t.keys[id]; ok {
+		t.forget(key)
+	}
+}
+
+// forget stops tracking the group with the given key. The caller must hold the lock.
+func (t *fadeTracker) forget(key uint64) {
+	g, ok := t.groups[key]
+	if !ok {
+		return
+	}
+	for _, id := range g.group.ObjectIDs() {
+		if t.keys[id] == key {
+			delete(t.keys, id)
+		}
+	}
+	delete(t.groups, key)
+}
+
+func (t *fadeTracker) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.groups = make(map[uint64]*fadingGroup)
+	t.keys = make(map[uint64]uint64)
+}
+
+// broadcastFades broadcasts FADED and VANISHED calls for groups which have gone without updates.
+func (c *controller) broadcastFades(ctx context.Context) {
+	for _, g := range c.fades.check(time.Now()) {
+		logger := log.With().Stringer("group", g.group).Logger()
+		if !c.shouldBroadcastFade(g.location, g.coalition) {
+			continue
+		}
+		g.group.SetDeclaration(brevity.Hostile)
+		switch g.status {
+		case faded:
+			logger.Info().Msg("broadcasting FADED call")
+			c.calls <- NewCall(ctx, brevity.FadedCall{Group: g.group})
+		case vanished:
+			logger.Info().Msg("broadcasting VANISHED call")
+			c.calls <- NewCall(ctx, brevity.VanishedCall{Group: g.group})
+		}
+	}
+}
+
+// shouldBroadcastFade returns true if a FADED or VANISHED call for a group of the given coalition at the given location
+// is relevant to friendly aircraft.
+func (c *controller) shouldBroadcastFade(location orb.Point, coalition coalitions.Coalition) bool {
+	isHostile := coalition == c.coalition.Opposite()
+	areHumansOnFrequency := c.srsClient.HumansOnFrequency() > 0
+	nearbyFriendlies := c.scope.FindNearbyGroupsWithBullseye(
+		location,
+		lowestAltitude,
+		highestAltitude,
+		fadeBroadcastRadius,
+		c.coalition,
+		brevity.Aircraft,
+		[]uint64{},
+	)
+	isNearFriendly := len(nearbyFriendlies) > 0
+
+	if isHostile && isNearFriendly && areHumansOnFrequency {
+		return true
+	}
+	log.Debug().
+		Bool("isHostile", isHostile).
+		Bool("isNearFriendly", isNearFriendly).
+		Bool("areHumansOnFrequency", areHumansOnFrequency).
+		Msg("skipping fade call because broadcast criteria are not met")
+	return false
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testGroup struct {
+	brevity.Group
+	ids []uint64
+}
+
+func (g *testGroup) ObjectIDs() []uint64 {
+	return g.ids
+}
+
+func TestFadeTrackerPromotesOnce(t *testing.T) {
+	t.Parallel()
+	tracker := newFadeTracker(30*time.Second, 2*time.Minute)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	groups := []*testGroup{{ids: []uint64{1, 2}}, {ids: []uint64{3}}}
+	for _, g := range groups {
+		tracker.update(orb.Point{}, g, coalitions.Red, start)
+	}
+
+	fadedCount := make(map[uint64]int)
+	vanishedCount := make(map[uint64]int)
+	for elapsed := time.Duration(0); elapsed <= 5*time.Minute; elapsed += 5 * time.Second {
+		for _, g := range tracker.check(start.Add(elapsed)) {
+			id := g.group.ObjectIDs()[0]
+			switch g.status {
+			case faded:
+				assert.GreaterOrEqual(t, elapsed, 30*time.Second)
+				fadedCount[id]++
+			case vanished:
+				assert.GreaterOrEqual(t, elapsed, 2*time.Minute)
+				assert.Equal(t, 1, fadedCount[id], "group must fade before it vanishes")
+				vanishedCount[id]++
+			case active:
+				t.Fatal("groups must not be promoted to active")
+			}
+		}
+	}
+
+	for _, g := range groups {
+		id := g.ids[0]
+		assert.Equal(t, 1, fadedCount[id])
+		assert.Equal(t, 1, vanishedCount[id])
+	}
+	assert.Empty(t, tracker.groups)
+}
+
+func TestFadeTrackerUpdateResets(t *testing.T) {
+	t.Parallel()
+	tracker := newFadeTracker(30*time.Second, 2*time.Minute)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	group := &testGroup{ids: []uint64{1}}
+	tracker.update(orb.Point{}, group, coalitions.Red, start)
+
+	promoted := tracker.check(start.Add(40 * time.Second))
+	require.Len(t, promoted, 1)
+	assert.Equal(t, faded, promoted[0].status)
+
+	// The group reappears before vanishing.
+	tracker.update(orb.Point{}, group, coalitions.Red, start.Add(90*time.Second))
+	assert.Empty(t, tracker.check(start.Add(110*time.Second)))
+
+	promoted = tracker.check(start.Add(130 * time.Second))
+	require.Len(t, promoted, 1)
+	assert.Equal(t, faded, promoted[0].status)
+}
+
+func TestFadeTrackerVanishDisabled(t *testing.T) {
+	t.Parallel()
+	tracker := newFadeTracker(0, 0)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tracker.update(orb.Point{}, &testGroup{ids: []uint64{1}}, coalitions.Red, start)
+
+	promoted := tracker.check(start)
+	require.Len(t, promoted, 1)
+	assert.Equal(t, faded, promoted[0].status)
+	assert.Empty(t, tracker.check(start.Add(time.Hour)))
+}
+
+func TestFadeTrackerRemove(t *testing.T) {
+	t.Parallel()
+	tracker := newFadeTracker(30*time.Second, 2*time.Minute)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tracker.update(orb.Point{}, &testGroup{ids: []uint64{1, 2}}, coalitions.Red, start)
+	tracker.remove(2)
+	assert.Empty(t, tracker.check(start.Add(time.Hour)))
+}
+
+func TestFadedGroupReappears(t *testing.T) {
+	t.Parallel()
+	c := &controller{
+		fades:    newFadeTracker(30*time.Second, 2*time.Minute),
+		numerous: newNumerousAlert(0, time.Minute, time.Second),
+	}
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	c.fades.update(orb.Point{}, &testGroup{ids: []uint64{1, 2}}, coalitions.Red, start)
+
+	promoted := c.fades.check(start.Add(40 * time.Second))
+	require.Len(t, promoted, 1)
+	assert.Equal(t, faded, promoted[0].status)
+
+	// Sensors reacquire one of the aircraft in the faded group, so the group must not be called VANISHED.
+	c.handleUpdated(2)
+	assert.Empty(t, c.fades.check(start.Add(time.Hour)))
+	assert.Empty(t, c.fades.keys)
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/geofences"
+	"github.com/paulmach/orb"
+)
+
+// outsideGeoFences returns the IDs of the given contacts which are outside every geo-fence, so that they can be excluded
+// from PICTURE and BOGEY DOPE responses. If the controller has no geo-fences, no contacts are excluded.
+func (c *controller) outsideGeoFences(positions map[uint64]orb.Point) []uint64 {
+	excludedIDs := []uint64{}
+	if len(c.geoFences) == 0 {
+		return excludedIDs
+	}
+	for id, point := range positions {
+		if !geofences.WithinAny(point, c.geoFences) {
+			excludedIDs = append(excludedIDs, id)
+		}
+	}
+	return excludedIDs
+}
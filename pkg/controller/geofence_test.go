@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/geofences"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutsideGeoFences(t *testing.T) {
+	t.Parallel()
+	positions := map[uint64]orb.Point{
+		1: {41.5, 42.5},
+		2: {43.5, 42.5},
+		3: {45.5, 42.5},
+	}
+
+	c := &controller{}
+	assert.Empty(t, c.outsideGeoFences(positions), "without geo-fences, no contacts should be excluded")
+
+	c.geoFences = []geofences.GeoFence{
+		{Name: "CAP West", Boundary: orb.Ring{{41, 42}, {42, 42}, {42, 43}, {41, 43}, {41, 42}}},
+		{Name: "CAP East", Boundary: orb.Ring{{43, 42}, {44, 42}, {44, 43}, {43, 43}, {43, 42}}},
+	}
+	assert.Equal(t, []uint64{3}, c.outsideGeoFences(positions))
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/paulmach/orb"
+)
+
+// gridPositionPoint returns the center of the grid square referenced by an MGRS grid reference. If the grid reference
+// has no grid zone designator, the grid zone of the origin is assumed, since pilots often omit it within a theater.
+func gridPositionPoint(grid string, origin orb.Point) (orb.Point, error) {
+	if !hasGridZoneDesignator(grid) {
+		zone, _, _ := strings.Cut(spatial.FormatMGRS(origin, 0), " ")
+		if zone == "" {
+			return orb.Point{}, fmt.Errorf("no grid zone for origin %v", origin)
+		}
+		grid = zone + " " + grid
+	}
+	point, err := spatial.ParseMGRS(grid)
+	if err != nil {
+		return orb.Point{}, fmt.Errorf("failed to parse grid reference: %w", err)
+	}
+	return point, nil
+}
+
+// hasGridZoneDesignator checks if the grid reference starts with a grid zone designator.
+func hasGridZoneDesignator(grid string) bool {
+	return grid != "" && unicode.IsDigit(rune(grid[0]))
+}
+
+// gridPrecision returns the number of digits in each of the easting and northing of a grid reference.
+func gridPrecision(grid string) int {
+	if hasGridZoneDesignator(grid) {
+		_, grid, _ = strings.Cut(grid, " ")
+	}
+	digits := 0
+	for _, r := range grid {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return digits / 2
+}
+
+// groupGridPosition returns the grid reference of the group's lead contact at the given precision. It returns an empty
+// string if none of the group's contacts are found.
+func (c *controller) groupGridPosition(group brevity.Group, precision int) string {
+	for _, id := range group.ObjectIDs() {
+		trackfile := c.scope.FindUnit(id)
+		if trackfile == nil {
+			continue
+		}
+		return spatial.FormatMGRS(trackfile.LastKnown().Point, precision)
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGridPositionPoint(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{41.5997, 41.6094}
+	target := orb.Point{41.75, 41.65}
+	grid := spatial.FormatMGRS(target, 3)
+
+	point, err := gridPositionPoint(grid, origin)
+	require.NoError(t, err)
+	assert.Less(t, spatial.Distance(target, point), 150*unit.Meter)
+
+	_, withoutZone, _ := strings.Cut(grid, " ")
+	point, err = gridPositionPoint(withoutZone, origin)
+	require.NoError(t, err, "grid zone should be taken from the origin")
+	assert.Less(t, spatial.Distance(target, point), 150*unit.Meter)
+
+	_, err = gridPositionPoint("IO 123 456", origin)
+	require.Error(t, err)
+}
+
+func TestGridPrecision(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 3, gridPrecision("38T LM 123 456"))
+	assert.Equal(t, 2, gridPrecision("LM 12 34"))
+	assert.Equal(t, 0, gridPrecision("38T LM"))
+}
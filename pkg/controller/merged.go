@@ -2,38 +2,51 @@ package controller
 
 import (
 	"context"
-	"maps"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
 // mergeTracker tracks hostile IDs and the friendly IDs they have merged with.
 type mergeTracker struct {
-	merged map[uint64]map[uint64]struct{}
+	// merged maps hostile IDs to friendly IDs to the state of their merge.
+	merged map[uint64]map[uint64]*brevity.Merge
 	lock   sync.RWMutex
 }
 
 func newMergeTracker() *mergeTracker {
 	return &mergeTracker{
-		merged: make(map[uint64]map[uint64]struct{}),
+		merged: make(map[uint64]map[uint64]*brevity.Merge),
 	}
 }
 
-// merge records that the given hostile has merged with the given friendly.
-func (t *mergeTracker) merge(hostileID, friendID uint64) {
+// update records the given hostile and friendly entering or exiting the merge according to the given criteria.
+// It returns true if the contacts newly entered the merge.
+func (t *mergeTracker) update(hostileID, friendID uint64, criteria brevity.MergeCriteria, distance, altitudeSeparation unit.Length, now time.Time) bool {
 	t.lock.Lock()
 	defer t.lock.Unlock()
+	return t.get(hostileID, friendID).Update(criteria, distance, altitudeSeparation, now)
+}
+
+// get returns the merge state for the given hostile and friendly, creating it if needed. The caller must hold the lock.
+func (t *mergeTracker) get(hostileID, friendID uint64) *brevity.Merge {
 	friendIDs, ok := t.merged[hostileID]
 	if !ok {
-		friendIDs = make(map[uint64]struct{})
+		friendIDs = make(map[uint64]*brevity.Merge)
 		t.merged[hostileID] = friendIDs
 	}
-	friendIDs[friendID] = struct{}{}
+	m, ok := friendIDs[friendID]
+	if !ok {
+		m = &brevity.Merge{}
+		friendIDs[friendID] = m
+	}
+	return m
 }
 
 // isMerged checks if the given hostile has merged with the given friendly.
@@ -44,19 +57,21 @@ func (t *mergeTracker) isMerged(hostileID, friendID uint64) bool {
 	if !ok {
 		return false
 	}
-	_, ok = friendIDs[friendID]
-	return ok
+	m, ok := friendIDs[friendID]
+	return ok && m.IsMerged()
 }
 
 // friendliesMergedWith returns the IDS that the given hostile ID is merged with.
 func (t *mergeTracker) friendliesMergedWith(hostileID uint64) []uint64 {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
-	friendIDs, ok := t.merged[hostileID]
-	if !ok {
-		return []uint64{}
+	ids := []uint64{}
+	for friendID, m := range t.merged[hostileID] {
+		if m.IsMerged() {
+			ids = append(ids, friendID)
+		}
 	}
-	return slices.Collect(maps.Keys(friendIDs))
+	return ids
 }
 
 // separate records that the given hostile and friendly IDs have exited the merge.
@@ -104,12 +119,12 @@ func (t *mergeTracker) keep(idsToKeep ...uint64) {
 func (t *mergeTracker) reset() {
 	t.lock.Lock()
 	defer t.lock.Unlock()
-	t.merged = make(map[uint64]map[uint64]struct{})
+	t.merged = make(map[uint64]map[uint64]*brevity.Merge)
 }
 
 // broadcastMerges updates the merge tracker and broadcasts merged calls for any new merges.
 func (c *controller) broadcastMerges(ctx context.Context) {
-	merges := c.scope.Merges(c.coalition)
+	merges := c.scope.Merges(c.coalition, c.mergeCriteria.ExitDistance)
 
 	hostileIDs := make([]uint64, 0)
 	for group := range merges {
@@ -121,7 +136,7 @@ func (c *controller) broadcastMerges(ctx context.Context) {
 		newMergedFriendlies := c.updateMergesForGroup(hostileGroup, friendlies)
 
 		logger := log.With().Stringer("group", hostileGroup).Logger()
-		mergedCall := c.createMergedCall(newMergedFriendlies)
+		mergedCall := c.createMergedCall(hostileGroup, newMergedFriendlies)
 		if len(mergedCall.Callsigns) > 0 {
 			logger.Info().Strs("callsigns", mergedCall.Callsigns).Msg("broadcasting merged call")
 			c.calls <- NewCall(ctx, mergedCall)
@@ -179,26 +194,32 @@ func (c *controller) updateMergesForContact(hostile, friendly *trackfiles.Trackf
 		c.merges.separate(hostile.Contact.ID, friendly.Contact.ID)
 		return false
 	}
-	distance := spatial.Distance(friendly.LastKnown().Point, hostile.LastKnown().Point)
-	enteredMerge := distance < brevity.MergeEntryDistance
-	exitedMerge := distance > brevity.MergeExitDistance
+	friendlyFrame := friendly.LastKnown()
+	hostileFrame := hostile.LastKnown()
+	distance := spatial.Distance(friendlyFrame.Point, hostileFrame.Point)
+	altitudeSeparation := friendlyFrame.Altitude - hostileFrame.Altitude
+	now := hostileFrame.Time
+	if friendlyFrame.Time.After(now) {
+		now = friendlyFrame.Time
+	}
 
-	if !isMerged && enteredMerge {
+	enteredMerge := c.merges.update(hostile.Contact.ID, friendly.Contact.ID, c.mergeCriteria, distance, altitudeSeparation, now)
+	if enteredMerge {
 		logger.Info().Msg("hostile and friendly merged")
-		c.merges.merge(hostile.Contact.ID, friendly.Contact.ID)
 		return true
-	} else if isMerged && exitedMerge {
+	}
+	if isMerged && !c.merges.isMerged(hostile.Contact.ID, friendly.Contact.ID) {
 		logger.Info().Msg("hostile and friendly exited merge")
-		c.merges.separate(hostile.Contact.ID, friendly.Contact.ID)
 	} else if isMerged {
 		logger.Debug().Msg("hostile and friendly were already merged")
 	}
 	return false
 }
 
-func (c *controller) createMergedCall(friendlies []*trackfiles.Trackfile) brevity.MergedCall {
+func (c *controller) createMergedCall(hostileGroup brevity.Group, friendlies []*trackfiles.Trackfile) brevity.MergedCall {
 	call := brevity.MergedCall{
-		Callsigns: make([]string, 0),
+		Callsigns:    make([]string, 0),
+		HostileGroup: hostileGroup,
 	}
 	for _, friendly := range friendlies {
 		call.Callsigns = c.addFriendlyToBroadcast(call.Callsigns, friendly)
@@ -2,31 +2,39 @@ package controller
 
 import (
 	"testing"
+	"time"
 
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
 	"github.com/stretchr/testify/assert"
 )
 
+// merge records that the given hostile has entered the merge with the given friendly.
+func merge(tracker *mergeTracker, hostileID, friendID uint64) {
+	tracker.update(hostileID, friendID, brevity.DefaultMergeCriteria, 0, 0, time.Now())
+}
+
 func TestMergeTrackerMerge(t *testing.T) {
 	t.Parallel()
 	tracker := newMergeTracker()
-	tracker.merge(1, 2)
+	merge(tracker, 1, 2)
 	assert.True(t, tracker.isMerged(1, 2))
 	assert.False(t, tracker.isMerged(2, 1))
 	assert.False(t, tracker.isMerged(1, 3))
-	tracker.merge(1, 3)
+	merge(tracker, 1, 3)
 	assert.True(t, tracker.isMerged(1, 3))
-	tracker.merge(4, 1)
+	merge(tracker, 4, 1)
 	assert.True(t, tracker.isMerged(4, 1))
 }
 
 func TestMergeTrackerFriendliesMergedWith(t *testing.T) {
 	t.Parallel()
 	tracker := newMergeTracker()
-	tracker.merge(1, 2)
+	merge(tracker, 1, 2)
 	assert.Len(t, tracker.friendliesMergedWith(1), 1)
 	assert.Contains(t, tracker.friendliesMergedWith(1), uint64(2))
 	assert.Empty(t, tracker.friendliesMergedWith(3))
-	tracker.merge(1, 3)
+	merge(tracker, 1, 3)
 	assert.Len(t, tracker.friendliesMergedWith(1), 2)
 	assert.Contains(t, tracker.friendliesMergedWith(1), uint64(3))
 }
@@ -34,8 +42,8 @@ func TestMergeTrackerFriendliesMergedWith(t *testing.T) {
 func TestMergeTrackerSeparate(t *testing.T) {
 	t.Parallel()
 	tracker := newMergeTracker()
-	tracker.merge(1, 2)
-	tracker.merge(1, 3)
+	merge(tracker, 1, 2)
+	merge(tracker, 1, 3)
 	assert.True(t, tracker.isMerged(1, 2))
 	assert.True(t, tracker.isMerged(1, 3))
 	tracker.separate(1, 2)
@@ -55,7 +63,7 @@ func TestMergeTrackerRemove(t *testing.T) {
 
 	for _, hostile := range []uint64{red1, red2, red3} {
 		for _, friendly := range []uint64{blue1, blue2, blue3} {
-			tracker.merge(hostile, friendly)
+			merge(tracker, hostile, friendly)
 		}
 	}
 
@@ -96,11 +104,11 @@ func TestMergeTrackerRemove(t *testing.T) {
 func TestMergeTrackerKeep(t *testing.T) {
 	t.Parallel()
 	tracker := newMergeTracker()
-	tracker.merge(1, 11)
-	tracker.merge(1, 12)
-	tracker.merge(2, 11)
-	tracker.merge(3, 12)
-	tracker.merge(4, 11)
+	merge(tracker, 1, 11)
+	merge(tracker, 1, 12)
+	merge(tracker, 2, 11)
+	merge(tracker, 3, 12)
+	merge(tracker, 4, 11)
 	tracker.keep(2, 4)
 	assert.False(t, tracker.isMerged(1, 11))
 	assert.False(t, tracker.isMerged(1, 12))
@@ -108,3 +116,20 @@ func TestMergeTrackerKeep(t *testing.T) {
 	assert.False(t, tracker.isMerged(3, 12))
 	assert.True(t, tracker.isMerged(4, 11))
 }
+
+func TestMergeTrackerUpdate(t *testing.T) {
+	t.Parallel()
+	tracker := newMergeTracker()
+	now := time.Now()
+	assert.False(t, tracker.update(1, 11, brevity.DefaultMergeCriteria, 4*unit.NauticalMile, 0, now))
+	assert.False(t, tracker.isMerged(1, 11))
+	assert.Empty(t, tracker.friendliesMergedWith(1))
+	assert.True(t, tracker.update(1, 11, brevity.DefaultMergeCriteria, 2*unit.NauticalMile, 0, now))
+	assert.True(t, tracker.isMerged(1, 11))
+	assert.False(t, tracker.update(1, 11, brevity.DefaultMergeCriteria, 2*unit.NauticalMile, 0, now))
+	assert.False(t, tracker.update(1, 11, brevity.DefaultMergeCriteria, 4*unit.NauticalMile, 0, now))
+	assert.True(t, tracker.isMerged(1, 11))
+	assert.False(t, tracker.update(1, 11, brevity.DefaultMergeCriteria, 6*unit.NauticalMile, 0, now))
+	assert.False(t, tracker.isMerged(1, 11))
+	assert.Empty(t, tracker.friendliesMergedWith(1))
+}
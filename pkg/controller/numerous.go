@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// numerousAlert decides when to alert that the number of hostile contacts has reached a threshold.
+type numerousAlert struct {
+	// threshold is the minimum number of hostile contacts which triggers an alert. Zero disables the alert.
+	threshold int
+	// cooldown is the minimum interval between alerts.
+	cooldown time.Duration
+	// interval is the minimum interval between counts of hostile contacts. Trackfiles are updated one at a time, so
+	// this limits counting to once per radar sweep rather than once per trackfile.
+	interval time.Duration
+	// deadline is the time after which another alert may be issued.
+	deadline time.Time
+	// nextCount is the time after which hostile contacts may be counted again.
+	nextCount time.Time
+	// lock protects deadline and nextCount.
+	lock sync.Mutex
+}
+
+func newNumerousAlert(threshold int, cooldown, interval time.Duration) *numerousAlert {
+	return &numerousAlert{
+		threshold: threshold,
+		cooldown:  cooldown,
+		interval:  interval,
+	}
+}
+
+// due returns true if hostile contacts should be counted at the given time. It returns false if the alert is disabled,
+// during the cooldown, and within the interval since the previous count.
+func (a *numerousAlert) due(now time.Time) bool {
+	if a.threshold <= 0 {
+		return false
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if now.Before(a.deadline) || now.Before(a.nextCount) {
+		return false
+	}
+	a.nextCount = now.Add(a.interval)
+	return true
+}
+
+// check returns true if an alert should be issued for the given number of hostile contacts at the given time. If so,
+// further alerts are suppressed until the cooldown expires.
+func (a *numerousAlert) check(contacts int, now time.Time) bool {
+	if a.threshold <= 0 || contacts < a.threshold {
+		return false
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if now.Before(a.deadline) {
+		return false
+	}
+	a.deadline = now.Add(a.cooldown)
+	return true
+}
+
+// reset allows an alert to be issued immediately.
+func (a *numerousAlert) reset() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.deadline = time.Time{}
+	a.nextCount = time.Time{}
+}
+
+// broadcastNumerous broadcasts a NUMEROUS call if the number of hostile contacts has reached the alert threshold. It
+// is called on each trackfile update, and counts hostile contacts at most once per radar sweep.
+func (c *controller) broadcastNumerous(ctx context.Context) {
+	now := time.Now()
+	if !c.numerous.due(now) || c.srsClient.ClientsOnFrequency() == 0 {
+		return
+	}
+	// Count the groups and contacts which a PICTURE would describe, so that both numbers describe the same contacts.
+	excludedIDs := c.outsideGeoFences(c.scope.Positions(c.coalition.Opposite()))
+	groups, contacts := c.scope.CountPicture(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing, excludedIDs)
+	if !c.numerous.check(contacts, now) {
+		return
+	}
+	log.Info().Int("contacts", contacts).Int("groups", groups).Msg("broadcasting NUMEROUS call")
+	c.calls <- NewCall(ctx, brevity.NumerousCall{Groups: groups, Contacts: contacts})
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/simpleradio/mock"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumerousAlert(t *testing.T) {
+	t.Parallel()
+	alert := newNumerousAlert(10, 5*time.Minute, 0)
+	now := time.Now()
+
+	assert.False(t, alert.check(9, now), "below threshold")
+	assert.True(t, alert.check(10, now), "at threshold")
+	assert.False(t, alert.check(12, now.Add(time.Minute)), "during cooldown")
+	assert.False(t, alert.check(12, now.Add(5*time.Minute-time.Second)), "just before cooldown expires")
+	assert.True(t, alert.check(12, now.Add(5*time.Minute)), "after cooldown expires")
+	assert.False(t, alert.check(12, now.Add(6*time.Minute)), "during second cooldown")
+
+	alert.reset()
+	assert.True(t, alert.check(12, now.Add(6*time.Minute)), "after reset")
+}
+
+func TestNumerousAlertDisabled(t *testing.T) {
+	t.Parallel()
+	alert := newNumerousAlert(0, 5*time.Minute, 0)
+	assert.False(t, alert.check(100, time.Now()))
+}
+
+func TestBroadcastNumerousCountsPictureContacts(t *testing.T) {
+	t.Parallel()
+	frequency := simpleradio.RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
+	srsClient := mock.NewMockClient(frequency)
+	srsClient.AddPeer(types.ClientInfo{
+		GUID:      "mobiusmobiusmobiusmob1",
+		Name:      "Mobius 1",
+		RadioInfo: types.RadioInfo{Radios: []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}}},
+	})
+	// Six hostile contacts are on the scope, but only four of them are in the PICTURE.
+	scope := &testRadar{
+		positions:       map[uint64]orb.Point{1: {}, 2: {}, 3: {}, 4: {}, 5: {}, 6: {}},
+		pictureGroups:   2,
+		pictureContacts: 4,
+	}
+
+	testCases := []struct {
+		threshold int
+		expected  []any
+	}{
+		{threshold: 5, expected: []any{}},
+		{threshold: 4, expected: []any{brevity.NumerousCall{Groups: 2, Contacts: 4}}},
+	}
+	for _, test := range testCases {
+		calls := make(chan Call, 1)
+		c := &controller{
+			coalition: coalitions.Blue,
+			scope:     scope,
+			srsClient: srsClient,
+			numerous:  newNumerousAlert(test.threshold, time.Minute, time.Second),
+			calls:     calls,
+		}
+		c.broadcastNumerous(context.Background())
+		close(calls)
+		actual := []any{}
+		for call := range calls {
+			actual = append(actual, call.Call)
+		}
+		require.Equal(t, test.expected, actual, "threshold %d", test.threshold)
+	}
+}
+
+func TestNumerousAlertDue(t *testing.T) {
+	t.Parallel()
+	alert := newNumerousAlert(10, 5*time.Minute, 2*time.Second)
+	now := time.Now()
+
+	assert.True(t, alert.due(now), "first count")
+	assert.False(t, alert.due(now.Add(time.Second)), "within sweep interval")
+	assert.True(t, alert.due(now.Add(2*time.Second)), "next sweep")
+	require.True(t, alert.check(10, now.Add(2*time.Second)))
+	assert.False(t, alert.due(now.Add(time.Minute)), "during cooldown")
+	assert.True(t, alert.due(now.Add(5*time.Minute+2*time.Second)), "after cooldown expires")
+
+	assert.False(t, newNumerousAlert(0, 5*time.Minute, 0).due(now), "disabled")
+}
+
+func TestNumerousOnRadarUpdate(t *testing.T) {
+	t.Parallel()
+	frequency := simpleradio.RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
+	srsClient := mock.NewMockClient(frequency)
+	srsClient.AddPeer(types.ClientInfo{
+		GUID:      "mobiusmobiusmobiusmob1",
+		Name:      "Mobius 1",
+		RadioInfo: types.RadioInfo{Radios: []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}}},
+	})
+	scope := &testRadar{
+		positions:       map[uint64]orb.Point{1: {}, 2: {}, 3: {}, 4: {}},
+		pictureGroups:   2,
+		pictureContacts: 4,
+	}
+	calls := make(chan Call, 3)
+	c := &controller{
+		coalition:       coalitions.Blue,
+		scope:           scope,
+		srsClient:       srsClient,
+		numerous:        newNumerousAlert(4, time.Hour, 0),
+		fades:           newFadeTracker(30*time.Second, 2*time.Minute),
+		merges:          newMergeTracker(),
+		threatCooldowns: newCooldownTracker(time.Minute),
+		responses:       newResponseCache(time.Minute, responseCacheThreshold),
+		calls:           calls,
+	}
+
+	// The first update alerts, and further updates are suppressed by the cooldown.
+	c.handleUpdated(1)
+	c.handleUpdated(2)
+	require.Len(t, calls, 1)
+
+	// A mission restart resets the cooldown and the clean picture state.
+	c.wasLastPictureClean.Store(true)
+	c.handleStarted()
+	assert.False(t, c.wasLastPictureClean.Load())
+	c.handleUpdated(3)
+	close(calls)
+	actual := []any{}
+	for call := range calls {
+		actual = append(actual, call.Call)
+	}
+	expected := brevity.NumerousCall{Groups: 2, Contacts: 4}
+	assert.Equal(t, []any{expected, expected}, actual)
+}
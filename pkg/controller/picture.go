@@ -2,10 +2,14 @@ package controller
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/dharmab/skyeye/internal/conf"
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -15,32 +19,108 @@ func (c *controller) HandlePicture(ctx context.Context, request *brevity.Picture
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
 
-	c.broadcastPicture(ctx, &logger, true)
+	if len(c.scope.Positions(c.coalition.Opposite())) == 0 {
+		logger.Info().Msg("no hostile contacts on the scope")
+		c.calls <- NewCall(ctx, noHostilesResponse(request.Callsign, c.scope.ContactCount()))
+		return
+	}
+
+	var ownship *brevity.Contact
+	if foundCallsign, trackfile, ok := c.findCallsign(request.Callsign); ok {
+		logger = logger.With().Str("foundCallsign", foundCallsign).Logger()
+		ownship = c.pictureOwnship(trackfile)
+	}
+
+	c.broadcastPicture(ctx, &logger, true, ownship)
+}
+
+// pictureOwnship returns the position of the requesting aircraft relative to the BULLSEYE, used to order the groups in
+// the PICTURE.
+func (c *controller) pictureOwnship(trackfile *trackfiles.Trackfile) *brevity.Contact {
+	bullseye := c.scope.Bullseye(c.coalition)
+	latest := trackfile.LastKnown()
+	bearing := c.scope.MagneticVariation().Magnetic(spatial.TrueBearing(bullseye, latest.Point), bullseye)
+	return &brevity.Contact{
+		Bullseye: brevity.NewBullseye(bearing, spatial.Distance(bullseye, latest.Point)),
+		Altitude: latest.Altitude,
+	}
+}
+
+// pictureFriendlies returns the positions of friendly aircraft relative to the BULLSEYE, used to anchor a PICTURE
+// broadcast to all friendly aircraft.
+func (c *controller) pictureFriendlies() []brevity.Contact {
+	positions := c.scope.Positions(c.coalition)
+	friendlies := make([]brevity.Contact, 0, len(positions))
+	for _, id := range slices.Sorted(maps.Keys(positions)) {
+		if trackfile := c.scope.FindUnit(id); trackfile != nil {
+			friendlies = append(friendlies, *c.pictureOwnship(trackfile))
+		}
+	}
+	return friendlies
 }
 
-func (c *controller) broadcastPicture(ctx context.Context, logger *zerolog.Logger, forceBroadcast bool) {
+// pictureCacheKey is the response cache key for the groups in a PICTURE.
+const pictureCacheKey = "PICTURE"
+
+// pictureGroups are the groups in a PICTURE before they are ordered. They are cached unordered, so that one cached
+// entry can be ordered for any requesting aircraft.
+type pictureGroups struct {
+	// count is the total number of groups.
+	count int
+	// groups are the highest priority groups.
+	groups []brevity.Group
+}
+
+// broadcastPicture broadcasts a PICTURE. If ownship is not nil, the groups are ordered by priority relative to the
+// ownship; otherwise, they are anchored relative to the friendly aircraft nearest to the groups.
+func (c *controller) broadcastPicture(ctx context.Context, logger *zerolog.Logger, forceBroadcast bool, ownship *brevity.Contact) {
 	if !forceBroadcast {
 		if c.srsClient.ClientsOnFrequency() == 0 {
 			logger.Debug().Msg("skipping PICTURE broadcast because no clients are on frequency")
 			return
 		}
+		if c.scope.ContactCount() == 0 {
+			logger.Debug().Msg("skipping PICTURE broadcast because the radar has no data")
+			return
+		}
 		c.scope.WaitUntilFadesResolve(ctx)
 	}
-	count, groups := c.scope.GetPicture(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing)
-	isPictureClean := count == 0
-	for _, group := range groups {
-		group.SetDeclaration(brevity.Hostile)
-		c.fillInMergeDetails(group)
+
+	positions := c.scope.Positions(c.coalition.Opposite())
+	var picture pictureGroups
+	cached, ok := c.responses.get(pictureCacheKey, positions, time.Now())
+	if forceBroadcast && ok {
+		logger.Info().Msg("using cached PICTURE groups because the picture has not changed")
+		picture = cached.(pictureGroups)
+	} else {
+		picture.count, picture.groups = c.scope.GetPicture(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing, c.outsideGeoFences(positions))
+		for _, group := range picture.groups {
+			group.SetDeclaration(brevity.Hostile)
+			c.fillInMergeDetails(group)
+		}
+		c.responses.put(pictureCacheKey, positions, picture, time.Now())
 	}
 
-	if c.wasLastPictureClean && isPictureClean && !forceBroadcast {
+	isPictureClean := picture.count == 0
+	if c.wasLastPictureClean.Load() && isPictureClean && !forceBroadcast {
 		logger.Info().Msg("skipping PICTURE broadcast because situation has not changed since last broadcast")
 	} else {
-		logger.Info().Int("groups", len(groups)).Int("count", count).Msg("broadcasting PICTURE")
-		c.calls <- NewCall(ctx, brevity.PictureResponse{Count: count, Groups: groups})
+		logger.Info().Int("groups", len(picture.groups)).Int("count", picture.count).Msg("broadcasting PICTURE")
+		var response brevity.PictureResponse
+		if ownship != nil {
+			response = brevity.NewPrioritizedPictureResponse(picture.count, picture.groups, *ownship, c.pictureWeights)
+		} else {
+			response = brevity.NewPictureResponse(picture.count, picture.groups, c.pictureFriendlies())
+		}
+		c.calls <- NewCall(ctx, response)
 	}
 
+	c.wasLastPictureClean.Store(isPictureClean)
+	c.extendPictureDeadline(logger)
+}
+
+// extendPictureDeadline postpones the next automatic PICTURE broadcast by the broadcast interval.
+func (c *controller) extendPictureDeadline(logger *zerolog.Logger) {
 	c.pictureBroadcastDeadline = time.Now().Add(c.pictureBroadcastInterval)
-	c.wasLastPictureClean = isPictureClean
 	logger.Info().Time("deadline", c.pictureBroadcastDeadline).Msg("extended next PICTURE broadcast time")
 }
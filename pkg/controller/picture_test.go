@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastPictureCachesGroupsOnce(t *testing.T) {
+	t.Parallel()
+	scope := &testRadar{positions: map[uint64]orb.Point{1: {33, 44}}}
+	calls := make(chan Call, 10)
+	c := &controller{
+		coalition: coalitions.Blue,
+		scope:     scope,
+		merges:    newMergeTracker(),
+		responses: newResponseCache(time.Minute, responseCacheThreshold),
+		calls:     calls,
+	}
+
+	for _, bearing := range []unit.Angle{0, 90 * unit.Degree, 180 * unit.Degree} {
+		ownship := &brevity.Contact{Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(bearing), 20*unit.NauticalMile)}
+		c.broadcastPicture(context.Background(), &log.Logger, true, ownship)
+		require.Len(t, calls, 1)
+		assert.IsType(t, brevity.PictureResponse{}, (<-calls).Call)
+	}
+
+	// Each requester's PICTURE is ordered from the same cached groups.
+	assert.Equal(t, 1, scope.pictures)
+	assert.Len(t, c.responses.responses, 1)
+}
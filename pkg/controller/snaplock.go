@@ -3,7 +3,9 @@ package controller
 import (
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/net/context"
 )
@@ -32,19 +34,15 @@ func (c *controller) HandleSnaplock(ctx context.Context, request *brevity.Snaplo
 	origin := trackfile.LastKnown().Point
 	pointOfInterest := spatial.PointAtBearingAndDistance(
 		origin,
-		request.BRA.Bearing().True(c.scope.Declination(origin)),
+		c.scope.MagneticVariation().True(request.BRA.Bearing(), origin),
 		request.BRA.Range(),
 	)
-	radius := 10 * unit.NauticalMile // TODO reduce to 3 when magvar is available
-	altitudeMargin := unit.Length(5000) * unit.Foot
-	minAltitude := request.BRA.Altitude() - altitudeMargin
-	maxAltitude := request.BRA.Altitude() + altitudeMargin
 	friendlyGroups := c.scope.FindNearbyGroupsWithBRAA(
 		origin,
 		pointOfInterest,
-		minAltitude,
-		maxAltitude,
-		radius,
+		lowestAltitude,
+		highestAltitude,
+		brevity.SnaplockSearchRadius,
 		c.coalition,
 		brevity.Aircraft,
 		[]uint64{trackfile.Contact.ID},
@@ -52,39 +50,54 @@ func (c *controller) HandleSnaplock(ctx context.Context, request *brevity.Snaplo
 	hostileGroups := c.scope.FindNearbyGroupsWithBRAA(
 		origin,
 		pointOfInterest,
-		minAltitude,
-		maxAltitude,
-		radius,
+		lowestAltitude,
+		highestAltitude,
+		brevity.SnaplockSearchRadius,
 		c.coalition.Opposite(),
 		brevity.Aircraft,
 		[]uint64{trackfile.Contact.ID},
 	)
 
-	response := brevity.SnaplockResponse{Callsign: foundCallsign}
-
-	// TODO better algorithm for picking from multiple possible groups
-	if len(friendlyGroups)+len(hostileGroups) == 0 {
-		response.Declaration = brevity.Clean
-	} else if len(friendlyGroups) > 0 && len(hostileGroups) == 0 {
-		response.Declaration = brevity.Friendly
-		response.Group = friendlyGroups[0]
-	} else if len(friendlyGroups) == 0 && len(hostileGroups) > 0 {
-		response.Declaration = brevity.Hostile
-		response.Group = hostileGroups[0]
-		for _, group := range hostileGroups {
-			if group.Aspect() == brevity.Hot {
-				response.Group = group
-				break
-			}
-		}
-	} else if len(friendlyGroups) > 0 && len(hostileGroups) > 0 {
-		response.Declaration = brevity.Furball
-	}
+	contacts := make([]brevity.SnaplockContact, 0)
+	contacts = append(contacts, c.snaplockContacts(pointOfInterest, friendlyGroups)...)
+	contacts = append(contacts, c.snaplockContacts(pointOfInterest, hostileGroups)...)
 
+	response := brevity.Snaplock(foundCallsign, *request, contacts)
 	if response.Group != nil {
-		response.Group.SetDeclaration(response.Declaration)
 		c.fillInMergeDetails(response.Group)
 	}
 
 	c.calls <- NewCall(ctx, response)
 }
+
+// snaplockContacts returns a contact for each group, with its declaration relative to the controller's coalition and
+// its offset from the point of interest to the group's nearest aircraft.
+func (c *controller) snaplockContacts(pointOfInterest orb.Point, groups []brevity.Group) []brevity.SnaplockContact {
+	contacts := make([]brevity.SnaplockContact, 0, len(groups))
+	for _, group := range groups {
+		var nearest *trackfiles.Trackfile
+		var distance unit.Length
+		for _, id := range group.ObjectIDs() {
+			trackfile := c.scope.FindUnit(id)
+			if trackfile == nil {
+				continue
+			}
+			d := spatial.Distance(pointOfInterest, trackfile.LastKnown().Point)
+			if nearest == nil || d < distance {
+				nearest = trackfile
+				distance = d
+			}
+		}
+		if nearest == nil {
+			continue
+		}
+		bearing := c.scope.MagneticVariation().Magnetic(spatial.TrueBearing(pointOfInterest, nearest.LastKnown().Point), pointOfInterest)
+		contacts = append(contacts, brevity.SnaplockContact{
+			Group:       group,
+			Declaration: brevity.CoalitionDeclaration(c.coalition, nearest.Contact.Coalition),
+			Bearing:     bearing,
+			Distance:    distance,
+		})
+	}
+	return contacts
+}
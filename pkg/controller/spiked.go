@@ -39,25 +39,23 @@ func (c *controller) HandleSpiked(ctx context.Context, request *brevity.SpikedRe
 
 	if nearestGroup == nil {
 		logger.Info().Msg("no hostile groups found within spike cone")
-		c.calls <- NewCall(ctx, brevity.SpikedResponse{
-			Callsign: foundCallsign,
-			Status:   false,
-			Bearing:  request.Bearing,
-		})
+		closestGroup := c.scope.FindNearestGroupWithBullseye(
+			origin,
+			lowestAltitude,
+			highestAltitude,
+			distance,
+			c.coalition.Opposite(),
+			brevity.FixedWing,
+		)
+		if closestGroup != nil {
+			closestGroup.SetDeclaration(brevity.Hostile)
+		}
+		c.calls <- NewCall(ctx, brevity.NewSpikedResponse(foundCallsign, request.Bearing, nil, closestGroup))
 		return
 	}
 
 	logger = logger.With().Stringer("group", nearestGroup).Logger()
 	logger.Debug().Msg("hostile group found within spike cone")
-	c.calls <- NewCall(ctx, brevity.SpikedResponse{
-		Callsign:    foundCallsign,
-		Status:      true,
-		Bearing:     request.Bearing,
-		Range:       nearestGroup.BRAA().Range(),
-		Altitude:    nearestGroup.BRAA().Altitude(),
-		Aspect:      nearestGroup.BRAA().Aspect(),
-		Track:       nearestGroup.Track(),
-		Declaration: brevity.Hostile,
-		Contacts:    nearestGroup.Contacts(),
-	})
+	nearestGroup.SetDeclaration(brevity.Hostile)
+	c.calls <- NewCall(ctx, brevity.NewSpikedResponse(foundCallsign, request.Bearing, nearestGroup, nil))
 }
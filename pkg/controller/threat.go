@@ -2,10 +2,12 @@ package controller
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
@@ -61,9 +63,27 @@ func (c *controller) broadcastThreats(ctx context.Context) {
 		return
 	}
 	threats := c.scope.Threats(c.coalition.Opposite())
+	for _, hostileGroup := range c.rankThreats(threats) {
+		c.broadcastThreat(ctx, hostileGroup, threats[hostileGroup])
+	}
+}
+
+// rankThreats orders the threatening groups from most to least threatening, so that the most urgent threat calls are
+// broadcast first. Each group is ranked by [brevity.CompareThreatRankBy] relative to the first friendly aircraft it
+// threatens.
+func (c *controller) rankThreats(threats map[brevity.Group][]uint64) []brevity.Group {
+	altitudes := make(map[brevity.Group]unit.Length, len(threats))
+	groups := make([]brevity.Group, 0, len(threats))
 	for hostileGroup, friendIDs := range threats {
-		c.broadcastThreat(ctx, hostileGroup, friendIDs)
+		if len(friendIDs) > 0 {
+			if friendly := c.scope.FindUnit(friendIDs[0]); friendly != nil {
+				altitudes[hostileGroup] = friendly.LastKnown().Altitude
+			}
+		}
+		groups = append(groups, hostileGroup)
 	}
+	slices.SortFunc(groups, brevity.CompareThreatRankBy(func(g brevity.Group) unit.Length { return altitudes[g] }))
+	return groups
 }
 
 func (c *controller) broadcastThreat(ctx context.Context, hostileGroup brevity.Group, friendIDs []uint64) {
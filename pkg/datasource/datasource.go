@@ -0,0 +1,26 @@
+// package datasource defines the interface between the GCI and the sources of radar contacts, such as Tacview
+// real-time telemetry or LotATC.
+package datasource
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/paulmach/orb"
+)
+
+// ContactSource provides radar contacts, bullseyes and mission time to the GCI.
+type ContactSource interface {
+	// Run reads data from the source until the context is cancelled or an error occurs.
+	Run(context.Context, *sync.WaitGroup) error
+	// Stream publishes mission starts, contact updates and contact fades to the given channels until the context is
+	// cancelled.
+	Stream(context.Context, *sync.WaitGroup, chan<- sim.Started, chan<- sim.Updated, chan<- sim.Faded)
+	// Bullseye returns the bullseye for the given coalition, or an error if the bullseye is not known.
+	Bullseye(coalitions.Coalition) (orb.Point, error)
+	// Time returns the current mission time.
+	Time() time.Time
+}
@@ -0,0 +1,158 @@
+// package dcs reads radar contacts from a DCS World export script which streams world objects as JSON over TCP.
+package dcs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/datasource"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// maxMessageSize is the largest single message the client will read.
+const maxMessageSize = 4 * 1024 * 1024
+
+// retryInterval is the minimum time between connection attempts.
+const retryInterval = 10 * time.Second
+
+// Client reads radar contacts from the DCS export socket.
+type Client struct {
+	// address of the export socket, including port.
+	address string
+	// coalition is the GCI's coalition. It is used to resolve IFF classifications in the type mapping.
+	coalition coalitions.Coalition
+	// mapping is the type mapping. It may be nil.
+	mapping *TypeMapping
+
+	*datasource.Snapshot
+}
+
+var _ datasource.ContactSource = &Client{}
+
+// NewClient creates a client which connects to the DCS export socket at the given address. The mapping may be nil.
+// Aircraft which appear more than once in a message are merged using the given deduplication keys.
+func NewClient(address string, coalition coalitions.Coalition, mapping *TypeMapping, updateInterval time.Duration, deduplication []datasource.DeduplicationKey) *Client {
+	return &Client{
+		address:   address,
+		coalition: coalition,
+		mapping:   mapping,
+		Snapshot:  datasource.NewSnapshot(updateInterval, deduplication),
+	}
+}
+
+// Run implements [datasource.ContactSource.Run]. It reconnects if the connection is lost.
+func (c *Client) Run(ctx context.Context, _ *sync.WaitGroup) error {
+	for {
+		nextAttempt := time.Now().Add(retryInterval)
+		if err := c.connect(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Error().Err(err).Msg("error reading DCS export, retrying")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Until(nextAttempt)):
+		}
+	}
+}
+
+func (c *Client) connect(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to DCS export socket: %w", err)
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	log.Info().Str("address", c.address).Msg("connected to DCS export socket")
+	return c.read(ctx, conn)
+}
+
+// read handles messages from the reader until it is exhausted. The export stream is newline-delimited JSON.
+func (c *Client) read(ctx context.Context, r io.Reader) error {
+	if err := c.Start(ctx); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := c.handleMessage(ctx, scanner.Bytes()); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Warn().Err(err).Msg("skipping invalid DCS export message")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read DCS export stream: %w", err)
+	}
+	return nil
+}
+
+// handleMessage updates the client state from a single message. Contacts which were in the previous message but are
+// missing from this one are faded.
+func (c *Client) handleMessage(ctx context.Context, b []byte) error {
+	m, err := parseMessage(b)
+	if err != nil {
+		return err
+	}
+
+	missionTime := m.Time
+	if missionTime.IsZero() {
+		missionTime = c.Time()
+	}
+	bullseyes := make(map[coalitions.Coalition]orb.Point, len(m.Bullseyes))
+	for name, position := range m.Bullseyes {
+		bullseyes[parseCoalitionName(name)] = position.point()
+	}
+
+	var errs error
+	updates := make([]sim.Updated, 0, len(m.Objects))
+	for key, object := range m.Objects {
+		if !object.isAircraft() {
+			continue
+		}
+		id, err := parseObjectID(key)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		updates = append(updates, sim.Updated{
+			Labels: c.labels(id, object),
+			Frame:  object.frame(missionTime),
+		})
+	}
+	if err := c.Update(ctx, missionTime, bullseyes, updates); err != nil {
+		return err
+	}
+	return errs
+}
+
+// labels converts the object to trackfile labels, applying the type mapping.
+func (c *Client) labels(id uint64, object worldObject) trackfiles.Labels {
+	return trackfiles.Labels{
+		ID:        id,
+		Name:      object.UnitName,
+		Coalition: c.mapping.Coalition(object.Name, parseCoalitionID(object.CoalitionID), c.coalition),
+		ACMIName:  c.mapping.Name(object.Name),
+	}
+}
@@ -0,0 +1,107 @@
+package dcs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestTypeMapping(t *testing.T) *TypeMapping {
+	t.Helper()
+	mapping, err := LoadTypeMapping(filepath.Join("testdata", "types.yaml"))
+	require.NoError(t, err)
+	return mapping
+}
+
+func TestReadRecordedStream(t *testing.T) {
+	t.Parallel()
+	c := NewClient("", coalitions.Blue, loadTestTypeMapping(t), time.Second, nil)
+	f, err := os.Open(filepath.Join("testdata", "export.jsonl"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	starts := make(chan sim.Started, 1)
+	fades := make(chan sim.Faded, 1)
+	go c.Stream(ctx, &sync.WaitGroup{}, starts, make(chan sim.Updated), fades)
+	require.NoError(t, c.read(ctx, f))
+	<-starts
+
+	// The Flanker is missing from the last message, so it fades.
+	assert.Equal(t, uint64(16778496), (<-fades).ID)
+
+	assert.Equal(t, time.Date(2024, 8, 1, 10, 15, 40, 0, time.UTC), c.Time())
+	bullseye, err := c.Bullseye(coalitions.Blue)
+	require.NoError(t, err)
+	assert.Equal(t, orb.Point{42.4826, 42.1769}, bullseye)
+	_, err = c.Bullseye(coalitions.Neutrals)
+	require.Error(t, err)
+
+	// The SAM site is not an aircraft.
+	contacts := make(map[uint64]sim.Updated)
+	for _, contact := range c.Contacts() {
+		contacts[contact.Labels.ID] = contact
+	}
+	require.Len(t, contacts, 3)
+
+	hornet := contacts[16778240]
+	assert.Equal(t, "Enfield 1-1", hornet.Labels.Name)
+	assert.Equal(t, "FA-18C_hornet", hornet.Labels.ACMIName)
+	assert.Equal(t, coalitions.Coalition(coalitions.Blue), hornet.Labels.Coalition)
+	assert.Equal(t, orb.Point{42.06, 42.25}, hornet.Frame.Point)
+	assert.InDelta(t, 25000, hornet.Frame.Altitude.Feet(), 1)
+	assert.InDelta(t, 270, hornet.Frame.Heading.Degrees(), 0.001)
+	assert.Equal(t, c.Time(), hornet.Frame.Time)
+
+	// The MiG's exported coalition is overridden by the type mapping.
+	assert.Equal(t, coalitions.Coalition(coalitions.Red), contacts[16778752].Labels.Coalition)
+	// The mod aircraft is renamed by the type mapping.
+	assert.Equal(t, "F-4E", contacts[16779008].Labels.ACMIName)
+}
+
+func TestTypeMappingCoalition(t *testing.T) {
+	t.Parallel()
+	mapping := loadTestTypeMapping(t)
+	testCases := []struct {
+		dcsType  string
+		exported coalitions.Coalition
+		own      coalitions.Coalition
+		expected coalitions.Coalition
+	}{
+		{dcsType: "MiG-29S", exported: coalitions.Neutrals, own: coalitions.Blue, expected: coalitions.Red},
+		{dcsType: "MiG-29S", exported: coalitions.Blue, own: coalitions.Red, expected: coalitions.Blue},
+		{dcsType: "MiG-29S", exported: coalitions.Red, own: coalitions.Neutrals, expected: coalitions.Red},
+		{dcsType: "An-26B", exported: coalitions.Red, own: coalitions.Blue, expected: coalitions.Neutrals},
+		{dcsType: "VSN_F4E", exported: coalitions.Red, own: coalitions.Blue, expected: coalitions.Red},
+		{dcsType: "F-16C_50", exported: coalitions.Blue, own: coalitions.Blue, expected: coalitions.Blue},
+	}
+	for _, test := range testCases {
+		t.Run(test.dcsType, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, mapping.Coalition(test.dcsType, test.exported, test.own))
+		})
+	}
+
+	var empty *TypeMapping
+	assert.Equal(t, coalitions.Coalition(coalitions.Red), empty.Coalition("MiG-29S", coalitions.Red, coalitions.Blue))
+	assert.Equal(t, "MiG-29S", empty.Name("MiG-29S"))
+}
+
+func TestParseInvalidTypeMapping(t *testing.T) {
+	t.Parallel()
+	_, err := ParseTypeMapping([]byte("types:\n  Su-27:\n    iff: foe\n"))
+	require.Error(t, err)
+	_, err = ParseTypeMapping([]byte("types: ["))
+	require.Error(t, err)
+}
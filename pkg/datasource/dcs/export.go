@@ -0,0 +1,116 @@
+package dcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// airLevel is the level1 type category of aircraft in DCS World.
+const airLevel = 1
+
+// message is a single line of the JSON export stream. Each message is a complete snapshot of the world objects visible
+// to the export script, in the structure returned by LoGetWorldObjects().
+type message struct {
+	// Time is the mission time of the snapshot.
+	Time time.Time `json:"time"`
+	// Bullseyes maps coalition names to bullseye positions.
+	Bullseyes map[string]position `json:"bullseyes"`
+	// Objects maps DCS object IDs to world objects.
+	Objects map[string]worldObject `json:"objects"`
+}
+
+// position is a latitude and longitude in decimal degrees.
+type position struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+}
+
+func (p position) point() orb.Point {
+	return orb.Point{p.Longitude, p.Latitude}
+}
+
+// worldObject is a single unit in the export stream.
+type worldObject struct {
+	// Name is the DCS type name, e.g. "FA-18C_hornet".
+	Name string `json:"Name"`
+	// UnitName is the player's name or the unit name from the mission editor.
+	UnitName string `json:"UnitName"`
+	// CoalitionID is 0 for neutral, 1 for red and 2 for blue.
+	CoalitionID int `json:"CoalitionID"`
+	// Type is the DCS type hierarchy of the object.
+	Type objectType `json:"Type"`
+	// LatLongAlt is the position of the object. Altitude is in meters above sea level.
+	LatLongAlt struct {
+		Lat  float64 `json:"Lat"`
+		Long float64 `json:"Long"`
+		Alt  float64 `json:"Alt"`
+	} `json:"LatLongAlt"`
+	// Heading is the true heading in radians.
+	Heading float64 `json:"Heading"`
+}
+
+// objectType is the DCS type hierarchy. Only the top level is used.
+type objectType struct {
+	Level1 int `json:"level1"`
+}
+
+func parseMessage(b []byte) (*message, error) {
+	var m message
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal DCS export message: %w", err)
+	}
+	return &m, nil
+}
+
+func parseObjectID(s string) (uint64, error) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse object ID %q: %w", s, err)
+	}
+	return id, nil
+}
+
+func parseCoalitionName(s string) coalitions.Coalition {
+	switch strings.ToLower(s) {
+	case "red":
+		return coalitions.Red
+	case "blue":
+		return coalitions.Blue
+	default:
+		return coalitions.Neutrals
+	}
+}
+
+func parseCoalitionID(id int) coalitions.Coalition {
+	switch id {
+	case 1:
+		return coalitions.Red
+	case 2:
+		return coalitions.Blue
+	default:
+		return coalitions.Neutrals
+	}
+}
+
+// isAircraft returns true if the object is an airplane or helicopter.
+func (o worldObject) isAircraft() bool {
+	return o.Type.Level1 == airLevel
+}
+
+// frame converts the object to a trackfile frame at the given time.
+func (o worldObject) frame(t time.Time) trackfiles.Frame {
+	return trackfiles.Frame{
+		Time:     t,
+		Point:    orb.Point{o.LatLongAlt.Long, o.LatLongAlt.Lat},
+		Altitude: unit.Length(o.LatLongAlt.Alt) * unit.Meter,
+		Heading:  unit.Angle(o.Heading) * unit.Radian,
+	}
+}
@@ -0,0 +1,98 @@
+package dcs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"gopkg.in/yaml.v3"
+)
+
+// Classification is the IFF classification of an aircraft type, relative to the GCI's coalition.
+type Classification string
+
+const (
+	// Friendly aircraft are reported as members of the GCI's coalition.
+	Friendly Classification = "friendly"
+	// Hostile aircraft are reported as members of the opposing coalition.
+	Hostile Classification = "hostile"
+	// Neutral aircraft are reported as members of the neutral coalition.
+	Neutral Classification = "neutral"
+)
+
+// TypeInfo describes how an aircraft type in the DCS export is mapped.
+type TypeInfo struct {
+	// Name is the name of the type in the aircraft encyclopedia. If empty, the DCS type name is used.
+	Name string `yaml:"name"`
+	// IFF is the classification of the type. If empty, the coalition from the DCS export is used.
+	IFF Classification `yaml:"iff"`
+}
+
+// TypeMapping maps DCS aircraft type names to encyclopedia names and IFF classifications. This allows mission makers to
+// classify types when the export does not include accurate coalition data, such as on servers which restrict exports.
+type TypeMapping struct {
+	// Types maps DCS type names to type information.
+	Types map[string]TypeInfo `yaml:"types"`
+}
+
+// LoadTypeMapping reads a type mapping from a YAML file.
+func LoadTypeMapping(path string) (*TypeMapping, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read type mapping: %w", err)
+	}
+	return ParseTypeMapping(b)
+}
+
+// ParseTypeMapping parses a type mapping from YAML.
+func ParseTypeMapping(b []byte) (*TypeMapping, error) {
+	var mapping TypeMapping
+	if err := yaml.Unmarshal(b, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse type mapping: %w", err)
+	}
+	var err error
+	for name, info := range mapping.Types {
+		switch info.IFF {
+		case "", Friendly, Hostile, Neutral:
+		default:
+			err = errors.Join(err, fmt.Errorf("type %q has invalid IFF classification %q", name, info.IFF))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+// Name returns the encyclopedia name of the given DCS type.
+func (m *TypeMapping) Name(dcsType string) string {
+	if m != nil {
+		if info, ok := m.Types[dcsType]; ok && info.Name != "" {
+			return info.Name
+		}
+	}
+	return dcsType
+}
+
+// Coalition returns the coalition of an aircraft of the given DCS type. If the type has an IFF classification, it is
+// resolved relative to the GCI's coalition. Otherwise, the coalition from the export is returned, as it is for a hostile
+// type if the GCI's coalition has no opposing coalition.
+func (m *TypeMapping) Coalition(dcsType string, exported coalitions.Coalition, own coalitions.Coalition) coalitions.Coalition {
+	if m == nil {
+		return exported
+	}
+	switch m.Types[dcsType].IFF {
+	case Friendly:
+		return own
+	case Hostile:
+		if hostile, err := coalitions.Opposing(own); err == nil {
+			return hostile
+		}
+		return exported
+	case Neutral:
+		return coalitions.Neutrals
+	default:
+		return exported
+	}
+}
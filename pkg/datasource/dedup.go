@@ -0,0 +1,86 @@
+package datasource
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+)
+
+// DeduplicationKey is a property used to recognize the same aircraft reported more than once by a data source, such as
+// a DCS export script installed in more than one export hook.
+type DeduplicationKey string
+
+const (
+	// DeduplicateByID treats contacts with the same unit ID as the same aircraft.
+	DeduplicateByID DeduplicationKey = "id"
+	// DeduplicateByPosition treats contacts of the same coalition and aircraft type within DeduplicationDistance of
+	// each other as the same aircraft.
+	DeduplicateByPosition DeduplicationKey = "position"
+)
+
+// DeduplicationKeys returns all deduplication keys.
+func DeduplicationKeys() []DeduplicationKey {
+	return []DeduplicationKey{DeduplicateByID, DeduplicateByPosition}
+}
+
+// ParseDeduplicationKey parses a deduplication key by name.
+func ParseDeduplicationKey(s string) (DeduplicationKey, error) {
+	key := DeduplicationKey(s)
+	if !slices.Contains(DeduplicationKeys(), key) {
+		return "", fmt.Errorf("unknown deduplication key %q", s)
+	}
+	return key, nil
+}
+
+// DeduplicationDistance is the distance, both horizontally and vertically, within which contacts are considered to be
+// at the same position by [DeduplicateByPosition].
+const DeduplicationDistance = 10 * unit.Meter
+
+// DeduplicateContacts merges contacts which are the same aircraft according to any of the given keys. Of each set of
+// duplicates, the contact with the lowest unit ID is kept; of duplicates with the same ID, the most recent is kept.
+// The result is ordered by unit ID. If no keys are given, the contacts are returned unchanged.
+func DeduplicateContacts(contacts []sim.Updated, keys []DeduplicationKey) []sim.Updated {
+	if len(keys) == 0 {
+		return contacts
+	}
+	byID := slices.Contains(keys, DeduplicateByID)
+	byPosition := slices.Contains(keys, DeduplicateByPosition)
+
+	sorted := slices.Clone(contacts)
+	slices.SortStableFunc(sorted, func(a, b sim.Updated) int {
+		return cmp.Or(
+			cmp.Compare(a.Labels.ID, b.Labels.ID),
+			b.Frame.Time.Compare(a.Frame.Time),
+		)
+	})
+
+	result := make([]sim.Updated, 0, len(sorted))
+	for _, contact := range sorted {
+		isDuplicate := slices.ContainsFunc(result, func(kept sim.Updated) bool {
+			if byID && kept.Labels.ID == contact.Labels.ID {
+				return true
+			}
+			return byPosition && isSamePosition(kept, contact)
+		})
+		if !isDuplicate {
+			result = append(result, contact)
+		}
+	}
+	return result
+}
+
+// isSamePosition returns true if the contacts are of the same coalition and aircraft type and within
+// DeduplicationDistance of each other.
+func isSamePosition(a, b sim.Updated) bool {
+	if a.Labels.Coalition != b.Labels.Coalition || a.Labels.ACMIName != b.Labels.ACMIName {
+		return false
+	}
+	isHorizontallyClose := spatial.Distance(a.Frame.Point, b.Frame.Point) <= DeduplicationDistance
+	altitudeDifference := a.Frame.Altitude - b.Frame.Altitude
+	isVerticallyClose := max(altitudeDifference, -altitudeDifference) <= DeduplicationDistance
+	return isHorizontallyClose && isVerticallyClose
+}
@@ -0,0 +1,102 @@
+package datasource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOrigin = orb.Point{33.405794, 69.047461}
+
+func newTestContact(id uint64, acmiName string, point orb.Point, altitude unit.Length, t time.Time) sim.Updated {
+	return sim.Updated{
+		Labels: trackfiles.Labels{
+			ID:        id,
+			Name:      acmiName,
+			Coalition: coalitions.Red,
+			ACMIName:  acmiName,
+		},
+		Frame: trackfiles.Frame{
+			Time:     t,
+			Point:    point,
+			Altitude: altitude,
+		},
+	}
+}
+
+func TestDeduplicateIdenticalContacts(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	contact := newTestContact(1, "MiG-29A", testOrigin, 20000*unit.Foot, now)
+	result := DeduplicateContacts([]sim.Updated{contact, contact}, DeduplicationKeys())
+	require.Len(t, result, 1)
+	assert.Equal(t, contact, result[0])
+}
+
+func TestDeduplicateByIDKeepsLatest(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	older := newTestContact(1, "MiG-29A", testOrigin, 20000*unit.Foot, now)
+	moved := spatial.PointAtBearingAndDistance(testOrigin, bearings.NewTrueBearing(90*unit.Degree), 1*unit.NauticalMile)
+	newer := newTestContact(1, "MiG-29A", moved, 20000*unit.Foot, now.Add(time.Second))
+	result := DeduplicateContacts([]sim.Updated{older, newer}, []DeduplicationKey{DeduplicateByID})
+	require.Len(t, result, 1)
+	assert.Equal(t, newer, result[0])
+}
+
+func TestDeduplicateByPosition(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		distance unit.Length
+		altitude unit.Length
+		acmiName string
+		expected int
+	}{
+		{name: "same position", acmiName: "MiG-29A", expected: 1},
+		{name: "within distance", distance: 5 * unit.Meter, altitude: 5 * unit.Meter, acmiName: "MiG-29A", expected: 1},
+		{name: "beyond distance", distance: 50 * unit.Meter, acmiName: "MiG-29A", expected: 2},
+		{name: "beyond altitude", altitude: 50 * unit.Meter, acmiName: "MiG-29A", expected: 2},
+		{name: "different type", acmiName: "Su-27", expected: 2},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			a := newTestContact(1, "MiG-29A", testOrigin, 20000*unit.Foot, now)
+			point := spatial.PointAtBearingAndDistance(testOrigin, bearings.NewTrueBearing(0), test.distance)
+			b := newTestContact(2, test.acmiName, point, 20000*unit.Foot+test.altitude, now)
+			result := DeduplicateContacts([]sim.Updated{b, a}, []DeduplicationKey{DeduplicateByPosition})
+			require.Len(t, result, test.expected)
+			assert.Equal(t, a, result[0])
+		})
+	}
+}
+
+func TestDeduplicateWithoutKeys(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	contact := newTestContact(1, "MiG-29A", testOrigin, 20000*unit.Foot, now)
+	result := DeduplicateContacts([]sim.Updated{contact, contact}, nil)
+	assert.Len(t, result, 2)
+}
+
+func TestParseDeduplicationKey(t *testing.T) {
+	t.Parallel()
+	for _, key := range DeduplicationKeys() {
+		parsed, err := ParseDeduplicationKey(string(key))
+		require.NoError(t, err)
+		assert.Equal(t, key, parsed)
+	}
+	_, err := ParseDeduplicationKey("callsign")
+	require.Error(t, err)
+}
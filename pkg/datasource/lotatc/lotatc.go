@@ -0,0 +1,100 @@
+// package lotatc reads radar contacts from a LotATC UDP JSON feed.
+package lotatc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/datasource"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// maxPacketSize is the largest UDP datagram the client will read.
+const maxPacketSize = 64 * 1024
+
+// Client reads radar contacts from a LotATC UDP JSON feed.
+type Client struct {
+	// address is the local UDP address to listen on.
+	address string
+
+	*datasource.Snapshot
+}
+
+var _ datasource.ContactSource = &Client{}
+
+// NewClient creates a client which listens for the LotATC feed on the given UDP address. Aircraft which appear more than
+// once in a packet are merged using the given deduplication keys.
+func NewClient(address string, updateInterval time.Duration, deduplication []datasource.DeduplicationKey) *Client {
+	return &Client{
+		address:  address,
+		Snapshot: datasource.NewSnapshot(updateInterval, deduplication),
+	}
+}
+
+// Run implements [datasource.ContactSource.Run].
+func (c *Client) Run(ctx context.Context, _ *sync.WaitGroup) error {
+	conn, err := net.ListenPacket("udp", c.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen for LotATC feed: %w", err)
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	log.Info().Str("address", conn.LocalAddr().String()).Msg("listening for LotATC feed")
+
+	if err := c.Start(ctx); err != nil {
+		return err
+	}
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to read LotATC packet: %w", err)
+		}
+		if err := c.handlePacket(ctx, buf[:n]); err != nil {
+			log.Warn().Err(err).Msg("skipping invalid LotATC packet")
+		}
+	}
+}
+
+// handlePacket updates the client state from a single packet. Contacts which were in the previous packet but are
+// missing from this one are faded.
+func (c *Client) handlePacket(ctx context.Context, b []byte) error {
+	p, err := parsePacket(b)
+	if err != nil {
+		return err
+	}
+
+	missionTime := p.Time
+	if missionTime.IsZero() {
+		missionTime = c.Time()
+	}
+	bullseyes := make(map[coalitions.Coalition]orb.Point, len(p.Bullseyes))
+	for name, position := range p.Bullseyes {
+		bullseyes[parseCoalition(name)] = position.point()
+	}
+	updates := make([]sim.Updated, 0, len(p.Contacts))
+	for _, contact := range p.Contacts {
+		updates = append(updates, sim.Updated{
+			Labels: contact.labels(),
+			Frame:  contact.frame(missionTime),
+		})
+	}
+	return c.Update(ctx, missionTime, bullseyes, updates)
+}
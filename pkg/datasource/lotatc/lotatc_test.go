@@ -0,0 +1,155 @@
+package lotatc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/datasource"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readPacket(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return b
+}
+
+func contactsByID(c *Client) map[uint64]sim.Updated {
+	contacts := make(map[uint64]sim.Updated)
+	for _, contact := range c.Contacts() {
+		contacts[contact.Labels.ID] = contact
+	}
+	return contacts
+}
+
+func TestHandlePacket(t *testing.T) {
+	t.Parallel()
+	c := NewClient("", time.Second, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, c.handlePacket(ctx, readPacket(t, "packet.json")))
+
+	assert.Equal(t, time.Date(2024, 8, 1, 10, 15, 30, 0, time.UTC), c.Time())
+	bullseye, err := c.Bullseye(coalitions.Blue)
+	require.NoError(t, err)
+	assert.Equal(t, orb.Point{42.4826, 42.1769}, bullseye)
+	_, err = c.Bullseye(coalitions.Neutrals)
+	require.Error(t, err)
+
+	contacts := contactsByID(c)
+	require.Len(t, contacts, 3)
+	flanker := contacts[16781826]
+	assert.Equal(t, "Yellow 13", flanker.Labels.Name)
+	assert.Equal(t, "Su-27", flanker.Labels.ACMIName)
+	assert.Equal(t, coalitions.Coalition(coalitions.Red), flanker.Labels.Coalition)
+	assert.Equal(t, orb.Point{41.2, 43.01}, flanker.Frame.Point)
+	assert.InDelta(t, 30000, flanker.Frame.Altitude.Feet(), 1)
+	assert.InDelta(t, 135.5, flanker.Frame.Heading.Degrees(), 0.001)
+	assert.Equal(t, c.Time(), flanker.Frame.Time)
+	assert.Equal(t, coalitions.Coalition(coalitions.Neutrals), contacts[16782082].Labels.Coalition)
+
+	// The Flanker is missing from the next packet, so it fades.
+	fades := make(chan sim.Faded, 1)
+	go c.Stream(ctx, &sync.WaitGroup{}, make(chan sim.Started), make(chan sim.Updated), fades)
+	require.NoError(t, c.handlePacket(ctx, readPacket(t, "packet2.json")))
+	assert.Equal(t, uint64(16781826), (<-fades).ID)
+	assert.Len(t, contactsByID(c), 2)
+	// Bullseyes are retained when a packet omits them.
+	_, err = c.Bullseye(coalitions.Red)
+	require.NoError(t, err)
+}
+
+func TestHandlePacketDeduplication(t *testing.T) {
+	t.Parallel()
+	// A section lined up on the runway, a few meters apart.
+	b := []byte(`{"time":"2024-08-01T10:15:30Z","contacts":[` +
+		`{"id":1,"callsign":"Mobius 1","type":"F-16C_50","coalition":"blue","lat":42.25,"lon":42.09,"alt":20,"heading":270},` +
+		`{"id":2,"callsign":"Mobius 2","type":"F-16C_50","coalition":"blue","lat":42.25003,"lon":42.09,"alt":20,"heading":270}]}`)
+
+	c := NewClient("", time.Second, []datasource.DeduplicationKey{datasource.DeduplicateByID})
+	require.NoError(t, c.handlePacket(context.Background(), b))
+	assert.Len(t, c.Contacts(), 2, "aircraft with different IDs should not be merged by ID")
+
+	c = NewClient("", time.Second, []datasource.DeduplicationKey{datasource.DeduplicateByPosition})
+	require.NoError(t, c.handlePacket(context.Background(), b))
+	contacts := c.Contacts()
+	require.Len(t, contacts, 1, "aircraft at the same position should be merged by position")
+	assert.Equal(t, uint64(1), contacts[0].Labels.ID)
+}
+
+func TestHandleInvalidPacket(t *testing.T) {
+	t.Parallel()
+	c := NewClient("", time.Second, nil)
+	require.Error(t, c.handlePacket(context.Background(), []byte("not json")))
+}
+
+func TestRunAndStream(t *testing.T) {
+	t.Parallel()
+	// Find a free port to listen on.
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.LocalAddr().String()
+	require.NoError(t, listener.Close())
+
+	c := NewClient(address, 50*time.Millisecond, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.Run(ctx, &wg)
+	}()
+
+	starts := make(chan sim.Started)
+	updates := make(chan sim.Updated)
+	fades := make(chan sim.Faded)
+	go c.Stream(ctx, &wg, starts, updates, fades)
+
+	select {
+	case <-starts:
+	case <-ctx.Done():
+		require.FailNow(t, "timed out waiting for start")
+	}
+
+	conn, err := net.Dial("udp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(readPacket(t, "packet.json"))
+	require.NoError(t, err)
+
+	received := make(map[uint64]sim.Updated)
+	for len(received) < 3 {
+		select {
+		case update := <-updates:
+			received[update.Labels.ID] = update
+		case <-ctx.Done():
+			require.FailNow(t, "timed out waiting for updates")
+		}
+	}
+	assert.Equal(t, "Mobius 1", received[16781570].Labels.Name)
+
+	_, err = conn.Write(readPacket(t, "packet2.json"))
+	require.NoError(t, err)
+	for {
+		select {
+		case fade := <-fades:
+			assert.Equal(t, uint64(16781826), fade.ID)
+			cancel()
+			require.ErrorIs(t, <-errChan, context.Canceled)
+			return
+		case <-updates:
+		case <-ctx.Done():
+			require.FailNow(t, "timed out waiting for fade")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package lotatc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// packet is a single datagram from the LotATC JSON feed. Each packet is a complete snapshot of the contacts visible to
+// the exporting coalition.
+type packet struct {
+	// Time is the mission time of the snapshot.
+	Time time.Time `json:"time"`
+	// Bullseyes maps coalition names to bullseye positions.
+	Bullseyes map[string]position `json:"bullseyes"`
+	// Contacts visible in this snapshot.
+	Contacts []contact `json:"contacts"`
+}
+
+// position is a latitude and longitude in decimal degrees.
+type position struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+}
+
+func (p position) point() orb.Point {
+	return orb.Point{p.Longitude, p.Latitude}
+}
+
+// contact is a single radar track in the LotATC feed.
+type contact struct {
+	// ID is the track number, which is stable for the lifetime of the track.
+	ID uint64 `json:"id"`
+	// Callsign is the player's name or the unit name.
+	Callsign string `json:"callsign"`
+	// Type is the DCS aircraft type, e.g. "F-16C_50".
+	Type string `json:"type"`
+	// Coalition is "red", "blue" or "neutral".
+	Coalition string `json:"coalition"`
+	position
+	// Altitude above sea level in meters.
+	Altitude float64 `json:"alt"`
+	// Heading is the true heading in degrees.
+	Heading float64 `json:"heading"`
+}
+
+func parsePacket(b []byte) (*packet, error) {
+	var p packet
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal LotATC packet: %w", err)
+	}
+	return &p, nil
+}
+
+func parseCoalition(s string) coalitions.Coalition {
+	switch strings.ToLower(s) {
+	case "red":
+		return coalitions.Red
+	case "blue":
+		return coalitions.Blue
+	default:
+		return coalitions.Neutrals
+	}
+}
+
+// labels converts the contact to trackfile labels.
+func (c contact) labels() trackfiles.Labels {
+	return trackfiles.Labels{
+		ID:        c.ID,
+		Name:      c.Callsign,
+		Coalition: parseCoalition(c.Coalition),
+		ACMIName:  c.Type,
+	}
+}
+
+// frame converts the contact to a trackfile frame at the given time.
+func (c contact) frame(t time.Time) trackfiles.Frame {
+	return trackfiles.Frame{
+		Time:     t,
+		Point:    c.point(),
+		Altitude: unit.Length(c.Altitude) * unit.Meter,
+		Heading:  unit.Angle(c.Heading) * unit.Degree,
+	}
+}
@@ -0,0 +1,175 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/paulmach/orb"
+)
+
+// Snapshot is the latest state reported by a data source which reports every contact in each message, such as the DCS
+// export script or LotATC. It implements [ContactSource.Stream], [ContactSource.Bullseye] and [ContactSource.Time], so
+// a data source only needs to read its messages and pass each one to [Snapshot.Update].
+type Snapshot struct {
+	// updateInterval is how often to send updates to the channels passed to Stream().
+	updateInterval time.Duration
+	// deduplication are the keys used to merge aircraft which appear more than once in a message.
+	deduplication []DeduplicationKey
+
+	starts chan sim.Started
+	fades  chan sim.Faded
+
+	// missionTime is the mission time of the latest message.
+	missionTime time.Time
+	// contacts maps object IDs to the latest labels and frame of each contact.
+	contacts map[uint64]sim.Updated
+	// bullseyes maps coalitions to bullseye positions.
+	bullseyes map[coalitions.Coalition]orb.Point
+	// lock protects missionTime, contacts and bullseyes.
+	lock sync.RWMutex
+}
+
+// NewSnapshot creates an empty snapshot which streams contact updates at the given interval. Aircraft which appear more
+// than once in a message are merged using the given deduplication keys.
+func NewSnapshot(updateInterval time.Duration, deduplication []DeduplicationKey) *Snapshot {
+	return &Snapshot{
+		updateInterval: updateInterval,
+		deduplication:  deduplication,
+		starts:         make(chan sim.Started),
+		fades:          make(chan sim.Faded),
+		contacts:       make(map[uint64]sim.Updated),
+		bullseyes:      make(map[coalitions.Coalition]orb.Point),
+	}
+}
+
+// Start publishes a mission start, e.g. when the data source connects.
+func (s *Snapshot) Start(ctx context.Context) error {
+	select {
+	case s.starts <- sim.Started{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Update records the state reported in a single message. A zero mission time leaves the mission time unchanged, and
+// bullseyes missing from the message are retained. The contacts replace the previous contacts after deduplication, and
+// contacts which were in the previous message but are missing from this one are faded.
+func (s *Snapshot) Update(ctx context.Context, missionTime time.Time, bullseyes map[coalitions.Coalition]orb.Point, contacts []sim.Updated) error {
+	faded := func() []uint64 {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if !missionTime.IsZero() {
+			s.missionTime = missionTime
+		}
+		for coalition, bullseye := range bullseyes {
+			s.bullseyes[coalition] = bullseye
+		}
+
+		contacts = DeduplicateContacts(contacts, s.deduplication)
+		seen := make(map[uint64]struct{}, len(contacts))
+		for _, contact := range contacts {
+			seen[contact.Labels.ID] = struct{}{}
+			s.contacts[contact.Labels.ID] = contact
+		}
+		ids := make([]uint64, 0)
+		for id := range s.contacts {
+			if _, ok := seen[id]; !ok {
+				delete(s.contacts, id)
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}()
+
+	for _, id := range faded {
+		select {
+		case s.fades <- sim.Faded{ID: id}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Contacts returns the latest labels and frame of each contact, in no particular order.
+func (s *Snapshot) Contacts() []sim.Updated {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	contacts := make([]sim.Updated, 0, len(s.contacts))
+	for _, contact := range s.contacts {
+		contacts = append(contacts, contact)
+	}
+	return contacts
+}
+
+// Stream implements [ContactSource.Stream].
+func (s *Snapshot) Stream(ctx context.Context, wg *sync.WaitGroup, starts chan<- sim.Started, updates chan<- sim.Updated, fades chan<- sim.Faded) {
+	ticker := time.NewTicker(s.updateInterval)
+	defer ticker.Stop()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case start := <-s.starts:
+				starts <- start
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case fade := <-s.fades:
+				fades <- fade
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sendUpdates(ctx, updates)
+		}
+	}
+}
+
+func (s *Snapshot) sendUpdates(ctx context.Context, updates chan<- sim.Updated) {
+	for _, update := range s.Contacts() {
+		select {
+		case updates <- update:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Bullseye implements [ContactSource.Bullseye].
+func (s *Snapshot) Bullseye(coalition coalitions.Coalition) (orb.Point, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if bullseye, ok := s.bullseyes[coalition]; ok {
+		return bullseye, nil
+	}
+	return orb.Point{}, errors.New("bullseye not found")
+}
+
+// Time implements [ContactSource.Time].
+func (s *Snapshot) Time() time.Time {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.missionTime
+}
@@ -0,0 +1,43 @@
+package datasource
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotUpdate(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewSnapshot(time.Hour, []DeduplicationKey{DeduplicateByID})
+	fades := make(chan sim.Faded, 1)
+	go s.Stream(ctx, &sync.WaitGroup{}, make(chan sim.Started), make(chan sim.Updated), fades)
+
+	missionTime := time.Date(2024, 8, 1, 10, 15, 30, 0, time.UTC)
+	bullseye := orb.Point{42.4826, 42.1769}
+	first := newTestContact(1, "MiG-29A", testOrigin, 20000*unit.Foot, missionTime)
+	second := newTestContact(2, "MiG-29A", testOrigin, 30000*unit.Foot, missionTime)
+	err := s.Update(ctx, missionTime, map[coalitions.Coalition]orb.Point{coalitions.Blue: bullseye}, []sim.Updated{first, second, second})
+	require.NoError(t, err)
+	assert.Len(t, s.Contacts(), 2, "duplicates should be merged")
+
+	// A message without a mission time or bullseyes leaves them unchanged, and the missing contact fades.
+	require.NoError(t, s.Update(ctx, time.Time{}, nil, []sim.Updated{second}))
+	assert.Equal(t, uint64(1), (<-fades).ID)
+	assert.Equal(t, []sim.Updated{second}, s.Contacts())
+	assert.Equal(t, missionTime, s.Time())
+	actual, err := s.Bullseye(coalitions.Blue)
+	require.NoError(t, err)
+	assert.Equal(t, bullseye, actual)
+	_, err = s.Bullseye(coalitions.Red)
+	require.Error(t, err)
+}
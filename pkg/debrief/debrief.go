@@ -0,0 +1,157 @@
+// package debrief records structured logs of GCI responses for post-mission debriefs.
+package debrief
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/rs/zerolog"
+)
+
+// Record is a structured record of a single response.
+type Record struct {
+	// Timestamp is when the response was issued.
+	Timestamp time.Time `json:"timestamp"`
+	// Response is the type of response, e.g. "PICTURE".
+	Response string `json:"response"`
+	// Callsign of the aircraft which requested the response. Empty for automatic broadcasts.
+	Callsign string `json:"callsign"`
+	// Coalition of the reported groups. This is sensitive and may be redacted.
+	Coalition string `json:"coalition,omitempty"`
+	// GroupCount is the total number of groups found, which may be more than the number of groups reported.
+	GroupCount int `json:"groupCount"`
+	// Groups which were reported.
+	Groups []Group `json:"groups"`
+}
+
+// Group is a structured record of a single group within a response.
+type Group struct {
+	// Bearing is the magnetic bearing from the requesting aircraft to the group in degrees, if known. This is sensitive
+	// and may be redacted.
+	Bearing *float64 `json:"bearing,omitempty"`
+	// Range from the requesting aircraft to the group in nautical miles, if known. This is sensitive and may be
+	// redacted.
+	Range *float64 `json:"range,omitempty"`
+	// Altitudes of each layer of the group's altitude STACK, in feet, highest first.
+	Altitudes []float64 `json:"altitudes"`
+	// Contacts is the number of contacts in the group.
+	Contacts int `json:"contacts"`
+	// Declaration is the IFF result for the group.
+	Declaration string `json:"declaration"`
+	// Bullseye is the group's position relative to the bullseye. This is sensitive and may be redacted.
+	Bullseye *Bullseye `json:"bullseye,omitempty"`
+}
+
+// Bullseye is a structured record of a bullseye position.
+type Bullseye struct {
+	// Bearing from the bullseye in magnetic degrees.
+	Bearing float64 `json:"bearing"`
+	// Distance from the bullseye in nautical miles.
+	Distance float64 `json:"distance"`
+}
+
+// NewPictureRecord creates a record of a PICTURE response.
+func NewPictureRecord(t time.Time, callsign string, coalition coalitions.Coalition, response brevity.PictureResponse) Record {
+	return newRecord(t, "PICTURE", callsign, coalition, response.Count, response.Groups...)
+}
+
+// NewBogeyDopeRecord creates a record of a BOGEY DOPE response.
+func NewBogeyDopeRecord(t time.Time, coalition coalitions.Coalition, response brevity.BogeyDopeResponse) Record {
+	if response.Group == nil {
+		return newRecord(t, "BOGEY DOPE", response.Callsign, coalition, 0)
+	}
+	return newRecord(t, "BOGEY DOPE", response.Callsign, coalition, 1, response.Group)
+}
+
+func newRecord(t time.Time, response, callsign string, coalition coalitions.Coalition, count int, groups ...brevity.Group) Record {
+	record := Record{
+		Timestamp:  t,
+		Response:   response,
+		Callsign:   callsign,
+		Coalition:  coalition.String(),
+		GroupCount: count,
+		Groups:     make([]Group, 0, len(groups)),
+	}
+	for _, group := range groups {
+		record.Groups = append(record.Groups, newGroup(group))
+	}
+	return record
+}
+
+func newGroup(group brevity.Group) Group {
+	g := Group{
+		Contacts:    group.Contacts(),
+		Declaration: string(group.Declaration()),
+		Altitudes:   make([]float64, 0),
+	}
+	for _, stack := range group.Stacks() {
+		g.Altitudes = append(g.Altitudes, stack.Altitude.Feet())
+	}
+	if braa := group.BRAA(); braa != nil {
+		bearing := braa.Bearing().RoundedDegrees()
+		distance := braa.Range().NauticalMiles()
+		g.Bearing, g.Range = &bearing, &distance
+	}
+	if bullseye := group.Bullseye(); bullseye != nil {
+		g.Bullseye = &Bullseye{
+			Bearing:  bullseye.Bearing().RoundedDegrees(),
+			Distance: bullseye.Distance().NauticalMiles(),
+		}
+	}
+	return g
+}
+
+// Field is a sensitive field which may be redacted from records.
+type Field string
+
+const (
+	// Coalition redacts the coalition of the reported groups.
+	Coalition Field = "coalition"
+	// Position redacts the bullseye position of each group, and its bearing and range from the requesting aircraft.
+	Position Field = "position"
+)
+
+// Fields returns all fields which may be redacted.
+func Fields() []Field {
+	return []Field{Coalition, Position}
+}
+
+// Filter redacts sensitive fields from records.
+type Filter struct {
+	redacted []Field
+}
+
+// NewFilter creates a filter which redacts the given fields.
+func NewFilter(fields ...Field) (Filter, error) {
+	for _, field := range fields {
+		if !slices.Contains(Fields(), field) {
+			return Filter{}, fmt.Errorf("unknown field %q", field)
+		}
+	}
+	return Filter{redacted: fields}, nil
+}
+
+// Apply returns a copy of the record with the filter's fields redacted.
+func (f Filter) Apply(record Record) Record {
+	if slices.Contains(f.redacted, Coalition) {
+		record.Coalition = ""
+	}
+	if slices.Contains(f.redacted, Position) {
+		groups := make([]Group, len(record.Groups))
+		for i, group := range record.Groups {
+			group.Bullseye = nil
+			group.Bearing, group.Range = nil, nil
+			groups[i] = group
+		}
+		record.Groups = groups
+	}
+	return record
+}
+
+// Log writes the record to the given logger after applying the filter.
+func Log(logger *zerolog.Logger, filter Filter, record Record) {
+	logger.Info().Interface("debrief", filter.Apply(record)).Msg("recorded response for debrief")
+}
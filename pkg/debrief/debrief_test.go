@@ -0,0 +1,174 @@
+package debrief
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGroup struct {
+	brevity.Group
+	contacts    int
+	altitudes   []unit.Length
+	declaration brevity.Declaration
+	braa        brevity.BRAA
+	bullseye    *brevity.Bullseye
+}
+
+func (g *fakeGroup) Contacts() int                    { return g.contacts }
+func (g *fakeGroup) Stacks() []brevity.Stack          { return brevity.Stacks(g.altitudes...) }
+func (g *fakeGroup) Declaration() brevity.Declaration { return g.declaration }
+func (g *fakeGroup) BRAA() brevity.BRAA               { return g.braa }
+func (g *fakeGroup) Bullseye() *brevity.Bullseye      { return g.bullseye }
+
+func logRecord(t *testing.T, filter Filter, record Record) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	Log(&logger, filter, record)
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	require.Contains(t, line, "debrief")
+	entry, ok := line["debrief"].(map[string]any)
+	require.True(t, ok)
+	return entry
+}
+
+func TestPictureRecord(t *testing.T) {
+	t.Parallel()
+	timestamp := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	response := brevity.PictureResponse{
+		Count: 3,
+		Groups: []brevity.Group{
+			&fakeGroup{
+				contacts:    2,
+				altitudes:   []unit.Length{25000 * unit.Foot, 25000 * unit.Foot},
+				declaration: brevity.Hostile,
+				bullseye:    brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 40*unit.NauticalMile),
+			},
+			&fakeGroup{
+				contacts:    1,
+				altitudes:   []unit.Length{5000 * unit.Foot},
+				declaration: brevity.Bogey,
+				bullseye:    brevity.NewBullseye(bearings.NewMagneticBearing(180*unit.Degree), 20*unit.NauticalMile),
+			},
+		},
+	}
+	record := NewPictureRecord(timestamp, "Eagle 1", coalitions.Red, response)
+	entry := logRecord(t, Filter{}, record)
+
+	assert.Equal(t, timestamp.Format(time.RFC3339), entry["timestamp"])
+	assert.Equal(t, "PICTURE", entry["response"])
+	assert.Equal(t, "Eagle 1", entry["callsign"])
+	assert.Equal(t, "Red", entry["coalition"])
+	assert.InDelta(t, 3, entry["groupCount"], 0)
+
+	groups, ok := entry["groups"].([]any)
+	require.True(t, ok)
+	require.Len(t, groups, 2)
+	group, ok := groups[0].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 2, group["contacts"], 0)
+	assert.Equal(t, "hostile", group["declaration"])
+	assert.Equal(t, []any{25000.0}, group["altitudes"])
+	assert.NotContains(t, group, "bearing")
+	assert.NotContains(t, group, "range")
+	bullseye, ok := group["bullseye"].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 90, bullseye["bearing"], 0.5)
+	assert.InDelta(t, 40, bullseye["distance"], 0.5)
+}
+
+func TestBogeyDopeRecord(t *testing.T) {
+	t.Parallel()
+	response := brevity.BogeyDopeResponse{
+		Callsign: "Viper 2",
+		Group: &fakeGroup{
+			contacts:    1,
+			altitudes:   []unit.Length{18000 * unit.Foot},
+			declaration: brevity.Hostile,
+			braa: brevity.NewBRAA(
+				bearings.NewMagneticBearing(270*unit.Degree),
+				35*unit.NauticalMile,
+				[]unit.Length{18000 * unit.Foot},
+				brevity.Hot,
+			),
+		},
+	}
+	record := NewBogeyDopeRecord(time.Now(), coalitions.Blue, response)
+	entry := logRecord(t, Filter{}, record)
+
+	assert.Equal(t, "BOGEY DOPE", entry["response"])
+	assert.Equal(t, "Viper 2", entry["callsign"])
+	assert.InDelta(t, 1, entry["groupCount"], 0)
+	groups, ok := entry["groups"].([]any)
+	require.True(t, ok)
+	require.Len(t, groups, 1)
+	group, ok := groups[0].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 270, group["bearing"], 0.5)
+	assert.InDelta(t, 35, group["range"], 0.5)
+	assert.Equal(t, []any{18000.0}, group["altitudes"])
+}
+
+func TestBogeyDopeRecordNoGroup(t *testing.T) {
+	t.Parallel()
+	record := NewBogeyDopeRecord(time.Now(), coalitions.Blue, brevity.BogeyDopeResponse{Callsign: "Viper 2"})
+	entry := logRecord(t, Filter{}, record)
+	assert.InDelta(t, 0, entry["groupCount"], 0)
+	assert.Equal(t, []any{}, entry["groups"])
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	response := brevity.PictureResponse{
+		Count: 1,
+		Groups: []brevity.Group{
+			&fakeGroup{
+				contacts:    1,
+				declaration: brevity.Hostile,
+				bullseye:    brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 40*unit.NauticalMile),
+				braa: brevity.NewBRAA(
+					bearings.NewMagneticBearing(360*unit.Degree),
+					20*unit.NauticalMile,
+					[]unit.Length{18000 * unit.Foot},
+					brevity.Hot,
+				),
+			},
+		},
+	}
+	record := NewPictureRecord(time.Now(), "", coalitions.Red, response)
+	filter, err := NewFilter(Coalition, Position)
+	require.NoError(t, err)
+	entry := logRecord(t, filter, record)
+
+	assert.NotContains(t, entry, "coalition")
+	groups, ok := entry["groups"].([]any)
+	require.True(t, ok)
+	group, ok := groups[0].(map[string]any)
+	require.True(t, ok)
+	assert.NotContains(t, group, "bullseye")
+	assert.NotContains(t, group, "bearing")
+	assert.NotContains(t, group, "range")
+	assert.Equal(t, "hostile", group["declaration"])
+
+	// The original record is not modified.
+	assert.Equal(t, "Red", record.Coalition)
+	assert.NotNil(t, record.Groups[0].Bullseye)
+	assert.NotNil(t, record.Groups[0].Bearing)
+}
+
+func TestNewFilterUnknownField(t *testing.T) {
+	t.Parallel()
+	_, err := NewFilter("callsign")
+	require.Error(t, err)
+}
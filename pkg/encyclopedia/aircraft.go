@@ -22,8 +22,44 @@ const (
 	Unarmed
 	Fighter
 	Attack
+	Bomber
+	Unmanned
 )
 
+// Role is a coarse classification of an aircraft's mission. It is used as a spoken fallback when an aircraft has no
+// more specific name.
+type Role int
+
+const (
+	UnknownRole Role = iota
+	FighterRole
+	AttackRole
+	BomberRole
+	HelicopterRole
+	UAVRole
+	TransportRole
+)
+
+// String returns the spoken word for the role.
+func (r Role) String() string {
+	switch r {
+	case FighterRole:
+		return "fighter"
+	case AttackRole:
+		return "attack"
+	case BomberRole:
+		return "bomber"
+	case HelicopterRole:
+		return "helicopter"
+	case UAVRole:
+		return "UAV"
+	case TransportRole:
+		return "transport"
+	default:
+		return "aircraft"
+	}
+}
+
 type Aircraft struct {
 	// ACMIShortName is the Name proeprty used in ACMI telemetry.
 	ACMIShortName string
@@ -61,6 +97,36 @@ func (a Aircraft) Category() brevity.ContactCategory {
 	return brevity.Aircraft
 }
 
+// Role returns the coarse role of the aircraft.
+func (a Aircraft) Role() Role {
+	switch {
+	case a.HasTag(Unmanned):
+		return UAVRole
+	case a.HasTag(RotaryWing):
+		return HelicopterRole
+	case a.HasTag(Bomber):
+		return BomberRole
+	case a.HasTag(Fighter):
+		return FighterRole
+	case a.HasTag(Attack):
+		return AttackRole
+	case a.HasTag(Unarmed):
+		return TransportRole
+	}
+	return UnknownRole
+}
+
+// ReportingName returns the name a controller should speak for the aircraft. In order of preference, this is the NATO
+// reporting name, nickname, official name, platform designation, or role.
+func (a Aircraft) ReportingName() string {
+	for _, name := range []string{a.NATOReportingName, a.Nickname, a.OfficialName, a.PlatformDesignation} {
+		if name != "" {
+			return name
+		}
+	}
+	return a.Role().String()
+}
+
 func (a Aircraft) Tags() []AircraftTag {
 	tags := []AircraftTag{}
 	for t := range a.tags {
@@ -459,6 +525,7 @@ func sa342Variants() []Aircraft {
 var ftData = Aircraft{
 	tags: map[AircraftTag]bool{
 		FixedWing: true,
+		Fighter:   true,
 	},
 	PlatformDesignation: "MiG-15",
 	NATOReportingName:   mig15NATOReportingName,
@@ -674,6 +741,7 @@ var mq9Data = Aircraft{
 	tags: map[AircraftTag]bool{
 		FixedWing: true,
 		Unarmed:   true,
+		Unmanned:  true,
 	},
 	PlatformDesignation: "MQ-9",
 	OfficialName:        "Reaper",
@@ -707,6 +775,7 @@ var aircraftData = []Aircraft{
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "A-20",
 		TypeDesignation:     "A-20G",
@@ -778,6 +847,7 @@ var aircraftData = []Aircraft{
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "B-17",
 		TypeDesignation:     "B-17G",
@@ -788,6 +858,7 @@ var aircraftData = []Aircraft{
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "B-52",
 		TypeDesignation:     "B-52H",
@@ -799,12 +870,34 @@ var aircraftData = []Aircraft{
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "B-1",
 		TypeDesignation:     "B-1B",
 		OfficialName:        "Lancer",
 		Nickname:            "Bone",
 	},
+	{
+		ACMIShortName: "Bf-109K-4",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "Bf 109",
+		TypeDesignation:     "Bf 109K-4",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "C-130J-30",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+		},
+		PlatformDesignation: "C-130",
+		TypeDesignation:     "C-130J",
+		OfficialName:        "Super Hercules",
+		Nickname:            "Herc",
+	},
 	{
 		ACMIShortName: "C-17A",
 		tags: map[AircraftTag]bool{
@@ -845,6 +938,15 @@ var aircraftData = []Aircraft{
 		TypeDesignation:     "CH-53E",
 		OfficialName:        "Super Stallion",
 	},
+	{
+		ACMIShortName: "Christen Eagle II",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+		},
+		PlatformDesignation: "Eagle II",
+		OfficialName:        "Christen Eagle",
+	},
 	{
 		ACMIShortName: "E-2C",
 		tags: map[AircraftTag]bool{
@@ -870,22 +972,66 @@ var aircraftData = []Aircraft{
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Attack:    true,
 		},
 		PlatformDesignation: "F-117",
 		TypeDesignation:     "F-117A",
 		OfficialName:        "Nighthawk",
 		Nickname:            "Goblin",
 	},
+	{
+		ACMIShortName: "FW-190A8",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "Fw 190",
+		TypeDesignation:     "Fw 190A-8",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "FW-190D9",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "Fw 190",
+		TypeDesignation:     "Fw 190D-9",
+		Nickname:            "Dora",
+		threatRadius:        SAR1IRThreat,
+	},
 	{
 		ACMIShortName: "H-6J",
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "Tu-16",
 		TypeDesignation:     "H-6J",
 		NATOReportingName:   "Badger",
 	},
+	{
+		ACMIShortName: "Hawk",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Attack:    true,
+		},
+		PlatformDesignation: "Hawk",
+		TypeDesignation:     "Hawk T.1A",
+		OfficialName:        "Hawk",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "I-16",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "I-16",
+		Nickname:            "Ishak",
+		threatRadius:        SAR1IRThreat,
+	},
 	{
 		ACMIShortName: "IL-76MD",
 		tags: map[AircraftTag]bool{
@@ -925,6 +1071,16 @@ var aircraftData = []Aircraft{
 		OfficialName:        "Thunder",
 		threatRadius:        ExtendedThreat,
 	},
+	{
+		ACMIShortName: "Ju-88A4",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+			Bomber:    true,
+		},
+		PlatformDesignation: "Ju 88",
+		TypeDesignation:     "Ju 88A-4",
+	},
 	{
 		ACMIShortName: "KA-27",
 		tags: map[AircraftTag]bool{
@@ -954,7 +1110,7 @@ var aircraftData = []Aircraft{
 		},
 		PlatformDesignation: "KJ-2000",
 		TypeDesignation:     "KJ-2000",
-		OfficialName:        "Mainring",
+		NATOReportingName:   "Mainring",
 	},
 	{
 		ACMIShortName: "M-2000C",
@@ -995,7 +1151,7 @@ var aircraftData = []Aircraft{
 		},
 		PlatformDesignation: "Mi-28",
 		TypeDesignation:     "Mi-28N",
-		OfficialName:        "Havoc",
+		NATOReportingName:   "Havoc",
 	},
 	{
 		ACMIShortName: "MiG-19P",
@@ -1063,21 +1219,92 @@ var aircraftData = []Aircraft{
 		OfficialName:        "Mirage 2000",
 		threatRadius:        SAR2AR1Threat,
 	},
+	{
+		ACMIShortName: "MosquitoFBMkVI",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Attack:    true,
+		},
+		PlatformDesignation: "Mosquito",
+		TypeDesignation:     "Mosquito FB Mk VI",
+		OfficialName:        "Mosquito",
+		threatRadius:        SAR1IRThreat,
+	},
 	{
 		ACMIShortName: "MQ-1",
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Unmanned:  true,
 		},
 		PlatformDesignation: "MQ-1",
 		TypeDesignation:     "MQ-1A",
 		OfficialName:        "Predator",
 	},
+	{
+		ACMIShortName: "P-47D-30",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "P-47",
+		TypeDesignation:     "P-47D",
+		OfficialName:        "Thunderbolt",
+		Nickname:            "Jug",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "P-47D-30bl1",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "P-47",
+		TypeDesignation:     "P-47D",
+		OfficialName:        "Thunderbolt",
+		Nickname:            "Jug",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "P-47D-40",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "P-47",
+		TypeDesignation:     "P-47D",
+		OfficialName:        "Thunderbolt",
+		Nickname:            "Jug",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "P-51D",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "P-51",
+		TypeDesignation:     "P-51D",
+		OfficialName:        "Mustang",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "P-51D-30-NA",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "P-51",
+		TypeDesignation:     "P-51D",
+		OfficialName:        "Mustang",
+		threatRadius:        SAR1IRThreat,
+	},
 	{
 		ACMIShortName: "RQ-1A Predator",
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Unmanned:  true,
 		},
 		PlatformDesignation: "RQ-1",
 		TypeDesignation:     "RQ-1A",
@@ -1117,6 +1344,28 @@ var aircraftData = []Aircraft{
 		TypeDesignation:     "SH-60B",
 		OfficialName:        "Seahawk",
 	},
+	{
+		ACMIShortName: "SpitfireLFMkIX",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "Spitfire",
+		TypeDesignation:     "Spitfire LF Mk IX",
+		OfficialName:        "Spitfire",
+		threatRadius:        SAR1IRThreat,
+	},
+	{
+		ACMIShortName: "SpitfireLFMkIXCW",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Fighter:   true,
+		},
+		PlatformDesignation: "Spitfire",
+		TypeDesignation:     "Spitfire LF Mk IX",
+		OfficialName:        "Spitfire",
+		threatRadius:        SAR1IRThreat,
+	},
 	{
 		ACMIShortName: "Su-17M4",
 		tags: map[AircraftTag]bool{
@@ -1160,38 +1409,62 @@ var aircraftData = []Aircraft{
 		},
 		PlatformDesignation: "Su-34",
 		TypeDesignation:     "Su-34",
-		OfficialName:        "Fullback",
+		NATOReportingName:   "Fullback",
 		threatRadius:        flankerData.threatRadius,
 	},
+	{
+		ACMIShortName: "TF-51D",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+		},
+		PlatformDesignation: "P-51",
+		TypeDesignation:     "TF-51D",
+		OfficialName:        "Mustang",
+	},
+	{
+		ACMIShortName: "Tu-160",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+			Bomber:    true,
+		},
+		PlatformDesignation: "Tu-160",
+		TypeDesignation:     "Tu-160",
+		NATOReportingName:   "Blackjack",
+	},
 	{
 		ACMIShortName: "Tu-22M3",
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "Tu-22",
 		TypeDesignation:     "Tu-22M",
-		OfficialName:        "Backfire",
+		NATOReportingName:   "Backfire",
 	},
 	{
 		ACMIShortName: "Tu-95MS",
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "Tu-95",
 		TypeDesignation:     "Tu-95MS",
-		OfficialName:        "Bear",
+		NATOReportingName:   "Bear",
 	},
 	{
 		ACMIShortName: "Tu-142",
 		tags: map[AircraftTag]bool{
 			FixedWing: true,
 			Unarmed:   true,
+			Bomber:    true,
 		},
 		PlatformDesignation: "Tu-142",
 		TypeDesignation:     "Tu-142",
-		OfficialName:        "Bear",
+		NATOReportingName:   "Bear",
 	},
 	{
 		ACMIShortName: "UH-1H",
@@ -1214,14 +1487,44 @@ var aircraftData = []Aircraft{
 		TypeDesignation:     "UH-60A",
 		OfficialName:        "Black Hawk",
 	},
+	{
+		ACMIShortName: "WingLoong-I",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+			Unmanned:  true,
+		},
+		PlatformDesignation: "Wing Loong",
+		TypeDesignation:     "Wing Loong I",
+		OfficialName:        "Wing Loong",
+	},
+	{
+		ACMIShortName: "Yak-40",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+		},
+		PlatformDesignation: "Yak-40",
+		TypeDesignation:     "Yak-40",
+		NATOReportingName:   "Codling",
+	},
+	{
+		ACMIShortName: "Yak-52",
+		tags: map[AircraftTag]bool{
+			FixedWing: true,
+			Unarmed:   true,
+		},
+		PlatformDesignation: "Yak-52",
+		TypeDesignation:     "Yak-52",
+	},
 }
 
 // aircraftDataLUT maps the name exported in ACMI data to aircraft data.
 var aircraftDataLUT map[string]Aircraft
 
-func init() {
-	aircraftDataLUT = make(map[string]Aircraft)
-	for _, vars := range [][]Aircraft{
+// allAircraftData returns every aircraft in the encyclopedia, including variants.
+func allAircraftData() [][]Aircraft {
+	return [][]Aircraft{
 		aircraftData,
 		a10Variants(),
 		ah64Variants(),
@@ -1249,7 +1552,12 @@ func init() {
 		s3Variants(),
 		tornadoVariants(),
 		mq9Variants(),
-	} {
+	}
+}
+
+func init() {
+	aircraftDataLUT = make(map[string]Aircraft)
+	for _, vars := range allAircraftData() {
 		for _, data := range vars {
 			aircraftDataLUT[data.ACMIShortName] = data
 		}
@@ -1271,3 +1579,13 @@ func GetAircraftData(name string) (Aircraft, bool) {
 	}
 	return data, ok
 }
+
+// ReportingName returns the name a controller should speak for the given aircraft type. The name should be the Name
+// property of an ACMI object. If the type is not in the encyclopedia, the generic word for an unknown role is returned.
+func ReportingName(name string) string {
+	data, ok := GetAircraftData(name)
+	if !ok {
+		return UnknownRole.String()
+	}
+	return data.ReportingName()
+}
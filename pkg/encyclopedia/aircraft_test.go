@@ -0,0 +1,79 @@
+package encyclopedia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAircraftDataNoDuplicates(t *testing.T) {
+	t.Parallel()
+	seen := make(map[string]string)
+	for _, vars := range allAircraftData() {
+		for _, data := range vars {
+			require.NotEmpty(t, data.ACMIShortName)
+			previous, ok := seen[data.ACMIShortName]
+			assert.False(t, ok, "%s is defined more than once (%s, %s)", data.ACMIShortName, previous, data.TypeDesignation)
+			seen[data.ACMIShortName] = data.TypeDesignation
+		}
+	}
+}
+
+func TestAircraftDataHasRole(t *testing.T) {
+	t.Parallel()
+	for name, data := range aircraftDataLUT {
+		assert.NotEqual(t, UnknownRole, data.Role(), "%s has no role", name)
+	}
+}
+
+func TestReportingName(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Su-27", expected: "Flanker"},
+		{name: "MiG-29S", expected: "Fulcrum"},
+		{name: "F-14B", expected: "Tomcat"},
+		{name: "Tu-22M3", expected: "Backfire"},
+		{name: "Su-34", expected: "Fullback"},
+		{name: "UH-1H", expected: "Huey"},
+		{name: "Unknown Type", expected: "aircraft"},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, ReportingName(test.name))
+		})
+	}
+}
+
+func TestReportingNameFallsBackToRole(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		tags     map[AircraftTag]bool
+		expected string
+	}{
+		{tags: map[AircraftTag]bool{FixedWing: true, Fighter: true}, expected: "fighter"},
+		{tags: map[AircraftTag]bool{FixedWing: true, Attack: true}, expected: "attack"},
+		{tags: map[AircraftTag]bool{FixedWing: true, Unarmed: true, Bomber: true}, expected: "bomber"},
+		{tags: map[AircraftTag]bool{RotaryWing: true, Attack: true}, expected: "helicopter"},
+		{tags: map[AircraftTag]bool{FixedWing: true, Unarmed: true, Unmanned: true}, expected: "UAV"},
+		{tags: map[AircraftTag]bool{FixedWing: true, Unarmed: true}, expected: "transport"},
+	}
+	for _, test := range testCases {
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, Aircraft{tags: test.tags}.ReportingName())
+		})
+	}
+}
+
+func BenchmarkGetAircraftData(b *testing.B) {
+	names := []string{"Su-27", "MiG-29S", "F-14B", "FA-18C_hornet", "Mi-24P"}
+	b.ResetTimer()
+	for i := range b.N {
+		_, _ = GetAircraftData(names[i%len(names)])
+	}
+}
@@ -0,0 +1,172 @@
+package encyclopedia
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+)
+
+// Data source:
+// https://github.com/Quaggles/dcs-lua-datamine/tree/master/_G/db/Units/GroundUnits/GroundUnit
+
+// SAM is a surface-to-air missile or gun system's radar.
+type SAM struct {
+	// DCSType is the unit type name used by DCS.
+	DCSType string
+	// System is the designation of the weapon system.
+	// e.g. SA-2, SA-10, Patriot
+	System string
+	// NATOReportingName is the NATO reporting name of the radar. Western systems do not have a NATO reporting name.
+	// e.g. Fan Song, Flap Lid
+	NATOReportingName string
+	// ThreatRadius is the nominal engagement radius of the system.
+	ThreatRadius unit.Length
+}
+
+// UnknownSAMThreat is the threat radius assumed for surface-to-air radars which are not in the encyclopedia.
+var UnknownSAMThreat = 10 * unit.NauticalMile
+
+// unknownSAMSystem is the system designation used for surface-to-air radars which are not in the encyclopedia.
+const unknownSAMSystem = "SAM"
+
+var samData = []SAM{
+	{
+		DCSType:           "SNR_75V",
+		System:            "SA-2",
+		NATOReportingName: "Fan Song",
+		ThreatRadius:      23 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "snr s-125 tr",
+		System:            "SA-3",
+		NATOReportingName: "Low Blow",
+		ThreatRadius:      13 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "p-19 s-125 sr",
+		System:            "SA-3",
+		NATOReportingName: "Flat Face",
+		ThreatRadius:      13 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "RPC_5N62V",
+		System:            "SA-5",
+		NATOReportingName: "Square Pair",
+		ThreatRadius:      130 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "Kub 1S91 str",
+		System:            "SA-6",
+		NATOReportingName: "Straight Flush",
+		ThreatRadius:      13 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "Osa 9A33 ln",
+		System:            "SA-8",
+		NATOReportingName: "Land Roll",
+		ThreatRadius:      5 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "S-300PS 40B6M tr",
+		System:            "SA-10",
+		NATOReportingName: "Flap Lid",
+		ThreatRadius:      40 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "S-300PS 40B6MD sr",
+		System:            "SA-10",
+		NATOReportingName: "Clam Shell",
+		ThreatRadius:      40 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "S-300PS 64H6E sr",
+		System:            "SA-10",
+		NATOReportingName: "Big Bird",
+		ThreatRadius:      40 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "SA-11 Buk LN 9A310M1",
+		System:            "SA-11",
+		NATOReportingName: "Fire Dome",
+		ThreatRadius:      19 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "SA-11 Buk SR 9S18M1",
+		System:            "SA-11",
+		NATOReportingName: "Snow Drift",
+		ThreatRadius:      19 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "Tor 9A331",
+		System:            "SA-15",
+		NATOReportingName: "Gauntlet",
+		ThreatRadius:      6 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "2S6 Tunguska",
+		System:            "SA-19",
+		NATOReportingName: "Grison",
+		ThreatRadius:      4 * unit.NauticalMile,
+	},
+	{
+		DCSType:           "ZSU-23-4 Shilka",
+		System:            "ZSU-23-4",
+		NATOReportingName: "Gun Dish",
+		ThreatRadius:      1 * unit.NauticalMile,
+	},
+	{
+		DCSType:      "Hawk tr",
+		System:       "Hawk",
+		ThreatRadius: 24 * unit.NauticalMile,
+	},
+	{
+		DCSType:      "Patriot str",
+		System:       "Patriot",
+		ThreatRadius: 55 * unit.NauticalMile,
+	},
+	{
+		DCSType:      "NASAMS_Radar_MPQ64F1",
+		System:       "NASAMS",
+		ThreatRadius: 13 * unit.NauticalMile,
+	},
+	{
+		DCSType:      "Roland ADS",
+		System:       "Roland",
+		ThreatRadius: 4 * unit.NauticalMile,
+	},
+}
+
+// samDataLUT maps the DCS unit type to SAM data.
+var samDataLUT map[string]SAM
+
+func init() {
+	samDataLUT = make(map[string]SAM)
+	for _, data := range samData {
+		samDataLUT[data.DCSType] = data
+	}
+}
+
+// GetSAMData returns the SAM data for the given DCS unit type, if it exists.
+// The second return value is false if the data does not exist.
+func GetSAMData(name string) (SAM, bool) {
+	data, ok := samDataLUT[name]
+	if !ok {
+		missingDataLogger.Warn().Str("sam", name).Msg("SAM missing from encyclopedia")
+	}
+	return data, ok
+}
+
+// NewSurfaceThreat describes the SAM of the given DCS unit type at the given location and range. If the type is not in
+// the encyclopedia, the threat is described as a generic SAM with [UnknownSAMThreat] radius.
+func NewSurfaceThreat(name string, bullseye brevity.Bullseye, _range unit.Length) brevity.SurfaceThreat {
+	data, ok := GetSAMData(name)
+	if !ok {
+		data = SAM{DCSType: name, System: unknownSAMSystem, ThreatRadius: UnknownSAMThreat}
+	}
+	return brevity.SurfaceThreat{
+		System:     data.System,
+		NATOName:   data.NATOReportingName,
+		Bullseye:   bullseye,
+		Range:      _range,
+		RingRadius: data.ThreatRadius,
+	}
+}
@@ -0,0 +1,57 @@
+package encyclopedia
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSAMDataNoDuplicates(t *testing.T) {
+	t.Parallel()
+	seen := make(map[string]struct{})
+	for _, data := range samData {
+		require.NotEmpty(t, data.DCSType)
+		require.NotEmpty(t, data.System)
+		assert.Positive(t, data.ThreatRadius)
+		_, ok := seen[data.DCSType]
+		assert.False(t, ok, "%s is defined more than once", data.DCSType)
+		seen[data.DCSType] = struct{}{}
+	}
+}
+
+func TestNewSurfaceThreat(t *testing.T) {
+	t.Parallel()
+	bullseye := *brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 40*unit.NauticalMile)
+	testCases := []struct {
+		name       string
+		system     string
+		threatName string
+		ringRadius unit.Length
+	}{
+		{name: "SNR_75V", system: "SA-2", threatName: "Fan Song", ringRadius: 23 * unit.NauticalMile},
+		{name: "snr s-125 tr", system: "SA-3", threatName: "Low Blow", ringRadius: 13 * unit.NauticalMile},
+		{name: "Kub 1S91 str", system: "SA-6", threatName: "Straight Flush", ringRadius: 13 * unit.NauticalMile},
+		{name: "Osa 9A33 ln", system: "SA-8", threatName: "Land Roll", ringRadius: 5 * unit.NauticalMile},
+		{name: "S-300PS 40B6M tr", system: "SA-10", threatName: "Flap Lid", ringRadius: 40 * unit.NauticalMile},
+		{name: "SA-11 Buk LN 9A310M1", system: "SA-11", threatName: "Fire Dome", ringRadius: 19 * unit.NauticalMile},
+		{name: "Tor 9A331", system: "SA-15", threatName: "Gauntlet", ringRadius: 6 * unit.NauticalMile},
+		{name: "Patriot str", system: "Patriot", threatName: "Patriot", ringRadius: 55 * unit.NauticalMile},
+		{name: "Unknown Radar", system: "SAM", threatName: "SAM", ringRadius: UnknownSAMThreat},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			threat := NewSurfaceThreat(test.name, bullseye, 20*unit.NauticalMile)
+			assert.Equal(t, test.system, threat.System)
+			assert.Equal(t, test.threatName, threat.Name())
+			assert.InDelta(t, test.ringRadius.NauticalMiles(), threat.RingRadius.NauticalMiles(), 0.01)
+			assert.InDelta(t, 20, threat.Range.NauticalMiles(), 0.01)
+			assert.InDelta(t, 40, threat.Bullseye.Distance().NauticalMiles(), 0.01)
+			assert.Equal(t, threat.Range <= threat.RingRadius, threat.Within())
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// package geofences defines named geographic areas which restrict the coverage of the GCI.
+package geofences
+
+import (
+	"github.com/dharmab/skyeye/pkg/areas"
+	"github.com/paulmach/orb"
+)
+
+// GeoFence is a named geographic area, such as a CAP box. The name identifies the geo-fence in logs.
+type GeoFence = areas.Area
+
+// WithinAny returns true if the point is inside at least one of the given geo-fences, or if no geo-fences are given.
+func WithinAny(point orb.Point, fences []GeoFence) bool {
+	if len(fences) == 0 {
+		return true
+	}
+	for _, fence := range fences {
+		if fence.Contains(point) {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads geo-fence definitions from a YAML file.
+func Load(path string) ([]GeoFence, error) {
+	return areas.Load(path, "geofences")
+}
+
+// Parse parses geo-fence definitions from YAML.
+func Parse(b []byte) ([]GeoFence, error) {
+	return areas.Parse(b, "geofences")
+}
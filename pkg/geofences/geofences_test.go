@@ -0,0 +1,41 @@
+package geofences
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	fences, err := Load(filepath.Join("testdata", "geofences.yaml"))
+	require.NoError(t, err)
+	require.Len(t, fences, 2)
+
+	west, east := fences[0], fences[1]
+	assert.Equal(t, "CAP West", west.Name)
+	assert.Equal(t, "CAP East", east.Name)
+	assert.True(t, west.Boundary.Closed())
+	assert.Len(t, west.Boundary, 5)
+	assert.True(t, east.Boundary.Closed())
+	assert.Len(t, east.Boundary, 4)
+
+	assert.True(t, west.Contains(orb.Point{41.5, 42.5}))
+	assert.False(t, west.Contains(orb.Point{42.5, 42.5}))
+	assert.True(t, east.Contains(orb.Point{43.5, 42.2}))
+	assert.False(t, east.Contains(orb.Point{43.1, 42.9}), "a point outside the triangle but within its bounding box should be outside")
+}
+
+func TestWithinAny(t *testing.T) {
+	t.Parallel()
+	fences, err := Load(filepath.Join("testdata", "geofences.yaml"))
+	require.NoError(t, err)
+
+	assert.True(t, WithinAny(orb.Point{41.5, 42.5}, fences))
+	assert.True(t, WithinAny(orb.Point{43.5, 42.2}, fences))
+	assert.False(t, WithinAny(orb.Point{42.5, 42.5}, fences))
+	assert.True(t, WithinAny(orb.Point{42.5, 42.5}, nil), "without geo-fences, every point should be covered")
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Reasons used as the "reason" label of [SRSDroppedPackets].
+const (
+	// DropOpposingCoalition is a packet sent by a client of the opposing coalition.
+	DropOpposingCoalition = "opposing_coalition"
+	// DropSpectator is a packet sent by a spectator.
+	DropSpectator = "spectator"
+	// DropFilteredCoalition is a packet sent by a client outside the coalition filter or, when the server enforces
+	// secure coalition radios, outside the GCI's coalition.
+	DropFilteredCoalition = "filtered_coalition"
+	// DropUnknownClient is a packet sent by a client which is not in the SRS client list.
+	DropUnknownClient = "unknown_client"
+)
+
+// Parse results used as the "result" label of [ParseTotal].
+const (
+	ParseOK   = "ok"
+	ParseFail = "fail"
+)
+
+var (
+	factory = promauto.With(DefaultRegistry)
+
+	// ParseTotal counts requests which were addressed to the GCI, partitioned by whether they were understood.
+	ParseTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "gci_parse_total",
+		Help: "Requests addressed to the GCI, by parse result.",
+	}, []string{"result"})
+	// DroppedRequests counts requests which were discarded because the response queue was full.
+	DroppedRequests = factory.NewCounter(prometheus.CounterOpts{
+		Name: "gci_dropped_requests_total",
+		Help: "Requests discarded because the response queue was full.",
+	})
+	// ResponseLatency measures the time from receiving a transmission to submitting the response for transmission, in
+	// seconds.
+	ResponseLatency = factory.NewHistogram(prometheus.HistogramOpts{
+		Name:    "gci_response_latency_seconds",
+		Help:    "Time from receiving a transmission to submitting the response to SRS.",
+		Buckets: []float64{0.5, 1, 2, 3, 5, 8, 13, 21, 34},
+	})
+	// SRSDroppedPackets counts received voice packets which were dropped because of the transmitting client's coalition.
+	SRSDroppedPackets = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "gci_srs_dropped_packets_total",
+		Help: "Received SRS voice packets dropped because of the transmitting client's coalition, by reason.",
+	}, []string{"reason"})
+	// ActiveContacts is the number of contacts currently tracked by each controller's radar, labeled by the controller's
+	// callsign and coalition.
+	ActiveContacts = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gci_active_contacts",
+		Help: "Number of contacts currently tracked by the radar, by controller and coalition.",
+	}, []string{"controller", "coalition"})
+	// SRSConnectionStatus is the status of each controller's connection to the SRS server, labeled by the controller's
+	// callsign and coalition. 0 is disconnected, 1 is connected and 2 is reconnecting.
+	SRSConnectionStatus = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gci_srs_connection_status",
+		Help: "Status of the SRS connection (0 = disconnected, 1 = connected, 2 = reconnecting), by controller and coalition.",
+	}, []string{"controller", "coalition"})
+)
@@ -0,0 +1,17 @@
+// package metrics exposes operational telemetry for scraping by Prometheus.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultRegistry contains the GCI's operational metrics.
+var DefaultRegistry = prometheus.NewRegistry()
+
+// Handler returns an HTTP handler which serves [DefaultRegistry] for scraping.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(DefaultRegistry, promhttp.HandlerOpts{})
+}
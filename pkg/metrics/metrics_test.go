@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	response, err := http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, string(expfmt.FmtText), response.Header.Get("Content-Type"))
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestHandlerExposesGCIMetrics(t *testing.T) {
+	t.Parallel()
+	ParseTotal.WithLabelValues(ParseOK).Inc()
+	ParseTotal.WithLabelValues(ParseFail).Inc()
+	ResponseLatency.Observe(1.5)
+	ActiveContacts.WithLabelValues("Focus", "blue").Set(2)
+	SRSConnectionStatus.WithLabelValues("Focus", "blue").Set(1)
+
+	body := scrape(t, Handler())
+	for _, name := range []string{
+		"gci_parse_total",
+		"gci_response_latency_seconds",
+		"gci_active_contacts",
+		"gci_srs_connection_status",
+		"gci_dropped_requests_total",
+	} {
+		assert.Contains(t, body, "# TYPE "+name+" ")
+	}
+	assert.Contains(t, body, `gci_parse_total{result="ok"} `)
+	assert.Contains(t, body, `gci_parse_total{result="fail"} `)
+	assert.Contains(t, body, `gci_response_latency_seconds_bucket{le="+Inf"} `)
+	assert.Contains(t, body, `gci_active_contacts{coalition="blue",controller="Focus"} 2`)
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/martinlindhe/unit"
+	"github.com/rodaine/numwords"
+)
+
+// angelsWord is spoken before an altitude in thousands of feet, e.g. "angels twenty" is 20,000 feet.
+const angelsWord = "angels"
+
+// altitudeUnitWords are unit words which a pilot may say after an altitude. Altitudes are always in feet, so these are
+// ignored.
+var altitudeUnitWords = []string{"feet", "foot", "ft"}
+
+// ParseAltitude parses a spoken altitude in one of the following styles:
+//   - A plain number of feet, e.g. "35000" or "35000 feet"
+//   - Number words with multipliers, e.g. "thirty five thousand" or "thirty five thousand five hundred feet"
+//   - Angels, e.g. "angels twenty" or "angels 20"
+//
+// Individually pronounced digits are combined, e.g. "two five thousand" is 25,000 feet. Tokens may be hyphenated, e.g.
+// "thirty-five".
+func ParseAltitude(tokens []string) (unit.Length, bool) {
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		for _, word := range strings.Split(strings.ToLower(token), "-") {
+			word = strings.ReplaceAll(word, ",", "")
+			if word == "" || word == "and" || slices.Contains(altitudeUnitWords, word) {
+				continue
+			}
+			words = append(words, word)
+		}
+	}
+
+	multiplier := 1
+	if len(words) > 0 && words[0] == angelsWord {
+		multiplier = 1000
+		words = words[1:]
+	}
+
+	d, ok := parseCompoundNumber(words)
+	if !ok {
+		return 0, false
+	}
+	return unit.Length(d*multiplier) * unit.Foot, true
+}
+
+// parseCompoundNumber parses a number composed of number words, digits, and the multipliers "hundred" and "thousand".
+func parseCompoundNumber(words []string) (int, bool) {
+	if len(words) == 0 {
+		return 0, false
+	}
+	total := 0
+	current := 0
+	previousWasDigit := false
+	for _, word := range words {
+		switch word {
+		case "thousand":
+			total += max(current, 1) * 1000
+			current = 0
+			previousWasDigit = false
+			continue
+		case "hundred":
+			current = max(current, 1) * 100
+			previousWasDigit = false
+			continue
+		}
+
+		d, err := strconv.Atoi(word)
+		if err != nil {
+			d, err = numwords.ParseInt(word)
+			if err != nil {
+				return 0, false
+			}
+		}
+		isDigit := 0 <= d && d < 10
+		if isDigit && previousWasDigit {
+			current = current*10 + d
+		} else {
+			current += d
+		}
+		previousWasDigit = isDigit
+	}
+	return total + current, true
+}
+
+// isAltitudeWord returns true if the word may be part of a spoken altitude.
+func isAltitudeWord(word string) bool {
+	if word == angelsWord || word == "thousand" || word == "hundred" || slices.Contains(altitudeUnitWords, word) {
+		return true
+	}
+	if _, err := strconv.Atoi(word); err == nil {
+		return true
+	}
+	_, err := numwords.ParseInt(word)
+	return err == nil
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAltitude(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		text     string
+		expected unit.Length
+		ok       bool
+	}{
+		// Plain numbers
+		{text: "35000", expected: 35000 * unit.Foot, ok: true},
+		{text: "35,000", expected: 35000 * unit.Foot, ok: true},
+		{text: "35000 feet", expected: 35000 * unit.Foot, ok: true},
+		{text: "500 ft", expected: 500 * unit.Foot, ok: true},
+		{text: "35 thousand", expected: 35000 * unit.Foot, ok: true},
+		// Number words
+		{text: "thirty five thousand", expected: 35000 * unit.Foot, ok: true},
+		{text: "THIRTY-FIVE THOUSAND", expected: 35000 * unit.Foot, ok: true},
+		{text: "thirty five thousand feet", expected: 35000 * unit.Foot, ok: true},
+		{text: "thirty five thousand five hundred", expected: 35500 * unit.Foot, ok: true},
+		{text: "twelve thousand", expected: 12000 * unit.Foot, ok: true},
+		{text: "one thousand", expected: 1000 * unit.Foot, ok: true},
+		{text: "thousand", expected: 1000 * unit.Foot, ok: true},
+		{text: "five hundred", expected: 500 * unit.Foot, ok: true},
+		{text: "two five thousand", expected: 25000 * unit.Foot, ok: true},
+		{text: "one thousand and five hundred feet", expected: 1500 * unit.Foot, ok: true},
+		// Angels
+		{text: "angels twenty", expected: 20000 * unit.Foot, ok: true},
+		{text: "ANGELS TWENTY", expected: 20000 * unit.Foot, ok: true},
+		{text: "angels 20", expected: 20000 * unit.Foot, ok: true},
+		{text: "angels twenty five", expected: 25000 * unit.Foot, ok: true},
+		{text: "angels two five", expected: 25000 * unit.Foot, ok: true},
+		{text: "angels three", expected: 3000 * unit.Foot, ok: true},
+		// Invalid
+		{text: "", ok: false},
+		{text: "angels", ok: false},
+		{text: "feet", ok: false},
+		{text: "high", ok: false},
+		{text: "thirty north", ok: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.text, func(t *testing.T) {
+			t.Parallel()
+			actual, ok := ParseAltitude(strings.Fields(test.text))
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.InDelta(t, test.expected.Feet(), actual.Feet(), 0.5)
+			}
+		})
+	}
+}
+
+// altitudeForms are equivalent ways of saying 20,000 feet.
+var altitudeForms = []string{"ANGELS TWENTY", "angels 20", "TWENTY THOUSAND", "twenty thousand feet", "20000", "20,000"}
+
+func TestParseAltitudeFormsAreEquivalent(t *testing.T) {
+	t.Parallel()
+	for _, form := range altitudeForms {
+		t.Run(form, func(t *testing.T) {
+			t.Parallel()
+			actual, ok := ParseAltitude(strings.Fields(form))
+			require.True(t, ok)
+			assert.Equal(t, 20000*unit.Foot, actual)
+		})
+	}
+}
+
+func TestParserAltitudeForms(t *testing.T) {
+	t.Parallel()
+	p := New(TestCallsign, true)
+	for _, form := range altitudeForms {
+		t.Run("declare "+form, func(t *testing.T) {
+			t.Parallel()
+			actual := p.Parse("anyface, eagle 1, declare bullseye 075 for 26 at " + form)
+			require.IsType(t, &brevity.DeclareRequest{}, actual)
+			request := actual.(*brevity.DeclareRequest)
+			assert.InDelta(t, 75, request.Bullseye.Bearing().Degrees(), 0.5)
+			assert.InDelta(t, 26, request.Bullseye.Distance().NauticalMiles(), 0.5)
+			assert.InDelta(t, 20000, request.Altitude.Feet(), 0.5)
+		})
+		t.Run("declare braa "+form, func(t *testing.T) {
+			t.Parallel()
+			actual := p.Parse("anyface, eagle 1, declare braa 176 24 " + form)
+			require.IsType(t, &brevity.DeclareRequest{}, actual)
+			request := actual.(*brevity.DeclareRequest)
+			assert.True(t, request.IsBRAA)
+			assert.InDelta(t, 20000, request.Altitude.Feet(), 0.5)
+		})
+		t.Run("snaplock "+form, func(t *testing.T) {
+			t.Parallel()
+			actual := p.Parse("anyface, eagle 1, snaplock 125 10 " + form)
+			require.IsType(t, &brevity.SnaplockRequest{}, actual)
+			request := actual.(*brevity.SnaplockRequest)
+			assert.InDelta(t, 125, request.BRA.Bearing().Degrees(), 0.5)
+			assert.InDelta(t, 10, request.BRA.Range().NauticalMiles(), 0.5)
+			assert.InDelta(t, 20000, request.BRA.Altitude().Feet(), 0.5)
+		})
+		// SPIKED requests carry only a bearing, but a pilot may still say the altitude of the spike. The altitude must
+		// not prevent the bearing from being parsed.
+		t.Run("spiked "+form, func(t *testing.T) {
+			t.Parallel()
+			actual := p.Parse("anyface, eagle 1, spiked 270 " + form)
+			require.IsType(t, &brevity.SpikedRequest{}, actual)
+			request := actual.(*brevity.SpikedRequest)
+			assert.Equal(t, bearings.NewMagneticBearing(270*unit.Degree), request.Bearing)
+		})
+	}
+}
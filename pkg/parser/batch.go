@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ParseResult is the result of parsing a single transmission.
+type ParseResult struct {
+	// IR is the intermediate representation returned by [Parser.Parse]. It may be nil.
+	IR any
+	// Confidence is a rough measure of how well the transmission was understood: 1 if a request was parsed, 0.5 if the
+	// GCI callsign was heard but the request was not understood, and 0 if the transmission was not addressed to the GCI.
+	Confidence float64
+	// OK is true if a request was parsed.
+	OK bool
+}
+
+// newParseResult classifies the result of [Parser.Parse].
+func newParseResult(ir any) ParseResult {
+	switch ir.(type) {
+	case nil:
+		return ParseResult{}
+	case *brevity.UnableToUnderstandRequest:
+		return ParseResult{IR: ir, Confidence: 0.5}
+	default:
+		return ParseResult{IR: ir, Confidence: 1, OK: true}
+	}
+}
+
+// ParseMany parses a batch of transmissions concurrently, using one goroutine per CPU. This is intended for offline
+// analysis of logged transmissions. The results are in the same order as the transmissions.
+func ParseMany(p Parser, txs []string) []ParseResult {
+	results := make([]ParseResult, len(txs))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.NumCPU(), len(txs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = newParseResult(p.Parse(txs[i]))
+			}
+		}()
+	}
+	for i := range txs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMany(t *testing.T) {
+	t.Parallel()
+	p := New(TestCallsign, false)
+	txs := make([]string, 1000)
+	for i := range txs {
+		switch i % 4 {
+		case 0:
+			txs[i] = fmt.Sprintf("anyface eagle %d radio check", i)
+		case 1:
+			txs[i] = fmt.Sprintf("anyface eagle %d alpha check", i)
+		case 2:
+			txs[i] = fmt.Sprintf("anyface eagle %d", i)
+		default:
+			txs[i] = fmt.Sprintf("eagle %d radio check", i)
+		}
+	}
+
+	results := ParseMany(p, txs)
+	require.Len(t, results, len(txs))
+	for i, result := range results {
+		callsign, ok := ParsePilotCallsign(fmt.Sprintf("eagle %d", i))
+		require.True(t, ok)
+		switch i % 4 {
+		case 0:
+			assert.Equal(t, &brevity.RadioCheckRequest{Callsign: callsign}, result.IR, txs[i])
+			assert.True(t, result.OK)
+			assert.InDelta(t, 1, result.Confidence, 0)
+		case 1:
+			assert.Equal(t, &brevity.AlphaCheckRequest{Callsign: callsign}, result.IR, txs[i])
+			assert.True(t, result.OK)
+			assert.InDelta(t, 1, result.Confidence, 0)
+		case 2:
+			assert.Equal(t, &brevity.UnableToUnderstandRequest{Callsign: callsign}, result.IR, txs[i])
+			assert.False(t, result.OK)
+			assert.InDelta(t, 0.5, result.Confidence, 0)
+		default:
+			assert.Nil(t, result.IR, txs[i])
+			assert.False(t, result.OK)
+			assert.Zero(t, result.Confidence)
+		}
+	}
+}
+
+func TestParseManyEmpty(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, ParseMany(New(TestCallsign, false), nil))
+}
@@ -15,16 +15,30 @@ func (p *parser) parseDeclare(callsign string, scanner *bufio.Scanner) (*brevity
 	var bearing bearings.Bearing
 	var _range unit.Length
 	var IsBRAA bool
+	var gridPosition string
 	for {
 		if scanner.Text() == "" {
 			scanner.Scan()
 			continue
 		}
 
+		if isGridReference(scanner.Text()) {
+			log.Debug().Str("text", scanner.Text()).Msg("found grid reference token")
+			grid, ok := p.parseGridReference(scanner)
+			if !ok {
+				return nil, false
+			}
+			log.Debug().Str("grid", grid).Msg("parsed grid reference")
+			gridPosition = grid
+			// Advance past the grid reference to the altitude.
+			scanner.Scan()
+			break
+		}
+
 		// if text only contains digits, assume bullseye format
 		isNumeric := true
 		for _, r := range scanner.Text() {
-			if !unicode.IsDigit(r) {
+			if !unicode.IsDigit(r) && r != '.' {
 				isNumeric = false
 				break
 			}
@@ -90,6 +104,14 @@ func (p *parser) parseDeclare(callsign string, scanner *bufio.Scanner) (*brevity
 	track := p.parseTrack(scanner)
 	log.Debug().Str("track", string(track)).Msg("parsed track")
 
+	if gridPosition != "" {
+		return &brevity.DeclareRequest{
+			Callsign:     callsign,
+			GridPosition: gridPosition,
+			Altitude:     altitude,
+			Track:        track,
+		}, true
+	}
 	if IsBRAA {
 		return &brevity.DeclareRequest{
 			Callsign: callsign,
@@ -25,6 +25,54 @@ func TestParserDeclare(t *testing.T) {
 				Track:    brevity.UnknownDirection,
 			},
 		},
+		{
+			text: "anyface, chevy one one, declare, 075.5 26 2000",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75.5*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 2000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare, 075 26 thirty five thousand",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 35000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare, 075 26 angels twenty",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 20000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare, 075 26 at 12000 feet",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 12000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
 		{
 			text: "anyface, chevy one one, declare, 075 26",
 			expected: &brevity.DeclareRequest{
@@ -177,6 +225,75 @@ func TestParserDeclare(t *testing.T) {
 				IsBRAA:   true,
 			},
 		},
+		{
+			text: "anyface, chevy one one, declare bullseye 075 26 miles 2000",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 2000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare bullseye 075 26 nautical miles at 2000",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 2000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare 075 26 nm 2000",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 2000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare bullseye 075 26 nautical altitude 2000",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 2000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, Chaos 11, declare braa 176 24 miles 3000",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chaos 1 1",
+				Bearing:  bearings.NewMagneticBearing(176 * unit.Degree),
+				Range:    24 * unit.NauticalMile,
+				Altitude: 3000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+				IsBRAA:   true,
+			},
+		},
+		{
+			text: "anyface, Chaos 11, declare braa 176 24 miles",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chaos 1 1",
+				Bearing:  bearings.NewMagneticBearing(176 * unit.Degree),
+				Range:    24 * unit.NauticalMile,
+				Track:    brevity.UnknownDirection,
+				IsBRAA:   true,
+			},
+		},
 	}
 	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
@@ -223,3 +340,113 @@ func TestParserDeclareUnable(t *testing.T) {
 		require.Equal(t, expected.Callsign, actual.Callsign)
 	})
 }
+
+func TestParserDeclareGrid(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface, eagle 1, declare grid 38T LM 123 456 angels 20",
+			expected: &brevity.DeclareRequest{
+				Callsign:     "eagle 1",
+				GridPosition: "38T LM 123 456",
+				Altitude:     20000 * unit.Foot,
+			},
+		},
+		{
+			text: "anyface, eagle 1, declare 38TLM123456",
+			expected: &brevity.DeclareRequest{
+				Callsign:     "eagle 1",
+				GridPosition: "38T LM 123 456",
+			},
+		},
+		{
+			text: "anyface, eagle 1, declare grid lima mike 1234 5678",
+			expected: &brevity.DeclareRequest{
+				Callsign:     "eagle 1",
+				GridPosition: "LM 1234 5678",
+			},
+		},
+		{
+			text: "anyface, eagle 1, declare grid 38 tango lima mike 123 456",
+			expected: &brevity.DeclareRequest{
+				Callsign:     "eagle 1",
+				GridPosition: "38T LM 123 456",
+			},
+		},
+		{
+			text: "anyface, eagle 1, declare LM123456 at 20000",
+			expected: &brevity.DeclareRequest{
+				Callsign:     "eagle 1",
+				GridPosition: "LM 123 456",
+				Altitude:     20000 * unit.Foot,
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.DeclareRequest)
+		actual := request.(*brevity.DeclareRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.Equal(t, expected.GridPosition, actual.GridPosition)
+		assert.InDelta(t, expected.Altitude.Feet(), actual.Altitude.Feet(), 50)
+	})
+}
+
+func TestParserDeclareRangeUnits(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{}
+	for _, units := range []string{"miles", "mile", "nautical miles", "nm", "nautical"} {
+		testCases = append(
+			testCases,
+			parserTestCase{
+				text: "anyface, chevy one one, declare bullseye 075 26 " + units + " 2000 track north",
+				expected: &brevity.DeclareRequest{
+					Callsign: "chevy 1 1",
+					Bullseye: *brevity.NewBullseye(
+						bearings.NewMagneticBearing(75*unit.Degree),
+						26*unit.NauticalMile,
+					),
+					Altitude: 2000 * unit.Foot,
+					Track:    brevity.North,
+				},
+			},
+			parserTestCase{
+				text: "anyface, chaos 11, declare braa 176 24 " + units + " 3000 track south",
+				expected: &brevity.DeclareRequest{
+					Callsign: "chaos 1 1",
+					Bearing:  bearings.NewMagneticBearing(176 * unit.Degree),
+					Range:    24 * unit.NauticalMile,
+					Altitude: 3000 * unit.Foot,
+					Track:    brevity.South,
+					IsBRAA:   true,
+				},
+			},
+			parserTestCase{
+				text: "anyface, chaos 11, declare braa 176 24 " + units,
+				expected: &brevity.DeclareRequest{
+					Callsign: "chaos 1 1",
+					Bearing:  bearings.NewMagneticBearing(176 * unit.Degree),
+					Range:    24 * unit.NauticalMile,
+					Track:    brevity.UnknownDirection,
+					IsBRAA:   true,
+				},
+			},
+		)
+	}
+	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.DeclareRequest)
+		actual := request.(*brevity.DeclareRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.Equal(t, expected.IsBRAA, actual.IsBRAA)
+		if expected.IsBRAA {
+			assert.InDelta(t, expected.Bearing.Degrees(), actual.Bearing.Degrees(), 0.5)
+			assert.InDelta(t, expected.Range.NauticalMiles(), actual.Range.NauticalMiles(), 0.5)
+		} else {
+			assert.InDelta(t, expected.Bullseye.Bearing().Degrees(), actual.Bullseye.Bearing().Degrees(), 0.5)
+			assert.InDelta(t, expected.Bullseye.Distance().NauticalMiles(), actual.Bullseye.Distance().NauticalMiles(), 0.5)
+		}
+		assert.InDelta(t, expected.Altitude.Feet(), actual.Altitude.Feet(), 50)
+		assert.Equal(t, expected.Track, actual.Track)
+	})
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// gridWord is a word which may precede a grid reference.
+const gridWord = "grid"
+
+// phoneticLetters maps words of the NATO phonetic alphabet to letters.
+var phoneticLetters = map[string]string{
+	"alpha":    "a",
+	"alfa":     "a",
+	"bravo":    "b",
+	"charlie":  "c",
+	"delta":    "d",
+	"echo":     "e",
+	"foxtrot":  "f",
+	"golf":     "g",
+	"hotel":    "h",
+	"india":    "i",
+	"juliet":   "j",
+	"juliett":  "j",
+	"kilo":     "k",
+	"lima":     "l",
+	"mike":     "m",
+	"november": "n",
+	"oscar":    "o",
+	"papa":     "p",
+	"quebec":   "q",
+	"romeo":    "r",
+	"sierra":   "s",
+	"tango":    "t",
+	"uniform":  "u",
+	"victor":   "v",
+	"whiskey":  "w",
+	"xray":     "x",
+	"yankee":   "y",
+	"zulu":     "z",
+}
+
+var (
+	// gridZoneDesignatorPattern matches a token which starts with an MGRS grid zone designator, e.g. "38t" or
+	// "38tlm123456".
+	gridZoneDesignatorPattern = regexp.MustCompile(`^\d{1,2}[c-hj-np-x]`)
+	// gridReferencePattern matches a complete MGRS grid zone designator and 100 km square, optionally followed by the
+	// easting and northing, e.g. "38tlm", "lm" or "lm123456".
+	gridReferencePattern = regexp.MustCompile(`^(\d{1,2}[c-hj-np-x])?([a-hj-np-z][a-hj-np-v])(\d*)$`)
+	// gluedGridSquarePattern matches a 100 km square followed by the easting and northing in a single token, e.g.
+	// "lm123456".
+	gluedGridSquarePattern = regexp.MustCompile(`^[a-hj-np-z][a-hj-np-v]\d+$`)
+)
+
+// isGridReference checks if the token starts a grid reference. A grid reference must start with the word "grid", a
+// grid zone designator, or a 100 km square immediately followed by digits. A 100 km square on its own is not enough,
+// since many short words such as "uh" are also valid 100 km squares.
+func isGridReference(token string) bool {
+	return token == gridWord || gridZoneDesignatorPattern.MatchString(token) || gluedGridSquarePattern.MatchString(token)
+}
+
+// parseGridReference parses an MGRS grid reference, e.g. "grid 38t lm 123 456" or "grid lima mike 123 456". The grid
+// zone designator is optional. Letters may be spoken using the NATO phonetic alphabet. The easting and northing may be
+// given together in one token or as two tokens of equal length. The grid reference is returned in upper case with
+// spaces between each part, e.g. "38T LM 123 456". The scanner is left on the last token of the grid reference.
+func (p *parser) parseGridReference(scanner *bufio.Scanner) (string, bool) {
+	if scanner.Text() == gridWord && !scanner.Scan() {
+		return "", false
+	}
+
+	// Accumulate tokens until they form a grid zone designator and 100 km square. Each part may be spoken as one or more
+	// tokens, e.g. "38t lm", "38 tango lima mike" or "38tlm".
+	reference := ""
+	var match []string
+	for {
+		token := scanner.Text()
+		if letter, ok := phoneticLetters[token]; ok {
+			token = letter
+		}
+		if token == "" || strings.ContainsFunc(token, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			return "", false
+		}
+		reference += token
+		match = gridReferencePattern.FindStringSubmatch(reference)
+		if match != nil {
+			break
+		}
+		// The longest incomplete prefix is a zone number, band and one letter of the 100 km square, e.g. "38tl".
+		if len(reference) > 4 || !scanner.Scan() {
+			return "", false
+		}
+	}
+	zone, square, digits := match[1], match[2], match[3]
+
+	var easting, northing string
+	if digits != "" {
+		if len(digits)%2 != 0 || len(digits) > 10 {
+			return "", false
+		}
+		easting, northing = digits[:len(digits)/2], digits[len(digits)/2:]
+	} else {
+		if !scanner.Scan() || !isNumeral(scanner.Text()) {
+			return "", false
+		}
+		easting = scanner.Text()
+		if !scanner.Scan() || !isNumeral(scanner.Text()) {
+			return "", false
+		}
+		northing = scanner.Text()
+		if len(easting) != len(northing) || len(easting) > 5 {
+			return "", false
+		}
+	}
+
+	parts := make([]string, 0, 4)
+	if zone != "" {
+		parts = append(parts, zone)
+	}
+	parts = append(parts, square, easting, northing)
+	return strings.ToUpper(strings.Join(parts, " ")), true
+}
@@ -84,6 +84,7 @@ func normalize(tx string) string {
 	tx = strings.ToLower(tx)
 	tx = strings.ReplaceAll(tx, "-", " ")
 	tx = strings.ReplaceAll(tx, "_", " ")
+	tx = spellDecimalPoints(tx)
 	for _, r := range tx {
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) {
 			tx = strings.ReplaceAll(tx, string(r), "")
@@ -97,6 +98,21 @@ func normalize(tx string) string {
 	return tx
 }
 
+// spellDecimalPoints replaces each decimal point between two digits with the word "point", so that the decimal point
+// is not removed along with other punctuation.
+func spellDecimalPoints(tx string) string {
+	runes := []rune(tx)
+	txBuilder := strings.Builder{}
+	for i, r := range runes {
+		if r == '.' && i > 0 && i < len(runes)-1 && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1]) {
+			txBuilder.WriteString(" point ")
+			continue
+		}
+		txBuilder.WriteRune(r)
+	}
+	return txBuilder.String()
+}
+
 func spaceDigits(tx string) string {
 	txBuilder := strings.Builder{}
 	for _, char := range tx {
@@ -202,7 +218,7 @@ func (p *parser) Parse(tx string) any {
 		event = event.Strs("args", requestArgs)
 	}
 	event.Msg("parsing request arguments")
-	scanner := bufio.NewScanner(strings.NewReader(strings.Join(requestArgs, " ")))
+	scanner := bufio.NewScanner(strings.NewReader(strings.Join(joinDecimals(requestArgs), " ")))
 	scanner.Split(bufio.ScanWords)
 
 	switch requestWord {
@@ -34,6 +34,9 @@ func TestParsePilotCallsign(t *testing.T) {
 		{"Red 054", "red 0 5 4"},
 		{"Gunfighter request", "gunfighter"},
 		{"This is Red 7", "red 7"},
+		{"EAGLE 1", "eagle 1"},
+		{"EAGLE 1 1", "eagle 1 1"},
+		{"EAGLE 11", "eagle 1 1"},
 	}
 
 	for _, test := range testCases {
@@ -129,6 +132,12 @@ func TestParserRadioCheck(t *testing.T) {
 				Callsign: "wildcat 1 1",
 			},
 		},
+		{
+			text: "anyface EAGLE 1 1 radio check",
+			expected: &brevity.RadioCheckRequest{
+				Callsign: "eagle 1 1",
+			},
+		},
 		{
 			text: "anyface intruder 11 radio check",
 			expected: &brevity.RadioCheckRequest{
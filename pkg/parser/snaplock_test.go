@@ -23,6 +23,28 @@ func TestParserSnaplock(t *testing.T) {
 				),
 			},
 		},
+		{
+			text: "ANYFACE, FREEDOM 31, SNAPLOCK 125 10, THIRTY-FIVE THOUSAND",
+			expected: &brevity.SnaplockRequest{
+				Callsign: "freedom 3 1",
+				BRA: brevity.NewBRA(
+					bearings.NewMagneticBearing(125*unit.Degree),
+					10*unit.NauticalMile,
+					35000*unit.Foot,
+				),
+			},
+		},
+		{
+			text: "ANYFACE, FREEDOM 31, SNAPLOCK 125 10, ANGELS TWENTY",
+			expected: &brevity.SnaplockRequest{
+				Callsign: "freedom 3 1",
+				BRA: brevity.NewBRA(
+					bearings.NewMagneticBearing(125*unit.Degree),
+					10*unit.NauticalMile,
+					20000*unit.Foot,
+				),
+			},
+		},
 		{
 			text: "Anyface Fox 1 2 snap lock 0-5-8-147-3000",
 			expected: &brevity.SnaplockRequest{
@@ -34,6 +56,50 @@ func TestParserSnaplock(t *testing.T) {
 				),
 			},
 		},
+		{
+			text: "Anyface Fox 1 2 snaplock 058 for 147 miles at 3000",
+			expected: &brevity.SnaplockRequest{
+				Callsign: "fox 1 2",
+				BRA: brevity.NewBRA(
+					bearings.NewMagneticBearing(58*unit.Degree),
+					147*unit.NauticalMile,
+					3000*unit.Foot,
+				),
+			},
+		},
+		{
+			text: "Anyface Fox 1 2 snaplock 058 147 nautical miles 3000",
+			expected: &brevity.SnaplockRequest{
+				Callsign: "fox 1 2",
+				BRA: brevity.NewBRA(
+					bearings.NewMagneticBearing(58*unit.Degree),
+					147*unit.NauticalMile,
+					3000*unit.Foot,
+				),
+			},
+		},
+		{
+			text: "Anyface Fox 1 2 snaplock 058 147 nm 3000",
+			expected: &brevity.SnaplockRequest{
+				Callsign: "fox 1 2",
+				BRA: brevity.NewBRA(
+					bearings.NewMagneticBearing(58*unit.Degree),
+					147*unit.NauticalMile,
+					3000*unit.Foot,
+				),
+			},
+		},
+		{
+			text: "Anyface Fox 1 2 snaplock 058 147 nautical 3000",
+			expected: &brevity.SnaplockRequest{
+				Callsign: "fox 1 2",
+				BRA: brevity.NewBRA(
+					bearings.NewMagneticBearing(58*unit.Degree),
+					147*unit.NauticalMile,
+					3000*unit.Foot,
+				),
+			},
+		},
 		{
 			text: "Anyface Fox 1 2 snaplock 058 for 147 at 3000",
 			expected: &brevity.SnaplockRequest{
@@ -56,3 +122,44 @@ func TestParserSnaplock(t *testing.T) {
 		require.InDelta(t, expected.BRA.Altitude().Feet(), actual.BRA.Altitude().Feet(), 50)
 	})
 }
+
+func TestParserSnaplockRangeUnits(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{}
+	for _, units := range []string{"miles", "mile", "nautical miles", "nm", "nautical"} {
+		testCases = append(
+			testCases,
+			parserTestCase{
+				text: "Anyface Fox 1 2 snaplock 058 147 " + units + " 3000",
+				expected: &brevity.SnaplockRequest{
+					Callsign: "fox 1 2",
+					BRA: brevity.NewBRA(
+						bearings.NewMagneticBearing(58*unit.Degree),
+						147*unit.NauticalMile,
+						3000*unit.Foot,
+					),
+				},
+			},
+			parserTestCase{
+				text: "Anyface Fox 1 2 snaplock 058 for 147 " + units + " at 12000",
+				expected: &brevity.SnaplockRequest{
+					Callsign: "fox 1 2",
+					BRA: brevity.NewBRA(
+						bearings.NewMagneticBearing(58*unit.Degree),
+						147*unit.NauticalMile,
+						12000*unit.Foot,
+					),
+				},
+			},
+		)
+	}
+	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.SnaplockRequest)
+		actual := request.(*brevity.SnaplockRequest)
+		require.Equal(t, expected.Callsign, actual.Callsign)
+		require.InDelta(t, expected.BRA.Bearing().Degrees(), actual.BRA.Bearing().Degrees(), 0.5)
+		require.InDelta(t, expected.BRA.Range().NauticalMiles(), actual.BRA.Range().NauticalMiles(), 0.5)
+		require.InDelta(t, expected.BRA.Altitude().Feet(), actual.BRA.Altitude().Feet(), 50)
+	})
+}
@@ -2,6 +2,10 @@ package parser
 
 import (
 	"bufio"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
@@ -61,19 +65,31 @@ func (p *parser) parseBRA(scanner *bufio.Scanner) (brevity.BRA, bool) {
 	return brevity.NewBRA(b, r, a), true
 }
 
-// parseBearing parses a 3 digit magnetic bearing. Each digit must be individually pronounced. Zeroes must be prefixed to values below 100.
+// parseBearing parses a 3 digit magnetic bearing, optionally followed by a fractional part, e.g. "2 7 0 point 5". Each
+// digit must be individually pronounced. Zeroes must be prefixed to values below 100, but a bearing below 1 degree may be
+// given as only its fractional part, e.g. "point 5". The fractional part must have been joined onto the preceding token
+// by [joinDecimals].
 func (p *parser) parseBearing(scanner *bufio.Scanner) (bearings.Bearing, bool) {
 	bearing := 0 * unit.Degree
 	digitsParsed := 0
 	for digitsParsed < 3 {
-		for _, char := range scanner.Text() {
-			if d, err := numwords.ParseInt(string(char)); err == nil {
-				bearing = bearing*10 + unit.Degree*unit.Angle(d)
-				digitsParsed++
-			}
+		whole, fraction, hasFraction := strings.Cut(scanner.Text(), ".")
+		for _, d := range parseDigits(whole) {
+			bearing = bearing*10 + unit.Degree*unit.Angle(d)
+			digitsParsed++
 			if digitsParsed == 3 {
-				return bearings.NewMagneticBearing(bearing), true
+				break
+			}
+		}
+		if hasFraction {
+			f, err := strconv.ParseFloat("0."+fraction, 64)
+			if err != nil {
+				return bearings.NewMagneticBearing(0), false
 			}
+			return bearings.NewMagneticBearing(bearing + unit.Degree*unit.Angle(f)), true
+		}
+		if digitsParsed == 3 {
+			return bearings.NewMagneticBearing(bearing), true
 		}
 		ok := scanner.Scan()
 		if !ok {
@@ -83,7 +99,96 @@ func (p *parser) parseBearing(scanner *bufio.Scanner) (bearings.Bearing, bool) {
 	return bearings.NewMagneticBearing(0), false
 }
 
-// parseRange parses a distance. The number must be pronounced as a whole cardinal number.
+// parseDigits returns the individually pronounced digits in the given token. The token may be a numeral such as "270",
+// or a single digit word such as "seven".
+func parseDigits(s string) []int {
+	if d, ok := parseDigitWord(s); ok {
+		return []int{d}
+	}
+	digits := make([]int, 0, len(s))
+	for _, char := range s {
+		if d, err := numwords.ParseInt(string(char)); err == nil {
+			digits = append(digits, d)
+		}
+	}
+	return digits
+}
+
+// digitWords maps words for single digits to their values.
+var digitWords = map[string]int{
+	"zero":  0,
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
+	"niner": 9,
+}
+
+// parseDigitWord parses a single digit spoken as a word, such as "seven".
+func parseDigitWord(s string) (int, bool) {
+	d, ok := digitWords[s]
+	return d, ok
+}
+
+// isNumeral returns true if the token consists only of digits.
+func isNumeral(s string) bool {
+	return s != "" && !strings.ContainsFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+}
+
+// decimalPointWords are words which a pilot may say between the whole and fractional parts of a number.
+var decimalPointWords = []string{"point", "decimal"}
+
+// joinDecimals joins each decimal point word and the digits which follow it onto the preceding token, e.g.
+// "2 7 0 point 5" becomes "2 7 0.5". If the preceding token is not a digit, the fractional part becomes its own token,
+// e.g. "point 5" becomes ".5". This allows a number with a fractional part to be parsed without looking ahead.
+func joinDecimals(fields []string) []string {
+	result := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		if !slices.Contains(decimalPointWords, fields[i]) {
+			result = append(result, fields[i])
+			continue
+		}
+		// The fractional part is either a single numeral or a run of digit words, since a following numeral is
+		// probably the next number, e.g. the range after a bearing.
+		fraction := strings.Builder{}
+		if i+1 < len(fields) && isNumeral(fields[i+1]) {
+			fraction.WriteString(fields[i+1])
+			i++
+		} else {
+			for i+1 < len(fields) {
+				d, ok := parseDigitWord(fields[i+1])
+				if !ok {
+					break
+				}
+				fraction.WriteString(strconv.Itoa(d))
+				i++
+			}
+		}
+		if fraction.Len() == 0 {
+			result = append(result, fields[i])
+			continue
+		}
+		decimal := "." + fraction.String()
+		if n := len(result); n > 0 && len(parseDigits(result[n-1])) > 0 && !strings.Contains(result[n-1], ".") {
+			result[n-1] += decimal
+		} else {
+			result = append(result, decimal)
+		}
+	}
+	return result
+}
+
+// rangeUnitWords are unit words which a pilot may say after a range. Ranges are always in nautical miles, so these are ignored.
+var rangeUnitWords = []string{"nautical", "miles", "mile", "nm"}
+
+// parseRange parses a distance, followed by any unit words such as "miles" or "nautical miles". The number must be
+// pronounced as a whole cardinal number. Since the scanner cannot look ahead, the scanner is left on the token
+// following the range and its unit words, or an empty token at the end of input.
 func (p *parser) parseRange(scanner *bufio.Scanner) (unit.Length, bool) {
 	if !scanner.Scan() {
 		return 0, false
@@ -95,21 +200,28 @@ func (p *parser) parseRange(scanner *bufio.Scanner) (unit.Length, bool) {
 	if !ok {
 		return 0, false
 	}
+	for scanner.Scan() {
+		if !slices.Contains(rangeUnitWords, scanner.Text()) {
+			break
+		}
+	}
 	return unit.Length(d) * unit.NauticalMile, true
 }
 
+// parseAltitude parses an altitude using [ParseAltitude], starting at the scanner's current token. The scanner is
+// advanced past the altitude.
 func (p *parser) parseAltitude(scanner *bufio.Scanner) (unit.Length, bool) {
-	if !scanner.Scan() {
-		return 0, false
-	}
 	if !skipWords(scanner, "at", "altitude") {
 		return 0, false
 	}
-	d, ok := p.parseNaturalNumber(scanner)
-	if !ok {
-		return 0, false
+	tokens := make([]string, 0)
+	for isAltitudeWord(scanner.Text()) {
+		tokens = append(tokens, scanner.Text())
+		if !scanner.Scan() {
+			break
+		}
 	}
-	return unit.Length(d) * unit.Foot, true
+	return ParseAltitude(tokens)
 }
 
 func (p *parser) parseTrack(scanner *bufio.Scanner) brevity.Track {
@@ -26,6 +26,27 @@ func TestParserSpiked(t *testing.T) {
 				Bearing:  bearings.NewMagneticBearing(unit.Angle(20) * unit.Degree),
 			},
 		},
+		{
+			text: "ANYFACE, EAGLE 1 SPIKED 270.5",
+			expected: &brevity.SpikedRequest{
+				Callsign: "eagle 1",
+				Bearing:  bearings.NewMagneticBearing(unit.Angle(270.5) * unit.Degree),
+			},
+		},
+		{
+			text: "ANYFACE, EAGLE 1 SPIKED TWO SEVEN ZERO POINT FIVE",
+			expected: &brevity.SpikedRequest{
+				Callsign: "eagle 1",
+				Bearing:  bearings.NewMagneticBearing(unit.Angle(270.5) * unit.Degree),
+			},
+		},
+		{
+			text: "ANYFACE, EAGLE 1 SPIKED POINT FIVE",
+			expected: &brevity.SpikedRequest{
+				Callsign: "eagle 1",
+				Bearing:  bearings.NewMagneticBearing(unit.Angle(0.5) * unit.Degree),
+			},
+		},
 	}
 	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseStream reads transmissions from the reader, one per line, parses them in order and publishes the results to the
+// given channel. Blank lines are skipped. It blocks until the reader is exhausted, the reader returns an error or the
+// context is cancelled, and returns nil only if the reader was exhausted. The results channel is not closed.
+func ParseStream(ctx context.Context, p Parser, r io.Reader, results chan<- ParseResult) error {
+	if r == nil {
+		return errors.New("reader is nil")
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		select {
+		case results <- newParseResult(p.Parse(line)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading transmissions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStream(t *testing.T) {
+	t.Parallel()
+	transcript := strings.Join([]string{
+		"anyface eagle 1 radio check",
+		"",
+		"eagle 2 radio check",
+		"anyface eagle 3 alpha check",
+		"anyface eagle 4",
+	}, "\n")
+	results := make(chan ParseResult, 10)
+	err := ParseStream(context.Background(), New(TestCallsign, false), strings.NewReader(transcript), results)
+	require.NoError(t, err)
+	close(results)
+
+	collected := make([]ParseResult, 0)
+	for result := range results {
+		collected = append(collected, result)
+	}
+	require.Len(t, collected, 4)
+	assert.Equal(t, &brevity.RadioCheckRequest{Callsign: "eagle 1"}, collected[0].IR)
+	assert.Nil(t, collected[1].IR)
+	assert.Equal(t, &brevity.AlphaCheckRequest{Callsign: "eagle 3"}, collected[2].IR)
+	assert.Equal(t, &brevity.UnableToUnderstandRequest{Callsign: "eagle 4"}, collected[3].IR)
+}
+
+func TestParseStreamNilReader(t *testing.T) {
+	t.Parallel()
+	err := ParseStream(context.Background(), New(TestCallsign, false), nil, make(chan ParseResult))
+	assert.Error(t, err)
+}
+
+func TestParseStreamReaderError(t *testing.T) {
+	t.Parallel()
+	failure := errors.New("disk on fire")
+	r := io.MultiReader(strings.NewReader("anyface eagle 1 radio check\n"), iotest.ErrReader(failure))
+	results := make(chan ParseResult, 10)
+	err := ParseStream(context.Background(), New(TestCallsign, false), r, results)
+	require.ErrorIs(t, err, failure)
+	assert.Len(t, results, 1)
+}
+
+func TestParseStreamCancelled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Nothing receives from the channel, so the stream can only return by observing the cancellation.
+	err := ParseStream(ctx, New(TestCallsign, false), strings.NewReader("anyface eagle 1 radio check\n"), make(chan ParseResult))
+	require.ErrorIs(t, err, context.Canceled)
+}
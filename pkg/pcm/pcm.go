@@ -38,6 +38,15 @@ func F32toS16LEBytes(in []float32) []byte {
 	return out
 }
 
+// F32toF32LEBytes converts a slice of float32 to a slice of bytes in F32LE format.
+func F32toF32LEBytes(in []float32) []byte {
+	out := make([]byte, 0, len(in)*4)
+	for _, f := range in {
+		out = binary.LittleEndian.AppendUint32(out, math.Float32bits(f))
+	}
+	return out
+}
+
 // S16LEtoF32 converts a slice of int16 bytes to a slice of float32 bytes. This is useful for converting from S16LE to F32LE.
 func F32LEBytesToS16LEBytes(in []byte) []byte {
 	out := make([]byte, 0)
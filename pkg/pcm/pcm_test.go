@@ -75,3 +75,14 @@ func TestS16toF32toS16RoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestF32toF32LEBytes(t *testing.T) {
+	t.Parallel()
+	actual := F32toF32LEBytes([]float32{0, 1, -0.5})
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x80, 0x3f,
+		0x00, 0x00, 0x00, 0xbf,
+	}
+	require.Equal(t, expected, actual)
+}
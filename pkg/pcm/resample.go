@@ -0,0 +1,29 @@
+package pcm
+
+import (
+	"math"
+
+	"github.com/martinlindhe/unit"
+)
+
+// Resample converts mono F32LE PCM audio from one sample rate to another by linear interpolation. The duration of the
+// audio is preserved: the output has ceil(len(in) * to / from) samples, so no input samples are lost at the end of the
+// audio. If the rates are equal, in is returned unchanged.
+func Resample(in []float32, from, to unit.Frequency) []float32 {
+	if from == to || len(in) == 0 {
+		return in
+	}
+	ratio := from.Hertz() / to.Hertz()
+	out := make([]float32, int(math.Ceil(float64(len(in))/ratio)))
+	for i := range out {
+		position := float64(i) * ratio
+		j := int(position)
+		if j >= len(in)-1 {
+			out[i] = in[len(in)-1]
+			continue
+		}
+		fraction := float32(position - float64(j))
+		out[i] = in[j] + (in[j+1]-in[j])*fraction
+	}
+	return out
+}
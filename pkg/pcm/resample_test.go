@@ -0,0 +1,58 @@
+package pcm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResampleLength(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		from     unit.Frequency
+		to       unit.Frequency
+		in       int
+		expected int
+	}{
+		{from: 16 * unit.Kilohertz, to: 16 * unit.Kilohertz, in: 641, expected: 641},
+		{from: 22050 * unit.Hertz, to: 16 * unit.Kilohertz, in: 22050, expected: 16000},
+		{from: 22050 * unit.Hertz, to: 16 * unit.Kilohertz, in: 22051, expected: 16001},
+		{from: 44100 * unit.Hertz, to: 16 * unit.Kilohertz, in: 44100, expected: 16000},
+		{from: 44100 * unit.Hertz, to: 16 * unit.Kilohertz, in: 1, expected: 1},
+		{from: 8 * unit.Kilohertz, to: 16 * unit.Kilohertz, in: 3, expected: 6},
+	}
+	for _, test := range testCases {
+		in := make([]float32, test.in)
+		out := Resample(in, test.from, test.to)
+		assert.Len(t, out, test.expected, "%v Hz to %v Hz", test.from.Hertz(), test.to.Hertz())
+	}
+}
+
+func TestResampleInterpolates(t *testing.T) {
+	t.Parallel()
+	out := Resample([]float32{0, 0.5, 1}, 8*unit.Kilohertz, 16*unit.Kilohertz)
+	require.Len(t, out, 6)
+	expected := []float32{0, 0.25, 0.5, 0.75, 1, 1}
+	for i := range expected {
+		assert.InDelta(t, expected[i], out[i], 1e-6, "sample %d", i)
+	}
+}
+
+func TestResamplePreservesTone(t *testing.T) {
+	t.Parallel()
+	const tone = 440.0
+	from := 44100 * unit.Hertz
+	to := 16 * unit.Kilohertz
+	in := make([]float32, int(from.Hertz()))
+	for i := range in {
+		in[i] = float32(math.Sin(2 * math.Pi * tone * float64(i) / from.Hertz()))
+	}
+	out := Resample(in, from, to)
+	for i, sample := range out {
+		expected := math.Sin(2 * math.Pi * tone * float64(i) / to.Hertz())
+		require.InDelta(t, expected, sample, 0.01, "sample %d", i)
+	}
+}
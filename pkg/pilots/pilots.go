@@ -0,0 +1,135 @@
+// package pilots maps the names of human pilots to their tactical callsigns.
+package pilots
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// PilotRegistry maps pilot names to tactical callsigns. Human-controlled aircraft in DCS are named after the player,
+// which may differ from the callsign the player uses on the radio. It is safe for concurrent use. A nil registry is
+// empty.
+type PilotRegistry struct {
+	// path to the registry file. This is empty if the registry was not loaded from a file.
+	path string
+	// modTime is the modification time of the registry file when it was last loaded.
+	modTime time.Time
+	// callsigns maps normalized pilot names to callsigns.
+	callsigns map[string]string
+	// lock protects modTime and callsigns.
+	lock sync.RWMutex
+}
+
+// registryFile is the format of the registry file.
+type registryFile struct {
+	// Pilots maps pilot names to callsigns.
+	Pilots map[string]string `yaml:"pilots"`
+}
+
+// NewPilotRegistry creates an empty registry.
+func NewPilotRegistry() *PilotRegistry {
+	return &PilotRegistry{callsigns: make(map[string]string)}
+}
+
+// LoadPilotRegistry creates a registry populated from the given YAML file.
+func LoadPilotRegistry(path string) (*PilotRegistry, error) {
+	r := NewPilotRegistry()
+	r.path = path
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// normalize returns the key used to look up a pilot name. Pilot names are case-insensitive.
+func normalize(pilot string) string {
+	return strings.ToLower(strings.TrimSpace(pilot))
+}
+
+// Reload replaces the registry's contents with the contents of the registry file.
+func (r *PilotRegistry) Reload() error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read pilot registry: %w", err)
+	}
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read pilot registry: %w", err)
+	}
+	var file registryFile
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return fmt.Errorf("failed to parse pilot registry: %w", err)
+	}
+	callsigns := make(map[string]string, len(file.Pilots))
+	for pilot, callsign := range file.Pilots {
+		callsigns[normalize(pilot)] = callsign
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.modTime = info.ModTime()
+	r.callsigns = callsigns
+	return nil
+}
+
+// Watch reloads the registry file whenever it is modified, so that the registry can be updated as players join and
+// leave. It checks the file at the given interval until the context is cancelled.
+func (r *PilotRegistry) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(r.path)
+			if err != nil {
+				log.Warn().Err(err).Str("path", r.path).Msg("failed to check pilot registry")
+				continue
+			}
+			r.lock.RLock()
+			isModified := info.ModTime().After(r.modTime)
+			r.lock.RUnlock()
+			if !isModified {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				log.Warn().Err(err).Str("path", r.path).Msg("failed to reload pilot registry")
+				continue
+			}
+			log.Info().Str("path", r.path).Msg("reloaded pilot registry")
+		}
+	}
+}
+
+// Lookup returns the callsign mapped to the given pilot name. The second return value is false if the pilot is not in
+// the registry.
+func (r *PilotRegistry) Lookup(pilot string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	callsign, ok := r.callsigns[normalize(pilot)]
+	return callsign, ok
+}
+
+// Callsign returns the callsign to use for an aircraft with the given name, in the normalized form returned by
+// [parser.ParsePilotCallsign]. If the name is in the registry, the mapped callsign is used. Otherwise, the callsign is
+// parsed from the aircraft's name, which is typically the name of the aircraft's group in the mission editor or a player
+// name that includes a callsign. The second return value is false if no callsign could be found.
+func (r *PilotRegistry) Callsign(name string) (string, bool) {
+	if callsign, ok := r.Lookup(name); ok {
+		name = callsign
+	}
+	name, _, _ = strings.Cut(name, "|")
+	return parser.ParsePilotCallsign(name)
+}
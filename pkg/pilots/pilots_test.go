@@ -0,0 +1,137 @@
+package pilots
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	registry, err := LoadPilotRegistry(filepath.Join("testdata", "pilots.yaml"))
+	require.NoError(t, err)
+
+	callsign, ok := registry.Lookup("Reaper")
+	require.True(t, ok)
+	assert.Equal(t, "Mobius 1", callsign)
+
+	callsign, ok = registry.Lookup(" [jg52] HARTMANN ")
+	require.True(t, ok)
+	assert.Equal(t, "Yellow 13", callsign)
+
+	_, ok = registry.Lookup("Pixy")
+	assert.False(t, ok)
+}
+
+func TestCallsign(t *testing.T) {
+	t.Parallel()
+	registry, err := LoadPilotRegistry(filepath.Join("testdata", "pilots.yaml"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{name: "Reaper", expected: "mobius 1", ok: true},
+		{name: "[JG52] Hartmann", expected: "yellow 1 3", ok: true},
+		// Names which are not in the registry fall back to the aircraft's group name.
+		{name: "Galm 2", expected: "galm 2", ok: true},
+		{name: "Galm 2 | Pixy", expected: "galm 2", ok: true},
+		{name: "", expected: "", ok: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			callsign, ok := registry.Callsign(test.name)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, callsign)
+		})
+	}
+}
+
+func TestNilRegistry(t *testing.T) {
+	t.Parallel()
+	var registry *PilotRegistry
+	_, ok := registry.Lookup("Reaper")
+	assert.False(t, ok)
+	callsign, ok := registry.Callsign("Mobius 1")
+	require.True(t, ok)
+	assert.Equal(t, "mobius 1", callsign)
+}
+
+func TestLoadInvalidRegistry(t *testing.T) {
+	t.Parallel()
+	_, err := LoadPilotRegistry(filepath.Join("testdata", "missing.yaml"))
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "pilots.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("pilots: ["), 0o600))
+	_, err = LoadPilotRegistry(path)
+	require.Error(t, err)
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "pilots.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("pilots:\n  Reaper: Mobius 1\n"), 0o600))
+	registry, err := LoadPilotRegistry(path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go registry.Watch(ctx, 10*time.Millisecond)
+
+	// A player joins and another leaves.
+	require.NoError(t, os.WriteFile(path, []byte("pilots:\n  Pixy: Galm 2\n"), 0o600))
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, future, future))
+
+	assert.Eventually(t, func() bool {
+		_, ok := registry.Lookup("Pixy")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	_, ok := registry.Lookup("Reaper")
+	assert.False(t, ok)
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "pilots.yaml")
+	var file strings.Builder
+	file.WriteString("pilots:\n")
+	for i := range 10 {
+		fmt.Fprintf(&file, "  pilot %d: Eagle %d\n", i, i)
+	}
+	require.NoError(t, os.WriteFile(path, []byte(file.String()), 0o600))
+	registry, err := LoadPilotRegistry(path)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				assert.NoError(t, registry.Reload())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				callsign, ok := registry.Lookup(fmt.Sprintf("pilot %d", i))
+				assert.True(t, ok)
+				assert.Equal(t, fmt.Sprintf("Eagle %d", i), callsign)
+			}
+		}()
+	}
+	wg.Wait()
+}
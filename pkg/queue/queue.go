@@ -0,0 +1,143 @@
+// Package queue limits the rate at which the GCI services requests, so that a burst of requests does not saturate the
+// frequency with back-to-back responses.
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/metrics"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+// DefaultMaxDepth is the default number of requests which may wait in a [ResponseQueue].
+const DefaultMaxDepth = 20
+
+// ResponseQueue holds requests and releases them in FIFO order, no faster than a maximum response rate. Requests which
+// arrive while the queue is full are dropped, whether or not the queue is rate limited. Urgent requests, such as requests on a guard frequency, are released ahead of other requests and are not delayed by
+// the response rate.
+type ResponseQueue[T any] struct {
+	// items holds queued requests.
+	items chan T
+	// urgent holds queued urgent requests.
+	urgent chan T
+	// limiter paces the release of requests. It allows a burst of up to one minute's worth of responses, and then
+	// refills at a steady rate.
+	limiter *rate.Limiter
+}
+
+// NewResponseQueue creates a queue which releases at most maxResponseRate requests per minute, and holds at most
+// maxDepth requests. A maxResponseRate of 0 or less disables rate limiting.
+func NewResponseQueue[T any](maxResponseRate, maxDepth int) *ResponseQueue[T] {
+	if maxDepth < 1 {
+		maxDepth = 1
+	}
+	return &ResponseQueue[T]{
+		items:   make(chan T, maxDepth),
+		urgent:  make(chan T, maxDepth),
+		limiter: newLimiter(maxResponseRate),
+	}
+}
+
+// newLimiter creates a limiter which allows perMinute requests per minute. A perMinute of 0 or less allows any number
+// of requests.
+func newLimiter(perMinute int) *rate.Limiter {
+	if perMinute <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), perMinute)
+}
+
+// Enqueue adds a request to the back of the queue. It never blocks; if the queue is full, the request is dropped,
+// [metrics.DroppedRequests] is incremented and false is returned.
+func (q *ResponseQueue[T]) Enqueue(item T) bool {
+	return enqueue(q.items, item)
+}
+
+// EnqueueUrgent adds an urgent request to the queue, behind any other urgent requests but ahead of all other requests.
+// It drops requests in the same way as [ResponseQueue.Enqueue].
+func (q *ResponseQueue[T]) EnqueueUrgent(item T) bool {
+	return enqueue(q.urgent, item)
+}
+
+// enqueue adds a request to the given channel without blocking. It returns false if the request was dropped.
+func enqueue[T any](items chan T, item T) bool {
+	select {
+	case items <- item:
+		return true
+	default:
+		log.Warn().Int("depth", cap(items)).Msg("dropping request because the response queue is full")
+		metrics.DroppedRequests.Inc()
+		return false
+	}
+}
+
+// Len returns the number of requests waiting in the queue.
+func (q *ResponseQueue[T]) Len() int {
+	return len(q.items) + len(q.urgent)
+}
+
+// Run publishes queued requests to the given channel in FIFO order, waiting as needed to stay within the maximum
+// response rate, until the context is cancelled. Urgent requests are published as soon as possible, even while another
+// request is waiting for the response rate.
+func (q *ResponseQueue[T]) Run(ctx context.Context, out chan<- T) {
+	for {
+		// Drain urgent requests before taking another request.
+		select {
+		case item := <-q.urgent:
+			if !publish(ctx, out, item) {
+				return
+			}
+			continue
+		default:
+		}
+
+		var item T
+		select {
+		case <-ctx.Done():
+			return
+		case urgent := <-q.urgent:
+			if !publish(ctx, out, urgent) {
+				return
+			}
+			continue
+		case item = <-q.items:
+		}
+
+		now := time.Now()
+		if wait := q.limiter.ReserveN(now, 1).DelayFrom(now); wait > 0 {
+			log.Info().Stringer("wait", wait).Int("queued", q.Len()).Msg("delaying request to stay within response rate")
+			timer := time.NewTimer(wait)
+		waiting:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case urgent := <-q.urgent:
+					if !publish(ctx, out, urgent) {
+						timer.Stop()
+						return
+					}
+				case <-timer.C:
+					break waiting
+				}
+			}
+		}
+
+		if !publish(ctx, out, item) {
+			return
+		}
+	}
+}
+
+// publish sends a request to the given channel. It returns false if the context is cancelled first.
+func publish[T any](ctx context.Context, out chan<- T, item T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- item:
+		return true
+	}
+}
@@ -0,0 +1,222 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// reserve takes a token from the limiter at the given time, and returns how long the caller must wait before the token
+// is available.
+func reserve(l *rate.Limiter, now time.Time) time.Duration {
+	return l.ReserveN(now, 1).DelayFrom(now)
+}
+
+func TestLimiterBurst(t *testing.T) {
+	t.Parallel()
+	l := newLimiter(5)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// A burst of 20 simultaneous requests at 5 per minute: the first 5 are serviced immediately, and the rest are
+	// serviced every 12 seconds afterwards.
+	for i := range 20 {
+		wait := reserve(l, start)
+		if i < 5 {
+			assert.Zero(t, wait, "request %d", i)
+		} else {
+			assert.Equal(t, time.Duration(i-4)*12*time.Second, wait, "request %d", i)
+		}
+	}
+}
+
+func TestLimiterRefills(t *testing.T) {
+	t.Parallel()
+	l := newLimiter(5)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for range 5 {
+		require.Zero(t, reserve(l, start))
+	}
+	assert.Equal(t, 12*time.Second, reserve(l, start))
+	for range 5 {
+		require.Zero(t, reserve(l, start.Add(10*time.Minute)))
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	t.Parallel()
+	l := newLimiter(0)
+	for range 100 {
+		assert.Zero(t, reserve(l, time.Now()))
+	}
+}
+
+func TestResponseQueueDropsWhenFull(t *testing.T) {
+	t.Parallel()
+	q := NewResponseQueue[int](5, 10)
+	dropped := testutil.ToFloat64(metrics.DroppedRequests)
+	accepted := 0
+	for i := range 20 {
+		if q.Enqueue(i) {
+			accepted++
+		}
+	}
+	assert.Equal(t, 10, accepted)
+	assert.Equal(t, 10, q.Len())
+	assert.GreaterOrEqual(t, testutil.ToFloat64(metrics.DroppedRequests)-dropped, float64(10))
+}
+
+func TestResponseQueueDropsWhenFullWithoutRateLimit(t *testing.T) {
+	t.Parallel()
+	q := NewResponseQueue[int](0, 10)
+	dropped := testutil.ToFloat64(metrics.DroppedRequests)
+	accepted := 0
+	for i := range 20 {
+		if q.Enqueue(i) {
+			accepted++
+		}
+	}
+	assert.Equal(t, 10, accepted)
+	assert.Equal(t, 10, q.Len())
+	assert.GreaterOrEqual(t, testutil.ToFloat64(metrics.DroppedRequests)-dropped, float64(10))
+}
+
+func TestResponseQueueUrgentDropsWhenFull(t *testing.T) {
+	t.Parallel()
+	for _, maxResponseRate := range []int{0, 5} {
+		q := NewResponseQueue[int](maxResponseRate, 2)
+		dropped := testutil.ToFloat64(metrics.DroppedRequests)
+		assert.True(t, q.EnqueueUrgent(0), "rate %d", maxResponseRate)
+		assert.True(t, q.EnqueueUrgent(1), "rate %d", maxResponseRate)
+		assert.False(t, q.EnqueueUrgent(2), "rate %d", maxResponseRate)
+		assert.Equal(t, 2, q.Len(), "rate %d", maxResponseRate)
+		assert.GreaterOrEqual(t, testutil.ToFloat64(metrics.DroppedRequests)-dropped, float64(1), "rate %d", maxResponseRate)
+	}
+}
+
+func TestResponseQueueWithoutRateLimit(t *testing.T) {
+	t.Parallel()
+	q := NewResponseQueue[int](0, 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan int)
+	go q.Run(ctx, out)
+	for i := range 20 {
+		require.True(t, q.Enqueue(i))
+	}
+	for i := range 20 {
+		select {
+		case actual := <-out:
+			assert.Equal(t, i, actual)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for queued request")
+		}
+	}
+}
+
+func TestResponseQueueFIFO(t *testing.T) {
+	t.Parallel()
+	q := NewResponseQueue[int](0, 20)
+	for i := range 20 {
+		require.True(t, q.Enqueue(i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan int)
+	go q.Run(ctx, out)
+	for i := range 20 {
+		select {
+		case actual := <-out:
+			assert.Equal(t, i, actual)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for queued request")
+		}
+	}
+}
+
+func TestResponseQueueRateLimited(t *testing.T) {
+	t.Parallel()
+	q := NewResponseQueue[int](5, 20)
+	for i := range 20 {
+		require.True(t, q.Enqueue(i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan int)
+	go q.Run(ctx, out)
+	for i := range 5 {
+		select {
+		case actual := <-out:
+			assert.Equal(t, i, actual)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for queued request")
+		}
+	}
+	select {
+	case <-out:
+		assert.Fail(t, "request was released faster than the rate limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, 14, q.Len())
+}
+
+func TestResponseQueueUrgentFirst(t *testing.T) {
+	t.Parallel()
+	q := NewResponseQueue[int](0, 20)
+	for i := range 5 {
+		require.True(t, q.Enqueue(i))
+	}
+	require.True(t, q.EnqueueUrgent(100))
+	require.True(t, q.EnqueueUrgent(101))
+	assert.Equal(t, 7, q.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan int)
+	go q.Run(ctx, out)
+	for _, expected := range []int{100, 101, 0, 1, 2, 3, 4} {
+		select {
+		case actual := <-out:
+			assert.Equal(t, expected, actual)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for queued request")
+		}
+	}
+}
+
+func TestResponseQueueUrgentNotRateLimited(t *testing.T) {
+	t.Parallel()
+	q := NewResponseQueue[int](5, 20)
+	for i := range 10 {
+		require.True(t, q.Enqueue(i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan int)
+	go q.Run(ctx, out)
+	for i := range 5 {
+		select {
+		case actual := <-out:
+			assert.Equal(t, i, actual)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for queued request")
+		}
+	}
+
+	// The next routine request is waiting for the rate limit, but an urgent request is released immediately.
+	require.True(t, q.EnqueueUrgent(100))
+	select {
+	case actual := <-out:
+		assert.Equal(t, 100, actual)
+	case <-time.After(time.Second):
+		require.FailNow(t, "urgent request was delayed by the rate limit")
+	}
+}
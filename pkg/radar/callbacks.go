@@ -15,6 +15,16 @@ func (s *scope) SetStartedCallback(callback StartedCallback) {
 	s.startedCallback = callback
 }
 
+// UpdatedCallback is a callback function that is called when sensors update a trackfile, including when a new
+// trackfile is created for a contact which was reacquired. The ID of the trackfile is provided.
+type UpdatedCallback func(id uint64)
+
+func (s *scope) SetUpdatedCallback(callback UpdatedCallback) {
+	s.callbackLock.Lock()
+	defer s.callbackLock.Unlock()
+	s.updatedCallback = callback
+}
+
 // FadedCallback is a callback function that is called when a group has not been updated by sensors for a timeout period.
 // The group and its coalition are provided.
 type FadedCallback func(location orb.Point, group brevity.Group, coalition coalitions.Coalition)
@@ -34,3 +44,13 @@ func (s *scope) SetRemovedCallback(callback RemovedCallback) {
 	defer s.callbackLock.Unlock()
 	s.removalCallback = callback
 }
+
+// SectorCleanCallback is a callback function that is called when the last contact of a coalition leaves or is removed
+// from a sector.
+type SectorCleanCallback func(event SectorClean)
+
+func (s *scope) SetSectorCleanCallback(callback SectorCleanCallback) {
+	s.callbackLock.Lock()
+	defer s.callbackLock.Unlock()
+	s.sectorCleanCallback = callback
+}
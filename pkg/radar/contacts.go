@@ -1,19 +1,24 @@
 package radar
 
 import (
+	"cmp"
 	"iter"
 	"slices"
-	"strings"
 	"sync"
 
 	"github.com/dharmab/skyeye/pkg/coalitions"
-	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/dharmab/skyeye/pkg/pilots"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	fuzz "github.com/hbollon/go-edlib"
 	"github.com/rs/zerolog/log"
 )
 
 // contactDatabase is a thread-safe trackfile database.
+//
+// The database is written by the goroutine which consumes simulation updates and read concurrently by the GCI controller,
+// the API server, and other radar goroutines. All methods may be called from any goroutine. Methods hold the database's
+// lock only for their own duration: values returns a snapshot, so the database may change while the caller iterates.
+// Trackfiles are shared rather than copied; each trackfile protects its own frames with its own lock.
 type contactDatabase interface {
 	// getByCallsignAndCoalititon returns the trackfile with the lowest edit distance to the given callsign, or nil if no closely named trackfile was found.
 	// The second return value is true if a trackfile was found, and false otherwise.
@@ -34,13 +39,20 @@ type contactDatabase interface {
 }
 
 type database struct {
-	lock        sync.RWMutex
-	contacts    map[uint64]*trackfiles.Trackfile
+	// lock protects contacts, callsignIdx, and indexedCallsigns.
+	lock sync.RWMutex
+	// contacts maps unit IDs to trackfiles.
+	contacts map[uint64]*trackfiles.Trackfile
+	// callsignIdx maps each coalition's callsigns to unit IDs.
 	callsignIdx map[coalitions.Coalition]map[string]uint64
+	// indexedCallsigns maps unit IDs to the callsign each trackfile is indexed by in callsignIdx.
+	indexedCallsigns map[uint64]string
+	// pilots maps pilot names to callsigns. It may be nil.
+	pilots *pilots.PilotRegistry
 }
 
-func newContactDatabase() contactDatabase {
-	d := &database{}
+func newContactDatabase(pilots *pilots.PilotRegistry) contactDatabase {
+	d := &database{pilots: pilots}
 	d.reset()
 	return d
 }
@@ -60,6 +72,10 @@ func (d *database) getByCallsignAndCoalititon(callsign string, coalition coaliti
 		for k := range d.callsignIdx[coalition] {
 			keys = append(keys, k)
 		}
+		// The fuzzy search returns the first of any equally similar callsigns. Search the longest callsigns first, so
+		// that the most specific callsign is preferred (e.g. "eagle 1 1" over "eagle 1"), and break any remaining ties
+		// alphabetically so that the result does not depend on map iteration order.
+		slices.SortFunc(keys, compareCallsigns)
 		logger.Info().Msg("callsign not found in index, attempting fuzzy search")
 		var err error
 		foundCallsign, err = fuzz.FuzzySearchThreshold(callsign, keys, 0.63, fuzz.Levenshtein)
@@ -91,25 +107,37 @@ func (d *database) set(trackfile *trackfiles.Trackfile) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
-	// TODO get this string munging out of here
-	callsign, _, _ := strings.Cut(trackfile.Contact.Name, "|")
-	callsign, ok := parser.ParsePilotCallsign(callsign)
+	callsign, ok := d.pilots.Callsign(trackfile.Contact.Name)
 	if !ok {
 		callsign = trackfile.Contact.Name
 	}
+	// The callsign may change if the pilot registry is updated, so remove any stale index entry.
+	d.unindex(trackfile.Contact.ID)
 	d.callsignIdx[trackfile.Contact.Coalition][callsign] = trackfile.Contact.ID
+	d.indexedCallsigns[trackfile.Contact.ID] = callsign
 	d.contacts[trackfile.Contact.ID] = trackfile
 }
 
+// unindex removes the callsign index entry for the given unit ID. The caller must hold the write lock.
+func (d *database) unindex(id uint64) {
+	contact, ok := d.contacts[id]
+	if !ok {
+		return
+	}
+	callsign := d.indexedCallsigns[id]
+	if d.callsignIdx[contact.Contact.Coalition][callsign] == id {
+		delete(d.callsignIdx[contact.Contact.Coalition], callsign)
+	}
+	delete(d.indexedCallsigns, id)
+}
+
 // delete implements [contactDatabase.delete].
 func (d *database) delete(id uint64) bool {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
-	contact, ok := d.contacts[id]
-	if ok {
-		delete(d.callsignIdx[contact.Contact.Coalition], contact.Contact.Name)
-	}
+	_, ok := d.contacts[id]
+	d.unindex(id)
 	delete(d.contacts, id)
 
 	return ok
@@ -121,6 +149,7 @@ func (d *database) reset() {
 	defer d.lock.Unlock()
 	d.contacts = make(map[uint64]*trackfiles.Trackfile)
 	d.callsignIdx = make(map[coalitions.Coalition]map[string]uint64)
+	d.indexedCallsigns = make(map[uint64]string)
 	for _, c := range []coalitions.Coalition{coalitions.Blue, coalitions.Red, coalitions.Neutrals} {
 		d.callsignIdx[c] = make(map[string]uint64)
 	}
@@ -137,3 +166,11 @@ func (d *database) values() iter.Seq[*trackfiles.Trackfile] {
 	}
 	return slices.Values(contacts)
 }
+
+// compareCallsigns orders callsigns from longest to shortest, then alphabetically.
+func compareCallsigns(a, b string) int {
+	if c := cmp.Compare(len(b), len(a)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a, b)
+}
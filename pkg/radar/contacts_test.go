@@ -1,11 +1,17 @@
 package radar
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/dharmab/skyeye/pkg/pilots"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
@@ -15,7 +21,7 @@ import (
 
 func TestGetByCallsign(t *testing.T) {
 	t.Parallel()
-	db := newContactDatabase()
+	db := newContactDatabase(nil)
 	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
 		ID:        1,
 		Name:      "Mobius 1 Reaper",
@@ -53,7 +59,7 @@ func TestRealCallsigns(t *testing.T) {
 		{Name: "Spare 15", heardAs: "spear 15"},
 		{Name: "Olympus-1-1", heardAs: "olympus 1 1"},
 	}
-	db := newContactDatabase()
+	db := newContactDatabase(nil)
 
 	for i, test := range testCases {
 		trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
@@ -77,7 +83,7 @@ func TestRealCallsigns(t *testing.T) {
 
 func TestGetByID(t *testing.T) {
 	t.Parallel()
-	db := newContactDatabase()
+	db := newContactDatabase(nil)
 	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
 		ID:        1,
 		Name:      "Mobius 1 Reaper",
@@ -96,7 +102,7 @@ func TestGetByID(t *testing.T) {
 
 func TestSet(t *testing.T) {
 	t.Parallel()
-	database := newContactDatabase()
+	database := newContactDatabase(nil)
 	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
 		ID:        1,
 		Name:      "Mobius 1 Reaper",
@@ -128,7 +134,7 @@ func TestSet(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	t.Parallel()
-	database := newContactDatabase()
+	database := newContactDatabase(nil)
 	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
 		ID:        1,
 		Name:      "Mobius 1 Reaper",
@@ -152,7 +158,7 @@ func TestDelete(t *testing.T) {
 
 func TestClear(t *testing.T) {
 	t.Parallel()
-	database := newContactDatabase()
+	database := newContactDatabase(nil)
 	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
 		ID:        1,
 		Name:      "Mobius 1 Reaper",
@@ -184,7 +190,7 @@ func TestClear(t *testing.T) {
 
 func TestValues(t *testing.T) {
 	t.Parallel()
-	db := newContactDatabase()
+	db := newContactDatabase(nil)
 
 	mobius := trackfiles.NewTrackfile(trackfiles.Labels{
 		ID:        1,
@@ -219,3 +225,144 @@ func TestValues(t *testing.T) {
 	assert.True(t, foundMobius)
 	assert.True(t, foundYellow)
 }
+
+func TestGetByRegisteredCallsign(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "pilots.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("pilots:\n  Reaper: Mobius 1\n"), 0o600))
+	registry, err := pilots.LoadPilotRegistry(path)
+	require.NoError(t, err)
+	db := newContactDatabase(registry)
+	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
+		ID:        1,
+		Name:      "Reaper",
+		Coalition: coalitions.Blue,
+		ACMIName:  "F-15C",
+	})
+	db.set(trackfile)
+
+	name, tf, ok := db.getByCallsignAndCoalititon("mobius 1", coalitions.Blue)
+	require.True(t, ok)
+	assert.Equal(t, "mobius 1", name)
+	assert.EqualValues(t, trackfile, tf)
+
+	// When the registry changes, the trackfile is re-indexed on the next update.
+	require.NoError(t, os.WriteFile(path, []byte("pilots:\n  Reaper: Galm 2\n"), 0o600))
+	require.NoError(t, registry.Reload())
+	db.set(trackfile)
+	name, _, ok = db.getByCallsignAndCoalititon("galm 2", coalitions.Blue)
+	require.True(t, ok)
+	assert.Equal(t, "galm 2", name)
+	_, ok = db.(*database).callsignIdx[coalitions.Blue]["mobius 1"]
+	assert.False(t, ok)
+
+	require.True(t, db.delete(1))
+	assert.Empty(t, db.(*database).callsignIdx[coalitions.Blue])
+}
+
+// TestConcurrentAccess exercises the database from many goroutines. Run with -race to detect data races.
+func TestConcurrentAccess(t *testing.T) {
+	t.Parallel()
+	db := newContactDatabase(pilots.NewPilotRegistry())
+	const (
+		writers    = 4
+		readers    = 4
+		iterations = 200
+	)
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				id := uint64(w*iterations + i)
+				trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
+					ID:        id,
+					Name:      fmt.Sprintf("Mobius %d", id),
+					Coalition: coalitions.Blue,
+					ACMIName:  "F-15C",
+				})
+				db.set(trackfile)
+				trackfile.Update(trackfiles.Frame{
+					Time:     time.Now(),
+					Point:    orb.Point{float64(i) / iterations, 1},
+					Altitude: 1000 * unit.Foot,
+				})
+				db.set(trackfile)
+				if i%3 == 0 {
+					db.delete(id)
+				}
+			}
+		}()
+	}
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				_, _ = db.getByID(uint64(i))
+				_, _, _ = db.getByCallsignAndCoalititon(fmt.Sprintf("mobius %d", i), coalitions.Blue)
+				for trackfile := range db.values() {
+					_ = trackfile.LastKnown()
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range iterations / 20 {
+			db.reset()
+		}
+	}()
+	wg.Wait()
+
+	db.reset()
+	count := 0
+	for range db.values() {
+		count++
+	}
+	assert.Zero(t, count)
+}
+
+func TestGetByCallsignPrefersLongestMatch(t *testing.T) {
+	t.Parallel()
+	db := newContactDatabase(nil)
+	for i, name := range []string{"Eagle 1", "Eagle 1-1", "Viper 2-3", "Viper 2-5"} {
+		db.set(trackfiles.NewTrackfile(trackfiles.Labels{
+			ID:        uint64(i + 1),
+			Name:      name,
+			Coalition: coalitions.Blue,
+			ACMIName:  "F-15C",
+		}))
+	}
+
+	testCases := []struct {
+		heardAs  string
+		expected string
+	}{
+		{heardAs: "eagle 1", expected: "eagle 1"},
+		{heardAs: "eagle 1 1", expected: "eagle 1 1"},
+		{heardAs: "eagel 1 1", expected: "eagle 1 1"},
+		// Equally similar callsigns of the same length are resolved alphabetically.
+		{heardAs: "viper 2 4", expected: "viper 2 3"},
+	}
+	for _, test := range testCases {
+		t.Run(test.heardAs, func(t *testing.T) {
+			t.Parallel()
+			// Repeat the search since map iteration order is random.
+			for range 20 {
+				name, _, ok := db.getByCallsignAndCoalititon(test.heardAs, coalitions.Blue)
+				require.True(t, ok)
+				assert.Equal(t, test.expected, name)
+			}
+		})
+	}
+}
+
+func TestCompareCallsigns(t *testing.T) {
+	t.Parallel()
+	callsigns := []string{"eagle 1", "viper 2 5", "eagle 1 1", "viper 2 3", "jeff"}
+	slices.SortFunc(callsigns, compareCallsigns)
+	assert.Equal(t, []string{"eagle 1 1", "viper 2 3", "viper 2 5", "eagle 1", "jeff"}, callsigns)
+}
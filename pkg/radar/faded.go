@@ -93,6 +93,7 @@ func (s *scope) handleFaded(fades []sim.Faded) {
 	// remove the faded contacts from the database
 	for _, fade := range fades {
 		s.contacts.delete(fade.ID)
+		s.removeFromSectors(fade.ID)
 	}
 
 	// call the faded callback for each group
@@ -105,6 +106,31 @@ func (s *scope) handleFaded(fades []sim.Faded) {
 	}
 }
 
+// PruneStale implements [Radar.PruneStale].
+func (s *scope) PruneStale(cutoff time.Time) int {
+	var fades []sim.Faded
+	for trackfile := range s.contacts.values() {
+		lastSeen := trackfile.LastObserved().Time
+		if lastSeen.IsZero() || !lastSeen.Before(cutoff) {
+			continue
+		}
+		log.Info().
+			Uint64("id", trackfile.Contact.ID).
+			Str("callsign", trackfile.Contact.Name).
+			Stringer("age", cutoff.Sub(lastSeen)).
+			Msg("pruning stale trackfile")
+		fades = append(fades, sim.Faded{ID: trackfile.Contact.ID})
+	}
+	if len(fades) == 0 {
+		return 0
+	}
+
+	s.pendingFadesLock.Lock()
+	defer s.pendingFadesLock.Unlock()
+	s.handleFaded(fades)
+	return len(fades)
+}
+
 func (s *scope) areFadesPending() bool {
 	s.pendingFadesLock.RLock()
 	defer s.pendingFadesLock.RUnlock()
@@ -0,0 +1,74 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneStale(t *testing.T) {
+	t.Parallel()
+	const ttl = 45 * time.Second
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue}).(*scope)
+	var faded []brevity.Group
+	s.SetFadedCallback(func(_ orb.Point, group brevity.Group, _ coalitions.Coalition) {
+		faded = append(faded, group)
+	})
+
+	t0 := time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC)
+	s.SetMissionTime(t0)
+	update := sim.Updated{
+		Labels: trackfiles.Labels{ID: 1, Name: "Yellow 13", Coalition: coalitions.Red, ACMIName: "Su-37"},
+		Frame:  trackfiles.Frame{Time: t0, Point: orb.Point{42, 42}, Altitude: 20000 * unit.Foot},
+	}
+	// The first update creates the trackfile and the second records its position.
+	s.handleUpdate(update)
+	s.handleUpdate(update)
+	require.NotNil(t, s.FindUnit(1))
+
+	// Each cutoff is the mission time minus the TTL.
+	assert.Equal(t, 0, s.PruneStale(t0.Add(ttl/2).Add(-ttl)), "a contact within the TTL should not be pruned")
+	assert.NotNil(t, s.FindUnit(1))
+	assert.Empty(t, faded)
+
+	assert.Equal(t, 1, s.PruneStale(t0.Add(ttl+time.Second).Add(-ttl)))
+	assert.Nil(t, s.FindUnit(1))
+	require.Len(t, faded, 1, "pruning a contact should produce exactly one FADED callout")
+	assert.Equal(t, []uint64{1}, faded[0].ObjectIDs())
+
+	assert.Equal(t, 0, s.PruneStale(t0.Add(2*ttl).Add(-ttl)))
+	assert.Len(t, faded, 1, "a pruned contact should not fade again")
+}
+
+func TestUpdatedCallbackOnReacquire(t *testing.T) {
+	t.Parallel()
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue}).(*scope)
+	var updated []uint64
+	s.SetUpdatedCallback(func(id uint64) {
+		updated = append(updated, id)
+	})
+
+	t0 := time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC)
+	s.SetMissionTime(t0)
+	update := sim.Updated{
+		Labels: trackfiles.Labels{ID: 1, Name: "Yellow 13", Coalition: coalitions.Red, ACMIName: "Su-37"},
+		Frame:  trackfiles.Frame{Time: t0, Point: orb.Point{42, 42}, Altitude: 20000 * unit.Foot},
+	}
+	s.handleUpdate(update)
+	s.handleUpdate(update)
+	assert.Equal(t, []uint64{1, 1}, updated)
+
+	// The contact fades, and is then reacquired with a new trackfile.
+	require.Equal(t, 1, s.PruneStale(t0.Add(time.Second)))
+	update.Frame.Time = t0.Add(time.Minute)
+	s.handleUpdate(update)
+	assert.Equal(t, []uint64{1, 1, 1}, updated)
+}
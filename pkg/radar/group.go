@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"slices"
 	"strings"
-	"time"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
@@ -13,7 +12,6 @@ import (
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
-	"github.com/rs/zerolog/log"
 
 	"github.com/paulmach/orb/geo"
 )
@@ -26,6 +24,10 @@ type group struct {
 	aspect      *brevity.Aspect
 	declaration brevity.Declaration
 	mergedWith  int
+	closure     brevity.Closure
+	// variation converts the bearing from the bullseye between true and magnetic north.
+	variation bearings.MagneticVariation
+	isPopup   bool
 }
 
 var _ brevity.Group = &group{}
@@ -48,23 +50,29 @@ func (g *group) SetThreat(isThreat bool) {
 	g.isThreat = isThreat
 }
 
+// Popup implements [brevity.Group.Popup].
+func (g *group) Popup() bool {
+	return g.isPopup
+}
+
 // Contacts implements [brevity.Group.Contacts].
 func (g *group) Contacts() int {
 	return len(g.contacts)
 }
 
+// SpokenContacts implements [brevity.Group.SpokenContacts].
+func (g *group) SpokenContacts() (string, bool) {
+	return brevity.SpokenContacts(g.Contacts())
+}
+
 // Bullseye implements [brevity.Group.Bullseye].
 func (g *group) Bullseye() *brevity.Bullseye {
 	if g.bullseye == nil {
 		return nil
 	}
 
-	declination, err := bearings.Declination(*g.bullseye, g.missionTime())
-	if err != nil {
-		log.Error().Err(err).Stringer("group", g).Msg("failed to get declination for group")
-	}
 	point := g.point()
-	bearing := spatial.TrueBearing(*g.bullseye, point).Magnetic(declination)
+	bearing := g.variation.Magnetic(spatial.TrueBearing(*g.bullseye, point), *g.bullseye)
 	distance := spatial.Distance(*g.bullseye, point)
 	return brevity.NewBullseye(bearing, distance)
 }
@@ -100,12 +108,12 @@ func (g *group) Track() brevity.Track {
 		return brevity.UnknownDirection
 	}
 	// TODO interpolate from all members
-	return g.contacts[0].Direction()
+	return g.contacts[0].Direction(g.variation)
 }
 
 func (g *group) course() bearings.Bearing {
 	// TODO interpolate from all members
-	return g.contacts[0].Course()
+	return g.contacts[0].Course(g.variation)
 }
 
 // Aspect implements [brevity.Group.Aspect].
@@ -126,6 +134,16 @@ func (g *group) BRAA() brevity.BRAA {
 	return g.braa
 }
 
+// Closure implements [brevity.Group.Closure].
+func (g *group) Closure() brevity.Closure {
+	return g.closure
+}
+
+// SetClosure implements [brevity.Group.SetClosure].
+func (g *group) SetClosure(closure brevity.Closure) {
+	g.closure = closure
+}
+
 // Declaration implements [brevity.Group.Declaration].
 func (g *group) Declaration() brevity.Declaration {
 	return g.declaration
@@ -138,24 +156,14 @@ func (g *group) SetDeclaration(declaration brevity.Declaration) {
 
 // Heavy implements [brevity.Group.Heavy].
 func (g *group) Heavy() bool {
-	return len(g.contacts) >= 3
+	return brevity.IsHeavy(len(g.contacts))
 }
 
 // Platforms implements [brevity.Group.Platforms].
 func (g *group) Platforms() []string {
 	platforms := make(map[string]struct{})
 	for _, trackfile := range g.contacts {
-		var name string
-		data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
-		if ok {
-			for _, reportingName := range []string{data.NATOReportingName, data.Nickname, data.OfficialName, data.PlatformDesignation} {
-				if reportingName != "" {
-					name = reportingName
-					break
-				}
-			}
-		}
-		platforms[name] = struct{}{}
+		platforms[encyclopedia.ReportingName(trackfile.Contact.ACMIName)] = struct{}{}
 	}
 	result := make([]string, 0, len(platforms))
 	for platform := range platforms {
@@ -171,11 +179,33 @@ func (g *group) High() bool {
 
 // Fast implements [brevity.Group.Fast].
 func (g *group) Fast() bool {
-	return false
+	return g.speed() == brevity.FastSpeed
 }
 
 // VeryFast implements [brevity.Group.VeryFast].
 func (g *group) VeryFast() bool {
+	return g.speed() == brevity.VeryFastSpeed
+}
+
+// speed returns the speed descriptor of the fastest contact in the group.
+func (g *group) speed() brevity.SpeedDescriptor {
+	fastest := brevity.NormalSpeed
+	for _, trackfile := range g.contacts {
+		descriptor := brevity.DescribeSpeed(trackfile.Speed(), trackfile.LastKnown().Altitude)
+		if descriptor > fastest {
+			fastest = descriptor
+		}
+	}
+	return fastest
+}
+
+// Extrapolated implements [brevity.Group.Extrapolated].
+func (g *group) Extrapolated() bool {
+	for _, trackfile := range g.contacts {
+		if trackfile.LastKnown().Extrapolated {
+			return true
+		}
+	}
 	return false
 }
 
@@ -214,6 +244,9 @@ func (g *group) String() string {
 		g.Contacts(),
 		strings.Join(g.Platforms(), ","),
 	)
+	if stacks := g.Stacks(); len(stacks) > 1 {
+		s += fmt.Sprintf(" [%s]", brevity.StacksString(stacks))
+	}
 	if g.isThreat {
 		s = "THREAT " + s
 	}
@@ -251,19 +284,8 @@ func (g *group) point() orb.Point {
 	return center
 }
 
-// missionTime returns the mission-time timestamp of the most recent trackfile in the group.
-func (g *group) missionTime() time.Time {
-	var latest time.Time
-	for _, trackfile := range g.contacts {
-		if trackfile.LastKnown().Time.After(latest) {
-			latest = trackfile.LastKnown().Time
-		}
-	}
-	return latest
-}
-
-// threatRadius returns the highest threat radius of all contacts in the group.
-func (g *group) threatRadius() unit.Length {
+// ThreatRadius implements [brevity.Group.ThreatRadius].
+func (g *group) ThreatRadius() unit.Length {
 	highest := unit.Length(0)
 	for _, trackfile := range g.contacts {
 		radius := encyclopedia.SAR2AR1Threat
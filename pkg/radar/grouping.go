@@ -1,6 +1,8 @@
 package radar
 
 import (
+	"cmp"
+	"math"
 	"slices"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
@@ -11,105 +13,203 @@ import (
 	"github.com/martinlindhe/unit"
 )
 
-func (s *scope) enumerateGroups(coalition coalitions.Coalition) []*group {
-	visited := make(map[uint64]struct{})
-	groups := make([]*group, 0)
-	for trackfile := range s.contacts.values() {
-		if _, ok := visited[trackfile.Contact.ID]; ok {
-			continue
-		}
-		visited[trackfile.Contact.ID] = struct{}{}
+// DefaultGroupLateralThreshold is the default maximum 2D distance between contacts which are linked into the same group.
+// It is increased from the conventional 3 nautical miles because the DCS AI isn't amazing at holding formation.
+const DefaultGroupLateralThreshold = 5 * unit.NauticalMile
 
-		if trackfile.Contact.Coalition != coalition {
-			continue
+// sweep enumerates the groups of both coalitions once, and records them in the POPUP detector and, if a group log is
+// configured, the group history.
+func (s *scope) sweep() {
+	missionTime := s.MissionTime()
+	candidates := make([]popupCandidate, 0)
+	memberships := make([][]uint64, 0)
+	for _, coalition := range []coalitions.Coalition{coalitions.Red, coalitions.Blue} {
+		for _, grp := range s.enumerateGroups(coalition) {
+			ids := grp.ObjectIDs()
+			candidates = append(candidates, popupCandidate{ids: ids, point: grp.point()})
+			memberships = append(memberships, ids)
 		}
+	}
+	s.popups.update(missionTime, candidates)
+	if s.groupLogPath != "" {
+		s.history.record(missionTime, memberships)
+	}
+}
 
-		if !isValidTrack(trackfile) {
-			continue
+// enumerateGroups returns every group of the given coalition. Contacts are grouped by single-linkage clustering: two
+// contacts are in the same group if [scope.isGroupedWith] is true for them, or if they are connected by a chain of such
+// contacts.
+func (s *scope) enumerateGroups(coalition coalitions.Coalition) []*group {
+	contacts := make([]*trackfiles.Trackfile, 0)
+	frames := make([]trackfiles.Frame, 0)
+	for trackfile := range s.contacts.values() {
+		if trackfile.Contact.Coalition == coalition && s.isValidTrack(trackfile) {
+			contacts = append(contacts, trackfile)
+			frames = append(frames, trackfile.LastKnown())
 		}
+	}
 
-		grp := s.findGroupForAircraft(trackfile)
-		if grp == nil {
-			continue
-		}
-		for _, id := range grp.ObjectIDs() {
-			visited[id] = struct{}{}
+	// Sweep over the contacts in order of latitude, so that each contact is only compared to contacts which are close
+	// enough in latitude to possibly be within the lateral threshold.
+	indices := make([]int, len(contacts))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortFunc(indices, func(a, b int) int {
+		return cmp.Compare(frames[a].Point.Lat(), frames[b].Point.Lat())
+	})
+	window := s.groupLateralThreshold.Meters() / minimumMetersPerDegreeLatitude
+	clusters := newDisjointSet(len(contacts))
+	for n, i := range indices {
+		for _, j := range indices[n+1:] {
+			if frames[j].Point.Lat()-frames[i].Point.Lat() > window {
+				break
+			}
+			if s.isGroupedWith(contacts[i], frames[i], contacts[j], frames[j]) {
+				clusters.union(i, j)
+			}
 		}
-		groups = append(groups, grp)
 	}
 
+	members := make(map[int][]*trackfiles.Trackfile)
+	roots := make([]int, 0)
+	for i, trackfile := range contacts {
+		root := clusters.find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], trackfile)
+	}
+	groups := make([]*group, 0, len(roots))
+	for _, root := range roots {
+		groups = append(groups, s.newGroup(members[root]))
+	}
 	return groups
 }
 
-// findGroupForAircraft creates a new group for the given trackfile and adds all nearby aircraft which can be considered part of the group.
+// findGroupForAircraft returns the group containing the given trackfile. The group contains the trackfile and every
+// valid contact of the same coalition which is connected to it by a chain of contacts for which [scope.isGroupedWith]
+// is true.
 func (s *scope) findGroupForAircraft(trackfile *trackfiles.Trackfile) *group {
 	if trackfile == nil {
 		return nil
 	}
-	bullseye := s.Bullseye(trackfile.Contact.Coalition)
+	contacts := []*trackfiles.Trackfile{trackfile}
+	if !trackfile.IsLastKnownPointZero() {
+		candidates := make([]*trackfiles.Trackfile, 0)
+		for other := range s.contacts.values() {
+			isCandidate := other.Contact.ID != trackfile.Contact.ID &&
+				other.Contact.Coalition == trackfile.Contact.Coalition &&
+				s.isValidTrack(other)
+			if isCandidate {
+				candidates = append(candidates, other)
+			}
+		}
+		// Search outward from the trackfile, adding each candidate linked to any member of the group.
+		for n := 0; n < len(contacts) && len(candidates) > 0; n++ {
+			this := contacts[n]
+			candidates = slices.DeleteFunc(candidates, func(other *trackfiles.Trackfile) bool {
+				if s.isGroupedWith(this, this.LastKnown(), other, other.LastKnown()) {
+					contacts = append(contacts, other)
+					return true
+				}
+				return false
+			})
+		}
+	}
+	return s.newGroup(contacts)
+}
+
+// newGroup creates a group of the given trackfiles, which must not be empty.
+func (s *scope) newGroup(contacts []*trackfiles.Trackfile) *group {
+	bullseye := s.Bullseye(contacts[0].Contact.Coalition)
 	grp := &group{
 		bullseye:    &bullseye,
-		contacts:    make([]*trackfiles.Trackfile, 0),
+		contacts:    contacts,
 		declaration: brevity.Unable,
+		variation:   s.MagneticVariation(),
 	}
-	grp.contacts = append(grp.contacts, trackfile)
-	if !trackfile.IsLastKnownPointZero() {
-		s.addNearbyAircraftToGroup(trackfile, grp)
-	}
+	grp.isPopup = s.popups.isPopup(grp.ObjectIDs(), s.MissionTime())
 	return grp
 }
 
-// addNearbyAircraftToGroup recursively adds all nearby aircraft which:
-//   - are of the same coalition
-//   - are within 5 nautical miles in 2D distance of each other
+// isGroupedWith checks if two contacts, whose last known frames are given, can be considered part of the same group.
+// Contacts are grouped if they:
+//   - are within the lateral threshold in 2D distance of each other
+//   - are within the vertical threshold in altitude of each other, if a vertical threshold is configured
+//   - are of the same contact category, or either is not in the encyclopedia
 //   - have similar tags
 //
-// The spread is increased from the ATP numbers beacause the DCS AI isn't amazing at holding formation.
 // We allow mixed platform groups because these are fairly common in DCS.
-func (s *scope) addNearbyAircraftToGroup(this *trackfiles.Trackfile, group *group) {
-	var tag encyclopedia.AircraftTag
-	thisData, ok := encyclopedia.GetAircraftData(this.Contact.ACMIName)
-	if ok {
-		if thisData.HasTag(encyclopedia.Fighter) {
-			tag = encyclopedia.Fighter
-		} else if thisData.HasTag(encyclopedia.Attack) {
-			tag = encyclopedia.Attack
-		} else if thisData.HasTag(encyclopedia.Unarmed) {
-			tag = encyclopedia.Unarmed
-		}
+func (s *scope) isGroupedWith(a *trackfiles.Trackfile, aFrame trackfiles.Frame, b *trackfiles.Trackfile, bFrame trackfiles.Frame) bool {
+	if s.groupVerticalThreshold > 0 && math.Abs((aFrame.Altitude-bFrame.Altitude).Feet()) > s.groupVerticalThreshold.Feet() {
+		return false
 	}
-	spreadInterval := 5 * unit.NauticalMile
-	for other := range s.contacts.values() {
-		// Skip if this one is already in the group
-		if slices.Contains(group.ObjectIDs(), other.Contact.ID) {
-			continue
-		}
+	if spatial.Distance(aFrame.Point, bFrame.Point) >= s.groupLateralThreshold {
+		return false
+	}
+	aData, aOK := encyclopedia.GetAircraftData(a.Contact.ACMIName)
+	bData, bOK := encyclopedia.GetAircraftData(b.Contact.ACMIName)
+	if aOK && bOK && aData.Category() != bData.Category() {
+		return false
+	}
+	return hasSimilarTags(aData, aOK, bData, bOK) || hasSimilarTags(bData, bOK, aData, aOK)
+}
 
-		// Check coalition, categoty, and filters
-		if !s.isMatch(other, this.Contact.Coalition, group.category()) {
-			continue
-		}
+// groupingTags are the tags which distinguish aircraft which should not be grouped together.
+var groupingTags = []encyclopedia.AircraftTag{encyclopedia.Fighter, encyclopedia.Attack, encyclopedia.Unarmed}
 
-		// Check tag similarity
-		subCategories := []encyclopedia.AircraftTag{encyclopedia.Fighter, encyclopedia.Attack, encyclopedia.Unarmed}
-		if slices.Contains(subCategories, tag) {
-			data, ok := encyclopedia.GetAircraftData(other.Contact.ACMIName)
-			if !ok {
-				continue
-			}
-			if !data.HasTag(tag) {
-				continue
-			}
+// hasSimilarTags checks if the other aircraft has the first of the grouping tags which this aircraft has. If this
+// aircraft is not in the encyclopedia or has none of the grouping tags, any other aircraft is similar.
+func hasSimilarTags(this encyclopedia.Aircraft, thisOK bool, other encyclopedia.Aircraft, otherOK bool) bool {
+	if !thisOK {
+		return true
+	}
+	for _, tag := range groupingTags {
+		if this.HasTag(tag) {
+			return otherOK && other.HasTag(tag)
 		}
+	}
+	return true
+}
 
-		// Check spread distance
-		distance := spatial.Distance(other.LastKnown().Point, this.LastKnown().Point)
-		isWithinSpread := distance < spreadInterval
-		if !isWithinSpread {
-			continue
-		}
+// minimumMetersPerDegreeLatitude is the length of one degree of latitude at the equator, where it is shortest.
+const minimumMetersPerDegreeLatitude = 110574.0
+
+// disjointSet is a union-find structure over the integers [0, n).
+type disjointSet struct {
+	parents []int
+	ranks   []int
+}
+
+func newDisjointSet(n int) *disjointSet {
+	parents := make([]int, n)
+	for i := range parents {
+		parents[i] = i
+	}
+	return &disjointSet{parents: parents, ranks: make([]int, n)}
+}
 
-		group.contacts = append(group.contacts, other)
-		s.addNearbyAircraftToGroup(other, group)
+// find returns the representative element of the set containing i.
+func (d *disjointSet) find(i int) int {
+	for d.parents[i] != i {
+		d.parents[i] = d.parents[d.parents[i]]
+		i = d.parents[i]
+	}
+	return i
+}
+
+// union merges the sets containing i and j.
+func (d *disjointSet) union(i, j int) {
+	a, b := d.find(i), d.find(j)
+	if a == b {
+		return
+	}
+	if d.ranks[a] < d.ranks[b] {
+		a, b = b, a
+	}
+	d.parents[b] = a
+	if d.ranks[a] == d.ranks[b] {
+		d.ranks[a]++
 	}
 }
@@ -0,0 +1,249 @@
+package radar
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// groupingContact is a hostile contact used in grouping tests.
+type groupingContact struct {
+	id       uint64
+	acmiName string
+	point    orb.Point
+	altitude unit.Length
+}
+
+// addGroupingContact adds a hostile contact to the scope, flying east at a few hundred knots from the given point.
+func addGroupingContact(s *scope, contact groupingContact, t0 time.Time) {
+	acmiName := contact.acmiName
+	if acmiName == "" {
+		acmiName = "Su-27"
+	}
+	labels := trackfiles.Labels{ID: contact.id, Name: "Yellow 13", Coalition: coalitions.Red, ACMIName: acmiName}
+	end := spatial.PointAtBearingAndDistance(contact.point, bearings.NewTrueBearing(90*unit.Degree), 250*unit.Meter)
+	for i, point := range []orb.Point{contact.point, contact.point, end} {
+		s.handleUpdate(sim.Updated{
+			Labels: labels,
+			Frame:  trackfiles.Frame{Time: t0.Add(time.Duration(i) * time.Second), Point: point, Altitude: contact.altitude},
+		})
+	}
+}
+
+// newGroupingScope creates a scope with the given grouping options, containing the given contacts.
+func newGroupingScope(opts Options, contacts []groupingContact) *scope {
+	opts.Coalition = coalitions.Blue
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), opts).(*scope)
+	t0 := time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC)
+	s.SetMissionTime(t0)
+	for _, contact := range contacts {
+		addGroupingContact(s, contact, t0)
+	}
+	return s
+}
+
+// groupIDs returns the unit IDs in each group, in order of their lowest unit ID.
+func groupIDs(groups []*group) [][]uint64 {
+	ids := make([][]uint64, 0, len(groups))
+	for _, grp := range groups {
+		ids = append(ids, grp.ObjectIDs())
+	}
+	slices.SortFunc(ids, func(a, b []uint64) int { return cmp.Compare(a[0], b[0]) })
+	return ids
+}
+
+func TestEnumerateGroups(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{33.5, 42.5}
+	east := bearings.NewTrueBearing(90 * unit.Degree)
+	north := bearings.NewTrueBearing(0)
+	at := func(bearing bearings.Bearing, distance unit.Length) orb.Point {
+		return spatial.PointAtBearingAndDistance(origin, bearing, distance)
+	}
+	conventional := Options{GroupLateralThreshold: 3 * unit.NauticalMile, GroupVerticalThreshold: 3000 * unit.Foot}
+
+	testCases := []struct {
+		name     string
+		opts     Options
+		contacts []groupingContact
+		expected [][]uint64
+	}{
+		{
+			name:     "no contacts",
+			expected: [][]uint64{},
+		},
+		{
+			name: "single contact",
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+			},
+			expected: [][]uint64{{1}},
+		},
+		{
+			name: "pair within thresholds",
+			opts: conventional,
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 2*unit.NauticalMile), altitude: 21000 * unit.Foot},
+			},
+			expected: [][]uint64{{1, 2}},
+		},
+		{
+			name: "pair separated laterally",
+			opts: conventional,
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 4*unit.NauticalMile), altitude: 20000 * unit.Foot},
+			},
+			expected: [][]uint64{{1}, {2}},
+		},
+		{
+			name: "pair within default lateral threshold",
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 4*unit.NauticalMile), altitude: 20000 * unit.Foot},
+			},
+			expected: [][]uint64{{1, 2}},
+		},
+		{
+			name: "pair separated vertically",
+			opts: conventional,
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 1*unit.NauticalMile), altitude: 25000 * unit.Foot},
+			},
+			expected: [][]uint64{{1}, {2}},
+		},
+		{
+			name: "pair stacked without vertical threshold",
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 1*unit.NauticalMile), altitude: 25000 * unit.Foot},
+			},
+			expected: [][]uint64{{1, 2}},
+		},
+		{
+			name: "chained trail formation merges transitively",
+			opts: conventional,
+			contacts: []groupingContact{
+				{id: 4, point: at(east, 7.5*unit.NauticalMile), altitude: 20000 * unit.Foot},
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 3, point: at(east, 5*unit.NauticalMile), altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 2.5*unit.NauticalMile), altitude: 20000 * unit.Foot},
+			},
+			expected: [][]uint64{{1, 2, 3, 4}},
+		},
+		{
+			name: "chained stack merges transitively",
+			opts: conventional,
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 10000 * unit.Foot},
+				{id: 2, point: at(north, 1*unit.NauticalMile), altitude: 12500 * unit.Foot},
+				{id: 3, point: at(north, 2*unit.NauticalMile), altitude: 15000 * unit.Foot},
+			},
+			expected: [][]uint64{{1, 2, 3}},
+		},
+		{
+			name: "broken chain",
+			opts: conventional,
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 2.5*unit.NauticalMile), altitude: 20000 * unit.Foot},
+				{id: 3, point: at(east, 6*unit.NauticalMile), altitude: 20000 * unit.Foot},
+				{id: 4, point: at(east, 8.5*unit.NauticalMile), altitude: 20000 * unit.Foot},
+			},
+			expected: [][]uint64{{1, 2}, {3, 4}},
+		},
+		{
+			name: "two separate formations",
+			opts: conventional,
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, point: at(east, 1*unit.NauticalMile), altitude: 20000 * unit.Foot},
+				{id: 3, point: at(north, 20*unit.NauticalMile), altitude: 30000 * unit.Foot},
+				{id: 4, point: spatial.PointAtBearingAndDistance(at(north, 20*unit.NauticalMile), east, 1*unit.NauticalMile), altitude: 30000 * unit.Foot},
+			},
+			expected: [][]uint64{{1, 2}, {3, 4}},
+		},
+		{
+			name: "fighter and unarmed aircraft",
+			contacts: []groupingContact{
+				{id: 1, point: origin, altitude: 20000 * unit.Foot},
+				{id: 2, acmiName: "A-50", point: at(east, 1*unit.NauticalMile), altitude: 20000 * unit.Foot},
+			},
+			expected: [][]uint64{{1}, {2}},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			s := newGroupingScope(test.opts, test.contacts)
+			groups := s.enumerateGroups(coalitions.Red)
+			assert.Equal(t, test.expected, groupIDs(groups))
+			for _, grp := range groups {
+				stacks := grp.Stacks()
+				count := 0
+				for _, stack := range stacks {
+					count += stack.Count
+				}
+				assert.Equal(t, grp.Contacts(), count, "each contact should be in one of the group's stacks")
+			}
+		})
+	}
+}
+
+// randomGroupingContacts returns contacts scattered across a theater.
+func randomGroupingContacts(n int) []groupingContact {
+	r := rand.New(rand.NewPCG(1, 2))
+	origin := orb.Point{33.5, 42.5}
+	contacts := make([]groupingContact, 0, n)
+	for i := range n {
+		bearing := bearings.NewTrueBearing(unit.Angle(r.Float64()*360) * unit.Degree)
+		distance := unit.Length(r.Float64()*150) * unit.NauticalMile
+		contacts = append(contacts, groupingContact{
+			id:       uint64(i + 1),
+			point:    spatial.PointAtBearingAndDistance(origin, bearing, distance),
+			altitude: unit.Length(1000+r.Float64()*39000) * unit.Foot,
+		})
+	}
+	return contacts
+}
+
+func TestFindGroupForAircraftMatchesEnumerateGroups(t *testing.T) {
+	t.Parallel()
+	s := newGroupingScope(
+		Options{GroupLateralThreshold: 10 * unit.NauticalMile, GroupVerticalThreshold: 3000 * unit.Foot},
+		randomGroupingContacts(200),
+	)
+	groups := s.enumerateGroups(coalitions.Red)
+	require.Greater(t, len(groups), 1)
+	require.Less(t, len(groups), 200)
+	for _, grp := range groups {
+		for _, trackfile := range grp.contacts {
+			assert.Equal(t, grp.ObjectIDs(), s.findGroupForAircraft(trackfile).ObjectIDs())
+		}
+	}
+}
+
+func BenchmarkEnumerateGroups(b *testing.B) {
+	s := newGroupingScope(
+		Options{GroupLateralThreshold: 3 * unit.NauticalMile, GroupVerticalThreshold: 3000 * unit.Foot},
+		randomGroupingContacts(300),
+	)
+	b.ResetTimer()
+	for range b.N {
+		_ = s.enumerateGroups(coalitions.Red)
+	}
+}
@@ -0,0 +1,141 @@
+package radar
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GroupEventType is the type of a change in group membership.
+type GroupEventType string
+
+const (
+	// GroupMerge is recorded when a contact's group gains contacts from another group.
+	GroupMerge GroupEventType = "merge"
+	// GroupSplit is recorded when a contact's group loses contacts to another group.
+	GroupSplit GroupEventType = "split"
+)
+
+// GroupEvent is a record of a change in a contact's group membership.
+type GroupEvent struct {
+	// Timestamp is the mission time when the change was observed.
+	Timestamp time.Time `json:"timestamp"`
+	// EventType is the type of change.
+	EventType GroupEventType `json:"eventType"`
+	// ContactID is the unit ID of the contact.
+	ContactID uint64 `json:"contactID"`
+	// GroupID identifies the contact's group after the change. It is the lowest unit ID among the group's contacts.
+	GroupID uint64 `json:"groupID"`
+}
+
+// groupHistory records changes in group membership for post-mission analysis.
+type groupHistory struct {
+	// memberships maps each contact's unit ID to the sorted unit IDs of the contacts in its group.
+	memberships map[uint64][]uint64
+	// events which have not yet been flushed.
+	events []GroupEvent
+	// lock protects memberships and events.
+	lock sync.Mutex
+}
+
+func newGroupHistory() *groupHistory {
+	return &groupHistory{
+		memberships: make(map[uint64][]uint64),
+	}
+}
+
+// record compares the given groups to the previously recorded groups and records an event for each contact whose group
+// gained or lost contacts. Each group is given as the unit IDs of its contacts. Contacts which are no longer on the scope
+// are forgotten without recording an event.
+func (h *groupHistory) record(t time.Time, groups [][]uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	current := make(map[uint64][]uint64)
+	for _, group := range groups {
+		members := slices.Clone(group)
+		slices.Sort(members)
+		for _, id := range members {
+			current[id] = members
+		}
+	}
+
+	for _, group := range groups {
+		for _, id := range group {
+			members := current[id]
+			previous, ok := h.memberships[id]
+			if !ok {
+				continue
+			}
+			gained := false
+			for _, other := range members {
+				if !slices.Contains(previous, other) {
+					gained = true
+					break
+				}
+			}
+			lost := false
+			for _, other := range previous {
+				if _, onScope := current[other]; onScope && !slices.Contains(members, other) {
+					lost = true
+					break
+				}
+			}
+			if gained {
+				h.events = append(h.events, GroupEvent{Timestamp: t, EventType: GroupMerge, ContactID: id, GroupID: members[0]})
+			}
+			if lost {
+				h.events = append(h.events, GroupEvent{Timestamp: t, EventType: GroupSplit, ContactID: id, GroupID: members[0]})
+			}
+		}
+	}
+
+	h.memberships = current
+}
+
+// flush writes all recorded events to the writer as JSON Lines and clears them.
+func (h *groupHistory) flush(w io.Writer) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	encoder := json.NewEncoder(w)
+	for i, event := range h.events {
+		if err := encoder.Encode(event); err != nil {
+			h.events = h.events[i:]
+			return fmt.Errorf("failed to write group event: %w", err)
+		}
+	}
+	h.events = nil
+	return nil
+}
+
+// reset clears all recorded memberships and events.
+func (h *groupHistory) reset() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.memberships = make(map[uint64][]uint64)
+	h.events = nil
+}
+
+// flushGroupHistory appends the recorded group events to the group log file.
+func (s *scope) flushGroupHistory() {
+	if s.groupLogPath == "" {
+		return
+	}
+	f, err := os.OpenFile(s.groupLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		log.Error().Err(err).Str("path", s.groupLogPath).Msg("failed to open group log")
+		return
+	}
+	defer f.Close()
+	if err := s.history.flush(f); err != nil {
+		log.Error().Err(err).Str("path", s.groupLogPath).Msg("failed to write group log")
+		return
+	}
+	log.Info().Str("path", s.groupLogPath).Msg("wrote group log")
+}
@@ -0,0 +1,79 @@
+package radar
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupHistoryMerge(t *testing.T) {
+	t.Parallel()
+	history := newGroupHistory()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	history.record(now, [][]uint64{{1}, {2}})
+	assert.Empty(t, history.events)
+
+	history.record(now.Add(5*time.Second), [][]uint64{{2, 1}})
+	require.Len(t, history.events, 2)
+	contactIDs := []uint64{}
+	for _, event := range history.events {
+		assert.Equal(t, GroupMerge, event.EventType)
+		assert.Equal(t, uint64(1), event.GroupID)
+		assert.Equal(t, now.Add(5*time.Second), event.Timestamp)
+		contactIDs = append(contactIDs, event.ContactID)
+	}
+	assert.ElementsMatch(t, []uint64{1, 2}, contactIDs)
+}
+
+func TestGroupHistorySplit(t *testing.T) {
+	t.Parallel()
+	history := newGroupHistory()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	history.record(now, [][]uint64{{1, 2, 3}})
+	history.record(now.Add(5*time.Second), [][]uint64{{1, 2}, {3}})
+	require.Len(t, history.events, 3)
+	for _, event := range history.events {
+		assert.Equal(t, GroupSplit, event.EventType)
+		if event.ContactID == 3 {
+			assert.Equal(t, uint64(3), event.GroupID)
+		} else {
+			assert.Equal(t, uint64(1), event.GroupID)
+		}
+	}
+}
+
+func TestGroupHistoryIgnoresLostContacts(t *testing.T) {
+	t.Parallel()
+	history := newGroupHistory()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	history.record(now, [][]uint64{{1, 2}})
+	history.record(now.Add(5*time.Second), [][]uint64{{1}})
+	assert.Empty(t, history.events)
+}
+
+func TestGroupHistoryFlush(t *testing.T) {
+	t.Parallel()
+	history := newGroupHistory()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	history.record(now, [][]uint64{{1}, {2}})
+	history.record(now, [][]uint64{{1, 2}})
+
+	var buf bytes.Buffer
+	require.NoError(t, history.flush(&buf))
+	assert.Empty(t, history.events)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var event GroupEvent
+		require.NoError(t, json.Unmarshal(line, &event))
+		assert.Equal(t, GroupMerge, event.EventType)
+	}
+}
@@ -3,6 +3,7 @@ package radar
 import (
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
 )
 
 func (s *scope) FindCallsign(callsign string, coalition coalitions.Coalition) (string, *trackfiles.Trackfile) {
@@ -20,3 +21,23 @@ func (s *scope) FindUnit(id uint64) *trackfiles.Trackfile {
 	}
 	return trackfile
 }
+
+// ContactCount implements [Radar.ContactCount].
+func (s *scope) ContactCount() int {
+	count := 0
+	for range s.contacts.values() {
+		count++
+	}
+	return count
+}
+
+// Positions implements [Radar.Positions].
+func (s *scope) Positions(coalition coalitions.Coalition) map[uint64]orb.Point {
+	positions := make(map[uint64]orb.Point)
+	for trackfile := range s.contacts.values() {
+		if trackfile.Contact.Coalition == coalition && s.isValidTrack(trackfile) {
+			positions[trackfile.Contact.ID] = trackfile.LastKnown().Point
+		}
+	}
+	return positions
+}
@@ -9,10 +9,11 @@ import (
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
 )
 
-// Merges returns a map of fixed-wing groups on the opposing coalition to the contacts on the given coalition that they are merged with.
-func (s *scope) Merges(coalition coalitions.Coalition) map[brevity.Group][]*trackfiles.Trackfile {
+// Merges returns a map of fixed-wing groups on the opposing coalition to the contacts on the given coalition that are within the given distance of them.
+func (s *scope) Merges(coalition coalitions.Coalition, distance unit.Length) map[brevity.Group][]*trackfiles.Trackfile {
 	visited := make(map[uint64]struct{})
 	merges := make(map[brevity.Group][]*trackfiles.Trackfile)
 	bullseye := s.Bullseye(coalition)
@@ -38,7 +39,7 @@ func (s *scope) Merges(coalition coalitions.Coalition) map[brevity.Group][]*trac
 		mergedWith := make(map[uint64]*trackfiles.Trackfile)
 		for _, contact := range grp.contacts {
 			visited[contact.Contact.ID] = struct{}{}
-			for _, trackfile := range s.mergesForContact(contact) {
+			for _, trackfile := range s.mergesForContact(contact, distance) {
 				mergedWith[trackfile.Contact.ID] = trackfile
 			}
 		}
@@ -54,8 +55,8 @@ func (s *scope) Merges(coalition coalitions.Coalition) map[brevity.Group][]*trac
 	return merges
 }
 
-// mergesForContact returns the opposing trackfiles that the given trackfile is merged with.
-func (s *scope) mergesForContact(trackfile *trackfiles.Trackfile) []*trackfiles.Trackfile {
+// mergesForContact returns the opposing trackfiles that are within the given distance of the given trackfile.
+func (s *scope) mergesForContact(trackfile *trackfiles.Trackfile, within unit.Length) []*trackfiles.Trackfile {
 	mergedWith := make([]*trackfiles.Trackfile, 0)
 	if trackfile.IsLastKnownPointZero() {
 		return mergedWith
@@ -68,7 +69,7 @@ func (s *scope) mergesForContact(trackfile *trackfiles.Trackfile) []*trackfiles.
 			continue
 		}
 		distance := spatial.Distance(trackfile.LastKnown().Point, other.LastKnown().Point)
-		if distance < brevity.MergeExitDistance {
+		if distance < within {
 			mergedWith = append(mergedWith, other)
 		}
 	}
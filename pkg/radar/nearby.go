@@ -56,7 +56,7 @@ func (s *scope) FindNearbyGroupsWithBRAA(origin, interest orb.Point, minAltitude
 	groups := s.findNearbyGroups(interest, minAltitude, maxAltitude, radius, coalition, filter, excludedIDs)
 	result := make([]brevity.Group, 0, len(groups))
 	for _, grp := range groups {
-		bearing := spatial.TrueBearing(origin, grp.point()).Magnetic(s.Declination(origin))
+		bearing := s.MagneticVariation().Magnetic(spatial.TrueBearing(origin, grp.point()), origin)
 		_range := spatial.Distance(origin, grp.point())
 		aspect := brevity.AspectFromAngle(bearing, grp.course())
 		grp.braa = brevity.NewBRAA(bearing, _range, grp.altitudes(), aspect)
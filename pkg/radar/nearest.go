@@ -53,48 +53,11 @@ func (s *scope) FindNearestTrackfile(
 	return nearestTrackfile
 }
 
-// FindNearestGroupWithBRAA implements [Radar.FindNearestGroupWithBRAA].
-func (s *scope) FindNearestGroupWithBRAA(
-	origin orb.Point,
-	minAltitude unit.Length,
-	maxAltitude unit.Length,
-	radius unit.Length,
-	coalition coalitions.Coalition,
-	filter brevity.ContactCategory,
-) brevity.Group {
-	trackfile := s.FindNearestTrackfile(origin, minAltitude, maxAltitude, radius, coalition, filter)
-	if trackfile == nil || trackfile.IsLastKnownPointZero() {
-		return nil
-	}
-
-	grp := s.findGroupForAircraft(trackfile)
-	if grp == nil {
-		return nil
-	}
-
-	declination := s.Declination(origin)
-	bearing := spatial.TrueBearing(origin, grp.point()).Magnetic(declination)
-	_range := spatial.Distance(origin, grp.point())
-	aspect := brevity.AspectFromAngle(bearing, trackfile.Course())
-	grp.braa = brevity.NewBRAA(
-		bearing,
-		_range,
-		grp.altitudes(),
-		aspect,
-	)
-	grp.bullseye = nil
-	grp.aspect = &aspect
-	grp.isThreat = _range < brevity.MandatoryThreatDistance
-
-	return grp
-}
-
 // FindNearestGroupWithBullseye implements [Radar.FindNearestGroupWithBullseye].
 func (s *scope) FindNearestGroupWithBullseye(origin orb.Point, minAltitude, maxAltitude, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) brevity.Group {
 	nearestTrackfile := s.FindNearestTrackfile(origin, minAltitude, maxAltitude, radius, coalition, filter)
 	grp := s.findGroupForAircraft(nearestTrackfile)
-	declination := s.Declination(origin)
-	bearing := spatial.TrueBearing(origin, grp.point()).Magnetic(declination)
+	bearing := s.MagneticVariation().Magnetic(spatial.TrueBearing(origin, grp.point()), origin)
 	aspect := brevity.AspectFromAngle(bearing, grp.course())
 
 	grp.aspect = &aspect
@@ -108,8 +71,8 @@ func (s *scope) FindNearestGroupWithBullseye(origin orb.Point, minAltitude, maxA
 func (s *scope) FindNearestGroupInSector(origin orb.Point, minAltitude, maxAltitude, length unit.Length, bearing bearings.Bearing, arc unit.Angle, coalition coalitions.Coalition, filter brevity.ContactCategory) brevity.Group {
 	logger := log.With().Any("origin", origin).Stringer("bearing", bearing).Float64("arc", arc.Degrees()).Logger()
 
-	declination := s.Declination(origin)
-	bearing = bearing.Magnetic(declination)
+	variation := s.MagneticVariation()
+	bearing = variation.Magnetic(bearing, origin)
 
 	ring := orb.Ring{origin}
 	for a := arc / 2; a > -arc/2; a -= arc / 10 {
@@ -153,8 +116,8 @@ func (s *scope) FindNearestGroupInSector(origin orb.Point, minAltitude, maxAltit
 	if grp == nil {
 		return nil
 	}
-	preciseBearing := spatial.TrueBearing(origin, nearestContact.LastKnown().Point).Magnetic(declination)
-	aspect := brevity.AspectFromAngle(preciseBearing, nearestContact.Course())
+	preciseBearing := variation.Magnetic(spatial.TrueBearing(origin, nearestContact.LastKnown().Point), origin)
+	aspect := brevity.AspectFromAngle(preciseBearing, nearestContact.Course(variation))
 	log.Debug().Str("aspect", string(aspect)).Msg("determined aspect")
 	_range := spatial.Distance(origin, nearestContact.LastKnown().Point)
 	grp.aspect = &aspect
@@ -8,11 +8,41 @@ import (
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 	"github.com/rs/zerolog/log"
 )
 
 // GetPicture implements [Radar.GetPicture].
-func (s *scope) GetPicture(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) (int, []brevity.Group) {
+func (s *scope) GetPicture(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory, excludedIDs []uint64) (int, []brevity.Group) {
+	origin, groups := s.pictureGroups(radius, coalition, filter, excludedIDs)
+
+	// Sort groups from highest to lowest threat
+	slices.SortFunc(groups, s.compareThreat)
+
+	// Return the top 3 groups
+	capacity := 3
+	if len(groups) < capacity {
+		capacity = len(groups)
+	}
+	result := make([]brevity.Group, capacity)
+	for i := range capacity {
+		result[i] = groups[i]
+	}
+	log.Info().Float64("centerLat", origin.Lat()).Float64("centerLon", origin.Lon()).Int("groups", len(groups)).Msg("generating PICTURE")
+	return len(groups), result
+}
+
+// CountPicture implements [Radar.CountPicture].
+func (s *scope) CountPicture(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory, excludedIDs []uint64) (groups, contacts int) {
+	_, picture := s.pictureGroups(radius, coalition, filter, excludedIDs)
+	for _, grp := range picture {
+		contacts += grp.Contacts()
+	}
+	return len(picture), contacts
+}
+
+// pictureGroups returns the center point of a PICTURE and all groups within the given radius of it.
+func (s *scope) pictureGroups(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory, excludedIDs []uint64) (orb.Point, []*group) {
 	// Find groups near the center point
 	s.centerLock.RLock()
 	defer s.centerLock.RUnlock()
@@ -32,23 +62,9 @@ func (s *scope) GetPicture(radius unit.Length, coalition coalitions.Coalition, f
 		radius,
 		coalition,
 		filter,
-		[]uint64{},
+		excludedIDs,
 	)
-
-	// Sort groups from highest to lowest threat
-	slices.SortFunc(groups, s.compareThreat)
-
-	// Return the top 3 groups
-	capacity := 3
-	if len(groups) < capacity {
-		capacity = len(groups)
-	}
-	result := make([]brevity.Group, capacity)
-	for i := range capacity {
-		result[i] = groups[i]
-	}
-	log.Info().Float64("centerLat", origin.Lat()).Float64("centerLon", origin.Lon()).Int("groups", len(groups)).Msg("generating PICTURE")
-	return len(groups), result
+	return origin, groups
 }
 
 func (s *scope) compareThreat(a, b *group) int {
@@ -67,8 +83,8 @@ func (s *scope) compareThreat(a, b *group) int {
 	// Prioritize aircraft within threat radius over aircraft outside threat radius
 	distanceA := spatial.Distance(s.center, a.point())
 	distanceB := spatial.Distance(s.center, b.point())
-	aIsThreat := distanceA < a.threatRadius()
-	bIsThreat := distanceB < b.threatRadius()
+	aIsThreat := distanceA < a.ThreatRadius()
+	bIsThreat := distanceB < b.ThreatRadius()
 	if aIsThreat && !bIsThreat {
 		return aIsHigherThreat
 	} else if !aIsThreat && bIsThreat {
@@ -87,8 +103,8 @@ func (s *scope) compareThreat(a, b *group) int {
 	}
 
 	// Compare distance relative to threat radius
-	weightedDistanceA := weightedDistance(distanceA, a.threatRadius())
-	weightedDistanceB := weightedDistance(distanceB, b.threatRadius())
+	weightedDistanceA := weightedDistance(distanceA, a.ThreatRadius())
+	weightedDistanceB := weightedDistance(distanceB, b.ThreatRadius())
 	if math.Abs(weightedDistanceA.NauticalMiles()-weightedDistanceB.NauticalMiles()) > 3 {
 		if weightedDistanceA < weightedDistanceB {
 			return aIsHigherThreat
@@ -0,0 +1,114 @@
+package radar
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+const (
+	// popupMatchRadius is the distance within which a group is considered to be the same group seen on a prior sweep.
+	popupMatchRadius = 10 * unit.NauticalMile
+	// popupMemory is how long a group is remembered after it was last seen. A group which fades and reappears near
+	// where it was last seen within this window is not a POPUP group.
+	popupMemory = 2 * time.Minute
+	// popupDuration is how long a new group is described as a POPUP group.
+	popupDuration = 1 * time.Minute
+)
+
+// sighting is a group's position on a prior sweep.
+type sighting struct {
+	point orb.Point
+	seen  time.Time
+}
+
+// popupCandidate is a group's contacts and position on the current sweep.
+type popupCandidate struct {
+	ids   []uint64
+	point orb.Point
+}
+
+// popupDetector compares the groups on each sweep against the groups seen on prior sweeps, to identify POPUP groups:
+// groups which appear where no group was recently seen, such as aircraft which just took off or emerged from terrain
+// masking.
+type popupDetector struct {
+	// initialized is false until the first sweep. Every group on the first sweep is new, but none are POPUP groups.
+	initialized bool
+	// sightings are the positions of groups seen within popupMemory.
+	sightings []sighting
+	// popups maps the unit IDs of contacts in POPUP groups to the time their group was detected.
+	popups map[uint64]time.Time
+	// lock protects all fields.
+	lock sync.Mutex
+}
+
+func newPopupDetector() *popupDetector {
+	return &popupDetector{popups: make(map[uint64]time.Time)}
+}
+
+// update records a sweep. Groups which do not match any recent sighting are marked as POPUP groups.
+func (d *popupDetector) update(now time.Time, candidates []popupCandidate) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	matched := make([]bool, len(d.sightings))
+	for _, candidate := range candidates {
+		isKnown := false
+		for i, s := range d.sightings {
+			if now.Sub(s.seen) > popupMemory {
+				continue
+			}
+			if spatial.Distance(s.point, candidate.point) <= popupMatchRadius {
+				isKnown = true
+				matched[i] = true
+			}
+		}
+		if !isKnown && d.initialized {
+			for _, id := range candidate.ids {
+				d.popups[id] = now
+			}
+		}
+	}
+
+	sightings := make([]sighting, 0, len(candidates)+len(d.sightings))
+	for _, candidate := range candidates {
+		sightings = append(sightings, sighting{point: candidate.point, seen: now})
+	}
+	for i, s := range d.sightings {
+		if !matched[i] && now.Sub(s.seen) <= popupMemory {
+			sightings = append(sightings, s)
+		}
+	}
+	d.sightings = sightings
+
+	for id, detected := range d.popups {
+		if now.Sub(detected) > popupDuration {
+			delete(d.popups, id)
+		}
+	}
+	d.initialized = true
+}
+
+// isPopup returns true if any of the given contacts are in a group which was detected as a POPUP group within
+// popupDuration.
+func (d *popupDetector) isPopup(ids []uint64, now time.Time) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return slices.ContainsFunc(ids, func(id uint64) bool {
+		detected, ok := d.popups[id]
+		return ok && now.Sub(detected) <= popupDuration
+	})
+}
+
+// reset forgets all sightings and POPUP groups.
+func (d *popupDetector) reset() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.initialized = false
+	d.sightings = nil
+	d.popups = make(map[uint64]time.Time)
+}
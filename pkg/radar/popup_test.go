@@ -0,0 +1,160 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopupDetector(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{42, 42}
+	east := bearings.NewTrueBearing(90 * unit.Degree)
+	at := func(distance unit.Length) orb.Point {
+		return spatial.PointAtBearingAndDistance(origin, east, distance)
+	}
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	sweep := func(n int) time.Time {
+		return start.Add(time.Duration(n) * 5 * time.Second)
+	}
+
+	type step struct {
+		now        time.Time
+		candidates []popupCandidate
+		popups     []uint64
+		notPopups  []uint64
+	}
+	testCases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "groups on the first sweep are not popups",
+			steps: []step{
+				{
+					now:        sweep(0),
+					candidates: []popupCandidate{{ids: []uint64{1, 2}, point: origin}, {ids: []uint64{3}, point: at(50 * unit.NauticalMile)}},
+					notPopups:  []uint64{1, 2, 3},
+				},
+			},
+		},
+		{
+			name: "moving group is not a popup",
+			steps: []step{
+				{now: sweep(0), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}},
+				{now: sweep(1), candidates: []popupCandidate{{ids: []uint64{1}, point: at(1 * unit.NauticalMile)}}, notPopups: []uint64{1}},
+				{now: sweep(2), candidates: []popupCandidate{{ids: []uint64{1}, point: at(2 * unit.NauticalMile)}}, notPopups: []uint64{1}},
+			},
+		},
+		{
+			name: "new group far from other groups is a popup",
+			steps: []step{
+				{now: sweep(0), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}},
+				{
+					now: sweep(1),
+					candidates: []popupCandidate{
+						{ids: []uint64{1}, point: origin},
+						{ids: []uint64{2, 3}, point: at(40 * unit.NauticalMile)},
+					},
+					popups:    []uint64{2, 3},
+					notPopups: []uint64{1},
+				},
+			},
+		},
+		{
+			name: "popup label expires",
+			steps: []step{
+				{now: sweep(0), candidates: []popupCandidate{}},
+				{now: sweep(1), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}, popups: []uint64{1}},
+				{now: sweep(1).Add(popupDuration / 2), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}, popups: []uint64{1}},
+				{now: sweep(1).Add(popupDuration + time.Second), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}, notPopups: []uint64{1}},
+			},
+		},
+		{
+			name: "group which fades and returns within the memory window is not a popup",
+			steps: []step{
+				{now: sweep(0), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}},
+				{now: sweep(1), candidates: []popupCandidate{}},
+				{now: sweep(2), candidates: []popupCandidate{}},
+				{now: sweep(0).Add(popupMemory - time.Second), candidates: []popupCandidate{{ids: []uint64{1}, point: at(3 * unit.NauticalMile)}}, notPopups: []uint64{1}},
+			},
+		},
+		{
+			name: "group which fades and returns after the memory window is a popup",
+			steps: []step{
+				{now: sweep(0), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}},
+				{now: sweep(1), candidates: []popupCandidate{}},
+				{now: sweep(0).Add(popupMemory + time.Second), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}, popups: []uint64{1}},
+			},
+		},
+		{
+			name: "reappearing contact with a new ID near a remembered group is not a popup",
+			steps: []step{
+				{now: sweep(0), candidates: []popupCandidate{{ids: []uint64{1}, point: origin}}},
+				{now: sweep(1), candidates: []popupCandidate{}},
+				{now: sweep(2), candidates: []popupCandidate{{ids: []uint64{7}, point: at(2 * unit.NauticalMile)}}, notPopups: []uint64{7}},
+			},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			detector := newPopupDetector()
+			for i, step := range test.steps {
+				detector.update(step.now, step.candidates)
+				for _, id := range step.popups {
+					assert.True(t, detector.isPopup([]uint64{id}, step.now), "step %d: contact %d should be a popup", i, id)
+				}
+				for _, id := range step.notPopups {
+					assert.False(t, detector.isPopup([]uint64{id}, step.now), "step %d: contact %d should not be a popup", i, id)
+				}
+			}
+		})
+	}
+}
+
+func TestPopupDetectorReset(t *testing.T) {
+	t.Parallel()
+	detector := newPopupDetector()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	detector.update(now, []popupCandidate{})
+	detector.update(now.Add(5*time.Second), []popupCandidate{{ids: []uint64{1}, point: orb.Point{42, 42}}})
+	assert.True(t, detector.isPopup([]uint64{1}, now.Add(5*time.Second)))
+	detector.reset()
+	assert.False(t, detector.isPopup([]uint64{1}, now.Add(5*time.Second)))
+	detector.update(now.Add(10*time.Second), []popupCandidate{{ids: []uint64{2}, point: orb.Point{43, 43}}})
+	assert.False(t, detector.isPopup([]uint64{2}, now.Add(10*time.Second)), "first sweep after reset should not have popups")
+}
+
+func TestSweepDetectsPopups(t *testing.T) {
+	t.Parallel()
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue}).(*scope)
+	t0 := time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC)
+	s.SetMissionTime(t0)
+	s.SetBullseye(orb.Point{42, 42}, coalitions.Red)
+	addMovingContact(s, 1, 20000*unit.Foot, t0)
+
+	// The mission time may be updated while the scope sweeps.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 100 {
+			s.SetMissionTime(t0.Add(time.Duration(i) * time.Millisecond))
+		}
+	}()
+	s.sweep()
+	<-done
+
+	s.SetMissionTime(t0.Add(5 * time.Second))
+	addMovingContact(s, 100, 20000*unit.Foot, t0.Add(2*time.Second))
+	s.sweep()
+	assert.False(t, s.popups.isPopup([]uint64{1}, s.MissionTime()))
+	assert.True(t, s.popups.isPopup([]uint64{100}, s.MissionTime()))
+}
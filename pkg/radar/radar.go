@@ -10,6 +10,8 @@ import (
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/pilots"
+	"github.com/dharmab/skyeye/pkg/sectors"
 	"github.com/dharmab/skyeye/pkg/sim"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
@@ -27,8 +29,11 @@ type Radar interface {
 	Bullseye(coalitions.Coalition) orb.Point
 	// SetMissionTime updates the mission time. The mission time is used for computing magnetic declination.
 	SetMissionTime(time.Time)
-	// Declination returns the magnetic declination at the given point, at the time provided in SetMissionTime.
-	Declination(orb.Point) unit.Angle
+	// MissionTime returns the mission time provided in SetMissionTime.
+	MissionTime() time.Time
+	// MagneticVariation returns the conversion between true and magnetic bearings at the time provided in
+	// SetMissionTime. If a magnetic variation was provided to New, it is used everywhere instead of the IGRF model.
+	MagneticVariation() bearings.MagneticVariation
 	// Run consumes updates from the simulation channels until the context is cancelled.
 	Run(context.Context, *sync.WaitGroup)
 	// FindCallsign returns the trackfile on the given coalition that mosty closely matches the given callsign,
@@ -38,15 +43,28 @@ type Radar interface {
 	FindCallsign(string, coalitions.Coalition) (string, *trackfiles.Trackfile)
 	// FindUnit returns the trackfile for the given unit ID, or nil if no trackfile was found.
 	FindUnit(uint64) *trackfiles.Trackfile
+	// ContactCount returns the number of trackfiles currently on the scope.
+	ContactCount() int
+	// Positions returns the last known positions of valid trackfiles on the given coalition, keyed by unit ID.
+	Positions(coalitions.Coalition) map[uint64]orb.Point
 	// GetPicture returns a picture of the radar scope anchored at the center point, within the given radius,
-	// filtered by the given coalition and contact category. The first return value is the total number of groups
-	// and the second is a slice of up to to 3 high priority groups. Each group has Bullseye set relative to the
-	// the point provided in SetBullseye.
+	// filtered by the given coalition and contact category. Any given unit IDs are excluded from the search. The first
+	// return value is the total number of groups and the second is a slice of up to to 3 high priority groups. Each
+	// group has Bullseye set relative to the the point provided in SetBullseye.
 	GetPicture(
 		radius unit.Length,
 		coalition coalitions.Coalition,
 		category brevity.ContactCategory,
+		excludedIDs []uint64,
 	) (int, []brevity.Group)
+	// CountPicture returns the total number of groups and contacts in the picture returned by GetPicture with the same
+	// arguments.
+	CountPicture(
+		radius unit.Length,
+		coalition coalitions.Coalition,
+		category brevity.ContactCategory,
+		excludedIDs []uint64,
+	) (groups, contacts int)
 	// FindNearbyGroupsWithBRAA returns all groups within the given radius of the given point of interest, within the given
 	// altitude block, filtered by the given coalition and contact category. Any given unit IDs are excluded from the search.
 	// Each group has BRAA set relative to the given origin. The groups are ordered by increasing distance from the point
@@ -74,17 +92,6 @@ type Radar interface {
 		category brevity.ContactCategory,
 		excludedIDs []uint64,
 	) []brevity.Group
-	// FindNearestGroupWithBRAA returns the nearest group to the given origin (up to the given radius), within the
-	// given altitude block, filtered by the given coalition and contact category. The group has BRAA set relative to
-	// the given origin. Returns nil if no group was found.
-	FindNearestGroupWithBRAA(
-		origin orb.Point,
-		minAltitude,
-		maxAltitude,
-		radius unit.Length,
-		coalition coalitions.Coalition,
-		category brevity.ContactCategory,
-	) brevity.Group
 	// FindNearestGroupWithBullseye returns the nearest group to the given point of interest (up to the given radius),
 	// within the given altitude block, filtered by the given coalition and contact category. The group has Bullseye
 	// set relative to the point provided in SetBullseye. Returns nil if no group was found.
@@ -111,16 +118,27 @@ type Radar interface {
 		category brevity.ContactCategory,
 	) brevity.Group
 	SetStartedCallback(StartedCallback)
+	// SetUpdatedCallback sets the callback function to be called when sensors update a trackfile.
+	SetUpdatedCallback(UpdatedCallback)
 	// SetFadedCallback sets the callback function to be called when a trackfile fades.
 	SetFadedCallback(FadedCallback)
 	// SetRemovedCallback sets the callback function to be called when a trackfile is aged out.
 	SetRemovedCallback(RemovedCallback)
+	// SetSectorCleanCallback sets the callback function to be called when the last contact of a coalition leaves or is
+	// removed from a sector.
+	SetSectorCleanCallback(SectorCleanCallback)
+	// MandatoryThreatRadius returns the briefed radius within which a hostile aircraft is always considered a threat.
+	MandatoryThreatRadius() unit.Length
 	// Threats returns a map of threat groups of the given coalition to threatened object IDs.
 	Threats(coalitions.Coalition) map[brevity.Group][]uint64
-	// Merges returns a map of hostile groups of the given coalition to friendly trackfiles.
-	Merges(coalitions.Coalition) map[brevity.Group][]*trackfiles.Trackfile
+	// Merges returns a map of hostile groups of the given coalition to friendly trackfiles within the given distance.
+	Merges(coalitions.Coalition, unit.Length) map[brevity.Group][]*trackfiles.Trackfile
 	// WaitUntilFadesResolve blocks until all fade events have been processed, or the context is cancelled.
 	WaitUntilFadesResolve(context.Context)
+	// PruneStale removes trackfiles which have not been observed since the given mission time, as if they had faded.
+	// The faded callback is called once for each group of removed trackfiles. It returns the number of trackfiles
+	// removed.
+	PruneStale(cutoff time.Time) int
 }
 
 var _ Radar = &scope{}
@@ -134,17 +152,23 @@ type scope struct {
 	fades <-chan sim.Faded
 	// missionTime should be continually updated to the current mission time.
 	missionTime time.Time
+	// missionTimeLock protects missionTime.
+	missionTimeLock sync.RWMutex
 	// bullsyses maps coalitions to their respective bullseye points.
 	bullseyes sync.Map
 	// contacts contains trackfiles for each aircraft.
 	contacts contactDatabase
 	// startedCallback is called when a start event is received.
 	startedCallback StartedCallback
+	// updatedCallback is called when an update event is received.
+	updatedCallback UpdatedCallback
 	// fadedCallback is called when a fade event is received.
 	fadedCallback FadedCallback
 	// removalCallback is called when a trackfile is removed for a reason other than a fade event.
 	removalCallback RemovedCallback
-	// callbackLock protects startedCallback, fadedCallback, and removalCallback.
+	// sectorCleanCallback is called when the last contact of a coalition leaves or is removed from a sector.
+	sectorCleanCallback SectorCleanCallback
+	// callbackLock protects startedCallback, updatedCallback, fadedCallback, removalCallback, and sectorCleanCallback.
 	callbackLock sync.RWMutex
 	// center is a point used to center PICTURE calls.
 	center orb.Point
@@ -156,27 +180,125 @@ type scope struct {
 	pendingFades []sim.Faded
 	// pendingFadesLock protects pendingFades.
 	pendingFadesLock sync.RWMutex
+	// history records changes in group membership.
+	history *groupHistory
+	// groupLogPath is the path to a JSON Lines file where group membership events are written at the end of each
+	// mission. Group membership is not recorded if this is empty.
+	groupLogPath string
+	// sectors tracks which contacts are within each sector.
+	sectors *sectorOccupancy
+	// magneticVariation overrides the magnetic declination computed by the IGRF model, if not nil.
+	magneticVariation *unit.Angle
+	// popups detects POPUP groups.
+	popups *popupDetector
+	// altitudeFloor is the altitude below which contacts are ignored.
+	altitudeFloor unit.Length
+	// altitudeCeiling is the altitude above which contacts are ignored. If zero, there is no ceiling.
+	altitudeCeiling unit.Length
+	// speedFloor is the speed at or below which contacts are ignored.
+	speedFloor unit.Speed
+	// speedCeiling is the speed above which contacts are ignored.
+	speedCeiling unit.Speed
+	// groupLateralThreshold is the maximum 2D distance between contacts which are linked into the same group.
+	groupLateralThreshold unit.Length
+	// groupVerticalThreshold is the maximum altitude separation between contacts which are linked into the same group.
+	// If zero, there is no vertical threshold.
+	groupVerticalThreshold unit.Length
+}
+
+const (
+	// DefaultSpeedFloor is the default speed at or below which contacts are ignored. This filters out static objects
+	// and aircraft parked or taxiing on the ground.
+	DefaultSpeedFloor = 50 * unit.Knot
+	// DefaultSpeedCeiling is the default speed above which contacts are ignored. This is comfortably faster than any
+	// aircraft, and filters out data artifacts with implausible speeds.
+	DefaultSpeedCeiling = 3000 * unit.Knot
+)
+
+// Options configure a radar scope.
+type Options struct {
+	// Coalition is the coalition the scope serves.
+	Coalition coalitions.Coalition
+	// MandatoryThreatRadius is the briefed radius within which a hostile aircraft is always considered a threat.
+	MandatoryThreatRadius unit.Length
+	// Pilots maps the names of human pilots to callsigns. It may be nil.
+	Pilots *pilots.PilotRegistry
+	// GroupLogPath is the path to a JSON Lines file where changes in group membership are appended at the end of each
+	// mission. If empty, group membership is not recorded.
+	GroupLogPath string
+	// Sectors are the sectors whose occupancy is tracked so that SectorClean events can be raised. It may be empty.
+	Sectors []sectors.Sector
+	// MagneticVariation overrides the magnetic declination computed by the IGRF model, if not nil.
+	MagneticVariation *unit.Angle
+	// AltitudeFloor is the altitude below which contacts are ignored.
+	AltitudeFloor unit.Length
+	// AltitudeCeiling is the altitude above which contacts are ignored. If zero, there is no ceiling.
+	AltitudeCeiling unit.Length
+	// SpeedFloor is the speed at or below which contacts are ignored. If zero, [DefaultSpeedFloor] is used.
+	SpeedFloor unit.Speed
+	// SpeedCeiling is the speed above which contacts are ignored. If zero, [DefaultSpeedCeiling] is used.
+	SpeedCeiling unit.Speed
+	// GroupLateralThreshold is the maximum 2D distance between contacts which are linked into the same group. If zero,
+	// [DefaultGroupLateralThreshold] is used.
+	GroupLateralThreshold unit.Length
+	// GroupVerticalThreshold is the maximum altitude separation between contacts which are linked into the same group.
+	// If zero, contacts are grouped regardless of altitude, so that stacked formations are called as one group with a
+	// STACK.
+	GroupVerticalThreshold unit.Length
 }
 
-func New(coalition coalitions.Coalition, starts <-chan sim.Started, updates <-chan sim.Updated, fades <-chan sim.Faded, mandatoryThreatRadius unit.Length) Radar {
+// New creates a new radar scope which receives events from the given channels.
+func New(starts <-chan sim.Started, updates <-chan sim.Updated, fades <-chan sim.Faded, opts Options) Radar {
+	speedFloor := opts.SpeedFloor
+	if speedFloor == 0 {
+		speedFloor = DefaultSpeedFloor
+	}
+	speedCeiling := opts.SpeedCeiling
+	if speedCeiling == 0 {
+		speedCeiling = DefaultSpeedCeiling
+	}
+	groupLateralThreshold := opts.GroupLateralThreshold
+	if groupLateralThreshold == 0 {
+		groupLateralThreshold = DefaultGroupLateralThreshold
+	}
 	return &scope{
-		starts:                starts,
-		updates:               updates,
-		fades:                 fades,
-		contacts:              newContactDatabase(),
-		mandatoryThreatRadius: mandatoryThreatRadius,
+		groupLateralThreshold:  groupLateralThreshold,
+		groupVerticalThreshold: opts.GroupVerticalThreshold,
+		altitudeFloor:          opts.AltitudeFloor,
+		altitudeCeiling:        opts.AltitudeCeiling,
+		speedFloor:             speedFloor,
+		speedCeiling:           speedCeiling,
+		magneticVariation:      opts.MagneticVariation,
+		starts:                 starts,
+		updates:                updates,
+		fades:                  fades,
+		contacts:               newContactDatabase(opts.Pilots),
+		mandatoryThreatRadius:  opts.MandatoryThreatRadius,
+		history:                newGroupHistory(),
+		groupLogPath:           opts.GroupLogPath,
+		sectors:                newSectorOccupancy(opts.Sectors),
+		popups:                 newPopupDetector(),
 	}
 }
 
 func (s *scope) SetMissionTime(t time.Time) {
+	s.missionTimeLock.Lock()
+	defer s.missionTimeLock.Unlock()
 	s.missionTime = t
 }
 
+// MissionTime implements [Radar.MissionTime].
+func (s *scope) MissionTime() time.Time {
+	s.missionTimeLock.RLock()
+	defer s.missionTimeLock.RUnlock()
+	return s.missionTime
+}
+
 func (s *scope) SetBullseye(bullseye orb.Point, coalition coalitions.Coalition) {
 	current := s.Bullseye(coalition)
 	if current.Lon() != bullseye.Lon() || current.Lat() != bullseye.Lat() {
 		log.Info().
-			Int("coalitionID", int(coalition)).
+			Stringer("coalition", coalition).
 			Float64("lon", bullseye.Lon()).
 			Float64("lat", bullseye.Lat()).
 			Msg("updating bullseye")
@@ -254,6 +376,22 @@ func (s *scope) Run(ctx context.Context, wg *sync.WaitGroup) {
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer s.flushGroupHistory()
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.sweep()
+			}
+		}
+	}()
+
 	<-ctx.Done()
 }
 
@@ -274,9 +412,22 @@ func (s *scope) handleUpdate(update sim.Updated) {
 		s.contacts.set(trackfile)
 		logger.Info().Msg("created new trackfile")
 	}
+	if !spatial.IsZero(update.Frame.Point) {
+		s.updateSectors(update.Labels.ID, update.Labels.Coalition, update.Frame.Point)
+	}
+
+	s.callbackLock.RLock()
+	defer s.callbackLock.RUnlock()
+	if s.updatedCallback != nil {
+		s.updatedCallback(update.Labels.ID)
+	}
 }
 
-// handleGarbageCollection removes trackfiles that have not been updated in a long time.
+// extrapolationThreshold is how long a trackfile may go without sensor updates before its position is extrapolated.
+const extrapolationThreshold = 10 * time.Second
+
+// handleGarbageCollection removes trackfiles that have not been updated in a long time, and extrapolates the positions
+// of trackfiles that have not been updated recently.
 func (s *scope) handleGarbageCollection() {
 	s.pendingFadesLock.RLock()
 	defer s.pendingFadesLock.RUnlock()
@@ -284,6 +435,7 @@ func (s *scope) handleGarbageCollection() {
 		return
 	}
 
+	missionTime := s.MissionTime()
 	for trackfile := range s.contacts.values() {
 		logger := log.With().
 			Uint64("id", trackfile.Contact.ID).
@@ -292,13 +444,23 @@ func (s *scope) handleGarbageCollection() {
 			Stringer("coalition", trackfile.Contact.Coalition).
 			Logger()
 
-		lastSeen := trackfile.LastKnown().Time
-		isOld := lastSeen.Before(s.missionTime.Add(-1 * time.Minute))
-		if !lastSeen.IsZero() && isOld {
+		lastSeen := trackfile.LastObserved().Time
+		if lastSeen.IsZero() {
+			continue
+		}
+		isOld := lastSeen.Before(missionTime.Add(-1 * time.Minute))
+		isStale := lastSeen.Before(missionTime.Add(-extrapolationThreshold))
+		if !isOld && isStale && trackfile.Extrapolate(missionTime) {
+			logger.Debug().
+				Stringer("age", missionTime.Sub(lastSeen)).
+				Msg("extrapolated trackfile position")
+		}
+		if isOld {
 			ok := s.contacts.delete(trackfile.Contact.ID)
 			if ok {
+				s.removeFromSectors(trackfile.Contact.ID)
 				logger.Info().
-					Stringer("age", s.missionTime.Sub(lastSeen)).
+					Stringer("age", missionTime.Sub(lastSeen)).
 					Msg("expired trackfile")
 				go func() {
 					s.callbackLock.RLock()
@@ -314,11 +476,16 @@ func (s *scope) handleGarbageCollection() {
 
 // isValidTrack checks if the trackfile is valid. This means the following conditions are met:
 //   - Last known position is not (0, 0)
-//   - Speed is above 50 knots
-func isValidTrack(trackfile *trackfiles.Trackfile) bool {
+//   - Speed is above the speed floor and not above the speed ceiling
+//   - Last known altitude is within the altitude floor and ceiling
+func (s *scope) isValidTrack(trackfile *trackfiles.Trackfile) bool {
 	isValidPosition := !spatial.IsZero(trackfile.LastKnown().Point)
-	isAboveSpeedFilter := trackfile.Speed() > 50*unit.Knot
-	isValid := isValidPosition && isAboveSpeedFilter
+	speed := trackfile.Speed()
+	isAboveSpeedFilter := speed > s.speedFloor && speed <= s.speedCeiling
+	altitude := trackfile.LastKnown().Altitude
+	isAboveFloor := altitude >= s.altitudeFloor
+	isBelowCeiling := s.altitudeCeiling == 0 || altitude <= s.altitudeCeiling
+	isValid := isValidPosition && isAboveSpeedFilter && isAboveFloor && isBelowCeiling
 	return isValid
 }
 
@@ -330,7 +497,7 @@ func (s *scope) isMatch(trackfile *trackfiles.Trackfile, coalition coalitions.Co
 	if trackfile.Contact.Coalition != coalition {
 		return false
 	}
-	if !isValidTrack(trackfile) {
+	if !s.isValidTrack(trackfile) {
 		return false
 	}
 	data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
@@ -339,10 +506,10 @@ func (s *scope) isMatch(trackfile *trackfiles.Trackfile, coalition coalitions.Co
 	return matchesFilter
 }
 
-func (s *scope) Declination(p orb.Point) unit.Angle {
-	declination, err := bearings.Declination(p, s.missionTime)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to get declination")
+// MagneticVariation implements [Radar.MagneticVariation].
+func (s *scope) MagneticVariation() bearings.MagneticVariation {
+	if s.magneticVariation != nil {
+		return bearings.NewFixedMagneticVariation(*s.magneticVariation)
 	}
-	return declination
+	return bearings.NewModelMagneticVariation(s.MissionTime())
 }
@@ -0,0 +1,123 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagneticVariation(t *testing.T) {
+	t.Parallel()
+	// The Persian Gulf theater has a magnetic variation of about 2.5° East.
+	variation := 2.5 * unit.Degree
+	rdr := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue, MagneticVariation: &variation})
+	point := orb.Point{56.25, 26.5}
+	assert.InDelta(t, 2.5, rdr.MagneticVariation().Declination(point).Degrees(), 0.001)
+	bearing := spatial.TrueBearing(point, spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(0), 10*unit.NauticalMile))
+	assert.InDelta(t, 357.5, rdr.MagneticVariation().Magnetic(bearing, point).Degrees(), 0.1)
+}
+
+func TestMagneticVariationBullseye(t *testing.T) {
+	t.Parallel()
+	variation := 2.5 * unit.Degree
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue, MagneticVariation: &variation}).(*scope)
+	bullseye := orb.Point{56.25, 26.5}
+	s.SetBullseye(bullseye, coalitions.Red)
+	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{ID: 1, Name: "Red 1", Coalition: coalitions.Red, ACMIName: "MiG-29A"})
+	trackfile.Update(trackfiles.Frame{
+		Time:     time.Now(),
+		Point:    spatial.PointAtBearingAndDistance(bullseye, bearings.NewTrueBearing(0), 20*unit.NauticalMile),
+		Altitude: 20000 * unit.Foot,
+	})
+	grp := s.findGroupForAircraft(trackfile)
+	require.NotNil(t, grp.Bullseye())
+	assert.InDelta(t, 357.5, grp.Bullseye().Bearing().Degrees(), 0.1)
+	assert.InDelta(t, 20, grp.Bullseye().Distance().NauticalMiles(), 0.5)
+}
+
+// addMovingContact adds a hostile contact at the given altitude to the scope, flying east at a few hundred knots.
+func addMovingContact(s *scope, id uint64, altitude unit.Length, t0 time.Time) {
+	labels := trackfiles.Labels{ID: id, Name: "Yellow 13", Coalition: coalitions.Red, ACMIName: "Su-37"}
+	start := orb.Point{42, 42 + float64(id)*0.01}
+	for i, point := range []orb.Point{start, start, spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(90*unit.Degree), 250*unit.Meter)} {
+		s.handleUpdate(sim.Updated{
+			Labels: labels,
+			Frame:  trackfiles.Frame{Time: t0.Add(time.Duration(i) * time.Second), Point: point, Altitude: altitude},
+		})
+	}
+}
+
+func TestAltitudeFloorAndCeiling(t *testing.T) {
+	t.Parallel()
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue, AltitudeFloor: 1000 * unit.Foot, AltitudeCeiling: 40000 * unit.Foot}).(*scope)
+	t0 := time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC)
+	s.SetMissionTime(t0)
+	s.SetBullseye(orb.Point{42, 42}, coalitions.Red)
+	addMovingContact(s, 1, 200*unit.Foot, t0)
+	addMovingContact(s, 2, 5000*unit.Foot, t0)
+	addMovingContact(s, 3, 50000*unit.Foot, t0)
+
+	positions := s.Positions(coalitions.Red)
+	assert.Len(t, positions, 1)
+	assert.Contains(t, positions, uint64(2))
+
+	count, groups := s.GetPicture(300*unit.NauticalMile, coalitions.Red, brevity.Aircraft, []uint64{})
+	assert.Equal(t, 1, count)
+	require.Len(t, groups, 1)
+	assert.Equal(t, []uint64{2}, groups[0].ObjectIDs())
+}
+
+func TestAltitudeFilterDisabled(t *testing.T) {
+	t.Parallel()
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue}).(*scope)
+	t0 := time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC)
+	s.SetMissionTime(t0)
+	addMovingContact(s, 1, 200*unit.Foot, t0)
+	addMovingContact(s, 2, 5000*unit.Foot, t0)
+	addMovingContact(s, 3, 50000*unit.Foot, t0)
+	assert.Len(t, s.Positions(coalitions.Red), 3, "with the default floor and ceiling, no contacts should be filtered")
+}
+
+func TestSpeedFloorAndCeiling(t *testing.T) {
+	t.Parallel()
+	s := New(make(chan sim.Started), make(chan sim.Updated), make(chan sim.Faded), Options{Coalition: coalitions.Blue}).(*scope)
+	t0 := time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC)
+	s.SetMissionTime(t0)
+
+	// Each contact is observed twice, one second apart, so its speed is the distance it moves in that second.
+	mach10 := unit.Speed(10 * 343 * unit.MetersPerSecond)
+	testCases := []struct {
+		id    uint64
+		name  string
+		speed unit.Speed
+	}{
+		{id: 1, name: "static object", speed: 0},
+		{id: 2, name: "fighter", speed: 450 * unit.Knot},
+		{id: 3, name: "Mach 10 artifact", speed: mach10},
+	}
+	for _, test := range testCases {
+		labels := trackfiles.Labels{ID: test.id, Name: test.name, Coalition: coalitions.Red, ACMIName: "Su-37"}
+		start := orb.Point{42, 42 + float64(test.id)*0.1}
+		end := spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(90*unit.Degree), unit.Length(test.speed.MetersPerSecond())*unit.Meter)
+		for i, point := range []orb.Point{start, start, end} {
+			s.handleUpdate(sim.Updated{
+				Labels: labels,
+				Frame:  trackfiles.Frame{Time: t0.Add(time.Duration(max(i-1, 0)) * time.Second), Point: point, Altitude: 20000 * unit.Foot},
+			})
+		}
+	}
+
+	positions := s.Positions(coalitions.Red)
+	assert.Len(t, positions, 1, "the static object and the Mach 10 artifact should be filtered out")
+	assert.Contains(t, positions, uint64(2))
+}
@@ -0,0 +1,117 @@
+package radar
+
+import (
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sectors"
+	"github.com/paulmach/orb"
+)
+
+// SectorClean is an event which occurs when the last contact of a coalition leaves or is removed from a sector.
+type SectorClean struct {
+	// Sector is the name of the sector.
+	Sector string
+	// Coalition is the coalition which no longer has any contacts in the sector.
+	Coalition coalitions.Coalition
+}
+
+// sectorOccupancy tracks which contacts are within each sector.
+type sectorOccupancy struct {
+	// sectors to track.
+	sectors []sectors.Sector
+	// occupants maps sector names to the unit IDs and coalitions of the contacts within the sector.
+	occupants map[string]map[uint64]coalitions.Coalition
+	// lock protects occupants.
+	lock sync.Mutex
+}
+
+func newSectorOccupancy(s []sectors.Sector) *sectorOccupancy {
+	o := &sectorOccupancy{sectors: s}
+	o.reset()
+	return o
+}
+
+// update records the position of a contact. It returns an event for each sector which the contact left, if it was the
+// last contact of its coalition in that sector.
+func (o *sectorOccupancy) update(id uint64, coalition coalitions.Coalition, point orb.Point) []SectorClean {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	events := make([]SectorClean, 0)
+	for _, sector := range o.sectors {
+		if sector.Contains(point) {
+			o.occupants[sector.Name][id] = coalition
+		} else if event, ok := o.vacate(sector.Name, id); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// remove forgets a contact. It returns an event for each sector which the contact was in, if it was the last contact of
+// its coalition in that sector.
+func (o *sectorOccupancy) remove(id uint64) []SectorClean {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	events := make([]SectorClean, 0)
+	for _, sector := range o.sectors {
+		if event, ok := o.vacate(sector.Name, id); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// vacate removes a contact from a sector. The second return value is true if the contact was the last contact of its
+// coalition in the sector. The caller must hold the lock.
+func (o *sectorOccupancy) vacate(sector string, id uint64) (SectorClean, bool) {
+	coalition, ok := o.occupants[sector][id]
+	if !ok {
+		return SectorClean{}, false
+	}
+	delete(o.occupants[sector], id)
+	for _, c := range o.occupants[sector] {
+		if c == coalition {
+			return SectorClean{}, false
+		}
+	}
+	return SectorClean{Sector: sector, Coalition: coalition}, true
+}
+
+// reset forgets all contacts without producing any events.
+func (o *sectorOccupancy) reset() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.occupants = make(map[string]map[uint64]coalitions.Coalition)
+	for _, sector := range o.sectors {
+		o.occupants[sector.Name] = make(map[uint64]coalitions.Coalition)
+	}
+}
+
+// updateSectors records the trackfile's position in the sector occupancy and calls the sectorCleanCallback for any
+// sector which the trackfile left.
+func (s *scope) updateSectors(id uint64, coalition coalitions.Coalition, point orb.Point) {
+	s.notifySectorsClean(s.sectors.update(id, coalition, point))
+}
+
+// removeFromSectors removes the trackfile from the sector occupancy and calls the sectorCleanCallback for any sector
+// which the trackfile was the last contact of its coalition in.
+func (s *scope) removeFromSectors(id uint64) {
+	s.notifySectorsClean(s.sectors.remove(id))
+}
+
+func (s *scope) notifySectorsClean(events []SectorClean) {
+	if len(events) == 0 {
+		return
+	}
+	go func() {
+		s.callbackLock.RLock()
+		defer s.callbackLock.RUnlock()
+		if s.sectorCleanCallback == nil {
+			return
+		}
+		for _, event := range events {
+			s.sectorCleanCallback(event)
+		}
+	}()
+}
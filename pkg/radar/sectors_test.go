@@ -0,0 +1,59 @@
+package radar
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sectors"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSector = sectors.Sector{
+	Name:     "North",
+	Boundary: orb.Ring{{41, 42}, {43, 42}, {43, 43}, {41, 43}, {41, 42}},
+}
+
+func TestSectorOccupancyRemove(t *testing.T) {
+	t.Parallel()
+	occupancy := newSectorOccupancy([]sectors.Sector{testSector})
+
+	events := occupancy.update(1, coalitions.Red, orb.Point{42, 42.5})
+	assert.Empty(t, events)
+
+	events = occupancy.remove(1)
+	require.Len(t, events, 1)
+	assert.Equal(t, SectorClean{Sector: "North", Coalition: coalitions.Red}, events[0])
+
+	events = occupancy.remove(1)
+	assert.Empty(t, events)
+}
+
+func TestSectorOccupancyLeave(t *testing.T) {
+	t.Parallel()
+	occupancy := newSectorOccupancy([]sectors.Sector{testSector})
+
+	assert.Empty(t, occupancy.update(1, coalitions.Red, orb.Point{42, 42.5}))
+	assert.Empty(t, occupancy.update(2, coalitions.Red, orb.Point{42.5, 42.5}))
+	assert.Empty(t, occupancy.update(3, coalitions.Blue, orb.Point{42.5, 42.5}))
+
+	// Another contact of the same coalition remains in the sector.
+	assert.Empty(t, occupancy.update(1, coalitions.Red, orb.Point{44, 42.5}))
+
+	events := occupancy.update(2, coalitions.Red, orb.Point{44, 42.5})
+	require.Len(t, events, 1)
+	assert.Equal(t, SectorClean{Sector: "North", Coalition: coalitions.Red}, events[0])
+
+	// Contacts which were never in the sector do not produce events.
+	assert.Empty(t, occupancy.update(4, coalitions.Red, orb.Point{44, 42.5}))
+	assert.Empty(t, occupancy.remove(4))
+}
+
+func TestSectorOccupancyReset(t *testing.T) {
+	t.Parallel()
+	occupancy := newSectorOccupancy([]sectors.Sector{testSector})
+	occupancy.update(1, coalitions.Red, orb.Point{42, 42.5})
+	occupancy.reset()
+	assert.Empty(t, occupancy.remove(1))
+}
@@ -4,7 +4,11 @@ import "github.com/rs/zerolog/log"
 
 func (s *scope) handleStarted() {
 	log.Info().Msg("clearing all trackfiles due to mission (re)start")
+	s.flushGroupHistory()
+	s.history.reset()
 	s.contacts.reset()
+	s.sectors.reset()
+	s.popups.reset()
 	s.callbackLock.RLock()
 	defer s.callbackLock.RUnlock()
 	if s.startedCallback != nil {
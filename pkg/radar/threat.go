@@ -9,6 +9,11 @@ import (
 	"github.com/martinlindhe/unit"
 )
 
+// MandatoryThreatRadius implements [Radar.MandatoryThreatRadius].
+func (s *scope) MandatoryThreatRadius() unit.Length {
+	return s.mandatoryThreatRadius
+}
+
 func (s *scope) Threats(coalition coalitions.Coalition) map[brevity.Group][]uint64 {
 	threats := make(map[*group][]uint64)
 	hostileGroups := s.enumerateGroups(coalition)
@@ -31,7 +36,7 @@ func (s *scope) Threats(coalition coalitions.Coalition) map[brevity.Group][]uint
 		ids := make([]uint64, 0)
 		for _, friendlyGroup := range friendlyGroups {
 			distance := spatial.Distance(grp.point(), friendlyGroup.point())
-			withinThreatRadius := distance < grp.threatRadius() || distance < s.mandatoryThreatRadius
+			withinThreatRadius := distance < grp.ThreatRadius() || distance < s.mandatoryThreatRadius
 			hostileIsHelo := grp.category() == brevity.RotaryWing
 			friendlyIsPlane := friendlyGroup.category() == brevity.FixedWing
 			heloVersusPlane := hostileIsHelo && friendlyIsPlane
@@ -50,8 +55,8 @@ func (s *scope) Threats(coalition coalitions.Coalition) map[brevity.Group][]uint
 			if !ok {
 				continue
 			}
-			declination := s.Declination(trackfile.LastKnown().Point)
-			bearing := spatial.TrueBearing(trackfile.LastKnown().Point, grp.point()).Magnetic(declination)
+			origin := trackfile.LastKnown().Point
+			bearing := s.MagneticVariation().Magnetic(spatial.TrueBearing(origin, grp.point()), origin)
 			_range := spatial.Distance(trackfile.LastKnown().Point, grp.point())
 			aspect := brevity.AspectFromAngle(bearing, grp.course())
 			grp.braa = brevity.NewBRAA(bearing, _range, grp.altitudes(), aspect)
@@ -0,0 +1,17 @@
+// package sectors defines named geographic areas which the GCI reports on.
+package sectors
+
+import "github.com/dharmab/skyeye/pkg/areas"
+
+// Sector is a named geographic area. The name is spoken when describing the sector, e.g. "North" or "Kobuleti".
+type Sector = areas.Area
+
+// Load reads sector definitions from a YAML file.
+func Load(path string) ([]Sector, error) {
+	return areas.Load(path, "sectors")
+}
+
+// Parse parses sector definitions from YAML.
+func Parse(b []byte) ([]Sector, error) {
+	return areas.Parse(b, "sectors")
+}
@@ -0,0 +1,30 @@
+package sectors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	sectors, err := Load(filepath.Join("testdata", "sectors.yaml"))
+	require.NoError(t, err)
+	require.Len(t, sectors, 2)
+
+	north, south := sectors[0], sectors[1]
+	assert.Equal(t, "North", north.Name)
+	assert.Equal(t, "South", south.Name)
+	for _, sector := range sectors {
+		assert.True(t, sector.Boundary.Closed())
+		assert.Len(t, sector.Boundary, 5)
+	}
+
+	assert.True(t, north.Contains(orb.Point{42, 42.5}))
+	assert.False(t, north.Contains(orb.Point{42, 41.5}))
+	assert.True(t, south.Contains(orb.Point{42, 41.5}))
+	assert.False(t, south.Contains(orb.Point{44, 41.5}))
+}
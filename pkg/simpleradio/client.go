@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/metrics"
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
-	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/martinlindhe/unit"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 )
 
@@ -19,12 +23,54 @@ type Audio []float32
 type Transmission struct {
 	TraceID    string
 	ClientName string
-	Audio      Audio
+	// Transmitter is the SRS client which sent a received transmission. It is zero for an outgoing transmission.
+	Transmitter TransmitterInfo
+	Audio       Audio
+	// SampleRate of Audio. If zero, Audio is at the SRS sample rate. Outgoing audio at any other rate, such as 22.05
+	// kHz or 44.1 kHz speech synthesizer output, is resampled before it is encoded.
+	SampleRate unit.Frequency
+	// Frequencies to transmit on. If empty, the transmission is sent on all of the client's frequencies. For a received
+	// transmission, this is the frequency the transmission arrived on.
+	Frequencies []RadioFrequency
+	// Guard is true if a received transmission arrived on a guard frequency.
+	Guard bool
+	// Priority of an outgoing transmission. Queued transmissions are sent in order of priority.
+	Priority Priority
+}
+
+// Priority orders outgoing transmissions which are waiting for a clear channel.
+type Priority int
+
+const (
+	// PriorityRoutine is the priority of most transmissions, such as responses to requests.
+	PriorityRoutine Priority = iota
+	// PriorityUrgent is the priority of time-critical calls, such as THREAT and MERGED calls. Urgent transmissions are
+	// sent before routine transmissions waiting for the same channel, but never interrupt a transmission in progress.
+	PriorityUrgent
+)
+
+// TransmitterInfo identifies the SRS client which sent a received transmission.
+type TransmitterInfo struct {
+	// GUID of the transmitting client. It is empty if the transmission was not received from an SRS server, e.g. during
+	// a replay.
+	GUID types.GUID
+	// Name of the transmitting client, as shown in the SRS client list. It is empty if the client is not in the list.
+	Name string
+	// Coalition of the transmitting client.
+	Coalition coalitions.Coalition
+	// Seat is the transmitting client's seat number in a multicrew aircraft.
+	Seat int
+	// Unit is the name of the unit the transmitting client is bound to.
+	Unit string
+	// UnitID is the in-game ID of the unit the transmitting client is bound to.
+	UnitID uint64
 }
 
 // Client is a SimpleRadio-Standalone client.
 type Client interface {
-	// Run starts the SimpleRadio-Standalone client. It should be called exactly once.
+	// Run starts the SimpleRadio-Standalone client. It should be called exactly once. It blocks until the context is
+	// canceled, or returns an error if the client cannot continue, such as when the SRS server rejects the External
+	// AWACS Mode password on connecting or reconnecting.
 	Run(context.Context, *sync.WaitGroup) error
 	// Send sends a message to the SRS server.
 	Send(types.Message) error
@@ -32,6 +78,9 @@ type Client interface {
 	Receive() <-chan Transmission
 	// Transmit queues a transmission to send over the radio. The audio data should be in F32LE PCM format.
 	Transmit(Transmission)
+	// TransmitAll queues a transmission to send simultaneously on each of the given frequencies. Frequencies which the
+	// client is not tuned to are skipped and reported in the returned error; the transmission is still sent on the rest.
+	TransmitAll([]RadioFrequency, Transmission) error
 	// Frequencies returns the frequencies the client is listening on.
 	Frequencies() []RadioFrequency
 	// ClientsOnFrequency returns the number of peers on the client's frequencies.
@@ -44,12 +93,35 @@ type Client interface {
 	BotsOnFrequency() int
 	// IsOnFrequency checks if the named unit is on any of the client's frequencies.
 	IsOnFrequency(string) bool
+	// Clients returns every other client connected to the SRS server, regardless of coalition or frequency, ordered by
+	// name.
+	Clients() []types.ClientInfo
+	// ClientByGUID returns the connected client with the given GUID. The second return value is false if no such client
+	// is connected.
+	ClientByGUID(types.GUID) (types.ClientInfo, bool)
+	// HealthStatus returns the status of the client's connection to the SRS server.
+	HealthStatus() ConnectionStatus
+	// StatusChanges returns a channel that receives the status of the client's connection to the SRS server each time
+	// it changes. Changes are dropped if the channel is not read.
+	StatusChanges() <-chan ConnectionStatus
+	// Retune tunes the client's radio on the first frequency to the second frequency and notifies the SRS server. Once
+	// it returns, no incoming packets are processed and no outgoing transmissions are started on the old frequency. The
+	// mode selects whether transmissions queued for the old frequency are sent before the radio is retuned or moved to
+	// the new frequency. It blocks until the radio is retuned or the context is done.
+	Retune(context.Context, RadioFrequency, RadioFrequency, RetuneMode) error
+	// Close stops accepting new transmissions, waits until queued and in-progress transmissions have been sent or the
+	// context is done, disconnects from the SRS server, and closes the client's connections. It should be called before
+	// the context passed to Run is canceled, so that transmissions are not cut off.
+	Close(context.Context) error
 }
 
 // client implements the SRS Client.
 type client struct {
 	// externalAWACSModePassword is the password for authenticating as an external AWACS in the SRS server.
 	externalAWACSModePassword string
+	// eamResponses receives the coalition in each External AWACS Mode password response from the SRS server. A zero
+	// coalition means the password was rejected.
+	eamResponses chan coalitions.Coalition
 
 	// address is the address of the SRS server, including the port.
 	address string
@@ -57,45 +129,106 @@ type client struct {
 	tcpConnection *net.TCPConn
 	// udpConnection is the UDP connection to the SRS server used for audio and pings.
 	udpConnection *net.UDPConn
+	// connectionsLock protects tcpConnection and udpConnection, which are replaced when the client reconnects.
+	connectionsLock sync.RWMutex
 
 	// clientInfo is the client information for this client. It is what players will see in the SRS client list, and in
 	/// the in-game overlay when this client transmits.
 	clientInfo types.ClientInfo
+	// radiosLock protects clientInfo.RadioInfo.Radios and receivers, which change when a radio is retuned, and
+	// clientInfo.Coalition, which changes when the SRS server accepts an External AWACS Mode password.
+	radiosLock sync.RWMutex
 	// clients is a map of GUIDs to client info, which the bot will use to filter out other clients that are not in the
 	// same coalition and frequency.
 	clients map[types.GUID]types.ClientInfo
-	// clientsLock controls access to the clients map.
+	// roster is a map of GUIDs to client info for every other client connected to the SRS server.
+	roster map[types.GUID]types.ClientInfo
+	// clientsLock controls access to the clients and roster maps.
 	clientsLock sync.RWMutex
 
 	// secureCoalitionRadios indicates if the client should only receive transmissions from the same coalition.
 	secureCoalitionRadios bool
+	// coalitionFilter is the coalition which the client receives transmissions from. If zero, no filter is applied.
+	coalitionFilter coalitions.Coalition
+	// allowSpectators indicates if the client should receive transmissions from spectators.
+	allowSpectators bool
+	// droppedPackets counts received packets dropped by isFromAllowedCoalition, by reason. It may be nil.
+	droppedPackets *prometheus.CounterVec
 
 	// rxChan is a channel where received transmission are published. A read-only version is available publicly.
 	rxChan chan Transmission
 	// txChan is a channel where outgoing transmissions are buffered.
 	txChan chan Transmission
+	// inFlight counts outgoing transmissions which have been queued but not yet completely sent.
+	inFlight atomic.Int64
+	// closing is set when the client starts closing. New transmissions are rejected, and the client no longer
+	// reconnects.
+	closing atomic.Bool
+	// closed is set when the client's connections are closed. The client no longer opens connections or changes its
+	// status once it is closed.
+	closed atomic.Bool
+	// closeOnce ensures the client's connections are closed only once.
+	closeOnce sync.Once
 	// receivers tracks the state of each radio we are listening to.
 	receivers map[types.Radio]*receiver
+	// retuneChan is a channel where radios waiting to be retuned are passed to the transmit scheduler.
+	retuneChan chan *retuneRequest
 	// packetNumber is incremented for each voice packet transmitted.
 	packetNumber uint64
-	// txLock prevents multiple outgoing transmissions from occurring simultaneously. It must be acquired before writing
-	// voice packets to the UDP connection.
-	txLock sync.Mutex
 	// mute suppresses audio transmission.
 	mute bool
-
+	// maxTransmitHold is how long an outgoing transmission is held while the channel is busy with incoming
+	// transmissions before it is sent anyway. If zero, transmissions are held until the channel is clear.
+	maxTransmitHold time.Duration
+
+	// pingInterval is how often the client pings the SRS server. If no pings are received for several intervals, the
+	// client will attempt to reconnect.
+	pingInterval time.Duration
+	// keepaliveTimeout is how long the client may go without receiving anything from the SRS server, or without
+	// receiving a UDP ping, before it reconnects.
+	keepaliveTimeout time.Duration
 	// lastPing tracks the last time a ping was received. If no pings are received for a period of time, the client will
 	// attempt to reconnect.
-	lastPing     time.Time
+	lastPing time.Time
+	// lastPingLock protects lastPing.
 	lastPingLock sync.RWMutex
+	// lastReceived tracks the last time any TCP message or UDP packet was received from the server, in Unix
+	// nanoseconds. If nothing is received for a period of time, the client will attempt to reconnect. It is atomic so
+	// that the receivers never wait on lastPingLock, which is held while reconnecting.
+	lastReceived atomic.Int64
+	// status is the current status of the connection to the SRS server.
+	status ConnectionStatus
+	// statusLock protects status.
+	statusLock sync.RWMutex
+	// statusChan is a channel where connection status changes are published. A read-only version is available
+	// publicly.
+	statusChan chan ConnectionStatus
+	// failures receives errors which stop the client after it has started, such as a rejected External AWACS Mode
+	// password after reconnecting.
+	failures chan error
 }
 
 func NewClient(config types.ClientConfiguration) (Client, error) {
 	guid := types.NewGUID()
 
+	pingInterval := config.PingInterval
+	if pingInterval <= 0 {
+		pingInterval = defaultPingInterval
+	}
+
+	keepaliveTimeout := config.KeepaliveTimeout
+	if keepaliveTimeout <= 0 {
+		keepaliveTimeout = 3 * pingInterval
+	}
+
+	maxTransmitHold := config.MaxTransmitHold
+	if maxTransmitHold <= 0 {
+		maxTransmitHold = defaultMaxTransmitHold
+	}
+
 	receivers := make(map[types.Radio]*receiver, len(config.Radios))
 	for _, radio := range config.Radios {
-		receivers[radio] = &receiver{}
+		receivers[radio] = newReceiver(config.JitterBufferDepth, config.EndOfTransmissionSilence, config.MaxTransmissionLength)
 	}
 
 	client := &client{
@@ -114,16 +247,29 @@ func NewClient(config types.ClientConfiguration) (Client, error) {
 			Position: &types.Position{},
 		},
 		externalAWACSModePassword: config.ExternalAWACSModePassword,
+		eamResponses:              make(chan coalitions.Coalition, 1),
 		clients:                   make(map[types.GUID]types.ClientInfo),
+		roster:                    make(map[types.GUID]types.ClientInfo),
+		coalitionFilter:           config.CoalitionFilter,
+		allowSpectators:           config.AllowSpectators,
+		droppedPackets:            metrics.SRSDroppedPackets,
+		maxTransmitHold:           maxTransmitHold,
+		keepaliveTimeout:          keepaliveTimeout,
 
 		txChan:       make(chan Transmission),
 		rxChan:       make(chan Transmission),
 		receivers:    receivers,
+		retuneChan:   make(chan *retuneRequest),
 		packetNumber: 1,
 		mute:         config.Mute,
+		pingInterval: pingInterval,
 		lastPing:     time.Now(),
+		statusChan:   make(chan ConnectionStatus, statusChangesCapacity),
+		failures:     make(chan error, 1),
 	}
 
+	client.markReceived()
+
 	err := client.connectTCP()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to SRS server: %w", err)
@@ -143,51 +289,24 @@ func (c *client) initialize() error {
 		return fmt.Errorf("sync failed: %w", err)
 	}
 
-	log.Info().Msg("reconnecting to external AWACS mode")
-	if err := c.connectExternalAWACSMode(); err != nil {
-		return fmt.Errorf("connecting external AWACS mode failed: %w", err)
+	if c.externalAWACSModePassword != "" {
+		log.Info().Msg("connecting to external AWACS mode")
+		if err := c.connectExternalAWACSMode(); err != nil {
+			return fmt.Errorf("connecting external AWACS mode failed: %w", err)
+		}
 	}
 
+	c.radiosLock.RLock()
 	for _, receiver := range c.receivers {
 		receiver.reset()
 	}
+	c.radiosLock.RUnlock()
 
 	c.SendPing()
 
 	return nil
 }
 
-// autoheal attempts to reconnect and reinitialize the SRS client if it stops receiving traffic from the SRS server.
-func (c *client) autoheal(ctx context.Context) {
-	ticker := time.NewTicker(pingInterval / 3)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			func() {
-				c.lastPingLock.Lock()
-				defer c.lastPingLock.Unlock()
-				if time.Since(c.lastPing) > pingInterval*3 {
-					log.Warn().Msg("stopped receiving traffic from SRS server")
-
-					log.Warn().Msg("attempting to reconnect to SRS server")
-					if reconnectErr := c.reconnect(ctx); reconnectErr != nil {
-						log.Err(reconnectErr).Msg("failed to reconnect to SRS server")
-						return
-					}
-					if initErr := c.initialize(); initErr != nil {
-						log.Err(initErr).Msg("failed to reinitialize SRS client")
-						return
-					}
-					c.lastPing = time.Now()
-				}
-			}()
-		}
-	}
-}
-
 // Run implements [Client.Run].
 func (c *client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 	log.Info().Msg("SRS client starting")
@@ -220,7 +339,7 @@ func (c *client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 	}()
 
 	udpVoiceRxChan := make(chan []byte, 64*0xFFFFF)
-	voiceBytesRxChan := make(chan []voice.VoicePacket, 0xFFFFF)
+	voiceBytesRxChan := make(chan receivedTransmission, 0xFFFFF)
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
@@ -231,7 +350,7 @@ func (c *client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 		c.decodeVoice(ctx, voiceBytesRxChan)
 	}()
 
-	voicePacketsTxChan := make(chan []voice.VoicePacket, 3)
+	voicePacketsTxChan := make(chan outgoingTransmission, 3)
 	wg.Add(4)
 	go func() {
 		defer wg.Done()
@@ -239,7 +358,7 @@ func (c *client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 	}()
 	go func() {
 		defer wg.Done()
-		c.transmit(ctx, voicePacketsTxChan)
+		c.transmit(ctx, wg, voicePacketsTxChan)
 	}()
 	go func() {
 		defer wg.Done()
@@ -250,30 +369,73 @@ func (c *client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 		c.autoheal(ctx)
 	}()
 
-	<-ctx.Done()
-	return nil
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-c.failures:
+		return err
+	}
 }
 
-func (c *client) getPeerName(guid types.GUID) (string, bool) {
+// peerInfo returns the client info of the client with the given GUID. The second return value is false if the client is
+// not in the client list.
+func (c *client) peerInfo(guid types.GUID) (types.ClientInfo, bool) {
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
+	if info, ok := c.roster[guid]; ok {
+		return info, true
+	}
 	info, ok := c.clients[guid]
-	if ok {
-		return info.Name, true
+	return info, ok
+}
+
+// transmitterInfo returns information about the client with the given GUID. If the client is not in the client list,
+// only the GUID is set.
+func (c *client) transmitterInfo(guid types.GUID) TransmitterInfo {
+	info, ok := c.peerInfo(guid)
+	if !ok {
+		return TransmitterInfo{GUID: guid}
+	}
+	return TransmitterInfo{
+		GUID:      guid,
+		Name:      info.Name,
+		Coalition: info.Coalition,
+		Seat:      info.Seat,
+		Unit:      info.RadioInfo.Unit,
+		UnitID:    info.RadioInfo.UnitID,
 	}
-	return "", false
 }
 
-// close the client's connections. Should be called after the autoheal goroutine has completed.
-func (c *client) close() {
+// Close implements [Client.Close].
+func (c *client) Close(ctx context.Context) error {
+	c.closing.Store(true)
+	log.Info().Int64("transmissions", c.inFlight.Load()).Msg("waiting for outgoing transmissions to finish before closing SRS client")
 	var err error
-	if tcpErr := c.tcpConnection.Close(); tcpErr != nil {
-		err = errors.Join(err, fmt.Errorf("error closing TCP connection to SRS: %w", tcpErr))
+	if !c.waitForTransmissions(ctx) {
+		err = fmt.Errorf("outgoing transmissions did not finish: %w", ctx.Err())
 	}
-	if udpErr := c.udpConnection.Close(); udpErr != nil {
-		err = errors.Join(err, fmt.Errorf("error closing UDP connection to SRS: %w", udpErr))
-	}
-	if err != nil {
-		log.Error().Err(err).Msg("error closing SRS client connections")
+	if sendErr := c.Send(c.newMessageWithClient(types.MessageClientDisconnect)); sendErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to send disconnect message: %w", sendErr))
 	}
+	c.close()
+	return err
+}
+
+// close the client's connections. A reconnection or health check in progress when the client is closed does not reopen
+// the connections or change the status. Subsequent calls do nothing.
+func (c *client) close() {
+	c.closeOnce.Do(func() {
+		c.closed.Store(true)
+		c.setStatus(StatusDisconnected)
+		var err error
+		if tcpErr := c.tcp().Close(); tcpErr != nil {
+			err = errors.Join(err, fmt.Errorf("error closing TCP connection to SRS: %w", tcpErr))
+		}
+		if udpErr := c.udp().Close(); udpErr != nil {
+			err = errors.Join(err, fmt.Errorf("error closing UDP connection to SRS: %w", udpErr))
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("error closing SRS client connections")
+		}
+	})
 }
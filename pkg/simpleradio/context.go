@@ -0,0 +1,33 @@
+package simpleradio
+
+import "context"
+
+type contextKey int
+
+const (
+	radioFrequencyKey contextKey = iota
+	priorityKey
+)
+
+// WithRadioFrequency records the frequency a request was received on, so that the response can be sent on the same
+// frequency.
+func WithRadioFrequency(ctx context.Context, frequency RadioFrequency) context.Context {
+	return context.WithValue(ctx, radioFrequencyKey, &frequency)
+}
+
+// GetRadioFrequency returns the frequency a request was received on, or nil if it is unknown.
+func GetRadioFrequency(ctx context.Context) *RadioFrequency {
+	frequency, _ := ctx.Value(radioFrequencyKey).(*RadioFrequency)
+	return frequency
+}
+
+// WithPriority records the priority of a call, so that urgent calls can be transmitted ahead of routine calls.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey, priority)
+}
+
+// GetPriority returns the priority of a call. Calls are routine unless marked otherwise.
+func GetPriority(ctx context.Context) Priority {
+	priority, _ := ctx.Value(priorityKey).(Priority)
+	return priority
+}
@@ -0,0 +1,25 @@
+package simpleradio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextRouting(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	assert.Nil(t, GetRadioFrequency(ctx))
+	assert.Equal(t, PriorityRoutine, GetPriority(ctx))
+
+	frequency := RadioFrequency{Frequency: 243 * unit.Megahertz, Modulation: types.ModulationAM}
+	ctx = WithRadioFrequency(ctx, frequency)
+	ctx = WithPriority(ctx, PriorityUrgent)
+	require.NotNil(t, GetRadioFrequency(ctx))
+	assert.Equal(t, frequency, *GetRadioFrequency(ctx))
+	assert.Equal(t, PriorityUrgent, GetPriority(ctx))
+}
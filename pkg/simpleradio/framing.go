@@ -0,0 +1,45 @@
+package simpleradio
+
+// pcmFramer splits PCM audio into Opus frames of exactly frameSize samples. Audio is buffered until a whole frame is
+// available, so audio may be written in chunks of any length.
+type pcmFramer struct {
+	// pending holds samples which do not yet fill a frame.
+	pending []float32
+}
+
+// write buffers the given audio and returns each frame completed by it. The returned frames do not share memory with
+// the given audio.
+func (f *pcmFramer) write(audio []float32) [][]float32 {
+	f.pending = append(f.pending, audio...)
+	size := int(frameSize)
+	frames := make([][]float32, 0, len(f.pending)/size)
+	for len(f.pending) >= size {
+		frames = append(frames, f.pending[:size:size])
+		f.pending = f.pending[size:]
+	}
+	// Copy the remainder so that the next write does not overwrite the last returned frame.
+	f.pending = append([]float32(nil), f.pending...)
+	return frames
+}
+
+// flush returns the buffered audio padded with silence to a whole frame, or nil if no audio is buffered.
+func (f *pcmFramer) flush() []float32 {
+	if len(f.pending) == 0 {
+		return nil
+	}
+	frame := make([]float32, frameSize)
+	copy(frame, f.pending)
+	f.pending = nil
+	return frame
+}
+
+// frames splits the given audio into Opus frames. The final frame is padded with silence, so that the end of the audio
+// is not truncated.
+func frames(audio []float32) [][]float32 {
+	var f pcmFramer
+	result := f.write(audio)
+	if frame := f.flush(); frame != nil {
+		result = append(result, frame)
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package simpleradio
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/pcm"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ramp returns n samples which are each distinct and non-zero, so that lost or reordered samples can be detected.
+func ramp(n int) []float32 {
+	audio := make([]float32, n)
+	for i := range audio {
+		audio[i] = float32(i+1) / float32(n+1)
+	}
+	return audio
+}
+
+// assertFramed checks that the frames are whole Opus frames which contain the given audio followed only by silence.
+func assertFramed(t *testing.T, audio []float32, result [][]float32) {
+	t.Helper()
+	size := int(frameSize)
+	require.Len(t, result, (len(audio)+size-1)/size)
+	joined := make([]float32, 0, len(result)*size)
+	for _, frame := range result {
+		require.Len(t, frame, size)
+		joined = append(joined, frame...)
+	}
+	assert.Equal(t, audio, joined[:len(audio)], "no samples should be lost or reordered")
+	for i, sample := range joined[len(audio):] {
+		require.Zero(t, sample, "padding sample %d should be silent", i)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	t.Parallel()
+	size := int(frameSize)
+	for _, n := range []int{0, 1, 7, size - 1, size, size + 1, 3*size - 1, 3 * size, 3*size + 1, 10*size + 333} {
+		audio := ramp(n)
+		result := frames(audio)
+		assertFramed(t, audio, result)
+		assert.Equal(t, ramp(n), audio, "framing should not modify the input audio")
+	}
+}
+
+func TestPCMFramerChunkedWrites(t *testing.T) {
+	t.Parallel()
+	audio := ramp(5*int(frameSize) + 123)
+	var framer pcmFramer
+	var result [][]float32
+	// Write the audio in odd-sized chunks which do not line up with frame boundaries.
+	for i, chunk := 0, 1; i < len(audio); i, chunk = i+chunk, chunk*3+1 {
+		result = append(result, framer.write(audio[i:min(i+chunk, len(audio))])...)
+	}
+	if frame := framer.flush(); frame != nil {
+		result = append(result, frame)
+	}
+	assertFramed(t, audio, result)
+	assert.Nil(t, framer.flush(), "flushing an empty framer should not produce a frame")
+}
+
+func TestFramesResampled(t *testing.T) {
+	t.Parallel()
+	for _, rate := range []unit.Frequency{22050 * unit.Hertz, 44100 * unit.Hertz} {
+		// One second of audio plus an odd number of samples.
+		audio := ramp(int(rate.Hertz()) + 7)
+		resampled := pcm.Resample(audio, rate, sampleRate)
+		result := frames(resampled)
+		assertFramed(t, resampled, result)
+		// One second at the SRS sample rate is exactly 25 frames, so the extra samples need one more frame.
+		assert.Len(t, result, 26, "%v Hz", rate.Hertz())
+	}
+}
+
+func TestNewVoicePacketNumbers(t *testing.T) {
+	t.Parallel()
+	c := &client{
+		clientInfo:   types.ClientInfo{GUID: "mobiusmobiusmobiusmob1"},
+		packetNumber: 1,
+	}
+	frequencies := []voice.Frequency{{Frequency: 251000000}}
+	for expected := uint64(1); expected <= 5; expected++ {
+		packet := c.newVoicePacket([]byte{1, 2, 3}, frequencies)
+		assert.Equal(t, expected, packet.PacketID)
+		assert.Equal(t, []byte("mobiusmobiusmobiusmob1"), packet.OriginGUID)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
 	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
@@ -60,43 +62,93 @@ func ParseRadioFrequency(s string) (*RadioFrequency, error) {
 	}, nil
 }
 
+// Guard frequencies are monitored by aircraft for emergencies and calls to aircraft which cannot be reached on a
+// tactical frequency.
+var (
+	// GuardUHF is the military UHF guard frequency.
+	GuardUHF = RadioFrequency{Frequency: 243 * unit.Megahertz, Modulation: types.ModulationAM}
+	// GuardVHF is the civil VHF guard frequency.
+	GuardVHF = RadioFrequency{Frequency: 121.5 * unit.Megahertz, Modulation: types.ModulationAM}
+)
+
+// IsGuard checks if the frequency is one of the guard frequencies.
+func (f RadioFrequency) IsGuard() bool {
+	return f.IsSameFrequency(GuardUHF) || f.IsSameFrequency(GuardVHF)
+}
+
+// WithGuardFrequencies returns the given frequencies followed by each guard frequency which is not already among them.
+func WithGuardFrequencies(frequencies []RadioFrequency) []RadioFrequency {
+	result := slices.Clone(frequencies)
+	for _, guard := range []RadioFrequency{GuardUHF, GuardVHF} {
+		if !slices.ContainsFunc(result, guard.IsSameFrequency) {
+			result = append(result, guard)
+		}
+	}
+	return result
+}
+
+// TacticalFrequencies returns the given frequencies except for the guard frequencies.
+func TacticalFrequencies(frequencies []RadioFrequency) []RadioFrequency {
+	result := make([]RadioFrequency, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		if !frequency.IsGuard() {
+			result = append(result, frequency)
+		}
+	}
+	return result
+}
+
+// IsSameFrequency checks if the other frequency has the same frequency and modulation as this frequency.
 func (f RadioFrequency) IsSameFrequency(other RadioFrequency) bool {
 	return f.Frequency == other.Frequency && f.Modulation == other.Modulation
 }
 
 // String representation of the RadioFrequency.
 func (f RadioFrequency) String() string {
-	var suffix string
-	switch f.Modulation {
-	case types.ModulationFM:
-		suffix = "FM"
-	case types.ModulationAM:
-		suffix = "AM"
-	}
+	return fmt.Sprintf("%.3f%s", f.Frequency.Megahertz(), f.Modulation)
+}
 
-	return fmt.Sprintf("%f.3%s", f.Frequency, suffix)
+// radioFrequency returns the frequency and modulation of the given radio.
+func radioFrequency(radio types.Radio) RadioFrequency {
+	return RadioFrequency{
+		Frequency:  unit.Frequency(radio.Frequency) * unit.Hertz,
+		Modulation: radio.Modulation,
+	}
 }
 
 // Frequencies implements [Client.Frequencies].
 func (c *client) Frequencies() []RadioFrequency {
 	frequencies := make([]RadioFrequency, 0)
-	for _, radio := range c.clientInfo.RadioInfo.Radios {
-		frequency := RadioFrequency{
-			Frequency:  unit.Frequency(radio.Frequency) * unit.Hertz,
-			Modulation: radio.Modulation,
-		}
-		frequencies = append(frequencies, frequency)
+	for _, radio := range c.radioInfo().Radios {
+		frequencies = append(frequencies, radioFrequency(radio))
 	}
 	return frequencies
 }
 
+// radioInfo returns the client's current radio information.
+func (c *client) radioInfo() types.RadioInfo {
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
+	return c.clientInfo.RadioInfo
+}
+
+// voiceFrequency returns the frequency to encode into voice packets transmitted on this frequency.
+func (f RadioFrequency) voiceFrequency() voice.Frequency {
+	return voice.Frequency{
+		Frequency:  f.Frequency.Hertz(),
+		Modulation: byte(f.Modulation),
+		Encryption: 0,
+	}
+}
+
 // ClientsOnFrequency implements [Client.ClientsOnFrequency].
 func (c *client) ClientsOnFrequency() int {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	count := 0
 	for _, client := range c.clients {
-		if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok {
+		if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok {
 			count++
 		}
 	}
@@ -109,11 +161,12 @@ func isBot(client types.ClientInfo) bool {
 
 // HumansOnFrequency implements [Client.HumansOnFrequency].
 func (c *client) HumansOnFrequency() int {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	count := 0
 	for _, client := range c.clients {
-		if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok && !isBot(client) {
+		if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok && !isBot(client) {
 			count++
 		}
 	}
@@ -122,11 +175,12 @@ func (c *client) HumansOnFrequency() int {
 
 // BotsOnFrequency implements [Client.BotsOnFrequency].
 func (c *client) BotsOnFrequency() int {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	count := 0
 	for _, client := range c.clients {
-		if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok && isBot(client) {
+		if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok && isBot(client) {
 			count++
 		}
 	}
@@ -135,11 +189,12 @@ func (c *client) BotsOnFrequency() int {
 
 // IsOnFrequency implements [Client.IsOnFrequency].
 func (c *client) IsOnFrequency(name string) bool {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	for _, client := range c.clients {
 		if client.Name == name {
-			if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok {
+			if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok {
 				return true
 			}
 		}
@@ -50,3 +50,39 @@ func TestParseFrequency(t *testing.T) {
 		})
 	}
 }
+
+func TestRadioFrequencyString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "251.000AM", RadioFrequency{251 * unit.Megahertz, types.ModulationAM}.String())
+	assert.Equal(t, "30.250FM", RadioFrequency{30.25 * unit.Megahertz, types.ModulationFM}.String())
+}
+
+func TestIsGuard(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"243.0AM", "243AM", "121.5AM"} {
+		frequency, err := ParseRadioFrequency(s)
+		require.NoError(t, err)
+		assert.True(t, frequency.IsGuard(), s)
+	}
+	for _, s := range []string{"251.0AM", "243.0FM", "121.0AM", "30.0FM"} {
+		frequency, err := ParseRadioFrequency(s)
+		require.NoError(t, err)
+		assert.False(t, frequency.IsGuard(), s)
+	}
+}
+
+func TestWithGuardFrequencies(t *testing.T) {
+	t.Parallel()
+	strike := RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
+	assert.Equal(t, []RadioFrequency{strike, GuardUHF, GuardVHF}, WithGuardFrequencies([]RadioFrequency{strike}))
+	assert.Equal(t, []RadioFrequency{GuardVHF, strike, GuardUHF}, WithGuardFrequencies([]RadioFrequency{GuardVHF, strike}))
+	assert.Equal(t, []RadioFrequency{GuardUHF, GuardVHF}, WithGuardFrequencies(nil))
+}
+
+func TestTacticalFrequencies(t *testing.T) {
+	t.Parallel()
+	strike := RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
+	fighter := RadioFrequency{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM}
+	assert.Equal(t, []RadioFrequency{strike, fighter}, TacticalFrequencies([]RadioFrequency{GuardUHF, strike, GuardVHF, fighter}))
+	assert.Empty(t, TacticalFrequencies([]RadioFrequency{GuardUHF, GuardVHF}))
+}
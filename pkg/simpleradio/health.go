@@ -0,0 +1,171 @@
+package simpleradio
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ConnectionStatus describes the health of the connection to the SRS server.
+type ConnectionStatus int
+
+const (
+	// StatusDisconnected means the client has not yet received any traffic from the SRS server, or has been closed.
+	StatusDisconnected ConnectionStatus = iota
+	// StatusConnected means the client is receiving pings from the SRS server.
+	StatusConnected
+	// StatusReconnecting means the client stopped receiving pings from the SRS server and is attempting to reconnect.
+	// The status changes to StatusConnected once the server responds to a ping.
+	StatusReconnecting
+)
+
+func (s ConnectionStatus) String() string {
+	switch s {
+	case StatusDisconnected:
+		return "disconnected"
+	case StatusConnected:
+		return "connected"
+	case StatusReconnecting:
+		return "reconnecting"
+	default:
+		return "unknown"
+	}
+}
+
+// statusChangesCapacity is the number of connection status changes buffered for [Client.StatusChanges].
+const statusChangesCapacity = 16
+
+// StatusChanges implements [Client.StatusChanges].
+func (c *client) StatusChanges() <-chan ConnectionStatus {
+	return c.statusChan
+}
+
+// HealthStatus implements [Client.HealthStatus].
+func (c *client) HealthStatus() ConnectionStatus {
+	c.statusLock.RLock()
+	defer c.statusLock.RUnlock()
+	return c.status
+}
+
+// setStatus changes the status of the connection. Once the client is closed, only a change to StatusDisconnected is
+// accepted, so that a health check which was in progress when the client closed does not revive the status.
+func (c *client) setStatus(status ConnectionStatus) {
+	c.statusLock.Lock()
+	defer c.statusLock.Unlock()
+	if c.closed.Load() && status != StatusDisconnected {
+		return
+	}
+	if c.status != status {
+		log.Debug().Stringer("status", status).Msg("SRS connection status changed")
+		publishStatus(c.statusChan, status)
+	}
+	c.status = status
+}
+
+// publishStatus sends a status change to the channel without blocking. If nobody is reading the channel and its buffer
+// is full, the change is dropped.
+func publishStatus(statusChan chan ConnectionStatus, status ConnectionStatus) {
+	select {
+	case statusChan <- status:
+	default:
+		log.Warn().Stringer("status", status).Msg("dropped SRS connection status change because the channel is full")
+	}
+}
+
+// connectionPollInterval is how often a waiting transmission checks whether the client has reconnected.
+const connectionPollInterval = 100 * time.Millisecond
+
+// waitForConnection blocks while the client is reconnecting to the SRS server. It returns false if the context is
+// canceled first.
+func (c *client) waitForConnection(ctx context.Context) bool {
+	for c.HealthStatus() == StatusReconnecting {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(connectionPollInterval):
+		}
+	}
+	return ctx.Err() == nil
+}
+
+// autoheal monitors the health of the connection to the SRS server. If the client stops receiving traffic or pings from
+// the SRS server, it reconnects and reinitializes the client, retrying with exponential backoff until successful. If the
+// client cannot continue after reconnecting, such as when the SRS server rejects the External AWACS Mode password, it
+// stops and the error is returned from [Client.Run].
+func (c *client) autoheal(ctx context.Context) {
+	ticker := time.NewTicker(c.pingInterval / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.closing.Load() {
+				continue
+			}
+			if err := c.heal(ctx); err != nil {
+				// The channel is buffered, and this is the only send.
+				c.failures <- err
+				return
+			}
+		}
+	}
+}
+
+// heal reconnects and reinitializes the client if the connection to the SRS server is dead. It returns an error if
+// the client cannot continue.
+func (c *client) heal(ctx context.Context) error {
+	c.lastPingLock.Lock()
+	defer c.lastPingLock.Unlock()
+	reason, isDead := c.isConnectionDead(time.Now())
+	if !isDead {
+		return nil
+	}
+	log.Warn().Str("reason", reason).Msg("SRS connection is dead")
+	c.setStatus(StatusReconnecting)
+
+	log.Warn().Msg("attempting to reconnect to SRS server")
+	if reconnectErr := c.reconnect(ctx); reconnectErr != nil {
+		log.Err(reconnectErr).Msg("failed to reconnect to SRS server")
+		return nil
+	}
+	if initErr := c.initialize(); initErr != nil {
+		if errors.Is(initErr, ErrExternalAWACSModeRejected) {
+			// Retrying with the same password will not help.
+			return initErr
+		}
+		log.Warn().Err(initErr).Msg("failed to reinitialize SRS client")
+		return nil
+	}
+	// The status remains StatusReconnecting until the server responds to a ping.
+	log.Info().Msg("reconnected to SRS server")
+	c.lastPing = time.Now()
+	c.markReceived()
+	return nil
+}
+
+// isConnectionDead checks if the connection to the SRS server should be considered dead at the given time, because
+// nothing has been received from the server or the server has stopped responding to UDP pings for longer than the
+// keepalive timeout. If so, it returns a description of the reason. The caller must hold lastPingLock.
+func (c *client) isConnectionDead(now time.Time) (string, bool) {
+	if now.Sub(time.Unix(0, c.lastReceived.Load())) > c.keepaliveTimeout {
+		return "stopped receiving traffic from SRS server", true
+	}
+	if now.Sub(c.lastPing) > c.keepaliveTimeout {
+		return "stopped receiving UDP pings from SRS server", true
+	}
+	return "", false
+}
+
+// markReceived records that a message or packet was received from the SRS server.
+func (c *client) markReceived() {
+	c.lastReceived.Store(time.Now().UnixNano())
+}
+
+// markDead records that the connection to the SRS server has failed, so that the client reconnects without waiting for
+// the keepalive timeout.
+func (c *client) markDead() {
+	c.lastReceived.Store(0)
+}
@@ -0,0 +1,406 @@
+package simpleradio
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockServer is a minimal SRS server which accepts TCP connections and echoes UDP pings.
+type mockServer struct {
+	listener   *net.TCPListener
+	packetConn *net.UDPConn
+	// online controls whether the server accepts connections and responds to pings.
+	online atomic.Bool
+	// eamPasswords maps External AWACS Mode passwords to coalitions. If nil, the server does not respond to External
+	// AWACS Mode password messages, as if External AWACS Mode were disabled.
+	eamPasswords map[string]coalitions.Coalition
+	// eamLock protects eamPasswords.
+	eamLock sync.Mutex
+
+	connections     []net.Conn
+	connectionsLock sync.Mutex
+
+	messages     []types.Message
+	messagesLock sync.Mutex
+
+	// wg tracks the server's goroutines, so that the test can wait for them to stop.
+	wg sync.WaitGroup
+}
+
+func newMockServer(t *testing.T, eamPasswords map[string]coalitions.Coalition) *mockServer {
+	t.Helper()
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	packetConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port})
+	require.NoError(t, err)
+	s := &mockServer{listener: listener, packetConn: packetConn, eamPasswords: eamPasswords}
+	s.online.Store(true)
+	t.Cleanup(func() {
+		_ = listener.Close()
+		_ = packetConn.Close()
+		s.drop()
+		s.wg.Wait()
+	})
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		s.acceptTCP()
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.echoPings()
+	}()
+	return s
+}
+
+func (s *mockServer) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *mockServer) acceptTCP() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		if !s.online.Load() {
+			_ = conn.Close()
+			continue
+		}
+		s.connectionsLock.Lock()
+		s.connections = append(s.connections, conn)
+		s.connectionsLock.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveTCP(conn)
+		}()
+	}
+}
+
+// serveTCP reads messages from a client and responds to External AWACS Mode password messages the way an SRS server
+// does: with the coalition whose password matched, or coalition zero if no password matched.
+func (s *mockServer) serveTCP(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		var message types.Message
+		if err := json.Unmarshal(line, &message); err != nil {
+			continue
+		}
+		s.messagesLock.Lock()
+		s.messages = append(s.messages, message)
+		s.messagesLock.Unlock()
+		if message.Type != types.MessageExternalAWACSModePassword {
+			continue
+		}
+		coalition, ok := s.authenticate(message.ExternalAWACSModePassword)
+		if !ok {
+			continue
+		}
+		response := types.Message{
+			Version: message.Version,
+			Client:  types.ClientInfo{Coalition: coalition},
+			Type:    types.MessageExternalAWACSModePassword,
+		}
+		// Announce another client straight after the response, so that the client handles messages while it takes
+		// its new coalition.
+		update := types.Message{
+			Version: message.Version,
+			Client: types.ClientInfo{
+				GUID:      types.NewGUID(),
+				Name:      "peer",
+				Coalition: coalition,
+				RadioInfo: types.RadioInfo{Radios: []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}}},
+			},
+			Type: types.MessageUpdate,
+		}
+		for _, m := range []types.Message{response, update} {
+			b, err := json.Marshal(m)
+			if err != nil {
+				return
+			}
+			if _, err := conn.Write(append(b, '\n')); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// authenticate returns the coalition of the given External AWACS Mode password, or coalition zero if no password
+// matched. The second return value is false if the server does not respond to External AWACS Mode password messages.
+func (s *mockServer) authenticate(password string) (coalitions.Coalition, bool) {
+	s.eamLock.Lock()
+	defer s.eamLock.Unlock()
+	if s.eamPasswords == nil {
+		return 0, false
+	}
+	return s.eamPasswords[password], true
+}
+
+// setEAMPasswords changes the server's External AWACS Mode passwords.
+func (s *mockServer) setEAMPasswords(passwords map[string]coalitions.Coalition) {
+	s.eamLock.Lock()
+	defer s.eamLock.Unlock()
+	s.eamPasswords = passwords
+}
+
+// hasReceived checks if the server has received a message of the given type.
+func (s *mockServer) hasReceived(messageType types.MessageType) bool {
+	s.messagesLock.Lock()
+	defer s.messagesLock.Unlock()
+	for _, message := range s.messages {
+		if message.Type == messageType {
+			return true
+		}
+	}
+	return false
+}
+
+// lastReceived returns the last message of the given type received by the server.
+func (s *mockServer) lastReceived(messageType types.MessageType) (types.Message, bool) {
+	s.messagesLock.Lock()
+	defer s.messagesLock.Unlock()
+	for _, message := range slices.Backward(s.messages) {
+		if message.Type == messageType {
+			return message, true
+		}
+	}
+	return types.Message{}, false
+}
+
+func (s *mockServer) echoPings() {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := s.packetConn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if n == types.GUIDLength && s.online.Load() {
+			_, _ = s.packetConn.WriteTo(buf[:n], addr)
+		}
+	}
+}
+
+// drop closes all open TCP connections.
+func (s *mockServer) drop() {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+	for _, conn := range s.connections {
+		_ = conn.Close()
+	}
+	s.connections = nil
+}
+
+func TestHealthMonitorReconnects(t *testing.T) {
+	t.Parallel()
+	server := newMockServer(t, nil)
+
+	c, err := NewClient(types.ClientConfiguration{
+		Address:      server.address(),
+		ClientName:   "test",
+		Coalition:    coalitions.Blue,
+		Radios:       []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}},
+		PingInterval: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusDisconnected, c.HealthStatus())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, c.Run(ctx, &wg))
+	}()
+
+	isConnected := func() bool { return c.HealthStatus() == StatusConnected }
+	require.Eventually(t, isConnected, 5*time.Second, 10*time.Millisecond)
+
+	// Drop the connection mid-session.
+	server.online.Store(false)
+	server.drop()
+	require.Eventually(t, func() bool { return c.HealthStatus() == StatusReconnecting }, 5*time.Second, 10*time.Millisecond)
+
+	// Bring the server back.
+	server.online.Store(true)
+	require.Eventually(t, isConnected, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+	assert.Equal(t, StatusDisconnected, c.HealthStatus())
+}
+
+func TestStatusChanges(t *testing.T) {
+	t.Parallel()
+	server := newMockServer(t, nil)
+
+	c, err := NewClient(types.ClientConfiguration{
+		Address:      server.address(),
+		ClientName:   "test",
+		Coalition:    coalitions.Blue,
+		Radios:       []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}},
+		PingInterval: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, c.Run(ctx, &wg))
+	}()
+
+	next := func() ConnectionStatus {
+		select {
+		case status := <-c.StatusChanges():
+			return status
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for status change")
+			return StatusDisconnected
+		}
+	}
+
+	assert.Equal(t, StatusConnected, next())
+
+	// Drop the connection mid-session, then bring the server back.
+	server.online.Store(false)
+	server.drop()
+	assert.Equal(t, StatusReconnecting, next())
+	server.online.Store(true)
+	assert.Equal(t, StatusConnected, next())
+
+	cancel()
+	wg.Wait()
+	assert.Equal(t, StatusDisconnected, next())
+}
+
+// connectionCount returns the number of open TCP connections.
+func (s *mockServer) connectionCount() int {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+	return len(s.connections)
+}
+
+// runKeepaliveClient runs a client with a short ping interval and keepalive timeout against the server until the test
+// ends. The test waits for the client's goroutines to stop before it ends.
+func runKeepaliveClient(t *testing.T, server *mockServer) Client {
+	t.Helper()
+	c, err := NewClient(types.ClientConfiguration{
+		Address:          server.address(),
+		ClientName:       "test",
+		Coalition:        coalitions.Blue,
+		Radios:           []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}},
+		PingInterval:     50 * time.Millisecond,
+		KeepaliveTimeout: 300 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, c.Run(ctx, &wg))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	require.Eventually(t, func() bool { return c.HealthStatus() == StatusConnected }, 5*time.Second, 10*time.Millisecond)
+	return c
+}
+
+func TestKeepaliveDetectsUnresponsiveServer(t *testing.T) {
+	t.Parallel()
+	server := newMockServer(t, nil)
+	c := runKeepaliveClient(t, server)
+
+	// The server stops answering pings, but leaves the TCP connection half-open rather than closing it.
+	server.online.Store(false)
+	start := time.Now()
+	require.Eventually(t, func() bool { return c.HealthStatus() == StatusReconnecting }, 5*time.Second, 10*time.Millisecond)
+	// The last ping may have been answered up to one ping interval before the server stopped responding.
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond, "the connection should not be declared dead before the keepalive timeout")
+
+	server.online.Store(true)
+	require.Eventually(t, func() bool { return c.HealthStatus() == StatusConnected }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestKeepaliveDetectsClosedConnection(t *testing.T) {
+	t.Parallel()
+	server := newMockServer(t, nil)
+	runKeepaliveClient(t, server)
+	require.Equal(t, 1, server.connectionCount())
+
+	// The server closes the TCP connection but keeps answering UDP pings. The client should notice the closed
+	// connection and reconnect, instead of staying connected over UDP alone.
+	server.drop()
+	require.Eventually(t, func() bool { return server.connectionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestIsConnectionDead(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	c := &client{keepaliveTimeout: 45 * time.Second}
+	c.lastPing = now.Add(-10 * time.Second)
+	c.lastReceived.Store(now.Add(-time.Second).UnixNano())
+	_, isDead := c.isConnectionDead(now)
+	assert.False(t, isDead)
+
+	c.lastPing = now.Add(-46 * time.Second)
+	reason, isDead := c.isConnectionDead(now)
+	assert.True(t, isDead, "a connection without UDP pings should be dead even if other traffic is received")
+	assert.Contains(t, reason, "UDP")
+
+	c.lastPing = now
+	c.markDead()
+	_, isDead = c.isConnectionDead(now)
+	assert.True(t, isDead, "a connection marked dead should be dead immediately")
+
+	c.markReceived()
+	_, isDead = c.isConnectionDead(time.Now())
+	assert.False(t, isDead)
+}
+
+func TestNextBackoff(t *testing.T) {
+	t.Parallel()
+	backoff := frameLength
+	for range 100 {
+		next := nextBackoff(backoff)
+		assert.GreaterOrEqual(t, next, backoff)
+		assert.LessOrEqual(t, next, maxReconnectBackoff)
+		backoff = next
+	}
+	assert.Equal(t, maxReconnectBackoff, backoff)
+}
+
+func TestWithJitter(t *testing.T) {
+	t.Parallel()
+	backoff := 10 * time.Second
+	delays := make(map[time.Duration]struct{})
+	for range 100 {
+		delay := withJitter(backoff)
+		assert.GreaterOrEqual(t, delay, backoff/2)
+		assert.LessOrEqual(t, delay, backoff)
+		delays[delay] = struct{}{}
+	}
+	assert.Greater(t, len(delays), 1, "delays should vary")
+}
@@ -0,0 +1,105 @@
+package simpleradio
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+)
+
+// maxGapFrames is the maximum number of frames of silence inserted for a gap in a transmission. Longer gaps are
+// shortened to this length, so a sender which skips ahead in packet IDs does not produce a long stretch of silence.
+const maxGapFrames = int(defaultEndOfTransmissionSilence / frameLength)
+
+// jitterBuffer reorders the voice packets of a single transmission by packet ID. Packets are held until they are
+// depth packets behind the newest packet received, giving late packets a chance to arrive and be put in order.
+// Duplicate packets and packets which arrive after a newer packet has been released are dropped.
+//
+// The zero value has a depth of zero, which releases each packet as soon as it is received.
+type jitterBuffer struct {
+	// depth is the number of packets held back to wait for late packets.
+	depth uint64
+	// pending packets, ordered by packet ID.
+	pending []voice.VoicePacket
+	// started is true once a packet has been released.
+	started bool
+	// next is the ID of the packet which follows the last released packet.
+	next uint64
+}
+
+// newJitterBuffer creates a jitterBuffer which waits up to the given duration for late packets.
+func newJitterBuffer(depth time.Duration) jitterBuffer {
+	if depth < 0 {
+		depth = 0
+	}
+	return jitterBuffer{depth: uint64(depth / frameLength)}
+}
+
+// push adds a packet to the buffer and returns any packets released as a result, in order. It returns false if the
+// packet was dropped because it is a duplicate or arrived too late.
+func (j *jitterBuffer) push(packet voice.VoicePacket) ([]voice.VoicePacket, bool) {
+	if j.started && packet.PacketID < j.next {
+		return nil, false
+	}
+	i, found := slices.BinarySearchFunc(j.pending, packet.PacketID, func(p voice.VoicePacket, id uint64) int {
+		return cmp.Compare(p.PacketID, id)
+	})
+	if found {
+		return nil, false
+	}
+	j.pending = slices.Insert(j.pending, i, packet)
+	return j.release(), true
+}
+
+// release removes packets from the front of the buffer which are either next in order or have fallen out of the
+// window.
+func (j *jitterBuffer) release() []voice.VoicePacket {
+	var released []voice.VoicePacket
+	for len(j.pending) > 0 {
+		lowest := j.pending[0].PacketID
+		highest := j.pending[len(j.pending)-1].PacketID
+		isNext := j.started && lowest == j.next
+		isOutsideWindow := highest-lowest >= j.depth
+		if !isNext && !isOutsideWindow {
+			break
+		}
+		released = append(released, j.pending[0])
+		j.pending = j.pending[1:]
+		j.started = true
+		j.next = lowest + 1
+	}
+	return released
+}
+
+// flush removes and returns all pending packets, in order.
+func (j *jitterBuffer) flush() []voice.VoicePacket {
+	released := j.pending
+	j.pending = nil
+	if len(released) > 0 {
+		j.started = true
+		j.next = released[len(released)-1].PacketID + 1
+	}
+	return released
+}
+
+// assemblePCM decodes the ordered packets of a transmission with the given function and concatenates the audio. For
+// each packet missing between two consecutive packets, a frame of silence is inserted, up to maxGapFrames per gap. The
+// returned error is the last error returned by decode; packets which fail to decode are skipped.
+func assemblePCM(packets []voice.VoicePacket, decode func([]byte) ([]float32, error)) ([]float32, error) {
+	pcm := make([]float32, 0, len(packets)*int(frameSize))
+	var err error
+	for i, packet := range packets {
+		if i > 0 && packet.PacketID > packets[i-1].PacketID+1 {
+			missing := min(packet.PacketID-packets[i-1].PacketID-1, uint64(maxGapFrames))
+			pcm = append(pcm, make([]float32, int(missing)*int(frameSize))...)
+		}
+		frame, decodeErr := decode(packet.AudioBytes)
+		if decodeErr != nil {
+			err = decodeErr
+			continue
+		}
+		pcm = append(pcm, frame...)
+	}
+	return pcm, err
+}
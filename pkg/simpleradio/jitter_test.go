@@ -0,0 +1,170 @@
+package simpleradio
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPacket returns a packet whose audio is a single byte equal to its ID, so decodeTestFrame can reconstruct it.
+func testPacket(id uint64) voice.VoicePacket {
+	return voice.VoicePacket{PacketID: id, AudioBytes: []byte{byte(id)}}
+}
+
+// decodeTestFrame decodes a packet created by testPacket into a frame filled with the packet's ID.
+func decodeTestFrame(b []byte) ([]float32, error) {
+	if len(b) != 1 {
+		return nil, errors.New("invalid test frame")
+	}
+	frame := make([]float32, frameSize)
+	for i := range frame {
+		frame[i] = float32(b[0])
+	}
+	return frame, nil
+}
+
+// receiveAll pushes the packets with the given IDs through a jitter buffer and returns the IDs of the released
+// packets, in order.
+func receiveAll(t *testing.T, j *jitterBuffer, ids []uint64) []uint64 {
+	t.Helper()
+	var released []voice.VoicePacket
+	for _, id := range ids {
+		packets, _ := j.push(testPacket(id))
+		released = append(released, packets...)
+	}
+	released = append(released, j.flush()...)
+	result := make([]uint64, 0, len(released))
+	for _, packet := range released {
+		result = append(result, packet.PacketID)
+	}
+	return result
+}
+
+func TestJitterBufferReorders(t *testing.T) {
+	t.Parallel()
+	j := newJitterBuffer(3 * frameLength)
+	ids := []uint64{2, 1, 3, 5, 4, 7, 6, 8}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8}, receiveAll(t, &j, ids))
+}
+
+func TestJitterBufferDropsDuplicates(t *testing.T) {
+	t.Parallel()
+	j := newJitterBuffer(3 * frameLength)
+	ids := []uint64{1, 1, 2, 3, 2, 4, 4, 5}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, receiveAll(t, &j, ids))
+
+	_, ok := j.push(testPacket(3))
+	assert.False(t, ok, "a packet which was already released should be dropped")
+}
+
+func TestJitterBufferDropsLatePackets(t *testing.T) {
+	t.Parallel()
+	j := newJitterBuffer(2 * frameLength)
+	// Packet 2 arrives after packet 5, which is outside the window, so packets 1, 3 and 4 have already been released.
+	ids := []uint64{1, 3, 4, 5, 2, 6}
+	assert.Equal(t, []uint64{1, 3, 4, 5, 6}, receiveAll(t, &j, ids))
+}
+
+func TestJitterBufferZeroDepth(t *testing.T) {
+	t.Parallel()
+	j := jitterBuffer{}
+	released, ok := j.push(testPacket(2))
+	require.True(t, ok)
+	require.Len(t, released, 1)
+	_, ok = j.push(testPacket(1))
+	assert.False(t, ok, "without a window, packets older than a released packet should be dropped")
+	released, ok = j.push(testPacket(3))
+	require.True(t, ok)
+	require.Len(t, released, 1)
+}
+
+func TestJitterBufferShuffled(t *testing.T) {
+	t.Parallel()
+	const count = 100
+	const window = 4
+	// Each packet is displaced by less than the window, and every packet is delivered twice.
+	ids := make([]uint64, 0, 2*count)
+	for i := uint64(1); i <= count; i += window {
+		block := []uint64{}
+		for id := i; id < i+window && id <= count; id++ {
+			block = append(block, id, id)
+		}
+		rand.Shuffle(len(block), func(a, b int) { block[a], block[b] = block[b], block[a] })
+		ids = append(ids, block...)
+	}
+
+	j := newJitterBuffer(2 * window * frameLength)
+	released := receiveAll(t, &j, ids)
+	require.Len(t, released, count)
+	for i, id := range released {
+		assert.Equal(t, uint64(i+1), id)
+	}
+}
+
+func TestReceiverReordersPackets(t *testing.T) {
+	t.Parallel()
+	r := newReceiver(3*frameLength, 0, 0)
+	origin := []byte("mobiusmobiusmobiusmob1")
+	for _, id := range []uint64{3, 1, 2, 2, 5, 4} {
+		packet := testPacket(id)
+		packet.OriginGUID = origin
+		r.receive(&packet)
+	}
+	other := testPacket(6)
+	other.OriginGUID = []byte("yellowyellowyellowyel1")
+	r.receive(&other)
+
+	packets := r.packets()
+	ids := make([]uint64, 0, len(packets))
+	for _, packet := range packets {
+		ids = append(ids, packet.PacketID)
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, ids)
+
+	r.reset()
+	assert.Empty(t, r.packets())
+	assert.Equal(t, uint64(3), r.jitter.depth, "reset should keep the jitter buffer depth")
+}
+
+func TestAssemblePCM(t *testing.T) {
+	t.Parallel()
+	j := newJitterBuffer(3 * frameLength)
+	var packets []voice.VoicePacket
+	for _, id := range []uint64{2, 1, 1, 3, 6, 5, 7} {
+		released, _ := j.push(testPacket(id))
+		packets = append(packets, released...)
+	}
+	packets = append(packets, j.flush()...)
+
+	pcm, err := assemblePCM(packets, decodeTestFrame)
+	require.NoError(t, err)
+	// Packet 4 was lost, so a frame of silence is inserted in its place.
+	expected := []float32{1, 2, 3, 0, 5, 6, 7}
+	require.Len(t, pcm, len(expected)*int(frameSize))
+	for i, value := range expected {
+		frame := pcm[i*int(frameSize) : (i+1)*int(frameSize)]
+		for _, sample := range frame {
+			require.InDelta(t, value, sample, 0, "frame %d", i)
+		}
+	}
+}
+
+func TestAssemblePCMLimitsGaps(t *testing.T) {
+	t.Parallel()
+	packets := []voice.VoicePacket{testPacket(1), testPacket(200)}
+	pcm, err := assemblePCM(packets, decodeTestFrame)
+	require.NoError(t, err)
+	assert.Len(t, pcm, (2+maxGapFrames)*int(frameSize))
+}
+
+func TestAssemblePCMSkipsUndecodablePackets(t *testing.T) {
+	t.Parallel()
+	corrupt := voice.VoicePacket{PacketID: 2, AudioBytes: []byte{1, 2}}
+	pcm, err := assemblePCM([]voice.VoicePacket{testPacket(1), corrupt, testPacket(3)}, decodeTestFrame)
+	require.Error(t, err)
+	assert.Len(t, pcm, 2*int(frameSize))
+}
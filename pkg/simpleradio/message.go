@@ -5,11 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/rs/zerolog/log"
 )
 
+// externalAWACSModeTimeout is how long to wait for the SRS server to respond to an External AWACS Mode password.
+const externalAWACSModeTimeout = 5 * time.Second
+
+// ErrExternalAWACSModeRejected is returned when the SRS server rejects the External AWACS Mode password.
+var ErrExternalAWACSModeRejected = errors.New("SRS server rejected the external AWACS mode password")
+
 // Send implements [Client.Send].
 func (c *client) Send(message types.Message) error {
 	// Sending a message means writing a JSON-serialized message to the TCP connection, followed by a newline.
@@ -21,7 +29,7 @@ func (c *client) Send(message types.Message) error {
 		return fmt.Errorf("failed to marshal message to JSON: %w", err)
 	}
 	b = append(b, byte('\n'))
-	_, err = c.tcpConnection.Write(b)
+	_, err = c.tcp().Write(b)
 	if err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
@@ -39,6 +47,8 @@ func (c *client) newMessage(t types.MessageType) types.Message {
 // newMessageWithClient creates a new message with the client's version, the given message type, and the client's info.
 func (c *client) newMessageWithClient(t types.MessageType) types.Message {
 	message := c.newMessage(t)
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
 	message.Client = c.clientInfo
 	return message
 }
@@ -63,12 +73,11 @@ func (c *client) handleMessage(message types.Message) {
 	case types.MessageClientDisconnect:
 		c.removeClient(message.Client)
 	case types.MessageExternalAWACSModePassword:
-		if message.Client.Coalition == c.clientInfo.Coalition {
-			log.Debug().Any("remoteClient", message.Client).Msg("received external AWACS mode password message")
-			// TODO is the update necessary?
-			if err := c.updateRadios(); err != nil {
-				log.Error().Err(err).Msg("failed to update radios")
-			}
+		log.Debug().Any("remoteClient", message.Client).Msg("received external AWACS mode password message")
+		select {
+		case c.eamResponses <- message.Client.Coalition:
+		default:
+			log.Warn().Msg("dropping unexpected external AWACS mode password message")
 		}
 	default:
 		log.Warn().Any("message", message).Msg("received unrecognized message")
@@ -98,16 +107,58 @@ func (c *client) updateRadios() error {
 	return nil
 }
 
-// connectExternalAWACSMode sends an external AWACS mode password message to the SRS server to authenticate as an external AWACS.
+// connectExternalAWACSMode sends an external AWACS mode password message to the SRS server to authenticate as an
+// external AWACS, and waits for the server's response.
+//
+// The server responds with the coalition whose password matched, which becomes the client's coalition. If the
+// password is rejected, [ErrExternalAWACSModeRejected] is returned. Servers with External AWACS Mode disabled do not
+// respond, so if no response arrives within externalAWACSModeTimeout the client continues with its configured
+// coalition.
 func (c *client) connectExternalAWACSMode() error {
+	// Discard any stale response from a previous connection.
+	select {
+	case <-c.eamResponses:
+	default:
+	}
+
 	message := c.newMessageWithClient(types.MessageExternalAWACSModePassword)
 	message.ExternalAWACSModePassword = c.externalAWACSModePassword
 	if err := c.Send(message); err != nil {
 		return fmt.Errorf("failed to authenticate with EAM password: %w", err)
 	}
+
+	select {
+	case coalition := <-c.eamResponses:
+		if coalition == 0 {
+			return ErrExternalAWACSModeRejected
+		}
+		if configured := c.coalition(); coalition != configured {
+			log.Warn().
+				Stringer("configured", configured).
+				Stringer("coalition", coalition).
+				Msg("EAM password belongs to a different coalition than configured, using the password's coalition")
+			c.radiosLock.Lock()
+			c.clientInfo.Coalition = coalition
+			c.radiosLock.Unlock()
+		}
+		log.Info().Stringer("coalition", coalition).Msg("authenticated with EAM password")
+	case <-time.After(externalAWACSModeTimeout):
+		log.Warn().Msg("SRS server did not respond to EAM password, external AWACS mode may be disabled on the server")
+	}
+
+	if err := c.updateRadios(); err != nil {
+		return fmt.Errorf("failed to update radios after authenticating with EAM password: %w", err)
+	}
 	return nil
 }
 
+// coalition returns the client's coalition.
+func (c *client) coalition() coalitions.Coalition {
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
+	return c.clientInfo.Coalition
+}
+
 // logMessageAndIgnore logs a message at DEBUG level.
 func logMessageAndIgnore(message types.Message) {
 	log.Debug().Any("message", message).Msg("received message")
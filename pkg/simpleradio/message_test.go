@@ -0,0 +1,159 @@
+package simpleradio
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eamPasswords are the External AWACS Mode passwords of the mock server used in these tests.
+var eamPasswords = map[string]coalitions.Coalition{
+	"sol":     coalitions.Blue,
+	"strigon": coalitions.Red,
+}
+
+func newEAMClient(t *testing.T, server *mockServer, password string) *client {
+	t.Helper()
+	c, err := NewClient(types.ClientConfiguration{
+		Address:                   server.address(),
+		ClientName:                "test",
+		ExternalAWACSModePassword: password,
+		Coalition:                 coalitions.Blue,
+		Radios:                    []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}},
+		PingInterval:              100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	return c.(*client)
+}
+
+func TestExternalAWACSModeAccepted(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		password string
+		expected coalitions.Coalition
+	}{
+		{password: "sol", expected: coalitions.Blue},
+		{password: "strigon", expected: coalitions.Red},
+	}
+	for _, test := range testCases {
+		t.Run(test.password, func(t *testing.T) {
+			t.Parallel()
+			server := newMockServer(t, eamPasswords)
+			c := newEAMClient(t, server, test.password)
+			ctx, cancel := context.WithCancel(context.Background())
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.receiveTCP(ctx)
+			}()
+			t.Cleanup(func() {
+				cancel()
+				c.close()
+				wg.Wait()
+			})
+
+			require.NoError(t, c.connectExternalAWACSMode())
+			assert.Equal(t, test.expected, c.coalition(), "the client should take the coalition of the password")
+		})
+	}
+}
+
+func TestExternalAWACSModeRejected(t *testing.T) {
+	t.Parallel()
+	server := newMockServer(t, eamPasswords)
+	c := newEAMClient(t, server, "mobius")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make(chan error, 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs <- c.Run(ctx, &wg)
+	}()
+
+	select {
+	case err := <-errs:
+		require.ErrorIs(t, err, ErrExternalAWACSModeRejected)
+	case <-time.After(externalAWACSModeTimeout / 2):
+		require.FailNow(t, "a rejected password should stop the client")
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestExternalAWACSModeAcceptedWhileRunning(t *testing.T) {
+	t.Parallel()
+	server := newMockServer(t, eamPasswords)
+	c := newEAMClient(t, server, "strigon")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, c.Run(ctx, &wg))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+
+	// The client takes its new coalition while its receivers handle the messages which follow the response.
+	require.Eventually(t, func() bool {
+		message, ok := server.lastReceived(types.MessageRadioUpdate)
+		return ok && message.Client.Coalition == coalitions.Red
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, coalitions.Red, c.coalition())
+	require.Eventually(t, func() bool { return c.HealthStatus() == StatusConnected }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExternalAWACSModeRejectedAfterReconnect(t *testing.T) {
+	t.Parallel()
+	server := newMockServer(t, eamPasswords)
+	// The ping interval is longer than the External AWACS Mode timeout, so the response after reconnecting is only
+	// read in time if the client resumes reading as soon as it reconnects.
+	c, err := NewClient(types.ClientConfiguration{
+		Address:                   server.address(),
+		ClientName:                "test",
+		ExternalAWACSModePassword: "sol",
+		Coalition:                 coalitions.Blue,
+		Radios:                    []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}},
+		PingInterval:              externalAWACSModeTimeout + time.Second,
+		KeepaliveTimeout:          time.Minute,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make(chan error, 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs <- c.Run(ctx, &wg)
+	}()
+	require.Eventually(t, func() bool { return c.HealthStatus() == StatusConnected }, 5*time.Second, 10*time.Millisecond)
+
+	// The password is changed on the server, which then drops the client.
+	server.setEAMPasswords(map[string]coalitions.Coalition{"strigon": coalitions.Red})
+	server.drop()
+
+	select {
+	case err := <-errs:
+		require.ErrorIs(t, err, ErrExternalAWACSModeRejected)
+	case <-time.After(2 * externalAWACSModeTimeout):
+		require.FailNow(t, "a password rejected after reconnecting should stop the client")
+	}
+	cancel()
+	wg.Wait()
+	assert.Equal(t, StatusDisconnected, c.HealthStatus())
+}
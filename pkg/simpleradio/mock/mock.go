@@ -0,0 +1,295 @@
+// package mock provides an in-memory SimpleRadio-Standalone client for testing code which uses [simpleradio.Client]
+// without a live SRS server.
+package mock
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/pcm"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+)
+
+// channelCapacity is the number of injected transmissions and status changes buffered before Inject and SetHealthStatus
+// block.
+const channelCapacity = 64
+
+// MockClient implements [simpleradio.Client] in memory. Tests inject incoming transmissions with [MockClient.Inject]
+// and inspect outgoing transmissions with [MockClient.Transmitted] and [MockClient.TransmittedBytes].
+type MockClient struct {
+	// frequencies the client is tuned to.
+	frequencies []simpleradio.RadioFrequency
+	// frequenciesLock protects frequencies.
+	frequenciesLock sync.RWMutex
+	// rxChan is where injected transmissions are published.
+	rxChan chan simpleradio.Transmission
+	// statusChan is where status changes are published.
+	statusChan chan simpleradio.ConnectionStatus
+
+	// lock protects the fields below.
+	lock sync.RWMutex
+	// transmitted records outgoing transmissions in the order they were queued.
+	transmitted []simpleradio.Transmission
+	// sent records messages sent to the server.
+	sent []types.Message
+	// peers are the other clients connected to the server.
+	peers map[types.GUID]types.ClientInfo
+	// status is the current connection status.
+	status simpleradio.ConnectionStatus
+}
+
+var _ simpleradio.Client = &MockClient{}
+
+// NewMockClient creates a MockClient tuned to the given frequencies.
+func NewMockClient(frequencies ...simpleradio.RadioFrequency) *MockClient {
+	return &MockClient{
+		frequencies: frequencies,
+		rxChan:      make(chan simpleradio.Transmission, channelCapacity),
+		statusChan:  make(chan simpleradio.ConnectionStatus, channelCapacity),
+		peers:       make(map[types.GUID]types.ClientInfo),
+	}
+}
+
+// Run implements [simpleradio.Client.Run]. The client is connected until the context is canceled.
+func (c *MockClient) Run(ctx context.Context, _ *sync.WaitGroup) error {
+	c.SetHealthStatus(simpleradio.StatusConnected)
+	<-ctx.Done()
+	c.SetHealthStatus(simpleradio.StatusDisconnected)
+	return nil
+}
+
+// Send implements [simpleradio.Client.Send]. The message is recorded and can be inspected with [MockClient.Sent].
+func (c *MockClient) Send(message types.Message) error {
+	if message.Version == "" {
+		return errors.New("message Version is required")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.sent = append(c.sent, message)
+	return nil
+}
+
+// Sent returns the messages sent to the server, in order.
+func (c *MockClient) Sent() []types.Message {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return slices.Clone(c.sent)
+}
+
+// Receive implements [simpleradio.Client.Receive].
+func (c *MockClient) Receive() <-chan simpleradio.Transmission {
+	return c.rxChan
+}
+
+// Inject publishes a transmission as if it were received over the radio. If the transmission has no frequencies, it
+// is tagged with the client's first frequency.
+func (c *MockClient) Inject(transmission simpleradio.Transmission) {
+	if frequencies := c.Frequencies(); len(transmission.Frequencies) == 0 && len(frequencies) > 0 {
+		transmission.Frequencies = []simpleradio.RadioFrequency{frequencies[0]}
+	}
+	c.rxChan <- transmission
+}
+
+// Transmit implements [simpleradio.Client.Transmit]. The transmission is recorded and can be inspected with
+// [MockClient.Transmitted].
+func (c *MockClient) Transmit(transmission simpleradio.Transmission) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.transmitted = append(c.transmitted, transmission)
+}
+
+// TransmitAll implements [simpleradio.Client.TransmitAll].
+func (c *MockClient) TransmitAll(frequencies []simpleradio.RadioFrequency, transmission simpleradio.Transmission) error {
+	var err error
+	transmission.Frequencies = make([]simpleradio.RadioFrequency, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		if !c.isTunedTo(frequency) {
+			err = errors.Join(err, fmt.Errorf("client is not tuned to %s", frequency))
+			continue
+		}
+		transmission.Frequencies = append(transmission.Frequencies, frequency)
+	}
+	if len(transmission.Frequencies) == 0 {
+		return errors.Join(err, errors.New("no frequencies to transmit on"))
+	}
+	c.Transmit(transmission)
+	return err
+}
+
+// Transmitted returns the outgoing transmissions, in the order they were queued.
+func (c *MockClient) Transmitted() []simpleradio.Transmission {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return slices.Clone(c.transmitted)
+}
+
+// TransmittedBytes returns the audio of each outgoing transmission as F32LE PCM bytes, in the order they were queued.
+func (c *MockClient) TransmittedBytes() [][]byte {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	result := make([][]byte, 0, len(c.transmitted))
+	for _, transmission := range c.transmitted {
+		result = append(result, pcm.F32toF32LEBytes(transmission.Audio))
+	}
+	return result
+}
+
+// isTunedTo checks if the client is tuned to the given frequency.
+func (c *MockClient) isTunedTo(frequency simpleradio.RadioFrequency) bool {
+	c.frequenciesLock.RLock()
+	defer c.frequenciesLock.RUnlock()
+	for _, f := range c.frequencies {
+		if f.IsSameFrequency(frequency) {
+			return true
+		}
+	}
+	return false
+}
+
+// Frequencies implements [simpleradio.Client.Frequencies].
+func (c *MockClient) Frequencies() []simpleradio.RadioFrequency {
+	c.frequenciesLock.RLock()
+	defer c.frequenciesLock.RUnlock()
+	return slices.Clone(c.frequencies)
+}
+
+// AddPeer adds or updates another client connected to the server.
+func (c *MockClient) AddPeer(info types.ClientInfo) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.peers[info.GUID] = info
+}
+
+// RemovePeer removes another client from the server.
+func (c *MockClient) RemovePeer(guid types.GUID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.peers, guid)
+}
+
+// isOnFrequency checks if the peer has a radio tuned to any of the client's frequencies.
+func (c *MockClient) isOnFrequency(peer types.ClientInfo) bool {
+	for _, radio := range peer.RadioInfo.Radios {
+		frequency := simpleradio.RadioFrequency{
+			Frequency:  unit.Frequency(radio.Frequency) * unit.Hertz,
+			Modulation: radio.Modulation,
+		}
+		if c.isTunedTo(frequency) {
+			return true
+		}
+	}
+	return false
+}
+
+// countPeersOnFrequency counts the peers on the client's frequencies which match the predicate.
+func (c *MockClient) countPeersOnFrequency(predicate func(types.ClientInfo) bool) int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	count := 0
+	for _, peer := range c.peers {
+		if c.isOnFrequency(peer) && predicate(peer) {
+			count++
+		}
+	}
+	return count
+}
+
+// isBot follows the convention that bot clients' names end with "[BOT]".
+func isBot(info types.ClientInfo) bool {
+	return strings.HasSuffix(info.Name, "[BOT]")
+}
+
+// ClientsOnFrequency implements [simpleradio.Client.ClientsOnFrequency].
+func (c *MockClient) ClientsOnFrequency() int {
+	return c.countPeersOnFrequency(func(types.ClientInfo) bool { return true })
+}
+
+// HumansOnFrequency implements [simpleradio.Client.HumansOnFrequency].
+func (c *MockClient) HumansOnFrequency() int {
+	return c.countPeersOnFrequency(func(info types.ClientInfo) bool { return !isBot(info) })
+}
+
+// BotsOnFrequency implements [simpleradio.Client.BotsOnFrequency].
+func (c *MockClient) BotsOnFrequency() int {
+	return c.countPeersOnFrequency(isBot)
+}
+
+// IsOnFrequency implements [simpleradio.Client.IsOnFrequency].
+func (c *MockClient) IsOnFrequency(name string) bool {
+	return c.countPeersOnFrequency(func(info types.ClientInfo) bool { return info.Name == name }) > 0
+}
+
+// Clients implements [simpleradio.Client.Clients].
+func (c *MockClient) Clients() []types.ClientInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	clients := make([]types.ClientInfo, 0, len(c.peers))
+	for _, info := range c.peers {
+		clients = append(clients, info)
+	}
+	slices.SortFunc(clients, func(a, b types.ClientInfo) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.GUID, b.GUID))
+	})
+	return clients
+}
+
+// ClientByGUID implements [simpleradio.Client.ClientByGUID].
+func (c *MockClient) ClientByGUID(guid types.GUID) (types.ClientInfo, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	info, ok := c.peers[guid]
+	return info, ok
+}
+
+// HealthStatus implements [simpleradio.Client.HealthStatus].
+func (c *MockClient) HealthStatus() simpleradio.ConnectionStatus {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.status
+}
+
+// SetHealthStatus changes the connection status, publishing the change to [MockClient.StatusChanges].
+func (c *MockClient) SetHealthStatus(status simpleradio.ConnectionStatus) {
+	c.lock.Lock()
+	changed := c.status != status
+	c.status = status
+	c.lock.Unlock()
+	if changed {
+		c.statusChan <- status
+	}
+}
+
+// StatusChanges implements [simpleradio.Client.StatusChanges].
+func (c *MockClient) StatusChanges() <-chan simpleradio.ConnectionStatus {
+	return c.statusChan
+}
+
+// Retune implements [simpleradio.Client.Retune]. Transmissions are recorded immediately, so the mode has no effect.
+func (c *MockClient) Retune(_ context.Context, from, to simpleradio.RadioFrequency, _ simpleradio.RetuneMode) error {
+	c.frequenciesLock.Lock()
+	defer c.frequenciesLock.Unlock()
+	i := slices.IndexFunc(c.frequencies, from.IsSameFrequency)
+	if i < 0 {
+		return fmt.Errorf("client is not tuned to %s", from)
+	}
+	if slices.ContainsFunc(c.frequencies, to.IsSameFrequency) {
+		return fmt.Errorf("client is already tuned to %s", to)
+	}
+	frequencies := slices.Clone(c.frequencies)
+	frequencies[i] = to
+	c.frequencies = frequencies
+	return nil
+}
+
+// Close implements [simpleradio.Client.Close]. Transmissions are recorded immediately, so there is nothing to wait for.
+func (c *MockClient) Close(context.Context) error {
+	c.SetHealthStatus(simpleradio.StatusDisconnected)
+	return nil
+}
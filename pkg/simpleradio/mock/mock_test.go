@@ -0,0 +1,110 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	uhf = simpleradio.RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
+	vhf = simpleradio.RadioFrequency{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM}
+)
+
+func TestInject(t *testing.T) {
+	t.Parallel()
+	client := NewMockClient(uhf)
+	client.Inject(simpleradio.Transmission{TraceID: "abc", Audio: simpleradio.Audio{0.5}})
+
+	transmission := <-client.Receive()
+	assert.Equal(t, "abc", transmission.TraceID)
+	assert.Equal(t, simpleradio.Audio{0.5}, transmission.Audio)
+	assert.Equal(t, []simpleradio.RadioFrequency{uhf}, transmission.Frequencies)
+}
+
+func TestTransmittedBytes(t *testing.T) {
+	t.Parallel()
+	client := NewMockClient(uhf)
+	client.Transmit(simpleradio.Transmission{Audio: simpleradio.Audio{0, 1}})
+	client.Transmit(simpleradio.Transmission{Audio: simpleradio.Audio{-0.5}})
+
+	require.Len(t, client.Transmitted(), 2)
+	assert.Equal(t, [][]byte{
+		{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x3f},
+		{0x00, 0x00, 0x00, 0xbf},
+	}, client.TransmittedBytes())
+}
+
+func TestTransmitAll(t *testing.T) {
+	t.Parallel()
+	client := NewMockClient(uhf)
+
+	require.Error(t, client.TransmitAll([]simpleradio.RadioFrequency{uhf, vhf}, simpleradio.Transmission{}))
+	transmitted := client.Transmitted()
+	require.Len(t, transmitted, 1)
+	assert.Equal(t, []simpleradio.RadioFrequency{uhf}, transmitted[0].Frequencies)
+
+	require.Error(t, client.TransmitAll([]simpleradio.RadioFrequency{vhf}, simpleradio.Transmission{}))
+	assert.Len(t, client.Transmitted(), 1)
+}
+
+func TestRetune(t *testing.T) {
+	t.Parallel()
+	client := NewMockClient(uhf)
+	guard := simpleradio.RadioFrequency{Frequency: 243 * unit.Megahertz, Modulation: types.ModulationAM}
+
+	require.Error(t, client.Retune(context.Background(), vhf, guard, simpleradio.RetuneDrain))
+	require.Error(t, client.Retune(context.Background(), uhf, uhf, simpleradio.RetuneDrain))
+	require.NoError(t, client.Retune(context.Background(), uhf, vhf, simpleradio.RetuneRetarget))
+	assert.Equal(t, []simpleradio.RadioFrequency{vhf}, client.Frequencies())
+	require.NoError(t, client.TransmitAll([]simpleradio.RadioFrequency{vhf}, simpleradio.Transmission{}))
+}
+
+func TestPeers(t *testing.T) {
+	t.Parallel()
+	client := NewMockClient(uhf)
+	onFrequency := types.RadioInfo{Radios: []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}}}
+	offFrequency := types.RadioInfo{Radios: []types.Radio{{Frequency: 133000000, Modulation: types.ModulationAM}}}
+	client.AddPeer(types.ClientInfo{GUID: "mobiusmobiusmobiusmob1", Name: "Mobius 1", RadioInfo: onFrequency})
+	client.AddPeer(types.ClientInfo{GUID: "botbotbotbotbotbotbot1", Name: "Skyeye [BOT]", RadioInfo: onFrequency})
+	client.AddPeer(types.ClientInfo{GUID: "yellowyellowyellowyel1", Name: "Yellow 13", RadioInfo: offFrequency})
+
+	assert.Equal(t, 2, client.ClientsOnFrequency())
+	assert.Equal(t, 1, client.HumansOnFrequency())
+	assert.Equal(t, 1, client.BotsOnFrequency())
+	assert.True(t, client.IsOnFrequency("Mobius 1"))
+	assert.False(t, client.IsOnFrequency("Yellow 13"))
+	assert.Len(t, client.Clients(), 3)
+
+	client.RemovePeer("mobiusmobiusmobiusmob1")
+	_, ok := client.ClientByGUID("mobiusmobiusmobiusmob1")
+	assert.False(t, ok)
+	assert.Equal(t, 0, client.HumansOnFrequency())
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	client := NewMockClient(uhf)
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- client.Run(ctx, &sync.WaitGroup{})
+	}()
+
+	assert.Equal(t, simpleradio.StatusConnected, <-client.StatusChanges())
+	cancel()
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Run did not return after the context was canceled")
+	}
+	assert.Equal(t, simpleradio.StatusDisconnected, client.HealthStatus())
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/rand/v2"
 	"net"
 	"time"
 
@@ -15,6 +16,9 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// errClosed is returned when the client attempts to connect after its connections were closed.
+var errClosed = errors.New("SRS client is closed")
+
 // connectTCP connects to the SRS server over TCP.
 func (c *client) connectTCP() error {
 	log.Info().Str("address", c.address).Msg("connecting to SRS server TCP socket")
@@ -26,6 +30,12 @@ func (c *client) connectTCP() error {
 	if err != nil {
 		return fmt.Errorf("failed to connect to data socket: %w", err)
 	}
+	c.connectionsLock.Lock()
+	defer c.connectionsLock.Unlock()
+	if c.closed.Load() {
+		_ = connection.Close()
+		return errClosed
+	}
 	c.tcpConnection = connection
 	return nil
 }
@@ -41,12 +51,52 @@ func (c *client) connectUDP() error {
 	if err != nil {
 		return fmt.Errorf("failed to connect to UDP socket: %w", err)
 	}
+	c.connectionsLock.Lock()
+	defer c.connectionsLock.Unlock()
+	if c.closed.Load() {
+		_ = connection.Close()
+		return errClosed
+	}
 	c.udpConnection = connection
 	return nil
 }
 
+// tcp returns the current TCP connection to the SRS server.
+func (c *client) tcp() *net.TCPConn {
+	c.connectionsLock.RLock()
+	defer c.connectionsLock.RUnlock()
+	return c.tcpConnection
+}
+
+// udp returns the current UDP connection to the SRS server.
+func (c *client) udp() *net.UDPConn {
+	c.connectionsLock.RLock()
+	defer c.connectionsLock.RUnlock()
+	return c.udpConnection
+}
+
+// maxReconnectBackoff is the longest delay between reconnection attempts.
+const maxReconnectBackoff = time.Minute
+
+// nextBackoff returns the delay before the reconnection attempt after one which waited for the given delay. The delay
+// grows exponentially up to maxReconnectBackoff.
+func nextBackoff(backoff time.Duration) time.Duration {
+	return min(time.Duration(float64(backoff)*math.Sqrt2), maxReconnectBackoff)
+}
+
+// withJitter returns a random delay between half of and the full backoff, so that many clients disconnected by the same
+// server restart do not all reconnect at the same moment.
+func withJitter(backoff time.Duration) time.Duration {
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+	return half + rand.N(backoff-half+1)
+}
+
 // reconnect closes the existing connections and attempts to reconnect to the
-// SRS server. It will retry until successful or the context is canceled.
+// SRS server. It will retry with exponential backoff and jitter until successful, the context is canceled or the client
+// is closed.
 func (c *client) reconnect(ctx context.Context) error {
 	var err error
 	backoff := frameLength
@@ -56,25 +106,29 @@ func (c *client) reconnect(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			log.Info().Msg("attempting to reconnect to SRS server")
-			_ = c.tcpConnection.Close()
+			log.Warn().Msg("attempting to reconnect to SRS server")
+			_ = c.tcp().Close()
 			err = c.connectTCP()
 			if err == nil {
 				log.Info().Msg("successfully reconnected to SRS server over TCP")
-				_ = c.udpConnection.Close()
+				_ = c.udp().Close()
 				err = c.connectUDP()
 				if err == nil {
 					log.Info().Msg("successfully reconnected to SRS server over UDP")
 					return nil
 				}
 			}
-			time.Sleep(backoff)
-			backoff = time.Duration(float64(backoff) * math.Sqrt2)
-			if backoff > time.Minute {
-				backoff = time.Minute
+			if errors.Is(err, errClosed) {
+				return err
 			}
-
-			log.Error().Err(err).Stringer("retryIn", backoff).Msg("failed to reconnect to SRS server, retrying")
+			delay := withJitter(backoff)
+			log.Warn().Err(err).Stringer("retryIn", delay).Msg("failed to reconnect to SRS server, retrying")
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			backoff = nextBackoff(backoff)
 		}
 	}
 }
@@ -88,8 +142,11 @@ func (c *client) receiveUDP(ctx context.Context, pingChan chan<- []byte, voiceCh
 			return
 		default:
 			buf := make([]byte, 1500)
-			n, err := c.udpConnection.Read(buf)
+			n, err := c.udp().Read(buf)
 			switch {
+			case errors.Is(err, net.ErrClosed) && c.closing.Load():
+				log.Info().Msg("stopping SRS packet receiver because the client was closed")
+				return
 			case errors.Is(err, net.ErrClosed):
 				if ctx.Err() == nil {
 					log.Error().Err(err).Msg("UDP connection closed")
@@ -102,6 +159,7 @@ func (c *client) receiveUDP(ctx context.Context, pingChan chan<- []byte, voiceCh
 			case n == 0:
 				log.Warn().Err(err).Msg("0 bytes read from UDP connection")
 			default:
+				c.markReceived()
 				packet := make([]byte, n)
 				copy(packet, buf)
 				switch {
@@ -121,7 +179,8 @@ func (c *client) receiveUDP(ctx context.Context, pingChan chan<- []byte, voiceCh
 
 // receiveTCP listens for incoming TCP messages and routes them to the appropriate handler.
 func (c *client) receiveTCP(ctx context.Context) {
-	reader := bufio.NewReader(c.tcpConnection)
+	connection := c.tcp()
+	reader := bufio.NewReader(connection)
 	for {
 		select {
 		case <-ctx.Done():
@@ -133,13 +192,26 @@ func (c *client) receiveTCP(ctx context.Context) {
 				if errors.Is(err, net.ErrClosed) && ctx.Err() != nil {
 					continue
 				}
+				if errors.Is(err, net.ErrClosed) && c.closing.Load() {
+					log.Info().Msg("stopping SRS client because it was closed")
+					return
+				}
 				log.Error().Err(err).Msg("error reading from SRS server TCP socket")
-				// Wait and try again in case it recovers by reconnecting
-				time.Sleep(pingInterval)
-				reader = bufio.NewReader(c.tcpConnection)
+				// A closed connection is expected while reconnecting. Any other error means the server has gone away.
+				if !errors.Is(err, net.ErrClosed) {
+					c.markDead()
+				}
+				// Resume reading as soon as the client reconnects, so that responses to the messages sent while
+				// reinitializing the client are not missed.
+				var ok bool
+				if connection, ok = c.awaitTCPReconnect(ctx, connection); !ok {
+					return
+				}
+				reader = bufio.NewReader(connection)
 				continue
 			}
 
+			c.markReceived()
 			var message types.Message
 			jsonErr := json.Unmarshal(line, &message)
 			if jsonErr != nil {
@@ -150,3 +222,21 @@ func (c *client) receiveTCP(ctx context.Context) {
 		}
 	}
 }
+
+// awaitTCPReconnect blocks until the client replaces the given TCP connection, and returns the new connection. It
+// returns false if the context is canceled or the client is closed first.
+func (c *client) awaitTCPReconnect(ctx context.Context, previous *net.TCPConn) (*net.TCPConn, bool) {
+	for {
+		if connection := c.tcp(); connection != previous {
+			return connection, true
+		}
+		if c.closed.Load() {
+			return nil, false
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(connectionPollInterval):
+		}
+	}
+}
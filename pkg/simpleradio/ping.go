@@ -10,14 +10,14 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// pingInterval is how often SRS pings should be sent.
-const pingInterval = 15 * time.Second
+// defaultPingInterval is how often SRS pings are sent if not otherwise configured.
+const defaultPingInterval = 15 * time.Second
 
 // sendPings pings the SRS server at regular intervals.
 func (c *client) sendPings(ctx context.Context) {
-	log.Info().Stringer("interval", pingInterval).Msg("starting pings")
+	log.Info().Stringer("interval", c.pingInterval).Msg("starting pings")
 	c.SendPing()
-	ticker := time.NewTicker(pingInterval)
+	ticker := time.NewTicker(c.pingInterval)
 	for {
 		select {
 		case <-ticker.C:
@@ -38,7 +38,7 @@ func (c *client) SendPing() {
 		logger.Error().Err(err).Msg("error sending TCP ping")
 	}
 
-	_, err := c.udpConnection.Write([]byte(guid))
+	_, err := c.udp().Write([]byte(guid))
 	if errors.Is(err, net.ErrClosed) {
 		logger.Warn().Msg("ping skipped due to closed connection")
 	} else if err != nil {
@@ -63,6 +63,7 @@ func (c *client) receivePings(ctx context.Context, in <-chan []byte) {
 					c.lastPingLock.Lock()
 					defer c.lastPingLock.Unlock()
 					c.lastPing = t
+					c.setStatus(StatusConnected)
 				}()
 			}
 		case <-ctx.Done():
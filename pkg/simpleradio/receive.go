@@ -2,9 +2,12 @@ package simpleradio
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/metrics"
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
 	"github.com/rs/zerolog/log"
@@ -14,15 +17,65 @@ import (
 type receiver struct {
 	// lock protects the receiver's state.
 	lock sync.RWMutex
-	// buffer of received voice packets.
+	// buffer of received voice packets, in order.
 	buffer []voice.VoicePacket
+	// jitter reorders packets before they are added to the buffer.
+	jitter jitterBuffer
 	// origin is the GUID of a client we are currently listening to. We can only listen to one client at a time, and whoever started broadcasting first wins.
 	origin types.GUID
 	// deadline is extended every time another voice packet is received. When we pass the deadline, the transmission is considered over.
 	deadline time.Time
-	// packetNumber is the number of the last received voice packet. We only record a packet if its packet number is larger than the last received packet's, and skip any that were dropped or delivered out of order.
-	// If we were more ambitious we would reassemble the packets and use Opus's forward error correction to recover from lost packets... too bad!
-	packetNumber uint64
+	// silence is how long after the last packet the transmission is considered over. If zero,
+	// defaultEndOfTransmissionSilence is used.
+	silence time.Duration
+	// maxLength is the length of buffered audio after which the transmission is considered over even if packets are
+	// still being received. If zero, defaultMaxTransmissionLength is used.
+	maxLength time.Duration
+}
+
+// newReceiver creates a receiver which waits up to jitterBufferDepth for packets delivered out of order, and which
+// considers a transmission over after the given duration of silence or once the given length of audio is buffered.
+func newReceiver(jitterBufferDepth, silence, maxLength time.Duration) *receiver {
+	return &receiver{
+		jitter:    newJitterBuffer(jitterBufferDepth),
+		silence:   silence,
+		maxLength: maxLength,
+	}
+}
+
+// clone returns a new, idle receiver with the same settings as this receiver.
+func (r *receiver) clone() *receiver {
+	return &receiver{
+		jitter:    newJitterBuffer(time.Duration(r.jitter.depth) * frameLength),
+		silence:   r.silence,
+		maxLength: r.maxLength,
+	}
+}
+
+// endOfTransmissionSilence returns how long after the last packet the transmission is considered over.
+func (r *receiver) endOfTransmissionSilence() time.Duration {
+	if r.silence <= 0 {
+		return defaultEndOfTransmissionSilence
+	}
+	return r.silence
+}
+
+// maxTransmissionLength returns the length of buffered audio after which the transmission is considered over.
+func (r *receiver) maxTransmissionLength() time.Duration {
+	if r.maxLength <= 0 {
+		return defaultMaxTransmissionLength
+	}
+	return r.maxLength
+}
+
+// receivedTransmission is a complete transmission received on one of the client's radios.
+type receivedTransmission struct {
+	// radio the transmission arrived on.
+	radio types.Radio
+	// transmitter is the client which sent the transmission.
+	transmitter TransmitterInfo
+	// packets of the transmission, in order.
+	packets []voice.VoicePacket
 }
 
 // Receive implements [Client.Receive].
@@ -31,16 +84,22 @@ func (c *client) Receive() <-chan Transmission {
 }
 
 // receive checks if the given packet is part of a new transmission or matches a transmission in progress.
-// If either case is true, the packet is buffered into the receiver.
+// If either case is true, the packet is passed through the jitter buffer into the receiver's buffer. Duplicate packets
+// and packets which arrive too late to be put in order are dropped.
 func (r *receiver) receive(packet *voice.VoicePacket) {
-	// Accept the packet if it is either:
-	// - the first packet of a new transmission
-	isNewTransmission := r.origin == "" && r.packetNumber == 0
-	// - a newer packet from the same origin
-	isNewerPacket := packet.PacketID > r.packetNumber
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Accept the packet if it is either the first packet of a new transmission or a packet from the same origin.
+	isNewTransmission := r.origin == ""
 	isSameOrigin := r.origin == types.GUID(packet.OriginGUID)
-	shouldAcceptPacket := isNewTransmission || (isNewerPacket && isSameOrigin)
-	if !shouldAcceptPacket {
+	if !isNewTransmission && !isSameOrigin {
+		return
+	}
+
+	released, ok := r.jitter.push(*packet)
+	if !ok {
+		log.Debug().Str("origin", string(packet.OriginGUID)).Uint64("packetID", packet.PacketID).Msg("dropping duplicate or late voice packet")
 		return
 	}
 
@@ -48,28 +107,44 @@ func (r *receiver) receive(packet *voice.VoicePacket) {
 		log.Info().Str("origin", string(packet.OriginGUID)).Msg("receiving transmission")
 	}
 
+	r.buffer = append(r.buffer, released...)
+	r.origin = types.GUID(packet.OriginGUID)
+	r.deadline = time.Now().Add(r.endOfTransmissionSilence())
+}
+
+// packets flushes the jitter buffer and returns a copy of the buffered packets, in order.
+func (r *receiver) packets() []voice.VoicePacket {
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	r.buffer = append(r.buffer, *packet)
-	r.origin = types.GUID(packet.OriginGUID)
-	r.deadline = time.Now().Add(maxRxGap)
-	r.packetNumber = packet.PacketID
+	r.buffer = append(r.buffer, r.jitter.flush()...)
+	return slices.Clone(r.buffer)
+}
+
+// transmissionOrigin returns the GUID of the client whose transmission is buffered.
+func (r *receiver) transmissionOrigin() types.GUID {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.origin
 }
 
-// hasTransmission checks if the receiver has a complete transmission buffered.
+// hasTransmission checks if the receiver has a complete transmission buffered. A transmission is complete once no
+// packets have been received for the end of transmission silence, or once the maximum transmission length is buffered.
 func (r *receiver) hasTransmission() bool {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
-	hasPackets := len(r.buffer) > 0
-	isComplete := time.Now().After(r.deadline)
-	return hasPackets && isComplete
+	count := len(r.buffer) + len(r.jitter.pending)
+	hasPackets := count > 0
+	isSilent := time.Now().After(r.deadline)
+	isTooLong := time.Duration(count)*frameLength >= r.maxTransmissionLength()
+	return hasPackets && (isSilent || isTooLong)
 }
 
-// isReceivingTransmission checks if the receiver is currently buffering an in-progress transmission.
-func (r *receiver) isReceivingTransmission() bool {
+// endOfTransmission returns when the incoming transmission ends if no more packets are received. It is in the past if
+// the receiver is not receiving a transmission.
+func (r *receiver) endOfTransmission() time.Time {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
-	return r.deadline.After(time.Now())
+	return r.deadline
 }
 
 // reset clears the receiver's buffer.
@@ -79,72 +154,162 @@ func (r *receiver) reset() {
 	r.buffer = make([]voice.VoicePacket, 0)
 	r.origin = ""
 	r.deadline = time.Time{}
-	r.packetNumber = 0
+	r.jitter = jitterBuffer{depth: r.jitter.depth}
 }
 
-// maxRxGap is a duration after which the receiver will assume the end of a transmission if no packets are received.
-const maxRxGap = 300 * time.Millisecond
+// defaultEndOfTransmissionSilence is the default duration after which the receiver will assume the end of a
+// transmission if no packets are received. Shorter durations cut off pilots who pause mid-sentence; longer durations
+// delay responses.
+const defaultEndOfTransmissionSilence = 500 * time.Millisecond
+
+// defaultMaxTransmissionLength is the default length of audio after which a transmission is passed to speech
+// recognition even if the transmitter is still talking, e.g. because of a stuck microphone.
+const defaultMaxTransmissionLength = 15 * time.Second
 
 // minRxDuration is the mimimum duration of a transmission to be considered for speech recognition. This reduces
 // thrashing due to transmissions too short to contain any useful content.
 const minRxDuration = 1 * time.Second // 1s is whisper.cpp's minimum duration, it errors for any samples shorter than this.
 
+// isFromAllowedCoalition checks if the given packet was sent by a client whose transmissions this client should receive.
+// Packets from the opposing coalition are always rejected, and packets from spectators are rejected unless spectators
+// are allowed. If the server enforces secure coalition radios, packets from other coalitions are rejected. If a
+// coalition filter is configured, packets from clients outside that coalition are rejected.
+//
+// A packet from a client which is not in the SRS client list, e.g. because the client connected after the last sync,
+// cannot be checked. It is accepted unless the server enforces secure coalition radios or a coalition filter is
+// configured.
+func (c *client) isFromAllowedCoalition(packet *voice.VoicePacket) bool {
+	logger := log.With().Str("GUID", string(packet.OriginGUID)).Logger()
+	isStrict := c.secureCoalitionRadios || c.coalitionFilter != 0
+	peer, ok := c.peerInfo(types.GUID(packet.OriginGUID))
+	if !ok {
+		if isStrict {
+			logger.Warn().Msg("ignoring voice packet from unknown client")
+			c.countDroppedPacket(metrics.DropUnknownClient)
+			return false
+		}
+		return true
+	}
+	coalition := peer.Coalition
+	logger = logger.With().Stringer("coalition", coalition).Logger()
+	ownCoalition := c.coalition()
+	if opposing, err := coalitions.Opposing(ownCoalition); err == nil && coalition == opposing {
+		logger.Trace().Msg("ignoring voice packet from opposing coalition")
+		c.countDroppedPacket(metrics.DropOpposingCoalition)
+		return false
+	}
+	if types.IsSpectator(coalition) && !c.allowSpectators {
+		logger.Trace().Msg("ignoring voice packet from spectator")
+		c.countDroppedPacket(metrics.DropSpectator)
+		return false
+	}
+	if c.secureCoalitionRadios && coalition != ownCoalition {
+		logger.Trace().Msg("ignoring voice packet from different coalition")
+		c.countDroppedPacket(metrics.DropFilteredCoalition)
+		return false
+	}
+	if c.coalitionFilter != 0 && coalition != c.coalitionFilter {
+		logger.Trace().Msg("ignoring voice packet from filtered coalition")
+		c.countDroppedPacket(metrics.DropFilteredCoalition)
+		return false
+	}
+	return true
+}
+
+// countDroppedPacket increments the dropped packet counter for the given reason.
+func (c *client) countDroppedPacket(reason string) {
+	if c.droppedPackets != nil {
+		c.droppedPackets.WithLabelValues(reason).Inc()
+	}
+}
+
+// isFromRadioCoalition checks if the given packet was sent by a client whose transmissions should be received on the
+// given radio. If the radio has no coalition, packets from any coalition are accepted.
+func (c *client) isFromRadioCoalition(packet *voice.VoicePacket, radio types.Radio) bool {
+	if radio.Coalition == 0 {
+		return true
+	}
+	peer, ok := c.peerInfo(types.GUID(packet.OriginGUID))
+	return ok && peer.Coalition == radio.Coalition
+}
+
+// radioForFrequency returns a radio tuned to the given voice packet frequency, for comparison with the client's radios.
+func radioForFrequency(frequency voice.Frequency) types.Radio {
+	return types.Radio{
+		Frequency:   frequency.Frequency,
+		Modulation:  types.Modulation(frequency.Modulation),
+		IsEncrypted: frequency.Encryption != 0,
+	}
+}
+
+// routePacket passes a voice packet to the receiver of each radio tuned to one of the packet's frequencies.
+func (c *client) routePacket(packet *voice.VoicePacket) {
+	// Hold the lock while routing, so that a packet is never routed to a radio after it is retuned.
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
+	for radio, receiver := range c.receivers {
+		if !c.isFromRadioCoalition(packet, radio) {
+			continue
+		}
+		for _, frequency := range packet.Frequencies {
+			if radioForFrequency(frequency).IsSameFrequency(radio) {
+				receiver.receive(packet)
+			}
+		}
+	}
+}
+
+// completedTransmissions returns each transmission which has ended, and resets the receivers they arrived on.
+// Transmissions below the minimum duration are discarded.
+func (c *client) completedTransmissions() []receivedTransmission {
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
+	var transmissions []receivedTransmission
+	for radio, receiver := range c.receivers {
+		if receiver.hasTransmission() {
+			packets := receiver.packets()
+			duration := time.Duration(len(packets)) * frameLength
+			logger := log.With().Stringer("duration", duration).Float64("frequency", radio.Frequency).Logger()
+			if duration > minRxDuration {
+				transmitter := c.transmitterInfo(receiver.transmissionOrigin())
+				logger.Info().Str("clientName", transmitter.Name).Str("GUID", string(transmitter.GUID)).Msg("received transmission")
+				transmissions = append(transmissions, receivedTransmission{radio: radio, transmitter: transmitter, packets: packets})
+			} else {
+				logger.Info().Msg("discarding transmission below minimum size")
+			}
+			receiver.reset()
+		}
+	}
+	return transmissions
+}
+
 // receiveVoice listens for incoming UDP voice packets, decodes them into VoicePacket structs, and routes them to the out channel for audio decoding.
-func (c *client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<- []voice.VoicePacket) {
+func (c *client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<- receivedTransmission) {
 	// t is a ticker which triggers the check for the end of a transmission.
 	t := time.NewTicker(frameLength)
 	for {
 		select {
 		case b := <-in:
+			if err := ValidatePacket(b); err != nil {
+				log.Debug().Err(err).Int("bytes", len(b)).Msg("discarding invalid voice packet")
+				continue
+			}
 			packet, err := voice.Decode(b)
 			if err != nil {
 				log.Debug().Err(err).Msg("failed to decode voice packet")
 				continue
 			}
 
-			logger := log.With().Str("GUID", string(packet.OriginGUID)).Logger()
-
-			if c.secureCoalitionRadios {
-				client, ok := c.clients[types.GUID(packet.OriginGUID)]
-				if !ok {
-					logger.Warn().Msg("ignoring voice packet from unknown client")
-					continue
-				}
-				if client.Coalition != c.clientInfo.Coalition {
-					logger.Trace().Msg("ignoring voice packet from different coalition")
-					continue
-				}
+			if !c.isFromAllowedCoalition(packet) {
+				continue
 			}
 
-			for radio, receiver := range c.receivers {
-				for _, frequency := range packet.Frequencies {
-					testRadio := types.Radio{
-						Frequency:   frequency.Frequency,
-						Modulation:  types.Modulation(frequency.Modulation),
-						IsEncrypted: frequency.Encryption != 0,
-					}
-					if testRadio.IsSameFrequency(radio) {
-						receiver.receive(packet)
-					}
-				}
-			}
+			c.routePacket(packet)
 		case <-t.C:
 			// Check if everyone has stopped talking.
 			if len(in) == 0 {
-				for _, receiver := range c.receivers {
-					if receiver.hasTransmission() {
-						duration := time.Duration(len(receiver.buffer)) * frameLength
-						logger := log.With().Stringer("duration", duration).Logger()
-						if duration > minRxDuration {
-							logger.Info().Msg("received transmission")
-							audio := make([]voice.VoicePacket, len(receiver.buffer))
-							copy(audio, receiver.buffer)
-							out <- audio
-						} else {
-							logger.Info().Msg("discarding transmission below minimum size")
-						}
-						receiver.reset()
-					}
+				for _, transmission := range c.completedTransmissions() {
+					out <- transmission
 				}
 			}
 		case <-ctx.Done():
@@ -0,0 +1,435 @@
+package simpleradio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/metrics"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsFromAllowedCoalition(t *testing.T) {
+	t.Parallel()
+	clients := map[types.GUID]types.ClientInfo{
+		"redredredredredredred1": {Name: "red", Coalition: coalitions.Red},
+		"blueblueblueblueblue01": {Name: "blue", Coalition: coalitions.Blue},
+		"spectatorspectator0001": {Name: "spectator", Coalition: coalitions.Neutrals},
+		"unusedcoalitionvalue01": {Name: "zero", Coalition: 0},
+		"negativecoalitionval01": {Name: "negative", Coalition: -1},
+	}
+	testCases := []struct {
+		name                  string
+		origin                types.GUID
+		secureCoalitionRadios bool
+		filter                coalitions.Coalition
+		allowSpectators       bool
+		expected              bool
+		reason                string
+	}{
+		{"no filter red", "redredredredredredred1", false, 0, false, false, metrics.DropOpposingCoalition},
+		{"no filter red allowing spectators", "redredredredredredred1", false, 0, true, false, metrics.DropOpposingCoalition},
+		{"no filter blue", "blueblueblueblueblue01", false, 0, false, true, ""},
+		{"no filter spectator", "spectatorspectator0001", false, 0, false, false, metrics.DropSpectator},
+		{"no filter zero", "unusedcoalitionvalue01", false, 0, false, false, metrics.DropSpectator},
+		{"no filter negative", "negativecoalitionval01", false, 0, false, false, metrics.DropSpectator},
+		{"no filter unknown", "unknownunknownunknown1", false, 0, false, true, ""},
+		{"allow spectators spectator", "spectatorspectator0001", false, 0, true, true, ""},
+		{"allow spectators negative", "negativecoalitionval01", false, 0, true, true, ""},
+		{"blue filter red", "redredredredredredred1", false, coalitions.Blue, false, false, metrics.DropOpposingCoalition},
+		{"blue filter blue", "blueblueblueblueblue01", false, coalitions.Blue, false, true, ""},
+		{"blue filter spectator", "spectatorspectator0001", false, coalitions.Blue, false, false, metrics.DropSpectator},
+		{"blue filter allowed spectator", "spectatorspectator0001", false, coalitions.Blue, true, false, metrics.DropFilteredCoalition},
+		{"blue filter zero", "unusedcoalitionvalue01", false, coalitions.Blue, false, false, metrics.DropSpectator},
+		{"blue filter unknown", "unknownunknownunknown1", false, coalitions.Blue, false, false, metrics.DropUnknownClient},
+		{"red filter red", "redredredredredredred1", false, coalitions.Red, false, false, metrics.DropOpposingCoalition},
+		{"red filter blue", "blueblueblueblueblue01", false, coalitions.Red, false, false, metrics.DropFilteredCoalition},
+		{"secure radios red", "redredredredredredred1", true, 0, false, false, metrics.DropOpposingCoalition},
+		{"secure radios blue", "blueblueblueblueblue01", true, 0, false, true, ""},
+		{"secure radios spectator", "spectatorspectator0001", true, 0, false, false, metrics.DropSpectator},
+		{"secure radios allowed spectator", "spectatorspectator0001", true, 0, true, false, metrics.DropFilteredCoalition},
+		{"secure radios unknown", "unknownunknownunknown1", true, 0, false, false, metrics.DropUnknownClient},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dropped := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "dropped"}, []string{"reason"})
+			c := &client{
+				clientInfo:            types.ClientInfo{Coalition: coalitions.Blue},
+				roster:                clients,
+				secureCoalitionRadios: test.secureCoalitionRadios,
+				coalitionFilter:       test.filter,
+				allowSpectators:       test.allowSpectators,
+				droppedPackets:        dropped,
+			}
+			packet := &voice.VoicePacket{OriginGUID: []byte(test.origin)}
+			assert.Equal(t, test.expected, c.isFromAllowedCoalition(packet))
+			for _, reason := range []string{
+				metrics.DropOpposingCoalition,
+				metrics.DropSpectator,
+				metrics.DropFilteredCoalition,
+				metrics.DropUnknownClient,
+			} {
+				expected := 0.0
+				if reason == test.reason {
+					expected = 1
+				}
+				assert.InDelta(t, expected, testutil.ToFloat64(dropped.WithLabelValues(reason)), 0, reason)
+			}
+		})
+	}
+}
+
+func TestReceiveTagsRadio(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	strike := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	// The fighter frequency is reserved for Blue.
+	fighter := types.Radio{Frequency: 133000000, Modulation: types.ModulationAM, Coalition: coalitions.Blue}
+	red := types.GUID("redredredredredredred1")
+	blue := types.GUID("blueblueblueblueblue01")
+	c := &client{
+		udpConnection: server.dial(t),
+		clients: map[types.GUID]types.ClientInfo{
+			red:  {Name: "red", Coalition: coalitions.Red},
+			blue: {Name: "blue", Coalition: coalitions.Blue},
+		},
+		receivers: map[types.Radio]*receiver{
+			strike:  {},
+			fighter: {},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pingChan := make(chan []byte, 0xF)
+	voiceChan := make(chan []byte, 0xFFF)
+	out := make(chan receivedTransmission, 2)
+	go c.receiveUDP(ctx, pingChan, voiceChan)
+	go c.receiveVoice(ctx, voiceChan, out)
+
+	// Register the client's address with the server.
+	_, err := c.udpConnection.Write([]byte(red))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		server.lock.Lock()
+		defer server.lock.Unlock()
+		return server.client != nil
+	}, time.Second, 10*time.Millisecond)
+
+	packet := func(radio types.Radio, origin types.GUID, packetID uint64) voice.VoicePacket {
+		frequency := voice.Frequency{Frequency: radio.Frequency, Modulation: byte(radio.Modulation)}
+		return voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{frequency}, 1, packetID, 0, []byte(origin), []byte(origin))
+	}
+	// Red transmits on both frequencies while Blue transmits on the fighter frequency.
+	for i := range uint64(30) {
+		server.send(t, packet(fighter, red, i+1))
+		server.send(t, packet(strike, red, i+1))
+		server.send(t, packet(fighter, blue, i+1))
+		time.Sleep(frameLength)
+	}
+
+	received := make(map[float64]receivedTransmission)
+	for range 2 {
+		select {
+		case transmission := <-out:
+			received[transmission.radio.Frequency] = transmission
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for transmissions")
+		}
+	}
+
+	require.Contains(t, received, strike.Frequency)
+	assert.Equal(t, strike, received[strike.Frequency].radio)
+	assert.Len(t, received[strike.Frequency].packets, 30)
+	for _, p := range received[strike.Frequency].packets {
+		assert.Equal(t, red, types.GUID(p.OriginGUID))
+	}
+
+	require.Contains(t, received, fighter.Frequency)
+	assert.Equal(t, fighter, received[fighter.Frequency].radio)
+	assert.Len(t, received[fighter.Frequency].packets, 30)
+	for _, p := range received[fighter.Frequency].packets {
+		assert.Equal(t, blue, types.GUID(p.OriginGUID))
+	}
+}
+
+func TestGuardAppliesCoalitionFilter(t *testing.T) {
+	t.Parallel()
+	guard := types.Radio{Frequency: GuardUHF.Frequency.Hertz(), Modulation: GuardUHF.Modulation}
+	blue := types.GUID("blueblueblueblueblue01")
+	spectator := types.GUID("spectatorspectator0001")
+	c := &client{
+		clientInfo:      types.ClientInfo{Coalition: coalitions.Blue},
+		coalitionFilter: coalitions.Blue,
+		allowSpectators: true,
+		roster: map[types.GUID]types.ClientInfo{
+			blue:      {Name: "blue", Coalition: coalitions.Blue},
+			spectator: {Name: "spectator", Coalition: coalitions.Neutrals},
+		},
+		receivers: map[types.Radio]*receiver{guard: {}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan []byte, 0xFF)
+	out := make(chan receivedTransmission, 2)
+	go c.receiveVoice(ctx, in, out)
+
+	// The spectator starts transmitting on guard first, so the receiver would lock on to it if it were not filtered.
+	frequency := GuardUHF.voiceFrequency()
+	for i := range uint64(60) {
+		origin := []byte(spectator)
+		if i%2 == 1 {
+			origin = []byte(blue)
+		}
+		p := voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{frequency}, 1, i/2+1, 0, origin, origin)
+		in <- p.Encode()
+	}
+
+	select {
+	case transmission := <-out:
+		assert.Equal(t, guard, transmission.radio)
+		assert.Len(t, transmission.packets, 30)
+		for _, p := range transmission.packets {
+			assert.Equal(t, blue, types.GUID(p.OriginGUID))
+		}
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for transmission")
+	}
+}
+
+func TestRadioFrequency(t *testing.T) {
+	t.Parallel()
+	radio := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM, Coalition: coalitions.Blue}
+	frequency := radioFrequency(radio)
+	assert.InDelta(t, 251, frequency.Frequency.Megahertz(), 0.001)
+	assert.Equal(t, types.ModulationAM, frequency.Modulation)
+}
+
+func TestReceiveFiltersModulation(t *testing.T) {
+	t.Parallel()
+	fm := types.Radio{Frequency: 30000000, Modulation: types.ModulationFM}
+	c := &client{
+		receivers: map[types.Radio]*receiver{fm: {}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan []byte, 0xFF)
+	out := make(chan receivedTransmission, 1)
+	go c.receiveVoice(ctx, in, out)
+
+	packet := func(modulation types.Modulation, origin types.GUID, packetID uint64) []byte {
+		frequency := voice.Frequency{Frequency: 30000000, Modulation: byte(modulation)}
+		p := voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{frequency}, 1, packetID, 0, []byte(origin), []byte(origin))
+		return p.Encode()
+	}
+	am := types.GUID("amamamamamamamamamama1")
+	fmOrigin := types.GUID("fmfmfmfmfmfmfmfmfmfmf1")
+	// The AM transmission starts first, so it would be received if modulation were ignored.
+	for i := range uint64(30) {
+		in <- packet(types.ModulationAM, am, i+1)
+		in <- packet(types.ModulationFM, fmOrigin, i+1)
+	}
+
+	select {
+	case transmission := <-out:
+		assert.Equal(t, fm, transmission.radio)
+		require.Len(t, transmission.packets, 30)
+		for _, p := range transmission.packets {
+			assert.Equal(t, fmOrigin, types.GUID(p.OriginGUID))
+			require.Len(t, p.Frequencies, 1)
+			assert.Equal(t, byte(types.ModulationFM), p.Frequencies[0].Modulation)
+		}
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for transmission")
+	}
+}
+
+func TestReceiveAttributesTransmitter(t *testing.T) {
+	t.Parallel()
+	strike := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	fighter := types.Radio{Frequency: 133000000, Modulation: types.ModulationAM}
+	mobius := types.ClientInfo{
+		GUID:      "mobiusmobiusmobiusmob1",
+		Name:      "Mobius 1",
+		Coalition: coalitions.Blue,
+		Seat:      1,
+		RadioInfo: types.RadioInfo{Unit: "F-22A", UnitID: 16777472},
+	}
+	garuda := types.ClientInfo{
+		GUID:      "garudagarudagarudagar1",
+		Name:      "Garuda 1",
+		Coalition: coalitions.Blue,
+		RadioInfo: types.RadioInfo{Unit: "F-15E", UnitID: 16777728},
+	}
+	unknown := types.GUID("unknownunknownunknown1")
+	c := &client{
+		roster: map[types.GUID]types.ClientInfo{
+			mobius.GUID: mobius,
+			garuda.GUID: garuda,
+		},
+		receivers: map[types.Radio]*receiver{
+			strike:  {},
+			fighter: {},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan []byte, 0xFF)
+	out := make(chan receivedTransmission, 2)
+	go c.receiveVoice(ctx, in, out)
+
+	packet := func(radio types.Radio, origin types.GUID, packetID uint64) []byte {
+		frequency := voice.Frequency{Frequency: radio.Frequency, Modulation: byte(radio.Modulation)}
+		p := voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{frequency}, 1, packetID, 0, []byte(origin), []byte(origin))
+		return p.Encode()
+	}
+	// Mobius 1 transmits on strike and Garuda 1 on fighter, while an unlisted client steps on Garuda 1.
+	for i := range uint64(30) {
+		in <- packet(strike, mobius.GUID, i+1)
+		in <- packet(fighter, garuda.GUID, i+1)
+		in <- packet(fighter, unknown, i+1)
+	}
+
+	received := make(map[float64]receivedTransmission)
+	for range 2 {
+		select {
+		case transmission := <-out:
+			received[transmission.radio.Frequency] = transmission
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for transmissions")
+		}
+	}
+
+	require.Contains(t, received, strike.Frequency)
+	assert.Equal(t, TransmitterInfo{
+		GUID:      mobius.GUID,
+		Name:      "Mobius 1",
+		Coalition: coalitions.Blue,
+		Seat:      1,
+		Unit:      "F-22A",
+		UnitID:    16777472,
+	}, received[strike.Frequency].transmitter)
+
+	require.Contains(t, received, fighter.Frequency)
+	assert.Equal(t, garuda.GUID, received[fighter.Frequency].transmitter.GUID)
+	assert.Equal(t, "Garuda 1", received[fighter.Frequency].transmitter.Name)
+	assert.Equal(t, "F-15E", received[fighter.Frequency].transmitter.Unit)
+	for _, p := range received[fighter.Frequency].packets {
+		assert.Equal(t, garuda.GUID, types.GUID(p.OriginGUID))
+	}
+}
+
+func TestTransmitterInfoUnknownClient(t *testing.T) {
+	t.Parallel()
+	c := &client{}
+	guid := types.GUID("unknownunknownunknown1")
+	assert.Equal(t, TransmitterInfo{GUID: guid}, c.transmitterInfo(guid))
+}
+
+func TestEndOfTransmission(t *testing.T) {
+	t.Parallel()
+	const silence = 150 * time.Millisecond
+	testCases := []struct {
+		name     string
+		pause    time.Duration
+		expected []int
+	}{
+		{name: "pause below threshold", pause: silence / 5, expected: []int{60}},
+		{name: "pause above threshold", pause: 3 * silence, expected: []int{30, 30}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			radio := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+			c := &client{
+				receivers: map[types.Radio]*receiver{radio: newReceiver(0, silence, 0)},
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			in := make(chan []byte, 0xFF)
+			out := make(chan receivedTransmission, 2)
+			go c.receiveVoice(ctx, in, out)
+
+			origin := []byte("mobiusmobiusmobiusmob1")
+			frequency := voice.Frequency{Frequency: radio.Frequency, Modulation: byte(radio.Modulation)}
+			send := func(first, last uint64) {
+				for id := first; id <= last; id++ {
+					p := voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{frequency}, 1, id, 0, origin, origin)
+					in <- p.Encode()
+				}
+			}
+			// The pilot says 30 frames, pauses, then says another 30 frames.
+			send(1, 30)
+			time.Sleep(test.pause)
+			send(31, 60)
+
+			for _, expected := range test.expected {
+				select {
+				case transmission := <-out:
+					assert.Len(t, transmission.packets, expected)
+				case <-time.After(5 * time.Second):
+					require.FailNow(t, "timed out waiting for transmission")
+				}
+			}
+			select {
+			case transmission := <-out:
+				assert.Failf(t, "unexpected transmission", "received %d packets", len(transmission.packets))
+			case <-time.After(3 * silence):
+			}
+		})
+	}
+}
+
+func TestMaxTransmissionLength(t *testing.T) {
+	t.Parallel()
+	r := newReceiver(0, time.Minute, 10*frameLength)
+	origin := []byte("mobiusmobiusmobiusmob1")
+	for id := range uint64(9) {
+		packet := voice.VoicePacket{PacketID: id + 1, OriginGUID: origin}
+		r.receive(&packet)
+	}
+	assert.False(t, r.hasTransmission(), "a transmission shorter than the maximum length should wait for silence")
+	packet := voice.VoicePacket{PacketID: 10, OriginGUID: origin}
+	r.receive(&packet)
+	assert.True(t, r.hasTransmission(), "a transmission should be complete once the maximum length is buffered")
+}
+
+func TestReceiverDefaults(t *testing.T) {
+	t.Parallel()
+	r := &receiver{}
+	assert.Equal(t, defaultEndOfTransmissionSilence, r.endOfTransmissionSilence())
+	assert.Equal(t, defaultMaxTransmissionLength, r.maxTransmissionLength())
+}
+
+func TestReceiveAMIgnoresFM(t *testing.T) {
+	t.Parallel()
+	am := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	c := &client{
+		receivers: map[types.Radio]*receiver{am: {}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan []byte, 0xFF)
+	out := make(chan receivedTransmission, 1)
+	go c.receiveVoice(ctx, in, out)
+
+	origin := []byte("yellowyellowyellowyel1")
+	frequency := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationFM)}
+	for i := range uint64(30) {
+		p := voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{frequency}, 1, i+1, 0, origin, origin)
+		in <- p.Encode()
+	}
+
+	select {
+	case transmission := <-out:
+		assert.Failf(t, "an AM radio received an FM transmission", "received %d packets", len(transmission.packets))
+	case <-time.After(2 * defaultEndOfTransmissionSilence):
+	}
+	assert.False(t, c.receivers[am].hasTransmission())
+}
@@ -0,0 +1,305 @@
+package simpleradio
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/lithammer/shortuuid/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// maxReplayLineSize is the maximum size of a single line in a replay file. This allows for about a minute of audio per line.
+const maxReplayLineSize = 8 * 1024 * 1024
+
+// ReplayRecord is a single pre-recorded transmission in a replay file. Replay files are JSON Lines files containing one
+// record per line, in chronological order.
+type ReplayRecord struct {
+	// Timestamp is when the transmission was received. Only the difference between timestamps matters; the first
+	// transmission in the file is played back immediately.
+	Timestamp time.Time `json:"timestamp"`
+	// ClientName is the name of the SRS client which sent the transmission.
+	ClientName string `json:"clientName"`
+	// Frequency is the frequency the transmission was received on, in the same format as ParseRadioFrequency. If empty,
+	// the transmission is received on every frequency.
+	Frequency string `json:"frequency,omitempty"`
+	// Audio is base64-encoded F32LE PCM audio data.
+	Audio string `json:"audio"`
+}
+
+// decodeAudio decodes the record's audio into F32LE PCM samples.
+func (r ReplayRecord) decodeAudio() (Audio, error) {
+	b, err := base64.StdEncoding.DecodeString(r.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 audio: %w", err)
+	}
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("audio length %d is not a multiple of 4 bytes", len(b))
+	}
+	audio := make(Audio, len(b)/4)
+	for i := range audio {
+		audio[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[i*4:]))
+	}
+	return audio, nil
+}
+
+// EncodeReplayAudio encodes F32LE PCM audio for a [ReplayRecord].
+func EncodeReplayAudio(audio Audio) string {
+	b := make([]byte, len(audio)*4)
+	for i, sample := range audio {
+		binary.LittleEndian.PutUint32(b[i*4:], math.Float32bits(sample))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// replayClient implements [Client] by playing back pre-recorded transmissions from a file. It allows testing the GCI
+// without a live SRS server.
+type replayClient struct {
+	// filePath is the path to the replay file.
+	filePath string
+	// speed is a multiplier for the playback speed. 1 is real-time.
+	speed float64
+	// frequencies which the client is "tuned" to. Transmissions on other frequencies are skipped.
+	frequencies []RadioFrequency
+	// frequenciesLock protects frequencies.
+	frequenciesLock sync.RWMutex
+	// rxChan is a channel where replayed transmissions are published.
+	rxChan chan Transmission
+	// status is the current status of the replay.
+	status ConnectionStatus
+	// statusLock protects status.
+	statusLock sync.RWMutex
+	// statusChan is a channel where status changes are published.
+	statusChan chan ConnectionStatus
+}
+
+var _ Client = &replayClient{}
+
+// NewReplayClient creates a [Client] which plays back transmissions from the given replay file instead of connecting to
+// an SRS server. Speed is a playback speed multiplier; for example, 2 plays back twice as fast as real-time.
+// Outgoing transmissions are discarded.
+func NewReplayClient(filePath string, speed float64, frequencies []RadioFrequency) (Client, error) {
+	if speed <= 0 {
+		return nil, fmt.Errorf("replay speed must be positive, got %f", speed)
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %w", err)
+	}
+	return &replayClient{
+		filePath:    filePath,
+		speed:       speed,
+		frequencies: frequencies,
+		rxChan:      make(chan Transmission),
+		statusChan:  make(chan ConnectionStatus, statusChangesCapacity),
+	}, nil
+}
+
+// Run implements [Client.Run]. It returns after all transmissions in the file have been played back.
+func (c *replayClient) Run(ctx context.Context, _ *sync.WaitGroup) error {
+	f, err := os.Open(c.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open replay file: %w", err)
+	}
+	defer f.Close()
+
+	c.setStatus(StatusConnected)
+	defer c.setStatus(StatusDisconnected)
+
+	log.Info().Str("path", c.filePath).Float64("speed", c.speed).Msg("replaying SRS transmissions")
+	if err := c.replay(ctx, f); err != nil {
+		return fmt.Errorf("error replaying transmissions: %w", err)
+	}
+	log.Info().Str("path", c.filePath).Msg("finished replaying SRS transmissions")
+	return nil
+}
+
+// replay reads records from the reader and publishes them to the receiving channel on schedule.
+func (c *replayClient) replay(ctx context.Context, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxReplayLineSize)
+
+	var origin time.Time
+	start := time.Now()
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record ReplayRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("failed to parse line %d: %w", line, err)
+		}
+		if origin.IsZero() {
+			origin = record.Timestamp
+		}
+
+		isGuard := false
+		if record.Frequency != "" {
+			frequency, err := ParseRadioFrequency(record.Frequency)
+			if err != nil {
+				return fmt.Errorf("failed to parse frequency on line %d: %w", line, err)
+			}
+			if !c.isTunedTo(*frequency) {
+				log.Debug().Int("line", line).Stringer("frequency", frequency).Msg("skipping transmission on untuned frequency")
+				continue
+			}
+			isGuard = frequency.IsGuard()
+		}
+
+		audio, err := record.decodeAudio()
+		if err != nil {
+			return fmt.Errorf("failed to decode audio on line %d: %w", line, err)
+		}
+
+		offset := time.Duration(float64(record.Timestamp.Sub(origin)) / c.speed)
+		select {
+		case <-time.After(time.Until(start.Add(offset))):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		log.Info().Str("clientName", record.ClientName).Int("len", len(audio)).Msg("publishing replayed audio to receiving channel")
+		select {
+		case c.rxChan <- Transmission{
+			TraceID:     shortuuid.New(),
+			ClientName:  record.ClientName,
+			Transmitter: TransmitterInfo{Name: record.ClientName},
+			Audio:       audio,
+			Guard:       isGuard,
+		}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return scanner.Err()
+}
+
+// Send implements [Client.Send]. Messages are discarded.
+func (c *replayClient) Send(types.Message) error {
+	return nil
+}
+
+// Receive implements [Client.Receive].
+func (c *replayClient) Receive() <-chan Transmission {
+	return c.rxChan
+}
+
+// Transmit implements [Client.Transmit]. Transmissions are discarded.
+func (c *replayClient) Transmit(transmission Transmission) {
+	log.Info().Str("traceID", transmission.TraceID).Int("len", len(transmission.Audio)).Msg("discarding transmission during replay")
+}
+
+// TransmitAll implements [Client.TransmitAll]. Transmissions are discarded.
+func (c *replayClient) TransmitAll(frequencies []RadioFrequency, transmission Transmission) error {
+	var err error
+	for _, frequency := range frequencies {
+		if !c.isTunedTo(frequency) {
+			err = errors.Join(err, fmt.Errorf("client is not tuned to %s", frequency))
+		}
+	}
+	c.Transmit(transmission)
+	return err
+}
+
+// Frequencies implements [Client.Frequencies].
+func (c *replayClient) Frequencies() []RadioFrequency {
+	c.frequenciesLock.RLock()
+	defer c.frequenciesLock.RUnlock()
+	return c.frequencies
+}
+
+func (c *replayClient) isTunedTo(frequency RadioFrequency) bool {
+	c.frequenciesLock.RLock()
+	defer c.frequenciesLock.RUnlock()
+	for _, f := range c.frequencies {
+		if f.IsSameFrequency(frequency) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientsOnFrequency implements [Client.ClientsOnFrequency]. There are no peers during replay.
+func (c *replayClient) ClientsOnFrequency() int {
+	return 0
+}
+
+// HumansOnFrequency implements [Client.HumansOnFrequency]. There are no peers during replay.
+func (c *replayClient) HumansOnFrequency() int {
+	return 0
+}
+
+// BotsOnFrequency implements [Client.BotsOnFrequency]. There are no peers during replay.
+func (c *replayClient) BotsOnFrequency() int {
+	return 0
+}
+
+// IsOnFrequency implements [Client.IsOnFrequency]. There are no peers during replay.
+func (c *replayClient) IsOnFrequency(string) bool {
+	return false
+}
+
+// Clients implements [Client.Clients]. There are no peers during replay.
+func (c *replayClient) Clients() []types.ClientInfo {
+	return []types.ClientInfo{}
+}
+
+// ClientByGUID implements [Client.ClientByGUID]. There are no peers during replay.
+func (c *replayClient) ClientByGUID(types.GUID) (types.ClientInfo, bool) {
+	return types.ClientInfo{}, false
+}
+
+// HealthStatus implements [Client.HealthStatus]. The status is connected while the replay is running.
+func (c *replayClient) HealthStatus() ConnectionStatus {
+	c.statusLock.RLock()
+	defer c.statusLock.RUnlock()
+	return c.status
+}
+
+func (c *replayClient) setStatus(status ConnectionStatus) {
+	c.statusLock.Lock()
+	defer c.statusLock.Unlock()
+	if c.status != status {
+		publishStatus(c.statusChan, status)
+	}
+	c.status = status
+}
+
+// StatusChanges implements [Client.StatusChanges].
+func (c *replayClient) StatusChanges() <-chan ConnectionStatus {
+	return c.statusChan
+}
+
+// Retune implements [Client.Retune]. Transmissions are discarded during replay, so the mode has no effect.
+func (c *replayClient) Retune(_ context.Context, from, to RadioFrequency, _ RetuneMode) error {
+	c.frequenciesLock.Lock()
+	defer c.frequenciesLock.Unlock()
+	i := slices.IndexFunc(c.frequencies, from.IsSameFrequency)
+	if i < 0 {
+		return fmt.Errorf("client is not tuned to %s", from)
+	}
+	if slices.ContainsFunc(c.frequencies, to.IsSameFrequency) {
+		return fmt.Errorf("client is already tuned to %s", to)
+	}
+	frequencies := slices.Clone(c.frequencies)
+	frequencies[i] = to
+	c.frequencies = frequencies
+	return nil
+}
+
+// Close implements [Client.Close]. Transmissions are discarded during replay, so there is nothing to wait for.
+func (c *replayClient) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,116 @@
+package simpleradio
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var replayFrequencies = []RadioFrequency{
+	{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM},
+	{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM},
+	{Frequency: 30 * unit.Megahertz, Modulation: types.ModulationFM},
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		file     string
+		speed    float64
+		duration time.Duration
+		expected []string
+		lengths  []int
+	}{
+		{
+			file:     "picture.jsonl",
+			speed:    50,
+			duration: 5500 * time.Millisecond,
+			// Tanker is on an untuned frequency.
+			expected: []string{"Eagle 1", "Eagle 2", "Viper 3"},
+			lengths:  []int{320, 480, 240},
+		},
+		{
+			file:     "radiocheck.jsonl",
+			speed:    10,
+			duration: time.Second,
+			expected: []string{"Hornet 1 1", "Hornet 1 2"},
+			lengths:  []int{160, 160},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.file, func(t *testing.T) {
+			t.Parallel()
+			c, err := NewReplayClient(filepath.Join("testdata", "replay", test.file), test.speed, replayFrequencies)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			var wg sync.WaitGroup
+			errChan := make(chan error, 1)
+			start := time.Now()
+			go func() {
+				errChan <- c.Run(ctx, &wg)
+			}()
+
+			for i, name := range test.expected {
+				select {
+				case transmission := <-c.Receive():
+					assert.Equal(t, name, transmission.ClientName)
+					assert.NotEmpty(t, transmission.TraceID)
+					require.Len(t, transmission.Audio, test.lengths[i])
+					assert.InDelta(t, 0, transmission.Audio[0], 1e-6)
+					for _, sample := range transmission.Audio {
+						assert.LessOrEqual(t, math.Abs(float64(sample)), 0.5)
+					}
+				case <-ctx.Done():
+					require.FailNow(t, "timed out waiting for transmission", name)
+				}
+			}
+			require.NoError(t, <-errChan)
+			elapsed := time.Since(start)
+			assert.GreaterOrEqual(t, elapsed, time.Duration(float64(test.duration)/test.speed))
+			assert.Equal(t, StatusDisconnected, c.HealthStatus())
+		})
+	}
+}
+
+func TestReplayCancel(t *testing.T) {
+	t.Parallel()
+	c, err := NewReplayClient(filepath.Join("testdata", "replay", "picture.jsonl"), 1, replayFrequencies)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.Run(ctx, &wg)
+	}()
+	<-c.Receive()
+	cancel()
+	require.ErrorIs(t, <-errChan, context.Canceled)
+}
+
+func TestNewReplayClient(t *testing.T) {
+	t.Parallel()
+	_, err := NewReplayClient(filepath.Join("testdata", "replay", "picture.jsonl"), 0, replayFrequencies)
+	require.Error(t, err)
+	_, err = NewReplayClient(filepath.Join("testdata", "replay", "missing.jsonl"), 1, replayFrequencies)
+	require.Error(t, err)
+}
+
+func TestReplayAudioRoundTrip(t *testing.T) {
+	t.Parallel()
+	audio := Audio{0, 0.25, -0.5, 1, -1}
+	record := ReplayRecord{Audio: EncodeReplayAudio(audio)}
+	decoded, err := record.decodeAudio()
+	require.NoError(t, err)
+	assert.Equal(t, audio, decoded)
+}
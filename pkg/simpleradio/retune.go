@@ -0,0 +1,159 @@
+package simpleradio
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/rs/zerolog/log"
+)
+
+// RetuneMode selects what happens to outgoing transmissions queued for a frequency when the radio tuned to it is
+// retuned.
+type RetuneMode int
+
+const (
+	// RetuneDrain sends the transmissions queued for the old frequency on the old frequency, then retunes the radio.
+	RetuneDrain RetuneMode = iota
+	// RetuneRetarget moves the transmissions queued for the old frequency to the new frequency. A transmission which is
+	// already in progress on the old frequency is finished before the radio is retuned, rather than being cut off.
+	RetuneRetarget
+)
+
+// retuneRequest is a radio waiting to be retuned by the transmit scheduler.
+type retuneRequest struct {
+	// ctx is the context of the call to Retune. The request is abandoned if it is done.
+	ctx context.Context
+	// from is the frequency the radio is tuned to.
+	from RadioFrequency
+	// to is the frequency to tune the radio to.
+	to RadioFrequency
+	// mode selects what happens to transmissions queued for the old frequency.
+	mode RetuneMode
+	// result receives the outcome of the request.
+	result chan error
+}
+
+// Retune implements [Client.Retune].
+func (c *client) Retune(ctx context.Context, from, to RadioFrequency, mode RetuneMode) error {
+	if c.closing.Load() {
+		return ErrClosing
+	}
+	if err := c.canRetune(from, to); err != nil {
+		return err
+	}
+
+	// The transmit scheduler applies the retune, so that it can hold or move the transmissions queued for the old
+	// frequency.
+	request := &retuneRequest{ctx: ctx, from: from, to: to, mode: mode, result: make(chan error, 1)}
+	select {
+	case c.retuneChan <- request:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to retune %s: %w", from, ctx.Err())
+	}
+	select {
+	case err := <-request.result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("failed to retune %s: %w", from, ctx.Err())
+	}
+}
+
+// canRetune checks if a radio is tuned to the old frequency and no radio is tuned to the new frequency.
+func (c *client) canRetune(from, to RadioFrequency) error {
+	if !c.isTunedTo(from) {
+		return fmt.Errorf("client is not tuned to %s", from)
+	}
+	if c.isTunedTo(to) {
+		return fmt.Errorf("client is already tuned to %s", to)
+	}
+	return nil
+}
+
+// retune applies each retune request whose old frequency is no longer needed for outgoing transmissions, and returns
+// the requests which are still waiting. For a request in [RetuneRetarget] mode, held transmissions are moved to the new
+// frequency first.
+func (c *client) retune(requests []*retuneRequest, pending []*scheduledTransmission, busy map[voice.Frequency]bool) []*retuneRequest {
+	waiting := requests[:0]
+	for _, request := range requests {
+		if err := request.ctx.Err(); err != nil {
+			request.result <- fmt.Errorf("failed to retune %s: %w", request.from, err)
+			continue
+		}
+		from := request.from.voiceFrequency()
+		if request.mode == RetuneRetarget {
+			for _, transmission := range pending {
+				transmission.retarget(from, request.to.voiceFrequency())
+			}
+		}
+		isQueued := slices.ContainsFunc(pending, func(transmission *scheduledTransmission) bool {
+			return slices.Contains(transmission.frequencies, from)
+		})
+		if busy[from] || isQueued {
+			waiting = append(waiting, request)
+			continue
+		}
+		request.result <- c.applyRetune(request.from, request.to)
+	}
+	clear(requests[len(waiting):])
+	return waiting
+}
+
+// retarget moves the transmission from one frequency to another. If the transmission is already sent on the new
+// frequency, the old frequency is removed.
+func (t *scheduledTransmission) retarget(from, to voice.Frequency) {
+	i := slices.Index(t.frequencies, from)
+	if i < 0 {
+		return
+	}
+	frequencies := slices.Clone(t.frequencies)
+	if slices.Contains(frequencies, to) {
+		frequencies = slices.Delete(frequencies, i, i+1)
+	} else {
+		frequencies[i] = to
+	}
+	t.frequencies = frequencies
+	// Rebuild the packets, since the packet length depends on the number of frequencies.
+	for i, p := range t.packets {
+		t.packets[i] = voice.NewVoicePacket(p.AudioBytes, frequencies, p.UnitID, p.PacketID, p.Hops, p.RelayGUID, p.OriginGUID)
+	}
+}
+
+// applyRetune tunes the radio on the old frequency to the new frequency and sends the updated radios to the SRS
+// server. The radio's receiver is replaced, so that any partially received transmission on the old frequency is
+// discarded and no packets are routed to the old frequency once the radio is retuned.
+func (c *client) applyRetune(from, to RadioFrequency) error {
+	err := func() error {
+		c.radiosLock.Lock()
+		defer c.radiosLock.Unlock()
+		radios := slices.Clone(c.clientInfo.RadioInfo.Radios)
+		if slices.ContainsFunc(radios, func(radio types.Radio) bool { return radioFrequency(radio).IsSameFrequency(to) }) {
+			return fmt.Errorf("client is already tuned to %s", to)
+		}
+		i := slices.IndexFunc(radios, func(radio types.Radio) bool { return radioFrequency(radio).IsSameFrequency(from) })
+		if i < 0 {
+			return fmt.Errorf("client is not tuned to %s", from)
+		}
+		old := radios[i]
+		radios[i].Frequency = to.Frequency.Hertz()
+		radios[i].Modulation = to.Modulation
+		// Replace the slice rather than modifying it, since copies of the client info may still refer to it.
+		c.clientInfo.RadioInfo.Radios = radios
+		if r, ok := c.receivers[old]; ok {
+			delete(c.receivers, old)
+			c.receivers[radios[i]] = r.clone()
+		}
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+	log.Info().Stringer("from", from).Stringer("to", to).Msg("retuned radio")
+
+	if err := c.updateRadios(); err != nil {
+		return fmt.Errorf("retuned %s to %s but failed to notify SRS server: %w", from, to, err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package simpleradio
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	strikeRadio  = types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	fighterRadio = types.Radio{Frequency: 133000000, Modulation: types.ModulationAM}
+)
+
+// newRetuneTestClient creates a client tuned to the strike frequency and connected to the given servers, and starts
+// its transmit scheduler.
+func newRetuneTestClient(t *testing.T, ctx context.Context, server *fakeServer, srs *mockServer) (*client, chan<- outgoingTransmission) {
+	t.Helper()
+	c := &client{
+		tcpConnection: dialMockServer(t, srs),
+		udpConnection: server.dial(t),
+		clientInfo: types.ClientInfo{
+			RadioInfo: types.RadioInfo{Radios: []types.Radio{strikeRadio}},
+		},
+		receivers:  map[types.Radio]*receiver{strikeRadio: newReceiver(0, 0, 0)},
+		retuneChan: make(chan *retuneRequest),
+	}
+	packetChan := make(chan outgoingTransmission, 3)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+	return c, packetChan
+}
+
+func TestRetuneDrainWhileTransmitting(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	srs := newMockServer(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, packetChan := newRetuneTestClient(t, ctx, server, srs)
+
+	strike := radioFrequency(strikeRadio)
+	fighter := radioFrequency(fighterRadio)
+	packetChan <- outgoingTransmission{packets: testVoicePackets(strike.voiceFrequency(), 1, 25)}
+	require.Eventually(t, func() bool {
+		return len(server.received(strike.voiceFrequency())) > 0
+	}, 5*time.Second, 5*time.Millisecond)
+	packetChan <- outgoingTransmission{packets: testVoicePackets(strike.voiceFrequency(), 26, 5)}
+
+	retuneCtx, cancelRetune := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelRetune()
+	require.NoError(t, c.Retune(retuneCtx, strike, fighter, RetuneDrain))
+
+	onStrike := server.received(strike.voiceFrequency())
+	require.Len(t, onStrike, 30, "queued transmissions should be sent on the old frequency before retuning")
+	for i, p := range onStrike {
+		assert.Equal(t, uint64(1+i), p.packet.PacketID)
+	}
+	assert.Empty(t, server.received(fighter.voiceFrequency()))
+	assert.Equal(t, []RadioFrequency{fighter}, c.Frequencies())
+	assert.Contains(t, c.receivers, fighterRadio)
+	assert.NotContains(t, c.receivers, strikeRadio)
+
+	require.Eventually(t, func() bool {
+		message, ok := srs.lastReceived(types.MessageRadioUpdate)
+		return ok && len(message.Client.RadioInfo.Radios) == 1 && message.Client.RadioInfo.Radios[0].IsSameFrequency(fighterRadio)
+	}, time.Second, 20*time.Millisecond, "the server should be sent the new frequency")
+}
+
+func TestRetuneRetargetWhileTransmitting(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	srs := newMockServer(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, packetChan := newRetuneTestClient(t, ctx, server, srs)
+
+	strike := radioFrequency(strikeRadio)
+	fighter := radioFrequency(fighterRadio)
+	guard := RadioFrequency{Frequency: 243 * unit.Megahertz, Modulation: types.ModulationAM}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(strike.voiceFrequency(), 1, 25)}
+	require.Eventually(t, func() bool {
+		return len(server.received(strike.voiceFrequency())) > 0
+	}, 5*time.Second, 5*time.Millisecond)
+	packetChan <- outgoingTransmission{packets: testVoicePackets(strike.voiceFrequency(), 26, 5)}
+	guid := []byte("skyeyeskyeyeskyeyesky1")
+	broadcast := make([]voice.VoicePacket, 0, 5)
+	for i := range 5 {
+		broadcast = append(broadcast, voice.NewVoicePacket(
+			[]byte{1, 2, 3, 4},
+			[]voice.Frequency{strike.voiceFrequency(), guard.voiceFrequency()},
+			100000002,
+			uint64(31+i),
+			0,
+			guid,
+			guid,
+		))
+	}
+	packetChan <- outgoingTransmission{packets: broadcast}
+
+	retuneCtx, cancelRetune := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelRetune()
+	require.NoError(t, c.Retune(retuneCtx, strike, fighter, RetuneRetarget))
+	require.Len(t, server.received(strike.voiceFrequency()), 25, "the transmission in progress should be finished on the old frequency")
+	assert.Equal(t, []RadioFrequency{fighter}, c.Frequencies())
+
+	require.Eventually(t, func() bool {
+		return len(server.received(fighter.voiceFrequency())) == 10
+	}, 10*time.Second, 20*time.Millisecond, "queued transmissions should be moved to the new frequency")
+	onFighter := server.received(fighter.voiceFrequency())
+	for i, p := range onFighter {
+		assert.Equal(t, uint64(26+i), p.packet.PacketID)
+	}
+	for _, p := range onFighter[5:] {
+		assert.Equal(t, []voice.Frequency{fighter.voiceFrequency(), guard.voiceFrequency()}, p.packet.Frequencies)
+	}
+	assert.Len(t, server.received(guard.voiceFrequency()), 5)
+	assert.Len(t, server.received(strike.voiceFrequency()), 25, "nothing should be sent on the old frequency after retuning")
+}
+
+func TestRetuneSwitchesReceiveFilter(t *testing.T) {
+	t.Parallel()
+	srs := newMockServer(t, nil)
+	c := &client{
+		tcpConnection: dialMockServer(t, srs),
+		clientInfo: types.ClientInfo{
+			RadioInfo: types.RadioInfo{Radios: []types.Radio{strikeRadio}},
+		},
+		receivers: map[types.Radio]*receiver{strikeRadio: newReceiver(0, 0, 0)},
+	}
+
+	strike := radioFrequency(strikeRadio)
+	fighter := radioFrequency(fighterRadio)
+	origin := []byte("yellowyellowyellowyel1")
+	packet := voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{strike.voiceFrequency()}, 1, 1, 0, origin, origin)
+	c.routePacket(&packet)
+
+	require.NoError(t, c.applyRetune(strike, fighter))
+	r, ok := c.receivers[fighterRadio]
+	require.True(t, ok)
+	assert.Empty(t, r.packets(), "a partial transmission on the old frequency should be discarded")
+
+	packet = voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{strike.voiceFrequency()}, 1, 2, 0, origin, origin)
+	c.routePacket(&packet)
+	assert.Empty(t, r.packets(), "packets on the old frequency should not be received after retuning")
+
+	packet = voice.NewVoicePacket([]byte{1, 2, 3, 4}, []voice.Frequency{fighter.voiceFrequency()}, 1, 3, 0, origin, origin)
+	c.routePacket(&packet)
+	assert.Len(t, r.packets(), 1, "packets on the new frequency should be received after retuning")
+}
+
+func TestRetuneInvalidFrequencies(t *testing.T) {
+	t.Parallel()
+	c := &client{
+		clientInfo: types.ClientInfo{
+			RadioInfo: types.RadioInfo{Radios: []types.Radio{strikeRadio, fighterRadio}},
+		},
+	}
+	strike := radioFrequency(strikeRadio)
+	fighter := radioFrequency(fighterRadio)
+	guard := RadioFrequency{Frequency: 243 * unit.Megahertz, Modulation: types.ModulationAM}
+
+	require.Error(t, c.Retune(context.Background(), guard, strike, RetuneDrain), "should not retune a frequency the client is not tuned to")
+	require.Error(t, c.Retune(context.Background(), strike, fighter, RetuneDrain), "should not retune to a frequency the client is already tuned to")
+	c.closing.Store(true)
+	require.ErrorIs(t, c.Retune(context.Background(), strike, guard, RetuneDrain), ErrClosing)
+}
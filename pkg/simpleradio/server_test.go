@@ -0,0 +1,91 @@
+package simpleradio
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedPacket is a voice packet received by a fakeServer.
+type receivedPacket struct {
+	packet     *voice.VoicePacket
+	receivedAt time.Time
+}
+
+// fakeServer is a minimal stand-in for the UDP socket of an SRS server. It records the voice packets it receives and
+// can send voice packets to the last client which wrote to it.
+type fakeServer struct {
+	connection *net.UDPConn
+	lock       sync.Mutex
+	packets    []receivedPacket
+	client     *net.UDPAddr
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	connection, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	s := &fakeServer{connection: connection}
+	t.Cleanup(func() { _ = connection.Close() })
+	go s.listen()
+	return s
+}
+
+func (s *fakeServer) listen() {
+	buf := make([]byte, 1500)
+	for {
+		n, address, err := s.connection.ReadFromUDP(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		s.lock.Lock()
+		s.client = address
+		if packet, err := voice.Decode(buf[:n]); err == nil {
+			s.packets = append(s.packets, receivedPacket{packet: packet, receivedAt: time.Now()})
+		}
+		s.lock.Unlock()
+	}
+}
+
+// dial connects a client to the server.
+func (s *fakeServer) dial(t *testing.T) *net.UDPConn {
+	t.Helper()
+	connection, err := net.DialUDP("udp", nil, s.connection.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = connection.Close() })
+	return connection
+}
+
+// received returns the voice packets received on the given frequency, in order of arrival.
+func (s *fakeServer) received(frequency voice.Frequency) []receivedPacket {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	result := make([]receivedPacket, 0)
+	for _, p := range s.packets {
+		for _, f := range p.packet.Frequencies {
+			if f == frequency {
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
+
+// send writes a voice packet to the client.
+func (s *fakeServer) send(t *testing.T, packet voice.VoicePacket) {
+	t.Helper()
+	s.lock.Lock()
+	address := s.client
+	s.lock.Unlock()
+	require.NotNil(t, address, "client has not written to the server")
+	_, err := s.connection.WriteToUDP(packet.Encode(), address)
+	require.NoError(t, err)
+}
@@ -1,21 +1,64 @@
 package simpleradio
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
-// syncClients calls syncClient for each client in the given slice.
+// syncClients calls syncClient for each client in the given slice. The slice is the full list of connected clients, so
+// any previously stored client which is not in it is forgotten.
 func (c *client) syncClients(others []types.ClientInfo) {
 	log.Info().Int("count", len(others)).Msg("syncronizing clients")
+	func() {
+		connected := make(map[types.GUID]struct{}, len(others))
+		for _, info := range others {
+			connected[info.GUID] = struct{}{}
+		}
+		c.clientsLock.Lock()
+		defer c.clientsLock.Unlock()
+		for guid := range c.roster {
+			if _, ok := connected[guid]; !ok {
+				delete(c.roster, guid)
+			}
+		}
+		for guid := range c.clients {
+			if _, ok := connected[guid]; !ok {
+				delete(c.clients, guid)
+			}
+		}
+	}()
 	for _, info := range others {
 		c.syncClient(info)
 	}
 }
 
+// Clients implements [Client.Clients].
+func (c *client) Clients() []types.ClientInfo {
+	c.clientsLock.RLock()
+	defer c.clientsLock.RUnlock()
+	clients := make([]types.ClientInfo, 0, len(c.roster))
+	for _, info := range c.roster {
+		clients = append(clients, info)
+	}
+	slices.SortFunc(clients, func(a, b types.ClientInfo) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.GUID, b.GUID))
+	})
+	return clients
+}
+
+// ClientByGUID implements [Client.ClientByGUID].
+func (c *client) ClientByGUID(guid types.GUID) (types.ClientInfo, bool) {
+	c.clientsLock.RLock()
+	defer c.clientsLock.RUnlock()
+	info, ok := c.roster[guid]
+	return info, ok
+}
+
 // syncClient checks if the given client matches this client's coalition and radios, and if so, stores it in the clients map. Non-matching clients are removed from the map if previously stored.
 func (c *client) syncClient(other types.ClientInfo) {
 	if other.GUID == c.clientInfo.GUID {
@@ -23,6 +66,15 @@ func (c *client) syncClient(other types.ClientInfo) {
 		return
 	}
 
+	func() {
+		c.clientsLock.Lock()
+		defer c.clientsLock.Unlock()
+		if c.roster == nil {
+			c.roster = make(map[types.GUID]types.ClientInfo)
+		}
+		c.roster[other.GUID] = other
+	}()
+
 	if len(other.RadioInfo.Radios) == 0 {
 		return
 	}
@@ -40,8 +92,9 @@ func (c *client) syncClient(other types.ClientInfo) {
 		Strs("frequencies", frequencies).
 		Msgf("synced with SRS client %q", other.Name)
 
-	isSameCoalition := c.clientInfo.Coalition == other.Coalition || types.IsSpectator(other.Coalition)
-	isOnFrequency := c.clientInfo.RadioInfo.IsOnFrequency(other.RadioInfo)
+	isSameCoalition := c.coalition() == other.Coalition || types.IsSpectator(other.Coalition)
+	radioInfo := c.radioInfo()
+	isOnFrequency := radioInfo.IsOnFrequency(other.RadioInfo)
 
 	// if the other client has a matching radio and is not in an opposing coalition, store it in the clients map. Otherwise, banish it to the shadow realm.
 	c.clientsLock.Lock()
@@ -53,11 +106,12 @@ func (c *client) syncClient(other types.ClientInfo) {
 	}
 }
 
-// removeClient removes the client with the given GUID from the clients map.
+// removeClient removes the client with the given GUID from the clients and roster maps.
 func (c *client) removeClient(info types.ClientInfo) {
 	c.clientsLock.Lock()
 	defer c.clientsLock.Unlock()
 	delete(c.clients, info.GUID)
+	delete(c.roster, info.GUID)
 }
 
 // sync sends a sync message to the SRS server containing this client's information.
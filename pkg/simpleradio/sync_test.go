@@ -0,0 +1,69 @@
+package simpleradio
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient() *client {
+	return &client{
+		clientInfo: types.ClientInfo{
+			GUID:      "skyeyeskyeyeskyeyesky1",
+			Name:      "SkyEye [BOT]",
+			Coalition: coalitions.Blue,
+			RadioInfo: types.RadioInfo{
+				Radios: []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}},
+			},
+		},
+		clients: make(map[types.GUID]types.ClientInfo),
+		roster:  make(map[types.GUID]types.ClientInfo),
+	}
+}
+
+func TestClientRoster(t *testing.T) {
+	t.Parallel()
+	c := newTestClient()
+	onFrequency := types.RadioInfo{Radios: []types.Radio{{Frequency: 251000000, Modulation: types.ModulationAM}}}
+	offFrequency := types.RadioInfo{Radios: []types.Radio{{Frequency: 133000000, Modulation: types.ModulationAM}}}
+	mobius := types.ClientInfo{GUID: "mobiusmobiusmobiusmob1", Name: "Mobius 1", Coalition: coalitions.Blue, RadioInfo: onFrequency}
+	yellow := types.ClientInfo{GUID: "yellowyellowyellowyel1", Name: "Yellow 13", Coalition: coalitions.Red, RadioInfo: offFrequency}
+	spectator := types.ClientInfo{GUID: "spectatorspectator0001", Name: "Observer", Coalition: 0}
+
+	c.handleMessage(types.Message{Type: types.MessageSync, Clients: []types.ClientInfo{c.clientInfo, yellow, mobius, spectator}})
+
+	clients := c.Clients()
+	require.Len(t, clients, 3, "the roster should include every other client regardless of coalition or frequency")
+	assert.Equal(t, []string{"Mobius 1", "Observer", "Yellow 13"}, []string{clients[0].Name, clients[1].Name, clients[2].Name})
+	info, ok := c.ClientByGUID(yellow.GUID)
+	require.True(t, ok)
+	assert.Equal(t, yellow, info)
+	_, ok = c.ClientByGUID(c.clientInfo.GUID)
+	assert.False(t, ok, "the roster should not include this client")
+	assert.Equal(t, 1, c.ClientsOnFrequency())
+
+	// An update replaces the stored client info.
+	yellow.RadioInfo.Unit = "Su-27"
+	c.handleMessage(types.Message{Type: types.MessageUpdate, Client: yellow})
+	info, ok = c.ClientByGUID(yellow.GUID)
+	require.True(t, ok)
+	assert.Equal(t, "Su-27", info.RadioInfo.Unit)
+
+	// A disconnect removes the client.
+	c.handleMessage(types.Message{Type: types.MessageClientDisconnect, Client: mobius})
+	_, ok = c.ClientByGUID(mobius.GUID)
+	assert.False(t, ok)
+	assert.Len(t, c.Clients(), 2)
+	assert.Equal(t, 0, c.ClientsOnFrequency())
+
+	// A full sync forgets clients which are no longer connected.
+	c.handleMessage(types.Message{Type: types.MessageSync, Clients: []types.ClientInfo{mobius}})
+	clients = c.Clients()
+	require.Len(t, clients, 1)
+	assert.Equal(t, mobius.GUID, clients[0].GUID)
+	assert.Equal(t, 1, c.ClientsOnFrequency())
+	assert.Equal(t, "Mobius 1", c.transmitterInfo(mobius.GUID).Name)
+}
@@ -3,67 +3,280 @@ package simpleradio
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand/v2"
 	"net"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
 	"github.com/rs/zerolog/log"
 )
 
+// ErrClosing is returned when a transmission is queued after the client has started closing.
+var ErrClosing = errors.New("SRS client is closing")
+
 // Transmit implements [Client.Transmit].
 func (c *client) Transmit(transmission Transmission) {
+	if !c.beginTransmission() {
+		log.Warn().Str("traceID", transmission.TraceID).Msg("discarding transmission because the SRS client is closing")
+		return
+	}
 	c.txChan <- transmission
 }
 
+// TransmitAll implements [Client.TransmitAll].
+func (c *client) TransmitAll(frequencies []RadioFrequency, transmission Transmission) error {
+	var err error
+	transmission.Frequencies = make([]RadioFrequency, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		if !c.isTunedTo(frequency) {
+			err = errors.Join(err, fmt.Errorf("client is not tuned to %s", frequency))
+			continue
+		}
+		transmission.Frequencies = append(transmission.Frequencies, frequency)
+	}
+	if len(transmission.Frequencies) == 0 {
+		return errors.Join(err, errors.New("no frequencies to transmit on"))
+	}
+	if !c.beginTransmission() {
+		return errors.Join(err, ErrClosing)
+	}
+	c.txChan <- transmission
+	return err
+}
+
+// beginTransmission counts a new outgoing transmission as in flight. It returns false if the client is closing, in
+// which case the transmission must be discarded.
+func (c *client) beginTransmission() bool {
+	// Count the transmission before checking if the client is closing, so that Close either waits for it or it is
+	// rejected.
+	c.inFlight.Add(1)
+	if c.closing.Load() {
+		c.inFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+// endTransmission marks an outgoing transmission as sent or discarded.
+func (c *client) endTransmission() {
+	c.inFlight.Add(-1)
+}
+
+// waitForTransmissions blocks until all outgoing transmissions have been sent. It returns false if the context is done
+// first.
+func (c *client) waitForTransmissions(ctx context.Context) bool {
+	for c.inFlight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(schedulerInterval):
+		}
+	}
+	return true
+}
+
+// isTunedTo checks if any of the client's radios are tuned to the given frequency.
+func (c *client) isTunedTo(frequency RadioFrequency) bool {
+	for _, f := range c.Frequencies() {
+		if f.IsSameFrequency(frequency) {
+			return true
+		}
+	}
+	return false
+}
+
+// channelGuardInterval is how long after an incoming transmission ends that the channel must stay clear before an
+// outgoing transmission starts, so that the bot does not step on a player who pauses briefly or a reply to the player.
+const channelGuardInterval = 250 * time.Millisecond
+
+// defaultMaxTransmitHold is how long an outgoing transmission is held for a busy channel if not otherwise configured.
+const defaultMaxTransmitHold = 30 * time.Second
+
+// schedulerInterval is how often the transmit scheduler checks if held transmissions can start.
+const schedulerInterval = frameLength
+
+// outgoingTransmission is an encoded transmission waiting to be sent.
+type outgoingTransmission struct {
+	// packets of the transmission, in order.
+	packets []voice.VoicePacket
+	// priority of the transmission. Higher priority transmissions are sent first.
+	priority Priority
+}
+
+// scheduledTransmission is an outgoing transmission held by the transmit scheduler.
+type scheduledTransmission struct {
+	outgoingTransmission
+	// frequencies the transmission is sent on.
+	frequencies []voice.Frequency
+	// queuedAt is when the transmission was queued.
+	queuedAt time.Time
+	// isDelayed is true once the transmission has been held for an incoming transmission.
+	isDelayed bool
+}
+
 // transmit voice packets from queued transmissions to the SRS server.
-func (c *client) transmit(ctx context.Context, packetChan <-chan []voice.VoicePacket) {
+//
+// A transmission is held until none of its frequencies are in use by another of the client's transmissions, and the
+// channel has been clear of incoming transmissions for channelGuardInterval. Transmissions on the same frequency are
+// sent one at a time, while transmissions on different frequencies may overlap. Held transmissions are sent in order
+// of priority, then in the order they were queued, so an urgent transmission may overtake routine transmissions but
+// never interrupts a transmission in progress. A transmission held for longer than the maximum hold time is sent even
+// if the channel is busy with incoming transmissions.
+//
+// A radio waiting to be retuned is retuned once no transmission in progress uses its old frequency and, in
+// [RetuneDrain] mode, no held transmission uses it either.
+func (c *client) transmit(ctx context.Context, wg *sync.WaitGroup, packetChan <-chan outgoingTransmission) {
+	// pending transmissions, ordered by priority and then by the time they were queued.
+	var pending []*scheduledTransmission
+	// busy is the set of frequencies the client is transmitting on.
+	busy := make(map[voice.Frequency]bool)
+	// done receives the frequencies of each transmission when it is complete.
+	done := make(chan []voice.Frequency)
+	// retunes are radios waiting to be retuned once their old frequency is no longer needed.
+	var retunes []*retuneRequest
+
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
 	for {
 		select {
-		case packets := <-packetChan:
-			func() {
-				c.txLock.Lock()
-				defer c.txLock.Unlock()
-				c.waitForClearChannel()
-				if !c.mute {
-					c.writePackets(packets)
-				}
-			}()
-			// Pause between transmissions to sound more natural.
-			pause := time.Duration(500+rand.IntN(500)) * time.Millisecond
-			time.Sleep(pause)
+		case transmission := <-packetChan:
+			if len(transmission.packets) == 0 {
+				c.endTransmission()
+				continue
+			}
+			scheduled := &scheduledTransmission{
+				outgoingTransmission: transmission,
+				frequencies:          transmission.packets[0].Frequencies,
+				queuedAt:             time.Now(),
+			}
+			// Insert after any transmissions of the same or higher priority.
+			i := len(pending)
+			for i > 0 && pending[i-1].priority < scheduled.priority {
+				i--
+			}
+			pending = slices.Insert(pending, i, scheduled)
+		case frequencies := <-done:
+			for _, frequency := range frequencies {
+				delete(busy, frequency)
+			}
+		case request := <-c.retuneChan:
+			retunes = append(retunes, request)
+		case <-ticker.C:
 		case <-ctx.Done():
 			log.Info().Msg("stopping SRS audio transmitter due to context cancellation")
 			return
 		}
+		// Retune before starting transmissions, so that a transmission is never started on a frequency after the radio
+		// tuned to it is retuned.
+		retunes = c.retune(retunes, pending, busy)
+		pending = c.startTransmissions(ctx, wg, pending, busy, done)
 	}
 }
 
-// waitForClearChannel waits for incoming transmissions to finish.
-func (c *client) waitForClearChannel() {
-	for {
-		isReceiving := false
-		deadline := time.Now()
-		for _, receiver := range c.receivers {
-			if receiver.isReceivingTransmission() {
-				isReceiving = true
-				if receiver.deadline.After(deadline) {
-					deadline = receiver.deadline
+// startTransmissions starts each pending transmission which can be sent now, and returns the transmissions which are
+// still held. Each transmission is sent by a goroutine added to the wait group. A transmission is held if any of its frequencies are busy, or are needed by a transmission ahead of it
+// in the queue, or are receiving an incoming transmission and the transmission has not been held for the maximum hold
+// time.
+func (c *client) startTransmissions(ctx context.Context, wg *sync.WaitGroup, pending []*scheduledTransmission, busy map[voice.Frequency]bool, done chan<- []voice.Frequency) []*scheduledTransmission {
+	now := time.Now()
+	// reserved are frequencies needed by a held transmission. Transmissions further back in the queue may not use them.
+	reserved := make(map[voice.Frequency]bool)
+	held := pending[:0]
+	for _, transmission := range pending {
+		canStart := true
+		for _, frequency := range transmission.frequencies {
+			if busy[frequency] || reserved[frequency] {
+				canStart = false
+			}
+		}
+		if canStart && !c.isChannelClear(transmission.frequencies, now) {
+			if c.maxTransmitHold > 0 && now.Sub(transmission.queuedAt) >= c.maxTransmitHold {
+				log.Warn().Stringer("hold", now.Sub(transmission.queuedAt)).Msg("channel is still busy after maximum hold time, transmitting anyway")
+			} else {
+				if !transmission.isDelayed {
+					log.Info().Msg("delaying outgoing transmission to avoid interrupting incoming transmission")
+					transmission.isDelayed = true
 				}
+				canStart = false
 			}
 		}
-		if isReceiving {
-			delay := time.Until(deadline) + 250*time.Millisecond
-			log.Info().Stringer("delay", delay).Msg("delaying outgoing transmission to avoid interrupting incoming transmission")
-			time.Sleep(delay)
-		} else {
-			return
+		if !canStart {
+			for _, frequency := range transmission.frequencies {
+				reserved[frequency] = true
+			}
+			held = append(held, transmission)
+			continue
+		}
+		for _, frequency := range transmission.frequencies {
+			busy[frequency] = true
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.send(ctx, transmission.packets)
+			c.endTransmission()
+			select {
+			case done <- transmission.frequencies:
+			case <-ctx.Done():
+			}
+		}()
+	}
+	clear(pending[len(held):])
+	return held
+}
+
+// send writes a transmission's packets to the SRS server, then pauses.
+func (c *client) send(ctx context.Context, packets []voice.VoicePacket) {
+	if !c.mute {
+		// If the connection drops mid-transmission, hold the rest of the transmission until the client reconnects.
+		for remaining := packets; len(remaining) > 0; {
+			if !c.waitForConnection(ctx) {
+				return
+			}
+			remaining = c.writePackets(remaining)
 		}
 	}
+	// Pause between transmissions to sound more natural.
+	pause := time.Duration(500+rand.IntN(500)) * time.Millisecond
+	select {
+	case <-time.After(pause):
+	case <-ctx.Done():
+	}
 }
 
-// writePackets writes voice packets to the UDP connection.
-func (c *client) writePackets(packets []voice.VoicePacket) {
+// isChannelClear checks if none of the client's radios tuned to the given frequencies have received an incoming
+// transmission within channelGuardInterval of the given time.
+func (c *client) isChannelClear(frequencies []voice.Frequency, now time.Time) bool {
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
+	for radio, receiver := range c.receivers {
+		if !isTunedToAny(radio, frequencies) {
+			continue
+		}
+		if receiver.endOfTransmission().Add(channelGuardInterval).After(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTunedToAny checks if the radio is tuned to any of the given frequencies.
+func isTunedToAny(radio types.Radio, frequencies []voice.Frequency) bool {
+	for _, frequency := range frequencies {
+		if radioForFrequency(frequency).IsSameFrequency(radio) {
+			return true
+		}
+	}
+	return false
+}
+
+// writePackets writes voice packets to the UDP connection. If the connection is closed, it stops and returns the
+// packets which were not written.
+func (c *client) writePackets(packets []voice.VoicePacket) []voice.VoicePacket {
 	startTime := time.Now()
 	for i, packet := range packets {
 		b := packet.Encode()
@@ -76,13 +289,14 @@ func (c *client) writePackets(packets []voice.VoicePacket) {
 				Add(-frameLength / 2),
 		)
 		time.Sleep(delay)
-		_, err := c.udpConnection.Write(b)
+		_, err := c.udp().Write(b)
 		if errors.Is(err, net.ErrClosed) {
-			log.Error().Err(err).Msg("UDP connection closed during transmission")
-			return
+			log.Error().Err(err).Int("remaining", len(packets)-i).Msg("UDP connection closed during transmission")
+			return packets[i:]
 		}
 		if err != nil {
 			log.Error().Err(err).Msg("failed to transmit voice packet")
 		}
 	}
+	return nil
 }
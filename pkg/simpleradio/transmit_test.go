@@ -0,0 +1,409 @@
+package simpleradio
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransmitAll(t *testing.T) {
+	t.Parallel()
+	c := &client{
+		clientInfo: types.ClientInfo{
+			RadioInfo: types.RadioInfo{
+				Radios: []types.Radio{
+					{Frequency: 251000000, Modulation: types.ModulationAM},
+					{Frequency: 133000000, Modulation: types.ModulationAM},
+				},
+			},
+		},
+		txChan: make(chan Transmission, 1),
+	}
+
+	strike := RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
+	fighter := RadioFrequency{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM}
+	untuned := RadioFrequency{Frequency: 30 * unit.Megahertz, Modulation: types.ModulationFM}
+	audio := Audio{0.1, 0.2, 0.3}
+
+	err := c.TransmitAll([]RadioFrequency{strike, untuned, fighter}, Transmission{TraceID: "test", Audio: audio})
+	require.Error(t, err)
+
+	transmission := <-c.txChan
+	assert.Equal(t, "test", transmission.TraceID)
+	assert.Equal(t, audio, transmission.Audio)
+	assert.ElementsMatch(t, []RadioFrequency{strike, fighter}, transmission.Frequencies)
+
+	frequencies := c.voiceFrequencies(transmission)
+	require.Len(t, frequencies, 2)
+	for _, f := range frequencies {
+		assert.Contains(t, []float64{251000000, 133000000}, f.Frequency)
+	}
+}
+
+func TestTransmitAllNoFrequencies(t *testing.T) {
+	t.Parallel()
+	c := &client{txChan: make(chan Transmission, 1)}
+	err := c.TransmitAll([]RadioFrequency{{Frequency: 251 * unit.Megahertz}}, Transmission{})
+	require.Error(t, err)
+	assert.Empty(t, c.txChan)
+}
+
+func testVoicePackets(frequency voice.Frequency, firstPacketID uint64, n int) []voice.VoicePacket {
+	guid := []byte("skyeyeskyeyeskyeyesky1")
+	packets := make([]voice.VoicePacket, 0, n)
+	for i := range n {
+		packets = append(packets, voice.NewVoicePacket(
+			[]byte{1, 2, 3, 4},
+			[]voice.Frequency{frequency},
+			100000002,
+			firstPacketID+uint64(i),
+			0,
+			guid,
+			guid,
+		))
+	}
+	return packets
+}
+
+func TestTransmitOverlapsAcrossFrequencies(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := &client{
+		udpConnection: server.dial(t),
+		receivers:     map[types.Radio]*receiver{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	packetChan := make(chan outgoingTransmission, 3)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+
+	strike := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	fighter := voice.Frequency{Frequency: 133000000, Modulation: byte(types.ModulationAM)}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(strike, 1, 5)}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(fighter, 6, 5)}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(strike, 11, 5)}
+
+	require.Eventually(t, func() bool {
+		return len(server.received(strike)) == 10 && len(server.received(fighter)) == 5
+	}, 10*time.Second, 20*time.Millisecond)
+
+	onStrike := server.received(strike)
+	first, second := onStrike[:5], onStrike[5:]
+	onFighter := server.received(fighter)
+	for i, p := range first {
+		assert.Equal(t, uint64(1+i), p.packet.PacketID, "transmissions on the same frequency should not interleave")
+	}
+	assert.True(
+		t,
+		onFighter[0].receivedAt.Before(first[len(first)-1].receivedAt),
+		"transmissions on different frequencies should overlap",
+	)
+	assert.False(
+		t,
+		second[0].receivedAt.Before(first[len(first)-1].receivedAt),
+		"transmissions on the same frequency should be serialized",
+	)
+}
+
+func TestTransmitOnMultipleFrequencies(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := &client{
+		udpConnection: server.dial(t),
+		receivers:     map[types.Radio]*receiver{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	packetChan := make(chan outgoingTransmission, 3)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+
+	strike := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	guard := voice.Frequency{Frequency: 30000000, Modulation: byte(types.ModulationFM)}
+	guid := []byte("skyeyeskyeyeskyeyesky1")
+	broadcast := make([]voice.VoicePacket, 0, 5)
+	for i := range 5 {
+		broadcast = append(broadcast, voice.NewVoicePacket(
+			[]byte{byte(i), byte(i), byte(i)},
+			[]voice.Frequency{strike, guard},
+			100000002,
+			uint64(6+i),
+			0,
+			guid,
+			guid,
+		))
+	}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(strike, 1, 5)}
+	packetChan <- outgoingTransmission{packets: broadcast}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(guard, 11, 5)}
+
+	require.Eventually(t, func() bool {
+		return len(server.received(strike)) == 10 && len(server.received(guard)) == 10
+	}, 10*time.Second, 20*time.Millisecond)
+
+	onStrike := server.received(strike)
+	onGuard := server.received(guard)
+	for i := range 5 {
+		assert.Equal(t, uint64(1+i), onStrike[i].packet.PacketID, "broadcast should wait for the transmission in progress on the strike frequency")
+		assert.Equal(t, uint64(11+i), onGuard[5+i].packet.PacketID, "transmissions on the guard frequency should wait for the broadcast")
+
+		want := []byte{byte(i), byte(i), byte(i)}
+		assert.Equal(t, want, onStrike[5+i].packet.AudioBytes)
+		assert.Equal(t, want, onGuard[i].packet.AudioBytes)
+		assert.Same(t, onStrike[5+i].packet, onGuard[i].packet, "broadcast should be sent once, tagged for both frequencies")
+		assert.Equal(t, []voice.Frequency{strike, guard}, onGuard[i].packet.Frequencies)
+	}
+}
+
+func TestTransmitWaitsForReconnection(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := &client{
+		udpConnection: server.dial(t),
+		receivers:     map[types.Radio]*receiver{},
+		status:        StatusReconnecting,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	packetChan := make(chan outgoingTransmission, 1)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+
+	frequency := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(frequency, 1, 5)}
+
+	time.Sleep(3 * connectionPollInterval)
+	assert.Empty(t, server.received(frequency), "transmission should be held while reconnecting")
+
+	c.setStatus(StatusConnected)
+	require.Eventually(t, func() bool {
+		return len(server.received(frequency)) == 5
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+// dialMockServer connects the TCP connection of a client to the server.
+func dialMockServer(t *testing.T, server *mockServer) *net.TCPConn {
+	t.Helper()
+	connection, err := net.DialTCP("tcp", nil, server.listener.Addr().(*net.TCPAddr))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = connection.Close() })
+	return connection
+}
+
+func TestCloseFlushesTransmissions(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	srs := newMockServer(t, nil)
+	c := &client{
+		tcpConnection: dialMockServer(t, srs),
+		udpConnection: server.dial(t),
+		receivers:     map[types.Radio]*receiver{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	packetChan := make(chan outgoingTransmission, 2)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+
+	frequency := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	for _, firstPacketID := range []uint64{1, 6} {
+		require.True(t, c.beginTransmission())
+		packetChan <- outgoingTransmission{packets: testVoicePackets(frequency, firstPacketID, 5)}
+	}
+
+	closeCtx, cancelClose := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelClose()
+	require.NoError(t, c.Close(closeCtx))
+
+	// Any packet written after the socket was closed would have been lost.
+	require.Eventually(t, func() bool {
+		return len(server.received(frequency)) == 10
+	}, time.Second, 20*time.Millisecond, "all queued packets should be sent before the socket is closed")
+	for i, p := range server.received(frequency) {
+		assert.Equal(t, uint64(1+i), p.packet.PacketID)
+	}
+	require.Eventually(t, func() bool {
+		return srs.hasReceived(types.MessageClientDisconnect)
+	}, time.Second, 20*time.Millisecond, "client should disconnect from the server")
+	_, err := c.udpConnection.Write([]byte{0})
+	require.ErrorIs(t, err, net.ErrClosed)
+	assert.Equal(t, StatusDisconnected, c.HealthStatus())
+
+	assert.False(t, c.beginTransmission(), "transmissions should be rejected after closing")
+}
+
+func TestCloseTimesOut(t *testing.T) {
+	t.Parallel()
+	srs := newMockServer(t, nil)
+	server := newFakeServer(t)
+	c := &client{
+		tcpConnection: dialMockServer(t, srs),
+		udpConnection: server.dial(t),
+	}
+	// A transmission which is never sent.
+	require.True(t, c.beginTransmission())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := c.Close(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = c.udpConnection.Write([]byte{0})
+	require.ErrorIs(t, err, net.ErrClosed)
+}
+
+func TestWritePacketsReturnsUnsentPackets(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	connection := server.dial(t)
+	require.NoError(t, connection.Close())
+	c := &client{udpConnection: connection}
+
+	frequency := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	packets := testVoicePackets(frequency, 1, 3)
+	assert.Equal(t, packets, c.writePackets(packets))
+
+	c.udpConnection = server.dial(t)
+	assert.Empty(t, c.writePackets(packets))
+}
+
+func TestVoiceFrequenciesModulation(t *testing.T) {
+	t.Parallel()
+	c := &client{}
+	frequencies := c.voiceFrequencies(Transmission{
+		Frequencies: []RadioFrequency{
+			{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM},
+			{Frequency: 30 * unit.Megahertz, Modulation: types.ModulationFM},
+		},
+	})
+	require.Len(t, frequencies, 2)
+	assert.Equal(t, byte(types.ModulationAM), frequencies[0].Modulation)
+	assert.Equal(t, byte(types.ModulationFM), frequencies[1].Modulation)
+}
+
+// talk simulates a player transmitting on the frequency until the context is canceled, and returns a channel which
+// receives the time of the player's last packet.
+func talk(ctx context.Context, r *receiver, frequency voice.Frequency) <-chan time.Time {
+	last := make(chan time.Time, 1)
+	go func() {
+		ticker := time.NewTicker(frameLength)
+		defer ticker.Stop()
+		for id := uint64(1); ; id++ {
+			packet := testVoicePackets(frequency, id, 1)[0]
+			packet.OriginGUID = []byte("mobiusmobiusmobiusmob1")
+			r.receive(&packet)
+			select {
+			case <-ctx.Done():
+				last <- time.Now()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return last
+}
+
+func TestTransmitWaitsForClearChannel(t *testing.T) {
+	t.Parallel()
+	const silence = 100 * time.Millisecond
+	server := newFakeServer(t)
+	radio := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	r := newReceiver(0, silence, 0)
+	c := &client{
+		udpConnection: server.dial(t),
+		receivers:     map[types.Radio]*receiver{radio: r},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	packetChan := make(chan outgoingTransmission, 1)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+
+	frequency := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	playerCtx, stopTalking := context.WithCancel(ctx)
+	lastPlayerPacket := talk(playerCtx, r, frequency)
+	time.Sleep(2 * frameLength)
+
+	packetChan <- outgoingTransmission{packets: testVoicePackets(frequency, 1, 5)}
+	time.Sleep(500 * time.Millisecond)
+	assert.Empty(t, server.received(frequency), "transmission should be held while a player is transmitting")
+
+	stopTalking()
+	stoppedAt := <-lastPlayerPacket
+	require.Eventually(t, func() bool {
+		return len(server.received(frequency)) == 5
+	}, 5*time.Second, 20*time.Millisecond)
+	first := server.received(frequency)[0]
+	assert.False(
+		t,
+		first.receivedAt.Before(stoppedAt.Add(silence+channelGuardInterval-frameLength)),
+		"transmission should start after the player's transmission ends and the guard interval passes",
+	)
+}
+
+func TestTransmitPriority(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	radio := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	r := newReceiver(0, 100*time.Millisecond, 0)
+	c := &client{
+		udpConnection: server.dial(t),
+		receivers:     map[types.Radio]*receiver{radio: r},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	packetChan := make(chan outgoingTransmission, 2)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+
+	frequency := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	playerCtx, stopTalking := context.WithCancel(ctx)
+	lastPlayerPacket := talk(playerCtx, r, frequency)
+	time.Sleep(2 * frameLength)
+
+	packetChan <- outgoingTransmission{packets: testVoicePackets(frequency, 1, 5), priority: PriorityRoutine}
+	packetChan <- outgoingTransmission{packets: testVoicePackets(frequency, 6, 5), priority: PriorityUrgent}
+	time.Sleep(200 * time.Millisecond)
+	stopTalking()
+	<-lastPlayerPacket
+
+	require.Eventually(t, func() bool {
+		return len(server.received(frequency)) == 10
+	}, 10*time.Second, 20*time.Millisecond)
+	received := server.received(frequency)
+	for i, p := range received[:5] {
+		assert.Equal(t, uint64(6+i), p.packet.PacketID, "the urgent transmission should be sent first")
+	}
+	for i, p := range received[5:] {
+		assert.Equal(t, uint64(1+i), p.packet.PacketID, "the routine transmission should not interleave with the urgent transmission")
+	}
+}
+
+func TestTransmitMaxHold(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	radio := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	r := newReceiver(0, 100*time.Millisecond, 0)
+	c := &client{
+		udpConnection:   server.dial(t),
+		receivers:       map[types.Radio]*receiver{radio: r},
+		maxTransmitHold: 300 * time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	packetChan := make(chan outgoingTransmission, 1)
+	go c.transmit(ctx, new(sync.WaitGroup), packetChan)
+
+	frequency := voice.Frequency{Frequency: 251000000, Modulation: byte(types.ModulationAM)}
+	talk(ctx, r, frequency)
+	time.Sleep(2 * frameLength)
+
+	queuedAt := time.Now()
+	packetChan <- outgoingTransmission{packets: testVoicePackets(frequency, 1, 5)}
+	require.Eventually(t, func() bool {
+		return len(server.received(frequency)) == 5
+	}, 5*time.Second, 20*time.Millisecond, "transmission should be forced after the maximum hold time")
+	assert.False(t, server.received(frequency)[0].receivedAt.Before(queuedAt.Add(c.maxTransmitHold)))
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/dharmab/skyeye/pkg/coalitions"
 )
 
-// IsSpectator returns true if the given coalition is not red or blue. SRS considers any other coalition ID to be a spectator.
+// IsSpectator returns true if the given coalition is not red or blue. SRS considers any other coalition ID to be a
+// spectator. This includes 0, the neutral coalition and any larger value, and negative values, which DCS sometimes
+// reports for uninitialized aircraft.
 func IsSpectator(c coalitions.Coalition) bool {
 	return (c != coalitions.Red) && (c != coalitions.Blue)
 }
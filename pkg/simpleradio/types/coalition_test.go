@@ -1,15 +1,34 @@
 package types
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/dharmab/skyeye/pkg/coalitions"
-	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestIsSpectator(t *testing.T) {
 	t.Parallel()
-	require.True(t, IsSpectator(coalitions.Neutrals))
-	require.False(t, IsSpectator(coalitions.Red))
-	require.False(t, IsSpectator(coalitions.Blue))
+	testCases := []struct {
+		coalition coalitions.Coalition
+		expected  bool
+	}{
+		{coalitions.Red, false},
+		{coalitions.Blue, false},
+		{coalitions.Neutrals, true},
+		{0, true},
+		{4, true},
+		{-1, true},
+		{-100, true},
+		{math.MaxInt, true},
+		{math.MinInt, true},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprint(int(test.coalition)), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, IsSpectator(test.coalition))
+		})
+	}
 }
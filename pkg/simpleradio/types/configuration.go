@@ -20,10 +20,37 @@ type ClientConfiguration struct {
 	ExternalAWACSModePassword string
 	// Coalition corresponds to [ClientInfo.Coalition].
 	Coalition coalitions.Coalition
-	// Radio is the [Radio] to listen and talk on.
+	// CoalitionFilter restricts received transmissions to clients in the given coalition. Transmissions from other
+	// coalitions and from spectators are dropped. If zero, transmissions from any coalition are received unless the
+	// server enforces secure coalition radios.
+	CoalitionFilter coalitions.Coalition
+	// AllowSpectators controls whether transmissions from spectators are received. Transmissions from the opposing
+	// coalition are never received.
+	AllowSpectators bool
+	// Radios are the [Radio]s to listen and talk on. Each received transmission is tagged with the radio it arrived on,
+	// and transmissions on different radios may overlap.
 	Radios []Radio
+	// JitterBufferDepth is how long to wait for received voice packets which arrive out of order. Packets are reordered
+	// within this window and packets which arrive later are dropped. If zero, packets are not reordered.
+	JitterBufferDepth time.Duration
+	// EndOfTransmissionSilence is how long after the last received voice packet a transmission is considered over. If
+	// zero, a default duration is used.
+	EndOfTransmissionSilence time.Duration
+	// MaxTransmissionLength is the length of received audio after which a transmission is considered over, even if the
+	// transmitter is still talking. If zero, a default length is used.
+	MaxTransmissionLength time.Duration
+	// MaxTransmitHold is how long an outgoing transmission may be held while players are transmitting on the same
+	// frequency, before it is sent anyway. If zero, a default duration is used.
+	MaxTransmitHold time.Duration
 	// AllowRecording corresponds to [ClientInfo.AllowRecording].
 	AllowRecording bool
 	// Mute is true if the client should not transmit.
 	Mute bool
+	// PingInterval is how often the client pings the SRS server to check the health of the connection. If the server
+	// stops responding, the client reconnects. If zero, a default interval is used.
+	PingInterval time.Duration
+	// KeepaliveTimeout is how long the client may go without receiving anything from the SRS server, or without a
+	// response to its UDP pings, before it considers the connection dead and reconnects. If zero, three ping intervals
+	// are used.
+	KeepaliveTimeout time.Duration
 }
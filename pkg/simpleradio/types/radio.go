@@ -1,7 +1,10 @@
 package types
 
 import (
+	"fmt"
 	"math"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
 )
 
 // This file implements types from https://github.com/ciribob/DCS-SimpleRadioStandalone/blob/master/DCS-SR-Common/DCSState/RadioInformation.cs
@@ -10,24 +13,50 @@ import (
 type Modulation byte
 
 const (
-	// ModulationAM is Amplitude Modulation.
-	ModulationAM = 0
-	// ModulationFM is Frequency Modulation.
-	ModulationFM = 1
+	// ModulationAM is Amplitude Modulation. It is used by most aircraft radios.
+	ModulationAM Modulation = 0
+	// ModulationFM is Frequency Modulation. It is used by helicopters and ground forces, e.g. 30.0 MHz FM. Clients tuned
+	// to FM cannot hear AM transmissions on the same frequency, and vice versa.
+	ModulationFM Modulation = 1
 	// ModulationIntercom is intercom (used for multi-crew).
-	ModulationIntercom = 2
-	ModulationDisabled = 3
-	// ModulationIntercom is HAVE QUICK (https://en.wikipedia.org/wiki/Have_Quick, unused).
-	ModulationHAVEQUICK = 4
+	ModulationIntercom Modulation = 2
+	// ModulationDisabled is a radio which is switched off.
+	ModulationDisabled Modulation = 3
+	// ModulationHAVEQUICK is HAVE QUICK (https://en.wikipedia.org/wiki/Have_Quick, unused).
+	ModulationHAVEQUICK Modulation = 4
 	// ModulationSATCOM is satellite voice channels (unused).
-	ModulationSATCOM = 5
+	ModulationSATCOM Modulation = 5
 	// ModulationMIDS is Multifunction Information Distribution System (datalink digital voice channels)
 	// These are used by F/A-18C for VOC A and VOC B.
-	ModulationMIDS = 6
+	ModulationMIDS Modulation = 6
 	// ModulationSINCGARS is Single Channel Ground and Airborne Radio System (https://en.wikipedia.org/wiki/SINCGARS, unused).
-	ModulationSINCGARS = 7
+	ModulationSINCGARS Modulation = 7
 )
 
+// String returns the name of the modulation as shown in the SRS client.
+func (m Modulation) String() string {
+	switch m {
+	case ModulationAM:
+		return "AM"
+	case ModulationFM:
+		return "FM"
+	case ModulationIntercom:
+		return "INTERCOM"
+	case ModulationDisabled:
+		return "DISABLED"
+	case ModulationHAVEQUICK:
+		return "HAVEQUICK"
+	case ModulationSATCOM:
+		return "SATCOM"
+	case ModulationMIDS:
+		return "MIDS"
+	case ModulationSINCGARS:
+		return "SINCGARS"
+	default:
+		return fmt.Sprintf("Unknown(%d)", m)
+	}
+}
+
 // Radio describes one of a client's radios.
 type Radio struct {
 	// Frequency is the transmission frequency in Hz.
@@ -42,6 +71,10 @@ type Radio struct {
 	// GuardFrequency is a second frequency the client can receive.
 	GuardFrequency   float64 `json:"secFreq"`
 	ShouldRetransmit bool    `json:"retransmit"`
+	// Coalition restricts the transmissions received on this radio to clients in the given coalition, e.g. for a
+	// frequency used only by Blue. It is local configuration and is not sent to the server. If zero,
+	// [ClientConfiguration.CoalitionFilter] applies.
+	Coalition coalitions.Coalition `json:"-"`
 }
 
 // IsSameFrequency is true if the other radio has the same frequency, modulation, and encryption settings as this radio.
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRadioIsSameFrequency(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		a        Radio
+		b        Radio
+		expected bool
+	}{
+		{
+			name:     "same frequency and modulation",
+			a:        Radio{Frequency: 30000000, Modulation: ModulationFM},
+			b:        Radio{Frequency: 30000000, Modulation: ModulationFM},
+			expected: true,
+		},
+		{
+			name:     "same frequency, different modulation",
+			a:        Radio{Frequency: 30000000, Modulation: ModulationFM},
+			b:        Radio{Frequency: 30000000, Modulation: ModulationAM},
+			expected: false,
+		},
+		{
+			name:     "within tolerance",
+			a:        Radio{Frequency: 251000000, Modulation: ModulationAM},
+			b:        Radio{Frequency: 251000400, Modulation: ModulationAM},
+			expected: true,
+		},
+		{
+			name:     "different frequency",
+			a:        Radio{Frequency: 251000000, Modulation: ModulationAM},
+			b:        Radio{Frequency: 251025000, Modulation: ModulationAM},
+			expected: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, test.a.IsSameFrequency(test.b))
+			assert.Equal(t, test.expected, test.b.IsSameFrequency(test.a))
+		})
+	}
+}
+
+func TestRadioJSONIncludesModulation(t *testing.T) {
+	t.Parallel()
+	b, err := json.Marshal(Radio{Frequency: 30000000, Modulation: ModulationFM})
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(b, &fields))
+	assert.InDelta(t, float64(ModulationFM), fields["modulation"], 0)
+	assert.NotContains(t, fields, "Coalition")
+}
+
+func TestModulationString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "AM", ModulationAM.String())
+	assert.Equal(t, "FM", ModulationFM.String())
+	assert.Equal(t, "SATCOM", ModulationSATCOM.String())
+	assert.Equal(t, "Unknown(99)", Modulation(99).String())
+}
@@ -0,0 +1,62 @@
+package simpleradio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+)
+
+var (
+	// ErrPacketTruncated is returned by [ValidatePacket] when a packet is shorter than its headers claim.
+	ErrPacketTruncated = errors.New("voice packet is truncated")
+	// ErrPacketMalformed is returned by [ValidatePacket] when a packet's headers are inconsistent.
+	ErrPacketMalformed = errors.New("voice packet is malformed")
+)
+
+// ValidatePacket checks that a UDP voice packet is framed correctly before it is deserialized.
+//
+// SRS voice packets do not have magic bytes or a version field. Instead, the header segment contains the length of the
+// packet and the length of each variable-length segment, and the packet ends with the GUID of the transmitting
+// client. This checks that the packet is at least as long as the smallest possible voice packet, that the packet length
+// header matches the number of bytes received, that the segments fit within the packet, that the frequencies segment
+// holds a whole number of frequencies, and that the origin GUID is made of printable characters.
+func ValidatePacket(b []byte) error {
+	if len(b) < voice.MinPacketLength {
+		return fmt.Errorf("%w: %d bytes is less than the minimum of %d bytes", ErrPacketTruncated, len(b), voice.MinPacketLength)
+	}
+
+	// Bytes 0:2 are the packet length, 2:4 the audio segment length and 4:6 the frequencies segment length.
+	packetLength := int(binary.LittleEndian.Uint16(b[0:2]))
+	audioSegmentLength := int(binary.LittleEndian.Uint16(b[2:4]))
+	frequenciesSegmentLength := int(binary.LittleEndian.Uint16(b[4:6]))
+
+	if packetLength > len(b) {
+		return fmt.Errorf("%w: packet length header is %d bytes but only %d bytes were received", ErrPacketTruncated, packetLength, len(b))
+	}
+	if packetLength < len(b) {
+		return fmt.Errorf("%w: packet length header is %d bytes but %d bytes were received", ErrPacketMalformed, packetLength, len(b))
+	}
+	if minLength := voice.MinPacketLength + audioSegmentLength + frequenciesSegmentLength; minLength > packetLength {
+		return fmt.Errorf(
+			"%w: audio segment of %d bytes and frequencies segment of %d bytes do not fit in a packet of %d bytes",
+			ErrPacketMalformed,
+			audioSegmentLength,
+			frequenciesSegmentLength,
+			packetLength,
+		)
+	}
+	if frequenciesSegmentLength%voice.FrequencyLength != 0 {
+		return fmt.Errorf("%w: frequencies segment length %d is not a multiple of %d", ErrPacketMalformed, frequenciesSegmentLength, voice.FrequencyLength)
+	}
+
+	origin := b[packetLength-types.GUIDLength : packetLength]
+	for _, c := range origin {
+		if c <= ' ' || c > '~' {
+			return fmt.Errorf("%w: origin GUID %q contains invalid characters", ErrPacketMalformed, origin)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package simpleradio
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validPacket() []byte {
+	frequencies := []voice.Frequency{
+		{Frequency: 251000000, Modulation: 0},
+		{Frequency: 30000000, Modulation: 1},
+	}
+	origin := []byte("mobiusmobiusmobiusmob1")
+	p := voice.NewVoicePacket([]byte{1, 2, 3, 4, 5}, frequencies, 1, 42, 0, origin, origin)
+	return p.Encode()
+}
+
+// packetVectors are valid, truncated and corrupted packet layouts. They are used as both test cases and fuzz seeds.
+func packetVectors() []struct {
+	name     string
+	packet   []byte
+	expected error
+} {
+	withHeaders := func(packetLength, audioLength, frequenciesLength uint16) []byte {
+		b := validPacket()
+		binary.LittleEndian.PutUint16(b[0:2], packetLength)
+		binary.LittleEndian.PutUint16(b[2:4], audioLength)
+		binary.LittleEndian.PutUint16(b[4:6], frequenciesLength)
+		return b
+	}
+	valid := validPacket()
+	length := uint16(len(valid))
+	withBadOrigin := validPacket()
+	withBadOrigin[len(withBadOrigin)-1] = 0
+
+	// An unpadded fixed segment, as sent by clients which do not pad the fixed segment.
+	unpadded := append([]byte{}, valid[:6+5+20]...)
+	unpadded = append(unpadded, valid[6+5+20+1:]...)
+	binary.LittleEndian.PutUint16(unpadded[0:2], uint16(len(unpadded)))
+
+	return []struct {
+		name     string
+		packet   []byte
+		expected error
+	}{
+		{name: "valid", packet: valid},
+		{name: "unpadded fixed segment", packet: unpadded},
+		{name: "empty", packet: []byte{}, expected: ErrPacketTruncated},
+		{name: "headers only", packet: valid[:6], expected: ErrPacketTruncated},
+		{name: "ping", packet: []byte("mobiusmobiusmobiusmob1"), expected: ErrPacketTruncated},
+		{name: "truncated by one byte", packet: valid[:len(valid)-1], expected: ErrPacketTruncated},
+		{name: "truncated to minimum length", packet: valid[:voice.MinPacketLength], expected: ErrPacketTruncated},
+		{name: "trailing bytes", packet: append(validPacket(), 0), expected: ErrPacketMalformed},
+		{name: "packet length too large", packet: withHeaders(length+1, 5, 20), expected: ErrPacketTruncated},
+		{name: "packet length too small", packet: withHeaders(length-1, 5, 20), expected: ErrPacketMalformed},
+		{name: "packet length zero", packet: withHeaders(0, 5, 20), expected: ErrPacketMalformed},
+		{name: "audio segment overflows", packet: withHeaders(length, 0xFFFF, 20), expected: ErrPacketMalformed},
+		{name: "frequencies segment overflows", packet: withHeaders(length, 5, 0xFFFF), expected: ErrPacketMalformed},
+		{name: "partial frequency", packet: withHeaders(length, 10, 15), expected: ErrPacketMalformed},
+		{name: "corrupt origin GUID", packet: withBadOrigin, expected: ErrPacketMalformed},
+	}
+}
+
+func TestValidatePacket(t *testing.T) {
+	t.Parallel()
+	for _, test := range packetVectors() {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidatePacket(test.packet)
+			if test.expected == nil {
+				require.NoError(t, err)
+				packet, err := voice.Decode(test.packet)
+				require.NoError(t, err)
+				assert.Equal(t, "mobiusmobiusmobiusmob1", string(packet.OriginGUID))
+				assert.Len(t, packet.Frequencies, 2)
+			} else {
+				require.ErrorIs(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func FuzzValidatePacket(f *testing.F) {
+	for _, test := range packetVectors() {
+		f.Add(test.packet)
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		if err := ValidatePacket(b); err != nil {
+			return
+		}
+		packet, err := voice.Decode(b)
+		require.NoError(t, err, "a packet which passed validation should decode")
+		require.Len(t, packet.OriginGUID, 22)
+	})
+}
@@ -3,6 +3,7 @@ package simpleradio
 import (
 	"context"
 
+	"github.com/dharmab/skyeye/pkg/pcm"
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
 	"github.com/lithammer/shortuuid/v3"
@@ -14,33 +15,37 @@ import (
 const opusApplicationVoIP = 2048
 
 // deocdeVoice decodes incoming voice packets from voicePacketsChan into F32LE PCM audio data published to the client's rxChan.
-func (c *client) decodeVoice(ctx context.Context, voicePacketsChan <-chan []voice.VoicePacket) {
+func (c *client) decodeVoice(ctx context.Context, voicePacketsChan <-chan receivedTransmission) {
 	for {
 		select {
-		case voicePackets := <-voicePacketsChan:
+		case received := <-voicePacketsChan:
+			voicePackets := received.packets
 			decoder, err := opus.NewDecoder(int(sampleRate.Hertz()), channels)
 			if err != nil {
 				log.Error().Err(err).Msg("failed to create Opus decoder")
 				continue
 			}
-			transmissionPCM := make([]float32, 0)
-			for _, packet := range voicePackets {
-				packetPCM, err := c.decodeFrame(decoder, packet.AudioBytes)
-				if err != nil {
-					log.Error().Err(err).Msg("failed to decode audio")
-				} else {
-					transmissionPCM = append(transmissionPCM, packetPCM...)
-				}
+			transmissionPCM, err := assemblePCM(voicePackets, func(b []byte) ([]float32, error) {
+				return c.decodeFrame(decoder, b)
+			})
+			if err != nil {
+				log.Error().Err(err).Msg("failed to decode audio")
 			}
 
 			if len(transmissionPCM) > 0 {
-				origin := types.GUID(voicePackets[0].OriginGUID)
-				name, _ := c.getPeerName(origin)
-				log.Info().Str("clientName", name).Int("len", len(transmissionPCM)).Msg("publishing received audio to receiving channel")
+				transmitter := received.transmitter
+				if transmitter.GUID == "" {
+					transmitter = c.transmitterInfo(types.GUID(voicePackets[0].OriginGUID))
+				}
+				log.Info().Str("clientName", transmitter.Name).Int("len", len(transmissionPCM)).Msg("publishing received audio to receiving channel")
+				frequency := radioFrequency(received.radio)
 				c.rxChan <- Transmission{
-					TraceID:    shortuuid.New(),
-					ClientName: name,
-					Audio:      transmissionPCM,
+					TraceID:     shortuuid.New(),
+					ClientName:  transmitter.Name,
+					Transmitter: transmitter,
+					Audio:       transmissionPCM,
+					Frequencies: []RadioFrequency{frequency},
+					Guard:       frequency.IsGuard(),
 				}
 			} else {
 				log.Debug().Msg("decoded transmission PCM is empty")
@@ -53,62 +58,65 @@ func (c *client) decodeVoice(ctx context.Context, voicePacketsChan <-chan []voic
 }
 
 // encodeVoice encodes audio from the client's txChan and publishes an entire transmission's worth of voice packets to packetCh.
-func (c *client) encodeVoice(ctx context.Context, packetChan chan<- []voice.VoicePacket) {
-	frequencyList := make([]voice.Frequency, 0, len(c.clientInfo.RadioInfo.Radios))
-	for _, radio := range c.clientInfo.RadioInfo.Radios {
-		frequencyList = append(frequencyList, voice.Frequency{
-			Frequency:  radio.Frequency,
-			Modulation: byte(radio.Modulation),
-			Encryption: 0,
-		})
-	}
+func (c *client) encodeVoice(ctx context.Context, packetChan chan<- outgoingTransmission) {
 	for {
 		select {
 		case transmission := <-c.txChan:
+			frequencyList := c.voiceFrequencies(transmission)
 			encoder, err := opus.NewEncoder(int(sampleRate.Hertz()), channels, opusApplicationVoIP)
 			if err != nil {
 				log.Error().Err(err).Msg("failed to create Opus encoder")
+				c.endTransmission()
 				continue
 			}
 
-			txPackets := make([]voice.VoicePacket, 0)
-			for i := 0; i < len(transmission.Audio); i += int(frameSize) {
-				logger := log.With().Int("index", i).Logger()
-				var frameAudio []float32
-				// pad frame to frame size
-				if i+int(frameSize) < len(transmission.Audio) {
-					frameAudio = transmission.Audio[i : i+int(frameSize)]
-				} else {
-					frameAudio = transmission.Audio[i:]
-				}
-				// Align audio to Opus frame size
-				if len(frameAudio) < int(frameSize) {
-					padding := make([]float32, int(frameSize)-len(frameAudio))
-					frameAudio = append(frameAudio, padding...)
-				}
+			audio := transmission.Audio
+			if transmission.SampleRate != 0 {
+				audio = pcm.Resample(audio, transmission.SampleRate, sampleRate)
+			}
+			audioFrames := frames(audio)
+			txPackets := make([]voice.VoicePacket, 0, len(audioFrames))
+			for i, frameAudio := range audioFrames {
 				audioBytes, err := c.encodeFrame(encoder, frameAudio)
 				if err != nil {
-					logger.Error().Err(err).Msg("failed to encode audio")
+					log.Error().Err(err).Int("frame", i).Msg("failed to encode audio")
 					continue
 				}
-
-				guid := c.clientInfo.GUID
-				voicePacket := voice.NewVoicePacket(
-					audioBytes,
-					frequencyList,
-					100000002,
-					c.packetNumber,
-					0,
-					[]byte(guid),
-					[]byte(guid),
-				)
-				c.packetNumber++
-				txPackets = append(txPackets, voicePacket)
+				txPackets = append(txPackets, c.newVoicePacket(audioBytes, frequencyList))
 			}
-			packetChan <- txPackets
+			packetChan <- outgoingTransmission{packets: txPackets, priority: transmission.Priority}
 		case <-ctx.Done():
 			log.Info().Msg("stopping voice encoder due to context cancellation")
 			return
 		}
 	}
 }
+
+// newVoicePacket creates a voice packet containing the given Opus frame, stamped with the next packet number.
+func (c *client) newVoicePacket(audioBytes []byte, frequencies []voice.Frequency) voice.VoicePacket {
+	guid := c.clientInfo.GUID
+	packet := voice.NewVoicePacket(
+		audioBytes,
+		frequencies,
+		100000002,
+		c.packetNumber,
+		0,
+		[]byte(guid),
+		[]byte(guid),
+	)
+	c.packetNumber++
+	return packet
+}
+
+// voiceFrequencies returns the frequencies to encode into the voice packets for the given transmission.
+func (c *client) voiceFrequencies(transmission Transmission) []voice.Frequency {
+	frequencies := transmission.Frequencies
+	if len(frequencies) == 0 {
+		frequencies = c.Frequencies()
+	}
+	frequencyList := make([]voice.Frequency, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		frequencyList = append(frequencyList, frequency.voiceFrequency())
+	}
+	return frequencyList
+}
@@ -113,8 +113,14 @@ const (
 	headerSegmentLength = 6
 	// fixedSegmentLength is the length of the fixed segment in bytes.
 	fixedSegmentLength = 58
-	// frequencyLength is the length of a Frequency in bytes.
-	frequencyLength = 10
+	// fixedFieldsLength is the length of the fields in the fixed segment in bytes. Encode reserves fixedSegmentLength
+	// bytes for the fixed segment, but other clients may not pad it, so this is the smallest fixed segment to accept.
+	fixedFieldsLength = 4 + 8 + 1 + types.GUIDLength + types.GUIDLength
+	// FrequencyLength is the length of a Frequency in bytes.
+	FrequencyLength = 10
+	// MinPacketLength is the length in bytes of the smallest possible voice packet, which has an empty audio segment and
+	// no frequencies.
+	MinPacketLength = headerSegmentLength + fixedFieldsLength
 )
 
 func NewVoicePacket(audioBytes []byte, frequencies []Frequency, unitID uint32, packetID uint64, hops byte, relay []byte, origin []byte) VoicePacket {
@@ -126,10 +132,10 @@ func NewVoicePacket(audioBytes []byte, frequencies []Frequency, unitID uint32, p
 	}
 
 	var frequenciesSegmentLength uint16
-	if len(frequencies)*frequencyLength > math.MaxUint16 {
+	if len(frequencies)*FrequencyLength > math.MaxUint16 {
 		frequenciesSegmentLength = math.MaxUint16
 	} else {
-		frequenciesSegmentLength = uint16(len(frequencies) * frequencyLength)
+		frequenciesSegmentLength = uint16(len(frequencies) * FrequencyLength)
 	}
 
 	return VoicePacket{
@@ -160,7 +166,7 @@ func (p *VoicePacket) Encode() []byte {
 
 	/* Frequencies Segment */
 	for i, frequency := range p.Frequencies {
-		offset := headerSegmentLength + int(p.AudioSegmentLength) + i*frequencyLength
+		offset := headerSegmentLength + int(p.AudioSegmentLength) + i*FrequencyLength
 		binary.LittleEndian.PutUint64(b[offset:offset+8], math.Float64bits(frequency.Frequency))
 		b[offset+8] = frequency.Modulation
 		b[offset+9] = frequency.Encryption
@@ -247,7 +253,7 @@ func Decode(b []byte) (packet *VoicePacket, err error) {
 	frequenciesSegmentPtr := int(6 + packet.AudioSegmentLength)
 	frequenciesSegment := b[frequenciesSegmentPtr : frequenciesSegmentPtr+int(packet.FrequenciesSegmentLength)]
 	// Iterate over the frequencies segment and decode each frequency.
-	for i := 0; i < len(frequenciesSegment); i = i + frequencyLength {
+	for i := 0; i < len(frequenciesSegment); i = i + FrequencyLength {
 		modulationPtr := i + 8
 		encryptionPtr := modulationPtr + 1
 		frequency := Frequency{
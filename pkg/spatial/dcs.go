@@ -0,0 +1,134 @@
+package spatial
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+)
+
+// Theater is a DCS map. DCS positions units on each map using a flat coordinate system of meters north (x) and east
+// (y) of an origin. The coordinate system is a Transverse Mercator projection of the WGS-84 ellipsoid, with a
+// central meridian and false origin specific to each map.
+type Theater struct {
+	// Name of the map.
+	Name string
+	// CentralMeridian is the longitude of the projection's central meridian, in degrees.
+	CentralMeridian float64
+	// FalseEasting is added to the projected easting to produce the DCS y coordinate, in meters.
+	FalseEasting float64
+	// FalseNorthing is added to the projected northing to produce the DCS x coordinate, in meters.
+	FalseNorthing float64
+	// ScaleFactor is the scale factor along the central meridian.
+	ScaleFactor float64
+}
+
+// Projection parameters for each map, as published for the DCS mission scripting environment's coord.LLtoLO and
+// coord.LOtoLL functions.
+var (
+	Caucasus = Theater{
+		Name:            "Caucasus",
+		CentralMeridian: 33,
+		FalseEasting:    -99516.9999999732,
+		FalseNorthing:   -4998114.999999984,
+		ScaleFactor:     0.9996,
+	}
+	Nevada = Theater{
+		Name:            "Nevada",
+		CentralMeridian: -117,
+		FalseEasting:    -193996.80999964548,
+		FalseNorthing:   -4410028.063999966,
+		ScaleFactor:     0.9996,
+	}
+	PersianGulf = Theater{
+		Name:            "PersianGulf",
+		CentralMeridian: 57,
+		FalseEasting:    75755.99999999645,
+		FalseNorthing:   -2894933.0000000377,
+		ScaleFactor:     0.9996,
+	}
+	Syria = Theater{
+		Name:            "Syria",
+		CentralMeridian: 39,
+		FalseEasting:    282801.00000003993,
+		FalseNorthing:   -3879865.9999999935,
+		ScaleFactor:     0.9996,
+	}
+)
+
+// WGS-84 ellipsoid parameters.
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+)
+
+var (
+	// e2 is the square of the first eccentricity.
+	e2 = wgs84Flattening * (2 - wgs84Flattening)
+	// ep2 is the square of the second eccentricity.
+	ep2 = e2 / (1 - e2)
+)
+
+// meridionalArc returns the distance along the central meridian from the equator to the given latitude in radians.
+func meridionalArc(φ float64) float64 {
+	e4 := e2 * e2
+	e6 := e4 * e2
+	return wgs84SemiMajorAxis * ((1-e2/4-3*e4/64-5*e6/256)*φ -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*φ) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*φ) -
+		(35*e6/3072)*math.Sin(6*φ))
+}
+
+// PointToDCS converts a point on the earth to DCS x (north) and y (east) coordinates in meters on the given map.
+func PointToDCS(point orb.Point, theater Theater) (x, y float64) {
+	φ := point.Lat() * math.Pi / 180
+	λ := (point.Lon() - theater.CentralMeridian) * math.Pi / 180
+	k0 := theater.ScaleFactor
+
+	sinφ, cosφ := math.Sincos(φ)
+	N := wgs84SemiMajorAxis / math.Sqrt(1-e2*sinφ*sinφ)
+	T := math.Tan(φ) * math.Tan(φ)
+	C := ep2 * cosφ * cosφ
+	A := λ * cosφ
+
+	easting := k0 * N * (A +
+		(1-T+C)*math.Pow(A, 3)/6 +
+		(5-18*T+T*T+72*C-58*ep2)*math.Pow(A, 5)/120)
+	northing := k0 * (meridionalArc(φ) + N*math.Tan(φ)*(A*A/2+
+		(5-T+9*C+4*C*C)*math.Pow(A, 4)/24+
+		(61-58*T+T*T+600*C-330*ep2)*math.Pow(A, 6)/720))
+
+	return northing + theater.FalseNorthing, easting + theater.FalseEasting
+}
+
+// DCSToPoint converts DCS x (north) and y (east) coordinates in meters on the given map to a point on the earth.
+func DCSToPoint(x, y float64, theater Theater) orb.Point {
+	k0 := theater.ScaleFactor
+	northing := x - theater.FalseNorthing
+	easting := y - theater.FalseEasting
+
+	e4 := e2 * e2
+	e6 := e4 * e2
+	μ := northing / k0 / (wgs84SemiMajorAxis * (1 - e2/4 - 3*e4/64 - 5*e6/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+	φ1 := μ +
+		(3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*μ) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*μ) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*μ) +
+		(1097*math.Pow(e1, 4)/512)*math.Sin(8*μ)
+
+	sinφ1, cosφ1 := math.Sincos(φ1)
+	C1 := ep2 * cosφ1 * cosφ1
+	T1 := math.Tan(φ1) * math.Tan(φ1)
+	N1 := wgs84SemiMajorAxis / math.Sqrt(1-e2*sinφ1*sinφ1)
+	R1 := wgs84SemiMajorAxis * (1 - e2) / math.Pow(1-e2*sinφ1*sinφ1, 1.5)
+	D := easting / (N1 * k0)
+
+	φ := φ1 - (N1*math.Tan(φ1)/R1)*(D*D/2-
+		(5+3*T1+10*C1-4*C1*C1-9*ep2)*math.Pow(D, 4)/24+
+		(61+90*T1+298*C1+45*T1*T1-252*ep2-3*C1*C1)*math.Pow(D, 6)/720)
+	λ := (D -
+		(1+2*T1+C1)*math.Pow(D, 3)/6 +
+		(5-2*C1+28*T1-3*C1*C1+8*ep2+24*T1*T1)*math.Pow(D, 5)/120) / cosφ1
+
+	return orb.Point{theater.CentralMeridian + λ*180/math.Pi, φ * 180 / math.Pi}
+}
@@ -0,0 +1,79 @@
+package spatial
+
+import (
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+// dcsOrigins are the points at DCS coordinates (0, 0) on each map.
+var dcsOrigins = []struct {
+	theater Theater
+	origin  orb.Point
+}{
+	{theater: Caucasus, origin: orb.Point{34.265515, 45.129497}},
+	{theater: Nevada, origin: orb.Point{-114.733412, 39.818116}},
+	{theater: PersianGulf, origin: orb.Point{56.241935, 26.171819}},
+	{theater: Syria, origin: orb.Point{35.900560, 35.021918}},
+}
+
+func TestDCSToPointOrigin(t *testing.T) {
+	t.Parallel()
+	for _, test := range dcsOrigins {
+		t.Run(test.theater.Name, func(t *testing.T) {
+			t.Parallel()
+			point := DCSToPoint(0, 0, test.theater)
+			assert.InDelta(t, 0, Distance(test.origin, point).Meters(), 1)
+		})
+	}
+}
+
+func TestPointToDCSOrigin(t *testing.T) {
+	t.Parallel()
+	for _, test := range dcsOrigins {
+		t.Run(test.theater.Name, func(t *testing.T) {
+			t.Parallel()
+			x, y := PointToDCS(test.origin, test.theater)
+			assert.InDelta(t, 0, x, 1)
+			assert.InDelta(t, 0, y, 1)
+		})
+	}
+}
+
+func TestDCSRoundTrip(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name    string
+		theater Theater
+		point   orb.Point
+	}{
+		{name: "Batumi", theater: Caucasus, point: orb.Point{41.6003, 41.6085}},
+		{name: "Nellis", theater: Nevada, point: orb.Point{-115.0342, 36.2361}},
+		{name: "Al Minhad", theater: PersianGulf, point: orb.Point{55.3664, 25.0272}},
+		{name: "Rayak", theater: Syria, point: orb.Point{35.9903, 33.8517}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			// The series expansions lose some precision far from the central meridian, but remain well within a meter.
+			x, y := PointToDCS(test.point, test.theater)
+			assert.Less(t, Distance(test.point, DCSToPoint(x, y, test.theater)), 1*unit.Meter)
+		})
+	}
+}
+
+func TestDCSScale(t *testing.T) {
+	t.Parallel()
+	for _, test := range dcsOrigins {
+		t.Run(test.theater.Name, func(t *testing.T) {
+			t.Parallel()
+			a := DCSToPoint(-100000, 100000, test.theater)
+			b := DCSToPoint(-90000, 100000, test.theater)
+			// Distance uses a spherical earth, which differs from the ellipsoid by up to about half a percent.
+			assert.InDelta(t, 10000, Distance(a, b).Meters(), 75, "10 km in DCS coordinates should be about 10 km on the ground")
+			assert.Greater(t, b.Lat(), a.Lat(), "DCS x should point north")
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package spatial
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/paulmach/orb"
+)
+
+// Military Grid Reference System (MGRS) parameters. A grid reference consists of a grid zone designator (a UTM zone
+// number and a latitude band letter), a 100 km square identifier of two letters, and an easting and northing within the
+// square of up to 5 digits each, e.g. "38T LM 12345 67890".
+const (
+	// mgrsBands are the latitude band letters, in 8 degree bands from 80°S. The X band is 12 degrees tall.
+	mgrsBands = "CDEFGHJKLMNPQRSTUVWX"
+	// mgrsRows are the 100 km square row letters, which repeat every 2,000 km of northing.
+	mgrsRows = "ABCDEFGHJKLMNPQRSTUV"
+	// mgrsSquareSize is the size of a 100 km square in meters.
+	mgrsSquareSize = 100000
+	// mgrsRowCycle is the northing after which the 100 km square row letters repeat, in meters.
+	mgrsRowCycle = 20 * mgrsSquareSize
+)
+
+// mgrsColumns are the 100 km square column letters, which depend on the UTM zone number modulo 3.
+var mgrsColumns = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+
+// utmZone returns the UTM projection for the given zone number and hemisphere.
+func utmZone(zone int, isNorth bool) Theater {
+	theater := Theater{
+		Name:            fmt.Sprintf("UTM %d", zone),
+		CentralMeridian: float64(zone*6 - 183),
+		FalseEasting:    500000,
+		ScaleFactor:     0.9996,
+	}
+	if !isNorth {
+		theater.FalseNorthing = 10000000
+	}
+	return theater
+}
+
+// utmZoneNumber returns the UTM zone number of the given position, including the exceptions for Norway and Svalbard.
+func utmZoneNumber(lat, lon float64) int {
+	zone := int(math.Floor((lon+180)/6)) + 1
+	if zone > 60 {
+		zone = 1
+	}
+	if lat >= 56 && lat < 64 && lon >= 3 && lon < 12 {
+		return 32
+	}
+	if lat >= 72 && lat < 84 && lon >= 0 && lon < 42 {
+		switch {
+		case lon < 9:
+			return 31
+		case lon < 21:
+			return 33
+		case lon < 33:
+			return 35
+		default:
+			return 37
+		}
+	}
+	return zone
+}
+
+// FormatMGRS returns the MGRS grid reference of a point, with the given number of digits of precision for each of the easting and northing, from 0 (a 100 km square) to 5 (a 1 m square). The grid reference is
+// formatted with spaces between each part, e.g. "38T LM 123 456". Positions outside of 80°S to 84°N are not supported
+// by MGRS; an empty string is returned for them.
+func FormatMGRS(point orb.Point, precision int) string {
+	lat, lon := point.Lat(), point.Lon()
+	if lat < -80 || lat >= 84 {
+		return ""
+	}
+	precision = min(max(precision, 0), 5)
+	zone := utmZoneNumber(lat, lon)
+	band := mgrsBands[min(int(math.Floor((lat+80)/8)), len(mgrsBands)-1)]
+	northing, easting := PointToDCS(point, utmZone(zone, lat >= 0))
+
+	column := mgrsColumns[(zone-1)%3][int(math.Floor(easting/mgrsSquareSize))-1]
+	rowIndex := int(math.Floor(northing/mgrsSquareSize)) % len(mgrsRows)
+	if zone%2 == 0 {
+		rowIndex = (rowIndex + 5) % len(mgrsRows)
+	}
+	row := mgrsRows[rowIndex]
+
+	s := fmt.Sprintf("%d%c %c%c", zone, band, column, row)
+	if precision == 0 {
+		return s
+	}
+	resolution := math.Pow10(5 - precision)
+	e := int(math.Floor(math.Mod(easting, mgrsSquareSize) / resolution))
+	n := int(math.Floor(math.Mod(northing, mgrsSquareSize) / resolution))
+	return fmt.Sprintf("%s %0*d %0*d", s, precision, e, precision, n)
+}
+
+// ParseMGRS parses an MGRS grid reference, such as "38T LM 12345 67890" or "38TLM1234567890", and returns the center of
+// the referenced grid square. Spaces are ignored. The easting and northing
+// may have between 0 and 5 digits each.
+func ParseMGRS(s string) (orb.Point, error) {
+	s = strings.ToUpper(strings.Join(strings.Fields(s), ""))
+
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i < 1 || i > 2 {
+		return orb.Point{}, fmt.Errorf("grid reference %q must start with a UTM zone number", s)
+	}
+	zone, err := strconv.Atoi(s[:i])
+	if err != nil || zone < 1 || zone > 60 {
+		return orb.Point{}, fmt.Errorf("grid reference %q has invalid UTM zone number", s)
+	}
+	s = s[i:]
+	if len(s) < 3 {
+		return orb.Point{}, fmt.Errorf("grid reference is missing latitude band or 100 km square: %q", s)
+	}
+
+	bandIndex := strings.IndexByte(mgrsBands, s[0])
+	if bandIndex < 0 {
+		return orb.Point{}, fmt.Errorf("invalid latitude band %q", s[0])
+	}
+	columnIndex := strings.IndexByte(mgrsColumns[(zone-1)%3], s[1])
+	if columnIndex < 0 {
+		return orb.Point{}, fmt.Errorf("invalid 100 km square column %q for UTM zone %d", s[1], zone)
+	}
+	rowIndex := strings.IndexByte(mgrsRows, s[2])
+	if rowIndex < 0 {
+		return orb.Point{}, fmt.Errorf("invalid 100 km square row %q", s[2])
+	}
+
+	digits := s[3:]
+	if len(digits)%2 != 0 || len(digits) > 10 {
+		return orb.Point{}, errors.New("easting and northing must have the same number of digits, at most 5 each")
+	}
+	precision := len(digits) / 2
+	resolution := math.Pow10(5 - precision)
+	var e, n int
+	if precision > 0 {
+		if e, err = strconv.Atoi(digits[:precision]); err != nil {
+			return orb.Point{}, fmt.Errorf("invalid easting %q: %w", digits[:precision], err)
+		}
+		if n, err = strconv.Atoi(digits[precision:]); err != nil {
+			return orb.Point{}, fmt.Errorf("invalid northing %q: %w", digits[precision:], err)
+		}
+	}
+
+	isNorth := mgrsBands[bandIndex] >= 'N'
+	projection := utmZone(zone, isNorth)
+
+	easting := float64(columnIndex+1)*mgrsSquareSize + float64(e)*resolution + resolution/2
+	if zone%2 == 0 {
+		rowIndex = (rowIndex - 5 + len(mgrsRows)) % len(mgrsRows)
+	}
+	northing := float64(rowIndex)*mgrsSquareSize + float64(n)*resolution + resolution/2
+
+	// The row letters repeat every 2,000 km, so use the latitude band to find which cycle the square is in. The lowest
+	// northing of the band is at the edge of the zone in the southern hemisphere and on the central meridian in the
+	// northern hemisphere.
+	bandLatitude := -80 + 8*float64(bandIndex)
+	bandNorthing, _ := PointToDCS(orb.Point{projection.CentralMeridian, bandLatitude}, projection)
+	edgeNorthing, _ := PointToDCS(orb.Point{projection.CentralMeridian - 3, bandLatitude}, projection)
+	bandNorthing = math.Min(bandNorthing, edgeNorthing)
+	squareNorthing := northing - math.Mod(northing, mgrsSquareSize)
+	for squareNorthing+mgrsSquareSize <= bandNorthing {
+		northing += mgrsRowCycle
+		squareNorthing += mgrsRowCycle
+	}
+
+	return DCSToPoint(northing, easting, projection), nil
+}
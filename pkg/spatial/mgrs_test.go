@@ -0,0 +1,89 @@
+package spatial
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMGRS(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		point     orb.Point
+		precision int
+		expected  string
+	}{
+		{name: "origin", point: orb.Point{0, 0}, precision: 5, expected: "31N AA 66021 00000"},
+		{name: "Washington Monument", point: orb.Point{-77.035278, 38.889484}, precision: 3, expected: "18S UJ 234 064"},
+		{name: "100 km square", point: orb.Point{-77.035278, 38.889484}, precision: 0, expected: "18S UJ"},
+		{name: "out of range", point: orb.Point{0, 85}, precision: 5, expected: ""},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, FormatMGRS(test.point, test.precision))
+		})
+	}
+}
+
+func TestParseMGRS(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		grid  string
+		lat   float64
+		lon   float64
+		delta float64
+	}{
+		{grid: "31N AA 66021 00000", lat: 0, lon: 0, delta: 1e-4},
+		{grid: "18SUJ2348306479", lat: 38.889484, lon: -77.035278, delta: 1e-4},
+		{grid: "18s uj 234 064", lat: 38.889484, lon: -77.035278, delta: 1e-3},
+	}
+	for _, test := range testCases {
+		t.Run(test.grid, func(t *testing.T) {
+			t.Parallel()
+			point, err := ParseMGRS(test.grid)
+			require.NoError(t, err)
+			assert.InDelta(t, test.lat, point.Lat(), test.delta)
+			assert.InDelta(t, test.lon, point.Lon(), test.delta)
+		})
+	}
+}
+
+func TestMGRSRoundTrip(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name string
+		lat  float64
+		lon  float64
+	}{
+		{name: "Caucasus", lat: 41.6094, lon: 41.5997},
+		{name: "Nevada", lat: 36.2362, lon: -115.0343},
+		{name: "Persian Gulf", lat: 25.2528, lon: 55.3644},
+		{name: "South Atlantic", lat: -51.8222, lon: -58.4472},
+		{name: "Norway", lat: 60.2, lon: 5.3},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			grid := FormatMGRS(orb.Point{test.lon, test.lat}, 5)
+			point, err := ParseMGRS(grid)
+			require.NoError(t, err, grid)
+			assert.InDelta(t, test.lat, point.Lat(), 1e-4, grid)
+			assert.InDelta(t, test.lon, point.Lon(), 1e-4, grid)
+		})
+	}
+}
+
+func TestParseMGRSInvalid(t *testing.T) {
+	t.Parallel()
+	for _, grid := range []string{"", "LM 123 456", "61T LM 123 456", "38I LM 123 456", "38T IM 123 456", "38T LM 1234 567"} {
+		t.Run(grid, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseMGRS(grid)
+			require.Error(t, err)
+		})
+	}
+}
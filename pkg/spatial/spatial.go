@@ -3,6 +3,7 @@ package spatial
 
 import (
 	"math"
+	"time"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/martinlindhe/unit"
@@ -34,21 +35,33 @@ func PointAtBearingAndDistance(origin orb.Point, bearing bearings.Bearing, dista
 	return geo.PointAtBearingAndDistance(origin, degrees, meters)
 }
 
+// ExtrapolatePosition returns the predicted position of an aircraft which departs the origin on the given course at the
+// given speed, after the given time has elapsed. The aircraft is assumed to fly a great circle track at constant speed.
+func ExtrapolatePosition(origin orb.Point, course bearings.Bearing, speed unit.Speed, elapsed time.Duration) orb.Point {
+	distance := unit.Length(speed.MetersPerSecond()*elapsed.Seconds()) * unit.Meter
+	return PointAtBearingAndDistance(origin, course, distance)
+}
+
+// RangeRate returns the rate at which the distance between two aircraft is changing, given each aircraft's position,
+// course and speed. The result is positive if the aircraft are moving apart and negative if they are moving together.
+func RangeRate(
+	a orb.Point,
+	aCourse bearings.Bearing,
+	aSpeed unit.Speed,
+	b orb.Point,
+	bCourse bearings.Bearing,
+	bSpeed unit.Speed,
+) unit.Speed {
+	const interval = time.Second
+	before := Distance(a, b)
+	after := Distance(
+		ExtrapolatePosition(a, aCourse, aSpeed, interval),
+		ExtrapolatePosition(b, bCourse, bSpeed, interval),
+	)
+	return unit.Speed((after-before).Meters()/interval.Seconds()) * unit.MetersPerSecond
+}
+
 // IsZero returns true if the point is the origin.
 func IsZero(point orb.Point) bool {
 	return point.Equal(orb.Point{})
 }
-
-// NormalizeAltitude returns the absolute length rounded to the nearest 1000 feet, or nearest 100 feet if less than 1000 feet.
-func NormalizeAltitude(altitude unit.Length) unit.Length {
-	if altitude < 0 {
-		altitude = -altitude
-	}
-	bucketWidth := 1000 * unit.Foot
-	if altitude < bucketWidth {
-		bucketWidth = 100. * unit.Foot
-	}
-	bucket := int(math.Round(altitude.Feet() / bucketWidth.Feet()))
-	rounded := int(bucketWidth.Feet()) * bucket
-	return unit.Length(rounded) * unit.Foot
-}
@@ -4,6 +4,7 @@ package spatial
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/martinlindhe/unit"
@@ -122,6 +123,60 @@ func TestTrueBearing(t *testing.T) {
 	}
 }
 
+func TestExtrapolatePosition(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{-115.0338, 36.2350}
+	testCases := []struct {
+		course  unit.Angle
+		speed   unit.Speed
+		elapsed time.Duration
+	}{
+		{course: 360 * unit.Degree, speed: 300 * unit.Knot, elapsed: 30 * time.Second},
+		{course: 90 * unit.Degree, speed: 450 * unit.Knot, elapsed: time.Minute},
+		{course: 225 * unit.Degree, speed: 600 * unit.Knot, elapsed: 2 * time.Minute},
+		{course: 180 * unit.Degree, speed: 0, elapsed: time.Minute},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%v at %v for %v", test.course.Degrees(), test.speed.Knots(), test.elapsed), func(t *testing.T) {
+			t.Parallel()
+			course := bearings.NewTrueBearing(test.course)
+			actual := ExtrapolatePosition(origin, course, test.speed, test.elapsed)
+			expectedDistance := unit.Length(test.speed.MetersPerSecond()*test.elapsed.Seconds()) * unit.Meter
+			assert.InDelta(t, expectedDistance.Meters(), Distance(origin, actual).Meters(), 1)
+			if expectedDistance > 0 {
+				assert.InDelta(t, test.course.Degrees(), TrueBearing(origin, actual).Degrees(), 0.5)
+			}
+		})
+	}
+}
+
+func TestRangeRate(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{-115.0338, 36.2350}
+	north := bearings.NewTrueBearing(360 * unit.Degree)
+	south := bearings.NewTrueBearing(180 * unit.Degree)
+	east := bearings.NewTrueBearing(90 * unit.Degree)
+	target := PointAtBearingAndDistance(origin, north, 20*unit.NauticalMile)
+	testCases := []struct {
+		name         string
+		targetCourse bearings.Bearing
+		targetSpeed  unit.Speed
+		expected     unit.Speed
+	}{
+		{name: "head-on", targetCourse: south, targetSpeed: 500 * unit.Knot, expected: -900 * unit.Knot},
+		{name: "tail chase closing", targetCourse: north, targetSpeed: 300 * unit.Knot, expected: -100 * unit.Knot},
+		{name: "tail chase opening", targetCourse: north, targetSpeed: 500 * unit.Knot, expected: 100 * unit.Knot},
+		{name: "perpendicular", targetCourse: east, targetSpeed: 400 * unit.Knot, expected: -400 * unit.Knot},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := RangeRate(origin, north, 400*unit.Knot, target, test.targetCourse, test.targetSpeed)
+			assert.InDelta(t, test.expected.Knots(), actual.Knots(), 5)
+		})
+	}
+}
+
 func TestIsZero(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -186,60 +241,3 @@ func TestPointAtBearingAndDistance(t *testing.T) {
 		})
 	}
 }
-
-func TestNormalizeAltitude(t *testing.T) {
-	t.Parallel()
-	testCases := []struct {
-		input    unit.Length
-		expected unit.Length
-	}{
-		{
-			input:    -100 * unit.Foot,
-			expected: 100 * unit.Foot,
-		},
-		{
-			input:    0,
-			expected: 0,
-		},
-		{
-			input:    40 * unit.Foot,
-			expected: 0,
-		},
-		{
-			input:    100 * unit.Foot,
-			expected: 100 * unit.Foot,
-		},
-		{
-			input:    120 * unit.Foot,
-			expected: 100 * unit.Foot,
-		},
-		{
-			input:    200 * unit.Foot,
-			expected: 200 * unit.Foot,
-		},
-		{
-			input:    249 * unit.Foot,
-			expected: 200 * unit.Foot,
-		},
-		{
-			input:    250 * unit.Foot,
-			expected: 300 * unit.Foot,
-		},
-		{
-			input:    1234 * unit.Foot,
-			expected: 1000 * unit.Foot,
-		},
-		{
-			input:    10000 * unit.Foot,
-			expected: 10000 * unit.Foot,
-		},
-	}
-
-	for _, test := range testCases {
-		t.Run(fmt.Sprintf("%fft", test.input.Feet()), func(t *testing.T) {
-			t.Parallel()
-			actual := NormalizeAltitude(test.input)
-			assert.InDelta(t, test.expected.Feet(), actual.Feet(), 0.1)
-		})
-	}
-}
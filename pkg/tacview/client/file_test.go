@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReaderSession(t *testing.T) {
+	t.Parallel()
+	reader := NewFileClient(filepath.Join("testdata", "session.txt.acmi"), 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	starts := make(chan sim.Started, 1)
+	updates := make(chan sim.Updated, 100)
+	fades := make(chan sim.Faded, 1)
+	go reader.Stream(ctx, &wg, starts, updates, fades)
+
+	// The reader returns an error once it reaches the end of the file.
+	err := reader.Run(ctx, &wg)
+	require.ErrorIs(t, err, io.EOF)
+
+	select {
+	case <-starts:
+	case <-ctx.Done():
+		require.FailNow(t, "timed out waiting for start")
+	}
+	select {
+	case fade := <-fades:
+		assert.Equal(t, uint64(0x102), fade.ID)
+	case <-ctx.Done():
+		require.FailNow(t, "timed out waiting for fade")
+	}
+
+	assert.Equal(t, time.Date(2024, 8, 1, 10, 0, 5, 0, time.UTC), reader.Time())
+
+	bullseye, err := reader.Bullseye(coalitions.Blue)
+	require.NoError(t, err)
+	assert.InDelta(t, 42.5, bullseye.Lon(), 0.0001)
+	assert.InDelta(t, 42.2, bullseye.Lat(), 0.0001)
+	bullseye, err = reader.Bullseye(coalitions.Red)
+	require.NoError(t, err)
+	assert.InDelta(t, 40, bullseye.Lon(), 0.0001)
+	assert.InDelta(t, 44.6, bullseye.Lat(), 0.0001)
+
+	// Only aircraft which are still present are updated.
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case update := <-updates:
+			require.Equal(t, uint64(0x101), update.Labels.ID)
+			assert.Equal(t, "Mobius 1", update.Labels.Name)
+			assert.Equal(t, "F-16C_50", update.Labels.ACMIName)
+			assert.Equal(t, coalitions.Coalition(coalitions.Blue), update.Labels.Coalition)
+			assert.InDelta(t, 42.09, update.Frame.Point.Lon(), 0.0001)
+			assert.InDelta(t, 42.2, update.Frame.Point.Lat(), 0.0001)
+			assert.InDelta(t, 7625, update.Frame.Altitude.Meters(), 0.001)
+			// Heading is retained from the earlier full transform.
+			assert.InDelta(t, 270, update.Frame.Heading.Degrees(), 0.001)
+			assert.Equal(t, reader.Time(), update.Frame.Time)
+		case <-deadline:
+			return
+		}
+	}
+}
+
+func TestIsRelevantObject(t *testing.T) {
+	t.Parallel()
+	assert.True(t, IsRelevantObject([]string{"Air", "FixedWing"}))
+	assert.True(t, IsRelevantObject([]string{"Air", "Rotorcraft"}))
+	assert.True(t, IsRelevantObject([]string{"Navaid", "Static", "Bullseye"}))
+	assert.False(t, IsRelevantObject([]string{"Ground", "Static", "Building"}))
+}
@@ -1,18 +1,13 @@
 package client
 
 import (
-	"context"
-	"sync"
-	"time"
-
-	"github.com/dharmab/skyeye/pkg/coalitions"
-	"github.com/dharmab/skyeye/pkg/sim"
-	"github.com/paulmach/orb"
+	"github.com/dharmab/skyeye/pkg/datasource"
 )
 
-type Client interface {
-	Run(context.Context, *sync.WaitGroup) error
-	Stream(context.Context, *sync.WaitGroup, chan<- sim.Started, chan<- sim.Updated, chan<- sim.Faded)
-	Bullseye(coalitions.Coalition) (orb.Point, error)
-	Time() time.Time
-}
+// Client reads ACMI data from Tacview real-time telemetry or an ACMI file.
+type Client = datasource.ContactSource
+
+var (
+	_ Client = &FileReader{}
+	_ Client = &TelemetryClient{}
+)
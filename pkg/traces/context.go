@@ -16,8 +16,9 @@ type contextKey int
 const (
 	traceIDKey contextKey = iota
 	errorKey
-	radioFrequencyKey
 	clientNameKey
+	transmitterGUIDKey
+	transmitterUnitKey
 	playerNameKey
 	requestKey
 	requestTextKey
@@ -64,6 +65,25 @@ func GetClientName(ctx context.Context) string {
 	return getValue[string](ctx, clientNameKey)
 }
 
+// WithTransmitterGUID records the GUID of the SRS client which transmitted a request, so that the request can be
+// cross-checked against the client's name and unit.
+func WithTransmitterGUID(ctx context.Context, guid string) context.Context {
+	return context.WithValue(ctx, transmitterGUIDKey, guid)
+}
+
+func GetTransmitterGUID(ctx context.Context) string {
+	return getValue[string](ctx, transmitterGUIDKey)
+}
+
+// WithTransmitterUnit records the name of the unit which the SRS client that transmitted a request is bound to.
+func WithTransmitterUnit(ctx context.Context, unit string) context.Context {
+	return context.WithValue(ctx, transmitterUnitKey, unit)
+}
+
+func GetTransmitterUnit(ctx context.Context) string {
+	return getValue[string](ctx, transmitterUnitKey)
+}
+
 func WithPlayerName(ctx context.Context, name string) context.Context {
 	return context.WithValue(ctx, playerNameKey, name)
 }
@@ -25,6 +25,9 @@ func (t *LogTracer) Trace(ctx context.Context) {
 	if clientName := GetClientName(ctx); clientName != "" {
 		loggerCtx = loggerCtx.Str("clientName", clientName)
 	}
+	if guid := GetTransmitterGUID(ctx); guid != "" {
+		loggerCtx = loggerCtx.Str("transmitterGUID", guid).Str("transmitterUnit", GetTransmitterUnit(ctx))
+	}
 	if text := GetRequestText(ctx); text != "" {
 		loggerCtx = loggerCtx.Str("requestText", text)
 	}
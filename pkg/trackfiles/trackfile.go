@@ -53,6 +53,8 @@ type Frame struct {
 	Altitude unit.Length
 	// Heading is the direction the contact is moving. This is not necessarily the direction the nose is poining.
 	Heading unit.Angle
+	// Extrapolated is true if the frame is a predicted position rather than a sensor observation.
+	Extrapolated bool
 }
 
 func NewTrackfile(labels Labels) *Trackfile {
@@ -76,10 +78,14 @@ func (t *Trackfile) String() string {
 	)
 }
 
-// Update the trackfile with a new frame. Frames older than the most recent one are discarded.
+// Update the trackfile with a new frame. Frames older than the most recent one are discarded. Any extrapolated frame is
+// replaced.
 func (t *Trackfile) Update(f Frame) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
+	if t.track.Len() > 0 && t.track.Front().Extrapolated {
+		t.track.PopFront()
+	}
 	if t.track.Len() > 0 && f.Time.Before(t.track.Front().Time) {
 		return
 	}
@@ -89,14 +95,13 @@ func (t *Trackfile) Update(f Frame) {
 	}
 }
 
-// Bullseye returns the bearing and distance from the bullseye to the track's last known position.
-func (t *Trackfile) Bullseye(bullseye orb.Point) brevity.Bullseye {
+// Bullseye returns the bearing and distance from the bullseye to the track's last known position. The bearing is
+// converted to magnetic north using the given variation.
+func (t *Trackfile) Bullseye(bullseye orb.Point, variation bearings.MagneticVariation) brevity.Bullseye {
 	latest := t.LastKnown()
-	declination, _ := bearings.Declination(bullseye, latest.Time)
-	bearing := spatial.TrueBearing(bullseye, latest.Point).Magnetic(declination)
-	log.Debug().Float64("bearing", bearing.Degrees()).Msg("calculated bullseye bearing for group")
-	distance := spatial.Distance(bullseye, latest.Point)
-	return *brevity.NewBullseye(bearing, distance)
+	b := brevity.BullseyeFromPoints(bullseye, latest.Point, variation.Declination(bullseye))
+	log.Debug().Float64("bearing", b.Bearing().Degrees()).Msg("calculated bullseye bearing for group")
+	return *b
 }
 
 // LastKnown returns the most recent frame in the trackfile.
@@ -110,43 +115,94 @@ func (t *Trackfile) LastKnown() Frame {
 	return t.track.Front()
 }
 
-func (t *Trackfile) IsLastKnownPointZero() bool {
-	return spatial.IsZero(t.LastKnown().Point)
+// LastObserved returns the most recent frame in the trackfile which was observed by sensors, ignoring any extrapolated
+// frame. If the trackfile is empty, a stub frame with a zero-value time is returned.
+func (t *Trackfile) LastObserved() Frame {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	for i := range t.track.Len() {
+		if frame := t.track.At(i); !frame.Extrapolated {
+			return frame
+		}
+	}
+	return Frame{}
 }
 
-func (t *Trackfile) bestAvailableDeclination() unit.Angle {
-	latest := t.LastKnown()
-	declincation, err := bearings.Declination(latest.Point, latest.Time)
-	if err != nil {
-		return 0
+// Extrapolate predicts the track's position at the given time from its last observed position, heading and speed, and
+// records the prediction as the most recent frame. Any previous extrapolated frame is replaced. It returns false if the
+// track has too few observations to extrapolate, or if the given time is not after the last observation.
+func (t *Trackfile) Extrapolate(at time.Time) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.track.Len() > 0 && t.track.Front().Extrapolated {
+		t.track.PopFront()
 	}
-	return declincation
+	if t.track.Len() < 2 {
+		return false
+	}
+	observed := t.track.Front()
+	if !at.After(observed.Time) {
+		return false
+	}
+
+	heading := t.heading()
+	t.track.PushFront(Frame{
+		Time:         at,
+		Point:        spatial.ExtrapolatePosition(observed.Point, bearings.NewTrueBearing(heading), t.speed(), at.Sub(observed.Time)),
+		Altitude:     observed.Altitude,
+		Heading:      heading,
+		Extrapolated: true,
+	})
+	for t.track.Len() > maxLength {
+		t.track.PopBack()
+	}
+	return true
 }
 
-// Course returns the angle that the track is moving in.
+func (t *Trackfile) IsLastKnownPointZero() bool {
+	return spatial.IsZero(t.LastKnown().Point)
+}
+
+// Course returns the magnetic angle that the track is moving in, converted from true north using the given variation.
 // If the track has not moved very far, the course may be unreliable.
 // You can check for this condition by checking if [Trackfile.Direction] returns [brevity.UnknownDirection].
-func (t *Trackfile) Course() bearings.Bearing {
+func (t *Trackfile) Course(variation bearings.MagneticVariation) bearings.Bearing {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
-	if t.track.Len() == 1 {
-		return bearings.NewTrueBearing(
-			unit.Angle(
-				t.track.Front().Heading,
-			) * unit.Degree,
-		).Magnetic(t.bestAvailableDeclination())
-	}
+	return t.course(variation)
+}
 
+func (t *Trackfile) course(variation bearings.MagneticVariation) bearings.Bearing {
 	latest := t.track.Front()
+	if t.track.Len() == 1 {
+		return variation.Magnetic(bearings.NewTrueBearing(unit.Angle(latest.Heading)*unit.Degree), latest.Point)
+	}
 	previous := t.track.At(1)
+	return variation.Magnetic(spatial.TrueBearing(previous.Point, latest.Point), latest.Point)
+}
+
+// Heading returns the true direction the track is moving in, computed from its two most recent positions. The result
+// is in the range (0, 360] degrees, so a track moving due north has a heading of 360 degrees. If only one position is
+// known, or the track is not moving faster than 1 m/s, the heading reported by the sensor is returned instead.
+func (t *Trackfile) Heading() unit.Angle {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.heading()
+}
 
-	declination := t.bestAvailableDeclination()
-	course := spatial.TrueBearing(previous.Point, latest.Point).Magnetic(declination)
-	return course
+func (t *Trackfile) heading() unit.Angle {
+	if t.track.Len() == 0 {
+		return 0
+	}
+	if t.track.Len() == 1 || t.groundSpeed() < 1*unit.MetersPerSecond {
+		return bearings.NewTrueBearing(t.track.Front().Heading).Value()
+	}
+	return spatial.TrueBearing(t.track.At(1).Point, t.track.Front().Point).Value()
 }
 
 // Direction returns the cardinal direction that the track is moving in, or [brevity.UnknownDirection] if the track is not moving faster than 1 m/s.
-func (t *Trackfile) Direction() brevity.Track {
+// The course is converted to magnetic north using the given variation.
+func (t *Trackfile) Direction(variation bearings.MagneticVariation) brevity.Track {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
 	if t.track.Len() < 2 {
@@ -156,8 +212,7 @@ func (t *Trackfile) Direction() brevity.Track {
 		return brevity.UnknownDirection
 	}
 
-	course := t.Course()
-	return brevity.TrackFromBearing(course)
+	return brevity.TrackFromBearing(t.course(variation))
 }
 
 // groundSpeed returns the approxmiate speed of the track along the ground (i.e. in two dimensions).
@@ -184,6 +239,10 @@ func (t *Trackfile) groundSpeed() unit.Speed {
 func (t *Trackfile) Speed() unit.Speed {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
+	return t.speed()
+}
+
+func (t *Trackfile) speed() unit.Speed {
 	if t.track.Len() < 2 {
 		return 0
 	}
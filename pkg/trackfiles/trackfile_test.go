@@ -177,12 +177,112 @@ func TestTracking(t *testing.T) {
 			})
 
 			require.InDelta(t, test.expectedApproxSpeed.MetersPerSecond(), trackfile.Speed().MetersPerSecond(), 0.5)
-			require.Equal(t, test.expectedDirection, trackfile.Direction())
+			variation := bearings.NewModelMagneticVariation(now)
+			require.Equal(t, test.expectedDirection, trackfile.Direction(variation))
 			if test.expectedDirection != brevity.UnknownDirection {
 				declination, err := bearings.Declination(dest, now)
 				require.NoError(t, err)
-				require.InDelta(t, bearings.NewTrueBearing(test.expectedApproxCourse).Magnetic(declination).Degrees(), trackfile.Course().Degrees(), 0.5)
+				require.InDelta(t, bearings.NewTrueBearing(test.expectedApproxCourse).Magnetic(declination).Degrees(), trackfile.Course(variation).Degrees(), 0.5)
 			}
 		})
 	}
 }
+
+func TestHeadingAndSpeed(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{-115.0338, 36.2350}
+	now := time.Now()
+	testCases := []struct {
+		name            string
+		distance        unit.Length
+		expectedHeading unit.Angle
+		expectedSpeed   unit.Speed
+	}{
+		{
+			name:            "Due north",
+			distance:        200 * unit.Meter,
+			expectedHeading: 360 * unit.Degree,
+			expectedSpeed:   100 * unit.MetersPerSecond,
+		},
+		{
+			name:            "Stationary",
+			distance:        0,
+			expectedHeading: 360 * unit.Degree,
+			expectedSpeed:   0,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			trackfile := NewTrackfile(Labels{ID: 1, ACMIName: "F-15C", Name: "Eagle 1", Coalition: coalitions.Blue})
+			trackfile.Update(Frame{Time: now.Add(-2 * time.Second), Point: origin, Altitude: 20000 * unit.Foot})
+			trackfile.Update(Frame{
+				Time:     now,
+				Point:    spatial.PointAtBearingAndDistance(origin, bearings.NewTrueBearing(0), test.distance),
+				Altitude: 20000 * unit.Foot,
+			})
+			require.InDelta(t, test.expectedHeading.Degrees(), trackfile.Heading().Degrees(), 0.5)
+			require.InDelta(t, test.expectedSpeed.MetersPerSecond(), trackfile.Speed().MetersPerSecond(), 0.5)
+		})
+	}
+}
+
+func TestExtrapolate(t *testing.T) {
+	t.Parallel()
+	trackfile := NewTrackfile(Labels{ID: 1, ACMIName: "F-15C", Name: "Eagle 1", Coalition: coalitions.Blue})
+	origin := orb.Point{-115.0338, 36.2350}
+	now := time.Now()
+	course := bearings.NewTrueBearing(90 * unit.Degree)
+
+	require.False(t, trackfile.Extrapolate(now))
+	trackfile.Update(Frame{Time: now.Add(-10 * time.Second), Point: origin, Altitude: 20000 * unit.Foot})
+	require.False(t, trackfile.Extrapolate(now), "a single frame cannot be extrapolated")
+
+	observed := spatial.PointAtBearingAndDistance(origin, course, 2000*unit.Meter)
+	trackfile.Update(Frame{Time: now, Point: observed, Altitude: 20000 * unit.Foot})
+	require.False(t, trackfile.Extrapolate(now), "extrapolation requires time to pass")
+
+	// The track flies east at 200 m/s. After a 30 second gap it should have flown a further 6 km east.
+	require.True(t, trackfile.Extrapolate(now.Add(30*time.Second)))
+	predicted := trackfile.LastKnown()
+	require.True(t, predicted.Extrapolated)
+	expected := spatial.PointAtBearingAndDistance(origin, course, 8000*unit.Meter)
+	require.InDelta(t, 0, spatial.Distance(expected, predicted.Point).Meters(), 10)
+	require.InDelta(t, 20000, predicted.Altitude.Feet(), 1)
+	require.Equal(t, now, trackfile.LastObserved().Time)
+	require.InDelta(t, 200, trackfile.Speed().MetersPerSecond(), 1)
+
+	// Extrapolating again replaces the previous prediction rather than compounding it.
+	require.True(t, trackfile.Extrapolate(now.Add(60*time.Second)))
+	expected = spatial.PointAtBearingAndDistance(origin, course, 14000*unit.Meter)
+	require.InDelta(t, 0, spatial.Distance(expected, trackfile.LastKnown().Point).Meters(), 20)
+	require.Equal(t, now, trackfile.LastObserved().Time)
+
+	// A sensor update replaces the prediction.
+	trackfile.Update(Frame{Time: now.Add(61 * time.Second), Point: expected, Altitude: 20000 * unit.Foot})
+	require.False(t, trackfile.LastKnown().Extrapolated)
+	require.Equal(t, now.Add(61*time.Second), trackfile.LastObserved().Time)
+}
+
+func TestCourseAndBullseyeUseVariation(t *testing.T) {
+	t.Parallel()
+	trackfile := NewTrackfile(Labels{ID: 1, ACMIName: "F-15C", Name: "Eagle 1", Coalition: coalitions.Blue})
+	now := time.Now()
+	bullseye := orb.Point{-115.0338, 36.2350}
+	start := spatial.PointAtBearingAndDistance(bullseye, bearings.NewTrueBearing(90*unit.Degree), 20*unit.NauticalMile)
+	trackfile.Update(Frame{Time: now.Add(-10 * time.Second), Point: start, Altitude: 20000 * unit.Foot})
+	trackfile.Update(Frame{
+		Time:     now,
+		Point:    spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(0), 2*unit.Kilometer),
+		Altitude: 20000 * unit.Foot,
+	})
+
+	variation := bearings.NewFixedMagneticVariation(10 * unit.Degree)
+	course := trackfile.Course(variation)
+	require.True(t, course.IsMagnetic())
+	require.InDelta(t, 350, course.Degrees(), 0.5)
+	require.Equal(t, brevity.North, trackfile.Direction(variation))
+	trueLocation := trackfile.Bullseye(bullseye, bearings.NewFixedMagneticVariation(0))
+	location := trackfile.Bullseye(bullseye, variation)
+	require.InDelta(t, trueLocation.Bearing().Degrees()-10, location.Bearing().Degrees(), 0.001)
+}